@@ -0,0 +1,62 @@
+// Comando de importação da base local de CEPs.
+//
+// Lê um arquivo CSV ou XLSX com as colunas do CEP, do logradouro, do bairro, do complemento e do código
+// IBGE do município (ex: "cep;logradouro;bairro;complemento;codigo_ibge"). A importação é idempotente:
+// pode ser executada novamente com uma base mais recente. Os municípios devem estar cadastrados antes
+// (ver cmd/import-ibge); CEPs de municípios desconhecidos são ignorados.
+package main
+
+import (
+	"flag"
+	"log"
+
+	"simple-erp-service/config"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/service"
+	"simple-erp-service/internal/utils/cep"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func main() {
+	file := flag.String("file", "", "Arquivo CSV ou XLSX com os CEPs")
+	flag.Parse()
+	if *file == "" {
+		log.Fatal("Informe o arquivo com -file")
+	}
+
+	records, err := cep.ReadFile(*file)
+	if err != nil {
+		log.Fatalf("Erro ao ler os CEPs: %v", err)
+	}
+
+	// Carregar configurações
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Erro ao carregar configurações: %v", err)
+	}
+
+	// Conectar ao banco de dados
+	database, err := gorm.Open(postgres.Open(cfg.Database.DSN()), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		log.Fatalf("Erro ao conectar ao banco de dados: %v", err)
+	}
+
+	locationRepo := repository.NewLocationRepository(database)
+	postalCodeRepo := repository.NewPostalCodeRepository(database)
+	postalCodeService := service.NewPostalCodeService(service.NewLocalPostalCodeProvider(postalCodeRepo), locationRepo, postalCodeRepo)
+
+	result, err := postalCodeService.Import(records)
+	if err != nil {
+		log.Fatalf("Erro ao importar os CEPs: %v", err)
+	}
+
+	if len(result.MissingCities) > 0 {
+		log.Printf("%d CEPs ignorados: municípios não cadastrados (códigos IBGE %v)", result.Skipped, result.MissingCities)
+	}
+	log.Printf("Importação concluída: %d CEPs inseridos ou atualizados", result.Imported)
+}
@@ -0,0 +1,99 @@
+// Comando de importação dos municípios do IBGE.
+//
+// Sem parâmetros, importa o conjunto embutido no binário. Com -api, baixa a tabela completa da API de
+// localidades do IBGE; com -file, lê um arquivo CSV/XLSX (ex: planilha da DTB, com as colunas do código
+// e do nome do município) ou o JSON da API salvo em disco. A importação é idempotente: pode ser executada
+// novamente para atualizar nomes e incluir municípios novos. Os estados devem estar cadastrados (seed).
+//
+// Com -export, grava os municípios lidos em um CSV no formato do conjunto embutido, sem acessar o banco.
+// É assim que o conjunto embutido usado pelo seed é atualizado:
+//
+//	go run ./cmd/import-ibge -api -export internal/utils/ibge/municipios.csv
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	"simple-erp-service/config"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/service"
+	"simple-erp-service/internal/utils/ibge"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func main() {
+	file := flag.String("file", "", "Arquivo CSV, XLSX ou JSON com os municípios")
+	fromAPI := flag.Bool("api", false, "Baixa os municípios da API de localidades do IBGE")
+	url := flag.String("url", ibge.APIURL, "Endereço da API de localidades do IBGE")
+	export := flag.String("export", "", "Grava os municípios neste arquivo CSV (codigo;nome) em vez de importá-los")
+	flag.Parse()
+	if *file != "" && *fromAPI {
+		log.Fatal("Informe somente uma origem: -file ou -api")
+	}
+
+	var municipalities []ibge.Municipality
+	var err error
+	switch {
+	case *file != "":
+		municipalities, err = ibge.ReadFile(*file)
+	case *fromAPI:
+		municipalities, err = ibge.Download(context.Background(), *url)
+	default:
+		municipalities, err = ibge.Embedded()
+	}
+	if err != nil {
+		log.Fatalf("Erro ao ler os municípios: %v", err)
+	}
+
+	if *export != "" {
+		if err := exportCSV(*export, municipalities); err != nil {
+			log.Fatalf("Erro ao exportar os municípios: %v", err)
+		}
+		log.Printf("Exportação concluída: %d municípios gravados em %s", len(municipalities), *export)
+		return
+	}
+
+	// Carregar configurações
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Erro ao carregar configurações: %v", err)
+	}
+
+	// Conectar ao banco de dados
+	database, err := gorm.Open(postgres.Open(cfg.Database.DSN()), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		log.Fatalf("Erro ao conectar ao banco de dados: %v", err)
+	}
+
+	locationService := service.NewLocationService(repository.NewLocationRepository(database))
+	result, err := locationService.ImportMunicipalities(municipalities)
+	if err != nil {
+		log.Fatalf("Erro ao importar os municípios: %v", err)
+	}
+
+	if len(result.MissingUFs) > 0 {
+		log.Printf("%d municípios ignorados: UFs sem estado cadastrado (códigos IBGE %v)", result.Skipped, result.MissingUFs)
+	}
+	log.Printf("Importação concluída: %d municípios inseridos ou atualizados", result.Imported)
+}
+
+// exportCSV grava os municípios no arquivo informado, no formato do conjunto embutido
+func exportCSV(path string, municipalities []ibge.Municipality) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := ibge.WriteCSV(file, municipalities); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
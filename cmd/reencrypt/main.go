@@ -0,0 +1,57 @@
+// Comando de rotação das chaves da criptografia de campos.
+//
+// Para trocar a chave de criptografia, adicione a nova chave no início de FIELD_ENCRYPTION_KEYS mantendo
+// as antigas (ex: "v2:<nova>,v1:<antiga>") e execute este comando; depois a chave antiga pode ser removida.
+// Para trocar FIELD_BLIND_INDEX_KEY, execute com -reindex: as buscas exatas e as verificações de unicidade
+// só encontram os registros já reindexados até o comando terminar.
+package main
+
+import (
+	"flag"
+	"log"
+
+	"simple-erp-service/config"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/repository/db"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func main() {
+	reindex := flag.Bool("reindex", false, "Processa todos os registros, recalculando também os índices cegos")
+	batchSize := flag.Int("batch", 500, "Quantidade de registros por transação")
+	flag.Parse()
+	if *batchSize <= 0 {
+		log.Fatal("O tamanho do lote deve ser maior que zero")
+	}
+
+	// Carregar configurações
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Erro ao carregar configurações: %v", err)
+	}
+	if err := db.ConfigureEncryption(cfg.Crypto); err != nil {
+		log.Fatalf("Erro ao configurar a criptografia: %v", err)
+	}
+
+	// Conectar ao banco de dados
+	database, err := gorm.Open(postgres.Open(cfg.Database.DSN()), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		log.Fatalf("Erro ao conectar ao banco de dados: %v", err)
+	}
+
+	encryptionRepo := repository.NewEncryptionRepository(database)
+	for _, column := range repository.EncryptedColumns {
+		updated, err := encryptionRepo.Reencrypt(column, *reindex, *batchSize)
+		if err != nil {
+			log.Fatalf("Erro ao recriptografar %s.%s (%d registros atualizados): %v", column.Table, column.Column, updated, err)
+		}
+		log.Printf("%s.%s: %d registros atualizados", column.Table, column.Column, updated)
+	}
+
+	log.Printf("Recriptografia concluída com a chave %q", cfg.Crypto.CurrentKeyID)
+}
@@ -1,9 +1,12 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/base64"
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -11,10 +14,15 @@ import (
 
 // Config armazena todas as configurações da aplicação
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	JWT      JWTConfig
-	App      AppConfig
+	Server    ServerConfig
+	Database  DatabaseConfig
+	JWT       JWTConfig
+	App       AppConfig
+	Credit    CreditConfig
+	LGPD      LGPDConfig
+	Crypto    CryptoConfig
+	Documents DocumentsConfig
+	Inventory InventoryConfig
 }
 
 // AppConfig armazena configurações gerais da aplicação
@@ -22,6 +30,43 @@ type AppConfig struct {
 	Env string // Ex: "development", "production", "test"
 }
 
+// Ações possíveis quando uma venda a prazo ultrapassa o limite de crédito
+const (
+	CreditActionReject   = "reject"   // A venda é recusada
+	CreditActionApproval = "approval" // A venda fica aguardando aprovação do gerente
+)
+
+// CreditConfig armazena as regras de bloqueio de vendas a prazo
+type CreditConfig struct {
+	OverdueToleranceDays int    // Dias de atraso tolerados antes de bloquear novas vendas a prazo
+	ExceededAction       string // CreditActionReject ou CreditActionApproval
+}
+
+// LGPDConfig armazena as regras de tratamento de dados pessoais dos titulares
+type LGPDConfig struct {
+	FiscalRetentionYears int // Anos de guarda obrigatória dos registros fiscais após a última movimentação
+}
+
+// DocumentsConfig armazena as regras de acompanhamento da validade dos documentos de clientes e fornecedores
+type DocumentsConfig struct {
+	ExpiryWarningDays             int           // Dias de antecedência para alertar sobre documentos a vencer
+	ExpiryCheckInterval           time.Duration // Intervalo da verificação de vencimentos; zero desativa a rotina
+	BlockExpiredSupplierPurchases bool          // Recusa compras de fornecedores com documentos obrigatórios vencidos
+}
+
+// InventoryConfig armazena as regras de reserva de estoque das vendas pendentes
+type InventoryConfig struct {
+	ReservationExpiry        time.Duration // Validade das reservas; zero: as reservas valem até a venda ser faturada ou cancelada
+	ReservationCheckInterval time.Duration // Intervalo da baixa das reservas expiradas; zero desativa a rotina
+}
+
+// CryptoConfig armazena as chaves da criptografia de campos com dados pessoais
+type CryptoConfig struct {
+	Keys          map[string][]byte // Chaves AES-256 indexadas pelo identificador gravado junto ao valor criptografado
+	CurrentKeyID  string            // Chave usada para criptografar novos valores (a primeira de FIELD_ENCRYPTION_KEYS)
+	BlindIndexKey []byte            // Chave HMAC dos índices cegos usados nas buscas exatas e na unicidade
+}
+
 // ServerConfig armazena configurações do servidor HTTP
 type ServerConfig struct {
 	Port         string
@@ -43,9 +88,10 @@ type DatabaseConfig struct {
 
 // JWTConfig armazena configurações do JWT
 type JWTConfig struct {
-	Secret          string
-	AccessTokenExp  time.Duration
-	RefreshTokenExp time.Duration
+	Secret                string
+	AccessTokenExp        time.Duration
+	RefreshTokenExp       time.Duration
+	ImpersonationTokenExp time.Duration
 }
 
 // Load carrega as configurações do ambiente
@@ -70,12 +116,38 @@ func Load() (*Config, error) {
 
 	// Configurações do JWT
 	jwtSecret := getEnv("JWT_SECRET", "your-secret-key")
-	jwtAccessExp, _ := strconv.Atoi(getEnv("JWT_ACCESS_EXP", "15"))      // 15 minutos
-	jwtRefreshExp, _ := strconv.Atoi(getEnv("JWT_REFRESH_EXP", "10080")) // 7 dias
+	jwtAccessExp, _ := strconv.Atoi(getEnv("JWT_ACCESS_EXP", "15"))               // 15 minutos
+	jwtRefreshExp, _ := strconv.Atoi(getEnv("JWT_REFRESH_EXP", "10080"))          // 7 dias
+	jwtImpersonationExp, _ := strconv.Atoi(getEnv("JWT_IMPERSONATION_EXP", "10")) // 10 minutos
+
+	// Configurações de crédito de clientes
+	creditOverdueTolerance, _ := strconv.Atoi(getEnv("CREDIT_OVERDUE_TOLERANCE_DAYS", "5"))
+	creditExceededAction := getEnv("CREDIT_EXCEEDED_ACTION", CreditActionApproval)
+	if creditExceededAction != CreditActionReject {
+		creditExceededAction = CreditActionApproval
+	}
+
+	// Configurações de LGPD
+	lgpdFiscalRetention, _ := strconv.Atoi(getEnv("LGPD_FISCAL_RETENTION_YEARS", "5"))
+
+	// Configurações de validade de documentos
+	documentExpiryWarning, _ := strconv.Atoi(getEnv("DOCUMENT_EXPIRY_WARNING_DAYS", "30"))
+	documentExpiryInterval, _ := strconv.Atoi(getEnv("DOCUMENT_EXPIRY_CHECK_INTERVAL", "24")) // Horas
+	documentBlockPurchases, _ := strconv.ParseBool(getEnv("DOCUMENT_BLOCK_EXPIRED_SUPPLIERS", "false"))
+
+	// Configurações de reserva de estoque
+	reservationHours, _ := strconv.Atoi(getEnv("STOCK_RESERVATION_HOURS", "72"))
+	reservationInterval, _ := strconv.Atoi(getEnv("STOCK_RESERVATION_CHECK_INTERVAL", "60")) // Minutos
 
 	// Configurações gerais da aplicação
 	appEnv := getEnv("APP_ENV", "development")
 
+	// Configurações da criptografia de campos
+	cryptoCfg, err := loadCryptoConfig(appEnv)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Config{
 		Server: ServerConfig{
 			Port:         port,
@@ -93,13 +165,31 @@ func Load() (*Config, error) {
 			DatabaseLink: dbLink,
 		},
 		JWT: JWTConfig{
-			Secret:          jwtSecret,
-			AccessTokenExp:  time.Duration(jwtAccessExp) * time.Minute,
-			RefreshTokenExp: time.Duration(jwtRefreshExp) * time.Minute,
+			Secret:                jwtSecret,
+			AccessTokenExp:        time.Duration(jwtAccessExp) * time.Minute,
+			RefreshTokenExp:       time.Duration(jwtRefreshExp) * time.Minute,
+			ImpersonationTokenExp: time.Duration(jwtImpersonationExp) * time.Minute,
 		},
 		App: AppConfig{
 			Env: appEnv,
 		},
+		Credit: CreditConfig{
+			OverdueToleranceDays: creditOverdueTolerance,
+			ExceededAction:       creditExceededAction,
+		},
+		LGPD: LGPDConfig{
+			FiscalRetentionYears: lgpdFiscalRetention,
+		},
+		Crypto: *cryptoCfg,
+		Documents: DocumentsConfig{
+			ExpiryWarningDays:             documentExpiryWarning,
+			ExpiryCheckInterval:           time.Duration(documentExpiryInterval) * time.Hour,
+			BlockExpiredSupplierPurchases: documentBlockPurchases,
+		},
+		Inventory: InventoryConfig{
+			ReservationExpiry:        time.Duration(reservationHours) * time.Hour,
+			ReservationCheckInterval: time.Duration(reservationInterval) * time.Minute,
+		},
 	}, nil
 }
 
@@ -116,6 +206,60 @@ func (c *DatabaseConfig) DSN() string {
 	)
 }
 
+// loadCryptoConfig lê as chaves da criptografia de campos.
+// FIELD_ENCRYPTION_KEYS lista as chaves no formato "id:base64,id:base64": a primeira criptografa os novos
+// valores e as demais só descriptografam, permitindo a rotação (ver cmd/reencrypt). FIELD_BLIND_INDEX_KEY
+// (base64) gera os índices cegos. Fora de produção, chaves fixas de desenvolvimento são usadas quando ausentes.
+func loadCryptoConfig(appEnv string) (*CryptoConfig, error) {
+	keysValue := getEnv("FIELD_ENCRYPTION_KEYS", "")
+	indexValue := getEnv("FIELD_BLIND_INDEX_KEY", "")
+
+	if keysValue == "" || indexValue == "" {
+		if appEnv == "production" {
+			return nil, fmt.Errorf("FIELD_ENCRYPTION_KEYS e FIELD_BLIND_INDEX_KEY são obrigatórias em produção")
+		}
+		if keysValue == "" {
+			keysValue = "dev:" + developmentKey("field-encryption")
+		}
+		if indexValue == "" {
+			indexValue = developmentKey("blind-index")
+		}
+	}
+
+	cfg := &CryptoConfig{Keys: map[string][]byte{}}
+	for _, entry := range strings.Split(keysValue, ",") {
+		id, encoded, found := strings.Cut(strings.TrimSpace(entry), ":")
+		if !found || id == "" {
+			return nil, fmt.Errorf("FIELD_ENCRYPTION_KEYS inválida: use o formato id:base64")
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(key) != 32 {
+			return nil, fmt.Errorf("FIELD_ENCRYPTION_KEYS inválida: a chave %q deve ter 32 bytes em base64", id)
+		}
+		if _, exists := cfg.Keys[id]; exists {
+			return nil, fmt.Errorf("FIELD_ENCRYPTION_KEYS inválida: a chave %q está repetida", id)
+		}
+		if cfg.CurrentKeyID == "" {
+			cfg.CurrentKeyID = id
+		}
+		cfg.Keys[id] = key
+	}
+
+	indexKey, err := base64.StdEncoding.DecodeString(indexValue)
+	if err != nil || len(indexKey) < 32 {
+		return nil, fmt.Errorf("FIELD_BLIND_INDEX_KEY inválida: informe ao menos 32 bytes em base64")
+	}
+	cfg.BlindIndexKey = indexKey
+
+	return cfg, nil
+}
+
+// developmentKey gera uma chave fixa para o ambiente de desenvolvimento
+func developmentKey(purpose string) string {
+	sum := sha256.Sum256([]byte("simple-erp-service development " + purpose))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
 // getEnv retorna o valor da variável de ambiente ou o valor padrão
 func getEnv(key, defaultValue string) string {
 	if value, exists := os.LookupEnv(key); exists {
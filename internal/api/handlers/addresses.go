@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"net/http"
+
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/service"
+	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/utils/path"
+	"simple-erp-service/internal/validator"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// AddressHandler gerencia as requisições de endereços de clientes ou fornecedores
+type AddressHandler struct {
+	addressService *service.AddressService
+	ownerType      string
+}
+
+// NewAddressHandler cria um novo handler de endereços para o tipo de dono informado (cliente ou fornecedor)
+func NewAddressHandler(db *gorm.DB, ownerType string) *AddressHandler {
+	addressRepo := repository.NewAddressRepository(db)
+	locationRepo := repository.NewLocationRepository(db)
+	customerRepo := repository.NewCustomerRepository(db)
+	supplierRepo := repository.NewSupplierRepository(db)
+
+	return &AddressHandler{
+		addressService: service.NewAddressService(addressRepo, locationRepo, customerRepo, supplierRepo),
+		ownerType:      ownerType,
+	}
+}
+
+// ownerFromPath monta o dono a partir do parâmetro :id da rota, respondendo com BadRequest no caso de erro
+func ownerFromPath(c *gin.Context, ownerType string) (models.Owner, error) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return models.Owner{}, err
+	}
+	return models.Owner{Type: ownerType, ID: id}, nil
+}
+
+// childIDFromPath busca o ID do sub-recurso, respondendo com BadRequest no caso de erro
+func childIDFromPath(c *gin.Context, paramName string) (uint, error) {
+	id, err := path.UintFromPathParam(c, paramName)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "ID inválido", err.Error())
+	}
+	return id, err
+}
+
+// GetAddresses retorna os endereços de um cliente ou fornecedor
+// @Summary Listar endereços
+// @Description Retorna os endereços de um cliente ou fornecedor, com o principal primeiro
+// @Tags addresses
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do cliente ou fornecedor"
+// @Success 200 {object} utils.Response{data=[]dto.ApiAddress} "Endereços encontrados"
+// @Failure 400 {object} utils.Response "ID inválido"
+// @Failure 404 {object} utils.Response "Registro não encontrado"
+// @Router /customers/{id}/addresses [get]
+// @Router /suppliers/{id}/addresses [get]
+func (h *AddressHandler) GetAddresses(c *gin.Context) {
+	owner, err := ownerFromPath(c, h.ownerType)
+	if err != nil {
+		return
+	}
+
+	addresses, err := h.addressService.GetAddresses(owner)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Registro não encontrado", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar endereços", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Endereços encontrados", addresses, nil)
+}
+
+// CreateAddress cria um endereço para um cliente ou fornecedor
+// @Summary Criar endereço
+// @Description Cria um endereço. O primeiro endereço cadastrado se torna o principal.
+// @Tags addresses
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do cliente ou fornecedor"
+// @Param request body models.CreateAddressRequest true "Dados do endereço"
+// @Success 201 {object} utils.Response{data=dto.ApiAddress} "Endereço criado com sucesso"
+// @Failure 400 {object} utils.Response "Dados inválidos"
+// @Failure 404 {object} utils.Response "Registro não encontrado"
+// @Router /customers/{id}/addresses [post]
+// @Router /suppliers/{id}/addresses [post]
+func (h *AddressHandler) CreateAddress(c *gin.Context) {
+	owner, err := ownerFromPath(c, h.ownerType)
+	if err != nil {
+		return
+	}
+
+	var req models.CreateAddressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, "Dados inválidos", err.Error())
+		return
+	}
+
+	address, err := h.addressService.CreateAddress(owner, req)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Registro não encontrado", err.Error())
+		} else if validator.IsValidationError(err) {
+			utils.ValidationErrorResponse(c, "Dados inválidos", validator.GetValidationErrors(err))
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao criar endereço", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Endereço criado com sucesso", address, nil)
+}
+
+// UpdateAddress atualiza um endereço de um cliente ou fornecedor
+// @Summary Atualizar endereço
+// @Description Atualiza um endereço. Enviar is_primary=true torna este o endereço principal.
+// @Tags addresses
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do cliente ou fornecedor"
+// @Param addressId path int true "ID do endereço"
+// @Param request body models.UpdateAddressRequest true "Dados do endereço"
+// @Success 200 {object} utils.Response{data=dto.ApiAddress} "Endereço atualizado com sucesso"
+// @Failure 400 {object} utils.Response "Dados inválidos"
+// @Failure 404 {object} utils.Response "Endereço não encontrado"
+// @Router /customers/{id}/addresses/{addressId} [put]
+// @Router /suppliers/{id}/addresses/{addressId} [put]
+func (h *AddressHandler) UpdateAddress(c *gin.Context) {
+	owner, err := ownerFromPath(c, h.ownerType)
+	if err != nil {
+		return
+	}
+	addressID, err := childIDFromPath(c, "addressId")
+	if err != nil {
+		return
+	}
+
+	var req models.UpdateAddressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, "Dados inválidos", err.Error())
+		return
+	}
+
+	address, err := h.addressService.UpdateAddress(owner, addressID, req)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Endereço não encontrado", err.Error())
+		} else if validator.IsValidationError(err) {
+			utils.ValidationErrorResponse(c, "Dados inválidos", validator.GetValidationErrors(err))
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao atualizar endereço", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Endereço atualizado com sucesso", address, nil)
+}
+
+// DeleteAddress exclui um endereço de um cliente ou fornecedor
+// @Summary Excluir endereço
+// @Description Exclui um endereço. Se for o principal, o endereço mais antigo restante assume.
+// @Tags addresses
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do cliente ou fornecedor"
+// @Param addressId path int true "ID do endereço"
+// @Success 200 {object} utils.Response "Endereço excluído com sucesso"
+// @Failure 400 {object} utils.Response "ID inválido"
+// @Failure 404 {object} utils.Response "Endereço não encontrado"
+// @Router /customers/{id}/addresses/{addressId} [delete]
+// @Router /suppliers/{id}/addresses/{addressId} [delete]
+func (h *AddressHandler) DeleteAddress(c *gin.Context) {
+	owner, err := ownerFromPath(c, h.ownerType)
+	if err != nil {
+		return
+	}
+	addressID, err := childIDFromPath(c, "addressId")
+	if err != nil {
+		return
+	}
+
+	if err := h.addressService.DeleteAddress(owner, addressID); err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Endereço não encontrado", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao excluir endereço", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Endereço excluído com sucesso", nil, nil)
+}
@@ -8,6 +8,7 @@ import (
 	"simple-erp-service/internal/data-structure/dto"
 	"simple-erp-service/internal/service"
 	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/utils/path"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -167,5 +168,141 @@ func (h *AuthHandler) GetMe(c *gin.Context) {
 		User: dto.ApiUserDetailFromModel(*user),
 	}
 
+	// Informar ao frontend que a sessão atual é uma personificação
+	if impersonatorID, isImpersonating := utils.GetImpersonatorIDFromContext(c); isImpersonating {
+		impersonator, err := h.authService.GetUserByID(impersonatorID)
+		if err == nil {
+			impersonatorDTO := dto.ApiUserFromModel(*impersonator)
+			userResponse.Impersonator = &impersonatorDTO
+		}
+	}
+
 	utils.SuccessResponse(c, http.StatusOK, "Usuário encontrado", userResponse, nil)
 }
+
+// Impersonate inicia uma personificação do usuário informado
+// @Summary Personificar usuário
+// @Description Gera um token de acesso de curta duração agindo como outro usuário. Todas as requisições feitas com ele são auditadas. O perfil do usuário alvo não pode ter permissões que o perfil do solicitante não tem.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do usuário a ser personificado"
+// @Success 200 {object} utils.Response{data=dto.ImpersonationSuccessResponse} "Personificação iniciada"
+// @Failure 400 {object} utils.Response "Dados inválidos"
+// @Failure 403 {object} utils.Response "Acesso negado"
+// @Failure 404 {object} utils.Response "Usuário não encontrado"
+// @Router /auth/impersonate/{id} [post]
+func (h *AuthHandler) Impersonate(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	// Não permitir personificação encadeada
+	if _, isImpersonating := utils.GetImpersonatorIDFromContext(c); isImpersonating {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Operação inválida", "Encerre a personificação atual antes de iniciar outra")
+		return
+	}
+
+	userID, exists := utils.GetUserIDFromContext(c)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Não autorizado", "Usuário não autenticado")
+		return
+	}
+
+	response, err := h.authService.Impersonate(userID, id, c.ClientIP())
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Usuário não encontrado", err.Error())
+		} else if err == utils.ErrForbidden {
+			utils.ErrorResponse(c, http.StatusForbidden, "Acesso negado", "O usuário alvo tem permissões que o seu perfil não tem")
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao personificar usuário", err.Error())
+		}
+		return
+	}
+
+	// Substituir apenas o Access Token: o Refresh Token continua sendo do usuário original,
+	// assim a personificação não pode ser renovada e termina ao expirar
+	c.SetCookie(
+		"access_token",
+		response.AccessToken,
+		int(h.cfg.JWT.ImpersonationTokenExp.Seconds()),
+		"/", "",
+		h.cfg.App.Env == "production",
+		true,
+	)
+
+	successResponse := dto.ImpersonationSuccessResponse{
+		User:         response.User,
+		Impersonator: response.Impersonator,
+		ExpiresAt:    response.ExpiresAt.Format("2006-01-02 15:04:05"),
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Personificação iniciada", successResponse, nil)
+}
+
+// StopImpersonation encerra a personificação e restaura a sessão do usuário original
+// @Summary Encerrar personificação
+// @Description Encerra a personificação atual e emite novamente os tokens do usuário original
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} utils.Response{data=dto.LoginSuccessResponse} "Personificação encerrada"
+// @Failure 400 {object} utils.Response "Nenhuma personificação ativa"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Router /auth/impersonate/stop [post]
+func (h *AuthHandler) StopImpersonation(c *gin.Context) {
+	impersonatorID, isImpersonating := utils.GetImpersonatorIDFromContext(c)
+	if !isImpersonating {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Operação inválida", "Nenhuma personificação ativa")
+		return
+	}
+
+	userID, _ := utils.GetUserIDFromContext(c)
+	h.authService.StopImpersonation(impersonatorID, userID, c.ClientIP())
+
+	// O Refresh Token no cookie pertence ao usuário original
+	refreshToken, err := c.Cookie("refresh_token")
+	if err != nil {
+		c.SetCookie("access_token", "", -1, "/", "", h.cfg.App.Env == "production", true)
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Falha ao restaurar sessão", "Token de refresh ausente no cookie")
+		return
+	}
+
+	response, err := h.authService.RefreshToken(refreshToken)
+	if err != nil {
+		c.SetCookie("access_token", "", -1, "/", "", h.cfg.App.Env == "production", true)
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Falha ao restaurar sessão", err.Error())
+		return
+	}
+
+	accessTokenDuration := time.Duration(h.cfg.JWT.AccessTokenExp.Minutes()) * time.Minute
+	refreshTokenDuration := time.Duration(h.cfg.JWT.RefreshTokenExp.Minutes()) * time.Minute
+
+	c.SetCookie(
+		"access_token",
+		response.AccessToken,
+		int(accessTokenDuration.Seconds()),
+		"/", "",
+		h.cfg.App.Env == "production",
+		true,
+	)
+
+	c.SetCookie(
+		"refresh_token",
+		response.RefreshToken,
+		int(refreshTokenDuration.Seconds()),
+		"/", "",
+		h.cfg.App.Env == "production",
+		true,
+	)
+
+	successResponse := dto.LoginSuccessResponse{
+		User: response.User,
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Personificação encerrada", successResponse, nil)
+}
@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"net/http"
+
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/service"
+	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/validator"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ContactHandler gerencia as requisições de contatos de clientes ou fornecedores
+type ContactHandler struct {
+	contactService *service.ContactService
+	ownerType      string
+}
+
+// NewContactHandler cria um novo handler de contatos para o tipo de dono informado (cliente ou fornecedor)
+func NewContactHandler(db *gorm.DB, ownerType string) *ContactHandler {
+	contactRepo := repository.NewContactRepository(db)
+	customerRepo := repository.NewCustomerRepository(db)
+	supplierRepo := repository.NewSupplierRepository(db)
+
+	return &ContactHandler{
+		contactService: service.NewContactService(contactRepo, customerRepo, supplierRepo),
+		ownerType:      ownerType,
+	}
+}
+
+// GetContacts retorna os contatos de um cliente ou fornecedor
+// @Summary Listar contatos
+// @Description Retorna os contatos de um cliente ou fornecedor, com o principal primeiro
+// @Tags contacts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do cliente ou fornecedor"
+// @Success 200 {object} utils.Response{data=[]dto.ApiContact} "Contatos encontrados"
+// @Failure 400 {object} utils.Response "ID inválido"
+// @Failure 404 {object} utils.Response "Registro não encontrado"
+// @Router /customers/{id}/contacts [get]
+// @Router /suppliers/{id}/contacts [get]
+func (h *ContactHandler) GetContacts(c *gin.Context) {
+	owner, err := ownerFromPath(c, h.ownerType)
+	if err != nil {
+		return
+	}
+
+	contactes, err := h.contactService.GetContacts(owner)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Registro não encontrado", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar contatos", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Contatos encontrados", contactes, nil)
+}
+
+// CreateContact cria um contato para um cliente ou fornecedor
+// @Summary Criar contato
+// @Description Cria um contato. O primeiro contato cadastrado se torna o principal.
+// @Tags contacts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do cliente ou fornecedor"
+// @Param request body models.CreateContactRequest true "Dados do contato"
+// @Success 201 {object} utils.Response{data=dto.ApiContact} "Contato criado com sucesso"
+// @Failure 400 {object} utils.Response "Dados inválidos"
+// @Failure 404 {object} utils.Response "Registro não encontrado"
+// @Router /customers/{id}/contacts [post]
+// @Router /suppliers/{id}/contacts [post]
+func (h *ContactHandler) CreateContact(c *gin.Context) {
+	owner, err := ownerFromPath(c, h.ownerType)
+	if err != nil {
+		return
+	}
+
+	var req models.CreateContactRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, "Dados inválidos", err.Error())
+		return
+	}
+
+	contact, err := h.contactService.CreateContact(owner, req)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Registro não encontrado", err.Error())
+		} else if validator.IsValidationError(err) {
+			utils.ValidationErrorResponse(c, "Dados inválidos", validator.GetValidationErrors(err))
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao criar contato", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Contato criado com sucesso", contact, nil)
+}
+
+// UpdateContact atualiza um contato de um cliente ou fornecedor
+// @Summary Atualizar contato
+// @Description Atualiza um contato. Enviar is_primary=true torna este o contato principal.
+// @Tags contacts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do cliente ou fornecedor"
+// @Param contactId path int true "ID do contato"
+// @Param request body models.UpdateContactRequest true "Dados do contato"
+// @Success 200 {object} utils.Response{data=dto.ApiContact} "Contato atualizado com sucesso"
+// @Failure 400 {object} utils.Response "Dados inválidos"
+// @Failure 404 {object} utils.Response "Contato não encontrado"
+// @Router /customers/{id}/contacts/{contactId} [put]
+// @Router /suppliers/{id}/contacts/{contactId} [put]
+func (h *ContactHandler) UpdateContact(c *gin.Context) {
+	owner, err := ownerFromPath(c, h.ownerType)
+	if err != nil {
+		return
+	}
+	contactID, err := childIDFromPath(c, "contactId")
+	if err != nil {
+		return
+	}
+
+	var req models.UpdateContactRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, "Dados inválidos", err.Error())
+		return
+	}
+
+	contact, err := h.contactService.UpdateContact(owner, contactID, req)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Contato não encontrado", err.Error())
+		} else if validator.IsValidationError(err) {
+			utils.ValidationErrorResponse(c, "Dados inválidos", validator.GetValidationErrors(err))
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao atualizar contato", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Contato atualizado com sucesso", contact, nil)
+}
+
+// DeleteContact exclui um contato de um cliente ou fornecedor
+// @Summary Excluir contato
+// @Description Exclui um contato. Se for o principal, o contato mais antigo restante assume.
+// @Tags contacts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do cliente ou fornecedor"
+// @Param contactId path int true "ID do contato"
+// @Success 200 {object} utils.Response "Contato excluído com sucesso"
+// @Failure 400 {object} utils.Response "ID inválido"
+// @Failure 404 {object} utils.Response "Contato não encontrado"
+// @Router /customers/{id}/contacts/{contactId} [delete]
+// @Router /suppliers/{id}/contacts/{contactId} [delete]
+func (h *ContactHandler) DeleteContact(c *gin.Context) {
+	owner, err := ownerFromPath(c, h.ownerType)
+	if err != nil {
+		return
+	}
+	contactID, err := childIDFromPath(c, "contactId")
+	if err != nil {
+		return
+	}
+
+	if err := h.contactService.DeleteContact(owner, contactID); err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Contato não encontrado", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao excluir contato", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Contato excluído com sucesso", nil, nil)
+}
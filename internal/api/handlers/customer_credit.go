@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"net/http"
+
+	"simple-erp-service/config"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/service"
+	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/utils/path"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// CustomerCreditHandler gerencia as requisições do perfil financeiro e do crédito de clientes
+type CustomerCreditHandler struct {
+	creditService *service.CreditService
+}
+
+// NewCustomerCreditHandler cria um novo handler de crédito de clientes
+func NewCustomerCreditHandler(db *gorm.DB, cfg config.CreditConfig) *CustomerCreditHandler {
+	customerRepo := repository.NewCustomerRepository(db)
+	transactionRepo := repository.NewTransactionRepository(db)
+	saleRepo := repository.NewSaleRepository(db)
+
+	return &CustomerCreditHandler{
+		creditService: service.NewCreditService(customerRepo, transactionRepo, saleRepo, cfg),
+	}
+}
+
+// UpdateCredit atualiza o perfil financeiro de um cliente
+// @Summary Atualizar crédito do cliente
+// @Description Atualiza o limite de crédito, o prazo de pagamento e o bloqueio manual de vendas a prazo
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do cliente"
+// @Param request body models.UpdateCustomerCreditRequest true "Perfil financeiro"
+// @Success 200 {object} utils.Response{data=dto.ApiCustomerCredit} "Crédito atualizado com sucesso"
+// @Failure 400 {object} utils.Response "Dados inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Cliente não encontrado"
+// @Router /customers/{id}/credit [put]
+func (h *CustomerCreditHandler) UpdateCredit(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	var req models.UpdateCustomerCreditRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, "Dados inválidos", err.Error())
+		return
+	}
+
+	userID, exists := utils.GetUserIDFromContext(c)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Usuário não autenticado", "")
+		return
+	}
+
+	credit, err := h.creditService.UpdateCredit(id, req, userID)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Cliente não encontrado", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao atualizar crédito", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Crédito atualizado com sucesso", credit, nil)
+}
+
+// GetStatement retorna o extrato financeiro de um cliente
+// @Summary Extrato do cliente
+// @Description Retorna os títulos a receber em aberto, a exposição, o crédito disponível e o aging por faixa de atraso
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do cliente"
+// @Success 200 {object} utils.Response{data=dto.ApiCustomerStatement} "Extrato gerado com sucesso"
+// @Failure 400 {object} utils.Response "ID inválido"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Cliente não encontrado"
+// @Router /customers/{id}/statement [get]
+func (h *CustomerCreditHandler) GetStatement(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	statement, err := h.creditService.GetStatement(id)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Cliente não encontrado", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao gerar extrato", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Extrato gerado com sucesso", statement, nil)
+}
+
+// CheckCredit avalia uma venda a prazo para o cliente
+// @Summary Análise de crédito
+// @Description Avalia se uma venda a prazo do valor informado é aprovada, recusada ou precisa de aprovação do gerente
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do cliente"
+// @Param request body models.CreditCheckRequest true "Valor da venda"
+// @Success 200 {object} utils.Response{data=dto.ApiCreditDecision} "Análise de crédito concluída"
+// @Failure 400 {object} utils.Response "Dados inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Cliente não encontrado"
+// @Router /customers/{id}/credit/check [post]
+func (h *CustomerCreditHandler) CheckCredit(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	var req models.CreditCheckRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, "Dados inválidos", err.Error())
+		return
+	}
+
+	decision, err := h.creditService.AuthorizeCreditSale(id, req.Amount)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Cliente não encontrado", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Erro na análise de crédito", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Análise de crédito concluída", decision, nil)
+}
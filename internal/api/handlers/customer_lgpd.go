@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"simple-erp-service/config"
+	"simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/service"
+	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/utils/path"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// CustomerLGPDHandler gerencia as solicitações dos titulares de dados (LGPD) de clientes
+type CustomerLGPDHandler struct {
+	lgpdService *service.LGPDService
+}
+
+// NewCustomerLGPDHandler cria um novo handler de LGPD de clientes
+func NewCustomerLGPDHandler(db *gorm.DB, cfg config.LGPDConfig) *CustomerLGPDHandler {
+	customerRepo := repository.NewCustomerRepository(db)
+	systemLogRepo := repository.NewSystemLogRepository(db)
+
+	return &CustomerLGPDHandler{
+		lgpdService: service.NewLGPDService(customerRepo, systemLogRepo, cfg),
+	}
+}
+
+// ExportCustomerData exporta todos os dados mantidos sobre o cliente
+// @Summary Exportar dados do titular (LGPD)
+// @Description Exporta cadastro, endereços, contatos, documentos, vendas, lançamentos e registros de auditoria do cliente.
+// @Description Com format=zip o pacote é baixado como um arquivo ZIP com um JSON por seção. Toda exportação é registrada na auditoria.
+// @Tags customers
+// @Accept json
+// @Produce json,application/zip
+// @Security ApiKeyAuth
+// @Param id path int true "ID do cliente"
+// @Param format query string false "Formato do pacote" Enums(json, zip) default(json)
+// @Success 200 {object} utils.Response{data=dto.ApiCustomerDataExport} "Dados exportados com sucesso"
+// @Failure 400 {object} utils.Response "Dados inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Cliente não encontrado"
+// @Router /customers/{id}/lgpd/export [get]
+func (h *CustomerLGPDHandler) ExportCustomerData(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	var params dto.InCustomerDataExport
+	if err := utils.BindQueryOrSendErrorRes(c, &params); err != nil {
+		return
+	}
+	if params.Format == "" {
+		params.Format = "json"
+	}
+
+	userID, exists := utils.GetUserIDFromContext(c)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Usuário não autenticado", "")
+		return
+	}
+
+	export, err := h.lgpdService.ExportCustomerData(id, params.Format, userID, c.ClientIP())
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Cliente não encontrado", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao exportar dados do cliente", err.Error())
+		}
+		return
+	}
+
+	if params.Format == "zip" {
+		archive, err := service.CustomerDataArchive(export)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao gerar pacote ZIP", err.Error())
+			return
+		}
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="cliente-%d-dados.zip"`, id))
+		c.Data(http.StatusOK, "application/zip", archive)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Dados exportados com sucesso", export, nil)
+}
+
+// AnonymizeCustomer anonimiza de forma irreversível os dados pessoais do cliente
+// @Summary Anonimizar titular (LGPD)
+// @Description Apaga definitivamente nome, observações, endereços, contatos e documentos do cliente, mantendo vendas e lançamentos.
+// @Description Também limpa os textos livres que citam o cliente: consultas registradas na auditoria, erros de importação e
+// @Description observações das vendas e dos eventos dos números de série.
+// @Description O CPF/CNPJ é mantido enquanto durar a guarda obrigatória dos registros fiscais; depois do prazo, uma nova
+// @Description anonimização o remove. A operação é registrada na auditoria e não pode ser desfeita.
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do cliente"
+// @Success 200 {object} utils.Response{data=dto.ApiCustomerAnonymization} "Cliente anonimizado com sucesso"
+// @Failure 400 {object} utils.Response "ID inválido"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Cliente não encontrado"
+// @Failure 409 {object} utils.Response "Cliente já anonimizado"
+// @Router /customers/{id}/lgpd/anonymize [post]
+func (h *CustomerLGPDHandler) AnonymizeCustomer(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	userID, exists := utils.GetUserIDFromContext(c)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Usuário não autenticado", "")
+		return
+	}
+
+	result, err := h.lgpdService.AnonymizeCustomer(id, userID, c.ClientIP())
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Cliente não encontrado", err.Error())
+		} else if err == service.ErrAlreadyAnonymized {
+			utils.ErrorResponse(c, http.StatusConflict, "Cliente já anonimizado", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao anonimizar cliente", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Cliente anonimizado com sucesso", result, nil)
+}
@@ -4,10 +4,12 @@ import (
 	"net/http"
 	"strconv"
 
+	"simple-erp-service/internal/data-structure/dto"
 	"simple-erp-service/internal/data-structure/models"
 	"simple-erp-service/internal/repository"
 	"simple-erp-service/internal/service"
 	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/utils/path"
 	"simple-erp-service/internal/validator"
 
 	"github.com/gin-gonic/gin"
@@ -30,7 +32,7 @@ func NewCustomerHandler(db *gorm.DB) *CustomerHandler {
 
 // GetCustomers retorna uma lista paginada de clientes
 // @Summary Listar clientes
-// @Description Retorna uma lista paginada de clientes
+// @Description Retorna uma lista paginada de clientes, com busca sem acentos e filtros
 // @Tags customers
 // @Accept json
 // @Produce json
@@ -39,14 +41,28 @@ func NewCustomerHandler(db *gorm.DB) *CustomerHandler {
 // @Param limit query int false "Limite de itens por página" default(10)
 // @Param sort query string false "Campo para ordenação" default(id)
 // @Param order query string false "Direção da ordenação (asc/desc)" default(asc)
+// @Param search query string false "Busca em nome, razão social, documento e contatos"
+// @Param personType query string false "Tipo de pessoa (F/J)"
+// @Param isActive query bool false "Somente ativos (true) ou inativos (false)"
+// @Param cityId query int false "ID da cidade de algum endereço"
+// @Param stateId query int false "ID do estado de algum endereço"
+// @Param uf query string false "UF de algum endereço (ex: SP)"
+// @Param createdFrom query string false "Criado a partir de (AAAA-MM-DD)"
+// @Param createdTo query string false "Criado até (AAAA-MM-DD, inclusive)"
 // @Success 200 {object} utils.Response "Clientes encontrados"
+// @Failure 400 {object} utils.Response "Filtros inválidos"
 // @Failure 401 {object} utils.Response "Não autorizado"
 // @Failure 500 {object} utils.Response "Erro ao buscar clientes"
 // @Router /customers [get]
 func (h *CustomerHandler) GetCustomers(c *gin.Context) {
 	pagination := utils.GetPaginationParams(c)
 
-	customers, err := h.customerService.GetCustomers(&pagination)
+	var filters dto.InGetPartiesFilters
+	if err := utils.BindQueryOrSendErrorRes(c, &filters); err != nil {
+		return
+	}
+
+	customers, err := h.customerService.GetCustomers(&pagination, filters)
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar clientes", err.Error())
 		return
@@ -118,7 +134,7 @@ func (h *CustomerHandler) CreateCustomer(c *gin.Context) {
 	customer, err := h.customerService.CreateCustomer(req, userID)
 	if err != nil {
 		if validator.IsValidationError(err) {
-			utils.ValidationErrorResponse(c, "Dados inválidos", err.Error())
+			utils.ValidationErrorResponse(c, "Dados inválidos", validator.GetValidationErrors(err))
 		} else {
 			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao criar cliente", err.Error())
 		}
@@ -160,7 +176,7 @@ func (h *CustomerHandler) UpdateCustomer(c *gin.Context) {
 		if err == utils.ErrNotFound {
 			utils.ErrorResponse(c, http.StatusNotFound, "Cliente não encontrado", err.Error())
 		} else if validator.IsValidationError(err) {
-			utils.ValidationErrorResponse(c, "Dados inválidos", err.Error())
+			utils.ValidationErrorResponse(c, "Dados inválidos", validator.GetValidationErrors(err))
 		} else {
 			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao atualizar cliente", err.Error())
 		}
@@ -201,3 +217,86 @@ func (h *CustomerHandler) DeleteCustomer(c *gin.Context) {
 
 	utils.SuccessResponse(c, http.StatusOK, "Cliente excluído com sucesso", nil, nil)
 }
+
+// GetDuplicateCustomers retorna os pares de clientes possivelmente duplicados
+// @Summary Buscar clientes duplicados
+// @Description Compara nomes (sem acentos, por similaridade), contatos normalizados e endereços (CEP e número)
+// @Description e retorna os pares com nota igual ou acima da mínima, do mais para o menos provável
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param minScore query number false "Nota mínima do par, de 0 a 1" default(0.5)
+// @Param limit query int false "Quantidade máxima de pares" default(50)
+// @Success 200 {object} utils.Response{data=[]dto.ApiCustomerDuplicate} "Possíveis duplicados encontrados"
+// @Failure 400 {object} utils.Response "Parâmetros inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Router /customers/duplicates [get]
+func (h *CustomerHandler) GetDuplicateCustomers(c *gin.Context) {
+	var params dto.InGetCustomerDuplicates
+	if err := utils.BindQueryOrSendErrorRes(c, &params); err != nil {
+		return
+	}
+	if params.MinScore == 0 {
+		params.MinScore = service.DefaultDuplicateScore
+	}
+	if params.Limit == 0 {
+		params.Limit = service.DefaultDuplicateResults
+	}
+
+	duplicates, err := h.customerService.FindDuplicateCustomers(params.MinScore, params.Limit)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar clientes duplicados", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Possíveis duplicados encontrados", duplicates, nil)
+}
+
+// MergeCustomer mescla um cliente duplicado no cliente informado na rota
+// @Summary Mesclar clientes
+// @Description Transfere vendas, lançamentos, endereços, contatos e documentos do duplicado para o cliente da rota,
+// @Description exclui o duplicado (soft delete) e registra a mesclagem no log de auditoria, em uma única transação
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do cliente que será mantido"
+// @Param request body models.MergeCustomerRequest true "Cliente duplicado que será incorporado"
+// @Success 200 {object} utils.Response{data=dto.ApiCustomerMergeResult} "Clientes mesclados com sucesso"
+// @Failure 400 {object} utils.Response "Dados inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Cliente não encontrado"
+// @Router /customers/{id}/merge [post]
+func (h *CustomerHandler) MergeCustomer(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	var req models.MergeCustomerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, "Dados inválidos", err.Error())
+		return
+	}
+
+	userID, exists := utils.GetUserIDFromContext(c)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Usuário não autenticado", "")
+		return
+	}
+
+	result, err := h.customerService.MergeCustomers(id, req, userID, c.ClientIP())
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Cliente não encontrado", err.Error())
+		} else if err == utils.ErrInvalidInput {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Um cliente não pode ser mesclado com ele mesmo", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao mesclar clientes", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Clientes mesclados com sucesso", result, nil)
+}
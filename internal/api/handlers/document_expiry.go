@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"net/http"
+
+	"simple-erp-service/config"
+	"simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/service"
+	"simple-erp-service/internal/utils"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// DocumentExpiryHandler gerencia as consultas de validade dos documentos de clientes e fornecedores
+type DocumentExpiryHandler struct {
+	expiryService *service.DocumentExpiryService
+}
+
+// NewDocumentExpiryHandler cria um novo handler de validade de documentos
+func NewDocumentExpiryHandler(db *gorm.DB, cfg config.DocumentsConfig) *DocumentExpiryHandler {
+	documentRepo := repository.NewDocumentRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+
+	return &DocumentExpiryHandler{
+		expiryService: service.NewDocumentExpiryService(documentRepo, userRepo, notificationRepo, cfg),
+	}
+}
+
+// GetExpiringDocuments retorna os documentos vencidos e a vencer
+// @Summary Listar documentos vencidos e a vencer
+// @Description Retorna os documentos de clientes e fornecedores vencidos ou que vencem nos próximos dias,
+// @Description do vencimento mais antigo para o mais recente. days_remaining é negativo para os vencidos.
+// @Tags documents
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param days query int false "Vencendo nos próximos N dias (padrão: DOCUMENT_EXPIRY_WARNING_DAYS)"
+// @Param ownerType query string false "Somente de clientes ou de fornecedores" Enums(customer, supplier)
+// @Param mandatoryOnly query bool false "Somente documentos obrigatórios"
+// @Success 200 {object} utils.Response{data=[]dto.ApiExpiringDocument} "Documentos encontrados"
+// @Failure 400 {object} utils.Response "Filtros inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 500 {object} utils.Response "Erro ao buscar documentos"
+// @Router /documents/expiring [get]
+func (h *DocumentExpiryHandler) GetExpiringDocuments(c *gin.Context) {
+	var filters dto.InGetExpiringDocumentsFilters
+	if err := utils.BindQueryOrSendErrorRes(c, &filters); err != nil {
+		return
+	}
+
+	documents, err := h.expiryService.GetExpiringDocuments(filters)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar documentos", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Documentos encontrados", documents, nil)
+}
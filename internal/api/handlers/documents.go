@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"net/http"
+
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/service"
+	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/validator"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// DocumentHandler gerencia as requisições de documentos de clientes ou fornecedores
+type DocumentHandler struct {
+	documentService *service.DocumentService
+	ownerType       string
+}
+
+// NewDocumentHandler cria um novo handler de documentos para o tipo de dono informado (cliente ou fornecedor)
+func NewDocumentHandler(db *gorm.DB, ownerType string) *DocumentHandler {
+	documentRepo := repository.NewDocumentRepository(db)
+	locationRepo := repository.NewLocationRepository(db)
+	customerRepo := repository.NewCustomerRepository(db)
+	supplierRepo := repository.NewSupplierRepository(db)
+
+	return &DocumentHandler{
+		documentService: service.NewDocumentService(documentRepo, locationRepo, customerRepo, supplierRepo),
+		ownerType:       ownerType,
+	}
+}
+
+// GetDocuments retorna os documentos de um cliente ou fornecedor
+// @Summary Listar documentos
+// @Description Retorna os documentos de um cliente ou fornecedor
+// @Tags documents
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do cliente ou fornecedor"
+// @Success 200 {object} utils.Response{data=[]dto.ApiDocument} "Documentos encontrados"
+// @Failure 400 {object} utils.Response "ID inválido"
+// @Failure 404 {object} utils.Response "Registro não encontrado"
+// @Router /customers/{id}/documents [get]
+// @Router /suppliers/{id}/documents [get]
+func (h *DocumentHandler) GetDocuments(c *gin.Context) {
+	owner, err := ownerFromPath(c, h.ownerType)
+	if err != nil {
+		return
+	}
+
+	documentes, err := h.documentService.GetDocuments(owner)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Registro não encontrado", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar documentos", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Documentos encontrados", documentes, nil)
+}
+
+// CreateDocument cria um documento para um cliente ou fornecedor
+// @Summary Criar documento
+// @Description Cria um documento validando as regras do tipo (CPF, CNPJ, RG, IE, IM, CNH, ALVARA, LICENCA, CERTIFICADO).
+// @Description Alvarás, licenças, certificados e documentos obrigatórios exigem a data de validade.
+// @Tags documents
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do cliente ou fornecedor"
+// @Param request body models.CreateDocumentRequest true "Dados do documento"
+// @Success 201 {object} utils.Response{data=dto.ApiDocument} "Documento criado com sucesso"
+// @Failure 400 {object} utils.Response "Dados inválidos"
+// @Failure 404 {object} utils.Response "Registro não encontrado"
+// @Router /customers/{id}/documents [post]
+// @Router /suppliers/{id}/documents [post]
+func (h *DocumentHandler) CreateDocument(c *gin.Context) {
+	owner, err := ownerFromPath(c, h.ownerType)
+	if err != nil {
+		return
+	}
+
+	var req models.CreateDocumentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, "Dados inválidos", err.Error())
+		return
+	}
+
+	document, err := h.documentService.CreateDocument(owner, req)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Registro não encontrado", err.Error())
+		} else if validator.IsValidationError(err) {
+			utils.ValidationErrorResponse(c, "Dados inválidos", validator.GetValidationErrors(err))
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao criar documento", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Documento criado com sucesso", document, nil)
+}
+
+// UpdateDocument atualiza um documento de um cliente ou fornecedor
+// @Summary Atualizar documento
+// @Description Atualiza um documento
+// @Tags documents
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do cliente ou fornecedor"
+// @Param documentId path int true "ID do documento"
+// @Param request body models.UpdateDocumentRequest true "Dados do documento"
+// @Success 200 {object} utils.Response{data=dto.ApiDocument} "Documento atualizado com sucesso"
+// @Failure 400 {object} utils.Response "Dados inválidos"
+// @Failure 404 {object} utils.Response "Documento não encontrado"
+// @Router /customers/{id}/documents/{documentId} [put]
+// @Router /suppliers/{id}/documents/{documentId} [put]
+func (h *DocumentHandler) UpdateDocument(c *gin.Context) {
+	owner, err := ownerFromPath(c, h.ownerType)
+	if err != nil {
+		return
+	}
+	documentID, err := childIDFromPath(c, "documentId")
+	if err != nil {
+		return
+	}
+
+	var req models.UpdateDocumentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, "Dados inválidos", err.Error())
+		return
+	}
+
+	document, err := h.documentService.UpdateDocument(owner, documentID, req)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Documento não encontrado", err.Error())
+		} else if validator.IsValidationError(err) {
+			utils.ValidationErrorResponse(c, "Dados inválidos", validator.GetValidationErrors(err))
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao atualizar documento", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Documento atualizado com sucesso", document, nil)
+}
+
+// DeleteDocument exclui um documento de um cliente ou fornecedor
+// @Summary Excluir documento
+// @Description Exclui um documento
+// @Tags documents
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do cliente ou fornecedor"
+// @Param documentId path int true "ID do documento"
+// @Success 200 {object} utils.Response "Documento excluído com sucesso"
+// @Failure 400 {object} utils.Response "ID inválido"
+// @Failure 404 {object} utils.Response "Documento não encontrado"
+// @Router /customers/{id}/documents/{documentId} [delete]
+// @Router /suppliers/{id}/documents/{documentId} [delete]
+func (h *DocumentHandler) DeleteDocument(c *gin.Context) {
+	owner, err := ownerFromPath(c, h.ownerType)
+	if err != nil {
+		return
+	}
+	documentID, err := childIDFromPath(c, "documentId")
+	if err != nil {
+		return
+	}
+
+	if err := h.documentService.DeleteDocument(owner, documentID); err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Documento não encontrado", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao excluir documento", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Documento excluído com sucesso", nil, nil)
+}
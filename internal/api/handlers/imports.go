@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/service"
+	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/utils/path"
+	"simple-erp-service/internal/utils/spreadsheet"
+	"simple-erp-service/internal/validator"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// importMaxFileSize é o tamanho máximo aceito para a planilha enviada (10 MB)
+const importMaxFileSize = 10 << 20
+
+// ImportHandler gerencia as requisições de importação em lote de clientes ou fornecedores
+type ImportHandler struct {
+	importService *service.ImportService
+	entity        string
+}
+
+// NewImportHandler cria um novo handler de importação para o tipo informado (cliente ou fornecedor)
+func NewImportHandler(db *gorm.DB, entity string) *ImportHandler {
+	jobRepo := repository.NewImportJobRepository(db)
+	customerRepo := repository.NewCustomerRepository(db)
+	supplierRepo := repository.NewSupplierRepository(db)
+
+	return &ImportHandler{
+		importService: service.NewImportService(jobRepo, customerRepo, supplierRepo),
+		entity:        entity,
+	}
+}
+
+// readSheetOrSendError lê a planilha enviada no campo "file", respondendo com BadRequest no caso de erro
+func readSheetOrSendError(c *gin.Context) (*spreadsheet.Sheet, string, bool) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Arquivo não enviado", err.Error())
+		return nil, "", false
+	}
+	if fileHeader.Size > importMaxFileSize {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Arquivo muito grande", "o tamanho máximo é de 10 MB")
+		return nil, "", false
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao abrir o arquivo", err.Error())
+		return nil, "", false
+	}
+	defer file.Close()
+
+	sheet, err := spreadsheet.Read(fileHeader.Filename, file, fileHeader.Size)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao ler a planilha", err.Error())
+		return nil, "", false
+	}
+
+	return sheet, fileHeader.Filename, true
+}
+
+// PreviewImport lê a planilha e devolve os cabeçalhos, uma amostra das linhas e a sugestão de mapeamento
+// @Summary Pré-visualizar importação
+// @Description Primeira etapa da importação: lê o CSV/XLSX e sugere o mapeamento entre colunas e campos
+// @Tags imports
+// @Accept multipart/form-data
+// @Produce json
+// @Security ApiKeyAuth
+// @Param file formData file true "Planilha CSV ou XLSX (a primeira linha deve ser o cabeçalho)"
+// @Success 200 {object} utils.Response{data=dto.ApiImportPreview} "Planilha lida com sucesso"
+// @Failure 400 {object} utils.Response "Arquivo inválido"
+// @Router /customers/import/preview [post]
+// @Router /suppliers/import/preview [post]
+func (h *ImportHandler) PreviewImport(c *gin.Context) {
+	sheet, fileName, ok := readSheetOrSendError(c)
+	if !ok {
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Planilha lida com sucesso", h.importService.Preview(fileName, sheet), nil)
+}
+
+// Import valida a planilha (dry_run=true, padrão) ou inicia a gravação em segundo plano (dry_run=false)
+// @Summary Importar planilha
+// @Description Com dry_run=true apenas valida as linhas e devolve o relatório de erros por linha.
+// @Description Com dry_run=false cria um job que grava as linhas válidas em lotes; acompanhe por GET /imports/{id}.
+// @Tags imports
+// @Accept multipart/form-data
+// @Produce json
+// @Security ApiKeyAuth
+// @Param file formData file true "Planilha CSV ou XLSX"
+// @Param mapping formData string true "Mapeamento campo -> coluna em JSON (ex: {\"first_name\":\"Nome\",\"document_number\":\"CPF\"})"
+// @Param dry_run formData bool false "Somente validar, sem gravar" default(true)
+// @Success 200 {object} utils.Response{data=dto.ApiImportReport} "Simulação concluída"
+// @Success 202 {object} utils.Response{data=dto.ApiImportJob} "Importação iniciada"
+// @Failure 400 {object} utils.Response "Dados inválidos"
+// @Router /customers/import [post]
+// @Router /suppliers/import [post]
+func (h *ImportHandler) Import(c *gin.Context) {
+	userID, exists := utils.GetUserIDFromContext(c)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Usuário não autenticado", "")
+		return
+	}
+
+	var mapping map[string]string
+	if err := json.Unmarshal([]byte(c.PostForm("mapping")), &mapping); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Mapeamento de colunas inválido", err.Error())
+		return
+	}
+
+	dryRun := true
+	if value := c.PostForm("dry_run"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Valor de dry_run inválido", err.Error())
+			return
+		}
+		dryRun = parsed
+	}
+
+	sheet, fileName, ok := readSheetOrSendError(c)
+	if !ok {
+		return
+	}
+
+	if dryRun {
+		report, err := h.importService.DryRun(h.entity, sheet, mapping)
+		if err != nil {
+			h.sendImportError(c, err)
+			return
+		}
+		utils.SuccessResponse(c, http.StatusOK, "Simulação concluída", report, nil)
+		return
+	}
+
+	job, err := h.importService.StartImport(h.entity, fileName, sheet, mapping, userID)
+	if err != nil {
+		h.sendImportError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusAccepted, "Importação iniciada", job, nil)
+}
+
+// GetImportJob retorna o andamento de uma importação iniciada pelo usuário autenticado
+// @Summary Acompanhar importação
+// @Description Retorna o progresso e os erros por linha de uma importação em segundo plano
+// @Tags imports
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID da importação"
+// @Success 200 {object} utils.Response{data=dto.ApiImportJob} "Importação encontrada"
+// @Failure 400 {object} utils.Response "ID inválido"
+// @Failure 404 {object} utils.Response "Importação não encontrada"
+// @Router /imports/{id} [get]
+func (h *ImportHandler) GetImportJob(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	userID, exists := utils.GetUserIDFromContext(c)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Usuário não autenticado", "")
+		return
+	}
+
+	job, err := h.importService.GetJob(id, userID)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Importação não encontrada", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar importação", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Importação encontrada", job, nil)
+}
+
+func (h *ImportHandler) sendImportError(c *gin.Context, err error) {
+	if validator.IsValidationError(err) {
+		utils.ValidationErrorResponse(c, "Dados inválidos", validator.GetValidationErrors(err))
+	} else {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao importar planilha", err.Error())
+	}
+}
@@ -0,0 +1,487 @@
+package handlers
+
+import (
+	"net/http"
+
+	"simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/service"
+	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/utils/path"
+	"simple-erp-service/internal/validator"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// InventoryHandler gerencia as requisições de locais de estoque, saldos por local, transferências, lotes,
+// números de série e valorização do estoque
+type InventoryHandler struct {
+	locationService  *service.StockLocationService
+	lotService       *service.StockLotService
+	serialService    *service.SerialNumberService
+	valuationService *service.InventoryValuationService
+}
+
+// NewInventoryHandler cria um novo handler de estoque
+func NewInventoryHandler(db *gorm.DB) *InventoryHandler {
+	locationRepo := repository.NewStockLocationRepository(db)
+	productRepo := repository.NewProductRepository(db)
+	lotRepo := repository.NewStockLotRepository(db)
+	customerRepo := repository.NewCustomerRepository(db)
+	serialRepo := repository.NewSerialNumberRepository(db)
+	supplierRepo := repository.NewSupplierRepository(db)
+	movementRepo := repository.NewInventoryMovementRepository(db)
+
+	return &InventoryHandler{
+		locationService:  service.NewStockLocationService(locationRepo, productRepo),
+		lotService:       service.NewStockLotService(lotRepo, productRepo, customerRepo),
+		serialService:    service.NewSerialNumberService(serialRepo, supplierRepo, customerRepo),
+		valuationService: service.NewInventoryValuationService(movementRepo),
+	}
+}
+
+// GetLocations lista os locais de estoque
+// @Summary Listar locais de estoque
+// @Description Retorna os depósitos e os seus endereços ordenados pelo código
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param isActive query bool false "Somente ativos (true) ou inativos (false)"
+// @Param parentId query int false "Somente os endereços do depósito"
+// @Success 200 {object} utils.Response{data=[]dto.ApiStockLocation} "Locais encontrados"
+// @Failure 400 {object} utils.Response "Filtros inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 500 {object} utils.Response "Erro ao buscar locais"
+// @Router /inventory/locations [get]
+func (h *InventoryHandler) GetLocations(c *gin.Context) {
+	var filters dto.InGetStockLocationsFilters
+	if err := utils.BindQueryOrSendErrorRes(c, &filters); err != nil {
+		return
+	}
+
+	locations, err := h.locationService.GetLocations(filters)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar locais", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Locais encontrados", locations, nil)
+}
+
+// GetLocation retorna um local de estoque específico
+// @Summary Buscar local de estoque
+// @Description Retorna um depósito ou endereço
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do local"
+// @Success 200 {object} utils.Response{data=dto.ApiStockLocation} "Local encontrado"
+// @Failure 400 {object} utils.Response "ID inválido"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Local não encontrado"
+// @Router /inventory/locations/{id} [get]
+func (h *InventoryHandler) GetLocation(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	location, err := h.locationService.GetLocationByID(id)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Local não encontrado", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar local", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Local encontrado", location, nil)
+}
+
+// CreateLocation cadastra um local de estoque
+// @Summary Criar local de estoque
+// @Description Cadastra um depósito ou, com parent_id, um endereço dentro do depósito. Um novo local padrão
+// @Description substitui o anterior nas movimentações sem local informado.
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body models.CreateStockLocationRequest true "Dados do local"
+// @Success 201 {object} utils.Response{data=dto.ApiStockLocation} "Local criado com sucesso"
+// @Failure 400 {object} utils.Response "Dados inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Router /inventory/locations [post]
+func (h *InventoryHandler) CreateLocation(c *gin.Context) {
+	var req models.CreateStockLocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, "Dados inválidos", err.Error())
+		return
+	}
+
+	location, err := h.locationService.CreateLocation(req)
+	if err != nil {
+		if validator.IsValidationError(err) {
+			utils.ValidationErrorResponse(c, "Dados inválidos", validator.GetValidationErrors(err))
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao criar local", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Local criado com sucesso", location, nil)
+}
+
+// UpdateLocation atualiza um local de estoque
+// @Summary Atualizar local de estoque
+// @Description Atualiza um local de estoque. Locais com saldo não podem ser desativados, e o local padrão só deixa
+// @Description de sê-lo quando outro local é marcado como padrão.
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do local"
+// @Param request body models.UpdateStockLocationRequest true "Dados do local"
+// @Success 200 {object} utils.Response{data=dto.ApiStockLocation} "Local atualizado com sucesso"
+// @Failure 400 {object} utils.Response "Dados inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Local não encontrado"
+// @Router /inventory/locations/{id} [put]
+func (h *InventoryHandler) UpdateLocation(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	var req models.UpdateStockLocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, "Dados inválidos", err.Error())
+		return
+	}
+
+	location, err := h.locationService.UpdateLocation(id, req)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Local não encontrado", err.Error())
+		} else if validator.IsValidationError(err) {
+			utils.ValidationErrorResponse(c, "Dados inválidos", validator.GetValidationErrors(err))
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao atualizar local", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Local atualizado com sucesso", location, nil)
+}
+
+// GetLocationBalances lista os saldos dos produtos no local
+// @Summary Saldos do local de estoque
+// @Description Retorna os produtos com saldo no local e as quantidades, na unidade de cada produto
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do local"
+// @Success 200 {object} utils.Response{data=[]dto.ApiStockBalance} "Saldos encontrados"
+// @Failure 400 {object} utils.Response "ID inválido"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Local não encontrado"
+// @Router /inventory/locations/{id}/balances [get]
+func (h *InventoryHandler) GetLocationBalances(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	balances, err := h.locationService.GetLocationBalances(id)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Local não encontrado", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar saldos", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Saldos encontrados", balances, nil)
+}
+
+// GetTransfers retorna uma lista paginada de transferências de estoque
+// @Summary Listar transferências de estoque
+// @Description Retorna uma lista paginada de transferências entre locais, com filtros por produto, local e período
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "Número da página" default(1)
+// @Param limit query int false "Limite de itens por página" default(10)
+// @Param sort query string false "Campo para ordenação" default(created_at)
+// @Param order query string false "Direção da ordenação (asc/desc)" default(desc)
+// @Param productId query int false "ID do produto"
+// @Param locationId query int false "ID do local de origem ou de destino"
+// @Param dateFrom query string false "Transferências a partir de (AAAA-MM-DD)"
+// @Param dateTo query string false "Transferências até (AAAA-MM-DD, inclusive)"
+// @Success 200 {object} utils.Response{data=dto.ApiStockTransferListPaginated} "Transferências encontradas"
+// @Failure 400 {object} utils.Response "Filtros inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 500 {object} utils.Response "Erro ao buscar transferências"
+// @Router /inventory/transfers [get]
+func (h *InventoryHandler) GetTransfers(c *gin.Context) {
+	pagination := utils.GetPaginationParams(c)
+
+	var filters dto.InGetStockTransfersFilters
+	if err := utils.BindQueryOrSendErrorRes(c, &filters); err != nil {
+		return
+	}
+
+	transfers, err := h.locationService.GetTransfers(&pagination, filters)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar transferências", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Transferências encontradas", transfers, nil)
+}
+
+// CreateTransfer transfere estoque entre locais
+// @Summary Transferir estoque
+// @Description Transfere estoque de um produto entre dois locais ativos, registrando a saída na origem e a entrada
+// @Description no destino. A quantidade não pode passar do saldo do produto na origem. Nos produtos com controle de
+// @Description lotes sem lote informado, saem os lotes da origem por ordem de validade. Nos produtos com número de
+// @Description série, informe os números transferidos.
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body models.CreateStockTransferRequest true "Produto, locais e quantidade"
+// @Success 201 {object} utils.Response{data=dto.ApiStockTransfer} "Transferência registrada com sucesso"
+// @Failure 400 {object} utils.Response "Dados inválidos ou saldo insuficiente"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Router /inventory/transfers [post]
+func (h *InventoryHandler) CreateTransfer(c *gin.Context) {
+	var req models.CreateStockTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, "Dados inválidos", err.Error())
+		return
+	}
+
+	userID, exists := utils.GetUserIDFromContext(c)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Usuário não autenticado", "")
+		return
+	}
+
+	transfer, err := h.locationService.CreateTransfer(req, userID)
+	if err != nil {
+		if validator.IsValidationError(err) {
+			utils.ValidationErrorResponse(c, "Dados inválidos", validator.GetValidationErrors(err))
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao transferir estoque", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Transferência registrada com sucesso", transfer, nil)
+}
+
+// GetLot retorna um lote específico
+// @Summary Buscar lote
+// @Description Retorna um lote com as datas de fabricação e validade, a origem e os saldos por local de estoque
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do lote"
+// @Success 200 {object} utils.Response{data=dto.ApiStockLot} "Lote encontrado"
+// @Failure 400 {object} utils.Response "ID inválido"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Lote não encontrado"
+// @Router /inventory/lots/{id} [get]
+func (h *InventoryHandler) GetLot(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	lot, err := h.lotService.GetLotByID(id)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Lote não encontrado", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar lote", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Lote encontrado", lot, nil)
+}
+
+// GetLotTraceability retorna a rastreabilidade de um lote
+// @Summary Rastreabilidade do lote
+// @Description Retorna os clientes que receberam o lote, com as quantidades, e as vendas em que ele foi entregue
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do lote"
+// @Success 200 {object} utils.Response{data=dto.ApiLotTraceability} "Rastreabilidade do lote"
+// @Failure 400 {object} utils.Response "ID inválido"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Lote não encontrado"
+// @Router /inventory/lots/{id}/traceability [get]
+func (h *InventoryHandler) GetLotTraceability(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	traceability, err := h.lotService.GetLotTraceability(id)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Lote não encontrado", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar rastreabilidade do lote", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Rastreabilidade do lote", traceability, nil)
+}
+
+// GetExpiringLots lista os lotes vencidos e a vencer
+// @Summary Lotes vencidos e a vencer
+// @Description Retorna os saldos dos lotes vencidos e dos que vencem nos próximos dias, por local de estoque, do que
+// @Description vence primeiro para o último
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param days query int false "Vencendo nos próximos N dias (padrão: 30)"
+// @Param locationId query int false "Somente os saldos no local"
+// @Success 200 {object} utils.Response{data=[]dto.ApiExpiringLot} "Lotes encontrados"
+// @Failure 400 {object} utils.Response "Filtros inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 500 {object} utils.Response "Erro ao buscar lotes"
+// @Router /inventory/lots/expiring [get]
+func (h *InventoryHandler) GetExpiringLots(c *gin.Context) {
+	var filters dto.InGetExpiringLotsFilters
+	if err := utils.BindQueryOrSendErrorRes(c, &filters); err != nil {
+		return
+	}
+
+	lots, err := h.lotService.GetExpiringLots(filters)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar lotes", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Lotes encontrados", lots, nil)
+}
+
+// GetSerialNumbers consulta os números de série
+// @Summary Consultar números de série
+// @Description Retorna uma lista paginada de números de série com a situação atual e a da garantia, contada a partir
+// @Description da data da venda. Use o filtro serial para localizar uma unidade.
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "Número da página" default(1)
+// @Param limit query int false "Limite de itens por página" default(10)
+// @Param sort query string false "Campo para ordenação" default(created_at)
+// @Param order query string false "Direção da ordenação (asc/desc)" default(desc)
+// @Param serial query string false "Número de série (busca parcial)"
+// @Param productId query int false "ID do produto"
+// @Param status query string false "Situação" Enums(em_estoque, vendido, devolvido)
+// @Param locationId query int false "Somente em estoque no local"
+// @Param customerId query int false "Somente vendidos ao cliente"
+// @Success 200 {object} utils.Response{data=dto.ApiSerialNumberListPaginated} "Números de série encontrados"
+// @Failure 400 {object} utils.Response "Filtros inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 500 {object} utils.Response "Erro ao buscar números de série"
+// @Router /inventory/serials [get]
+func (h *InventoryHandler) GetSerialNumbers(c *gin.Context) {
+	pagination := utils.GetPaginationParams(c)
+
+	var filters dto.InGetSerialNumbersFilters
+	if err := utils.BindQueryOrSendErrorRes(c, &filters); err != nil {
+		return
+	}
+
+	serials, err := h.serialService.GetSerialNumbers(&pagination, filters)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar números de série", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Números de série encontrados", serials, nil)
+}
+
+// GetSerialNumber retorna um número de série com o histórico
+// @Summary Buscar número de série
+// @Description Retorna um número de série com a garantia e o histórico: de qual fornecedor foi recebido, a qual
+// @Description cliente foi vendido, as transferências e as devoluções
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do número de série"
+// @Success 200 {object} utils.Response{data=dto.ApiSerialNumber} "Número de série encontrado"
+// @Failure 400 {object} utils.Response "ID inválido"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Número de série não encontrado"
+// @Router /inventory/serials/{id} [get]
+func (h *InventoryHandler) GetSerialNumber(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	serial, err := h.serialService.GetSerialNumberByID(id)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Número de série não encontrado", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar número de série", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Número de série encontrado", serial, nil)
+}
+
+// GetValuation retorna o relatório de valorização do estoque
+// @Summary Valorização do estoque
+// @Description Retorna o valor do estoque pelo custo médio ponderado no fim do dia informado, por categoria e local,
+// @Description com os totais de cada categoria e de cada local. Os saldos e os custos de datas passadas são
+// @Description reconstruídos pelas movimentações de estoque.
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param date query string false "Data da posição (AAAA-MM-DD, padrão: hoje)"
+// @Param categoryId query int false "Somente a categoria e as subcategorias"
+// @Param locationId query int false "Somente o local e os seus endereços"
+// @Success 200 {object} utils.Response{data=dto.ApiInventoryValuation} "Valorização calculada"
+// @Failure 400 {object} utils.Response "Filtros inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 500 {object} utils.Response "Erro ao calcular a valorização do estoque"
+// @Router /inventory/valuation [get]
+func (h *InventoryHandler) GetValuation(c *gin.Context) {
+	var filters dto.InGetInventoryValuationFilters
+	if err := utils.BindQueryOrSendErrorRes(c, &filters); err != nil {
+		return
+	}
+
+	valuation, err := h.valuationService.GetValuation(filters)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao calcular a valorização do estoque", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Valorização calculada", valuation, nil)
+}
@@ -0,0 +1,333 @@
+package handlers
+
+import (
+	"net/http"
+
+	"simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/service"
+	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/utils/path"
+	"simple-erp-service/internal/validator"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// InventoryCountHandler gerencia as requisições relacionadas a inventários (contagens físicas de estoque)
+type InventoryCountHandler struct {
+	countService *service.InventoryCountService
+}
+
+// NewInventoryCountHandler cria um novo handler de inventários
+func NewInventoryCountHandler(db *gorm.DB) *InventoryCountHandler {
+	countRepo := repository.NewInventoryCountRepository(db)
+	locationRepo := repository.NewStockLocationRepository(db)
+	categoryRepo := repository.NewProductCategoryRepository(db)
+
+	return &InventoryCountHandler{
+		countService: service.NewInventoryCountService(countRepo, locationRepo, categoryRepo),
+	}
+}
+
+// GetCounts retorna uma lista paginada de inventários
+// @Summary Listar inventários
+// @Description Retorna uma lista paginada de inventários, com filtros por situação, local e período
+// @Tags inventory-counts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "Número da página" default(1)
+// @Param limit query int false "Limite de itens por página" default(10)
+// @Param sort query string false "Campo para ordenação" default(created_at)
+// @Param order query string false "Direção da ordenação (asc/desc)" default(desc)
+// @Param status query string false "Situação do inventário" Enums(aberto, aprovado, cancelado)
+// @Param locationId query int false "ID do local inventariado"
+// @Param dateFrom query string false "Inventários abertos a partir de (AAAA-MM-DD)"
+// @Param dateTo query string false "Inventários abertos até (AAAA-MM-DD, inclusive)"
+// @Success 200 {object} utils.Response{data=dto.ApiInventoryCountListPaginated} "Inventários encontrados"
+// @Failure 400 {object} utils.Response "Filtros inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 500 {object} utils.Response "Erro ao buscar inventários"
+// @Router /inventory-counts [get]
+func (h *InventoryCountHandler) GetCounts(c *gin.Context) {
+	pagination := utils.GetPaginationParams(c)
+
+	var filters dto.InGetInventoryCountsFilters
+	if err := utils.BindQueryOrSendErrorRes(c, &filters); err != nil {
+		return
+	}
+
+	counts, err := h.countService.GetCounts(&pagination, filters)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar inventários", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Inventários encontrados", counts, nil)
+}
+
+// GetCount retorna um inventário específico
+// @Summary Buscar inventário
+// @Description Retorna um inventário com os itens a contar e as contagens de cada rodada. No inventário cego
+// @Description aberto, as quantidades esperadas e contadas não são exibidas.
+// @Tags inventory-counts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do inventário"
+// @Success 200 {object} utils.Response{data=dto.ApiInventoryCount} "Inventário encontrado"
+// @Failure 400 {object} utils.Response "ID inválido"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Inventário não encontrado"
+// @Router /inventory-counts/{id} [get]
+func (h *InventoryCountHandler) GetCount(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	count, err := h.countService.GetCountByID(id)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Inventário não encontrado", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar inventário", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Inventário encontrado", count, nil)
+}
+
+// CreateCount abre um inventário
+// @Summary Abrir inventário
+// @Description Abre um inventário do local (com os seus endereços), da categoria (com as subcategorias) ou dos dois,
+// @Description registrando o saldo esperado de cada produto por local e, nos produtos com controle de lotes, por
+// @Description lote. Produtos com número de série não entram no inventário.
+// @Tags inventory-counts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body models.CreateInventoryCountRequest true "Local, categoria e se o inventário é cego"
+// @Success 201 {object} utils.Response{data=dto.ApiInventoryCount} "Inventário aberto com sucesso"
+// @Failure 400 {object} utils.Response "Dados inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Router /inventory-counts [post]
+func (h *InventoryCountHandler) CreateCount(c *gin.Context) {
+	var req models.CreateInventoryCountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, "Dados inválidos", err.Error())
+		return
+	}
+
+	userID, exists := utils.GetUserIDFromContext(c)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Usuário não autenticado", "")
+		return
+	}
+
+	count, err := h.countService.CreateCount(req, userID)
+	if err != nil {
+		if validator.IsValidationError(err) {
+			utils.ValidationErrorResponse(c, "Dados inválidos", validator.GetValidationErrors(err))
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao abrir inventário", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Inventário aberto com sucesso", count, nil)
+}
+
+// SubmitCounts registra as quantidades contadas
+// @Summary Registrar contagens
+// @Description Registra as quantidades contadas pelo usuário na rodada atual de cada item; uma nova contagem do mesmo
+// @Description usuário na rodada substitui a anterior. Quando as contagens de contadores diferentes divergem, o item
+// @Description vai para recontagem em uma nova rodada.
+// @Tags inventory-counts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do inventário"
+// @Param request body models.SubmitInventoryCountRequest true "Itens e quantidades contadas"
+// @Success 200 {object} utils.Response{data=dto.ApiInventoryCount} "Contagens registradas com sucesso"
+// @Failure 400 {object} utils.Response "Dados inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Inventário não encontrado"
+// @Failure 409 {object} utils.Response "Inventário não está aberto"
+// @Router /inventory-counts/{id}/counts [post]
+func (h *InventoryCountHandler) SubmitCounts(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	var req models.SubmitInventoryCountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, "Dados inválidos", err.Error())
+		return
+	}
+
+	userID, exists := utils.GetUserIDFromContext(c)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Usuário não autenticado", "")
+		return
+	}
+
+	count, err := h.countService.SubmitCounts(id, req, userID)
+	if err != nil {
+		h.sendCountError(c, err, "Erro ao registrar contagens")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Contagens registradas com sucesso", count, nil)
+}
+
+// RecountItems pede a recontagem de itens
+// @Summary Pedir recontagem
+// @Description Envia os itens informados para uma nova rodada de contagem, descartando a quantidade apurada
+// @Tags inventory-counts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do inventário"
+// @Param request body models.RecountInventoryCountRequest true "Itens a recontar"
+// @Success 200 {object} utils.Response{data=dto.ApiInventoryCount} "Recontagem solicitada com sucesso"
+// @Failure 400 {object} utils.Response "Dados inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Inventário não encontrado"
+// @Failure 409 {object} utils.Response "Inventário não está aberto"
+// @Router /inventory-counts/{id}/recount [post]
+func (h *InventoryCountHandler) RecountItems(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	var req models.RecountInventoryCountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, "Dados inválidos", err.Error())
+		return
+	}
+
+	count, err := h.countService.RecountItems(id, req)
+	if err != nil {
+		h.sendCountError(c, err, "Erro ao solicitar recontagem")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Recontagem solicitada com sucesso", count, nil)
+}
+
+// ApproveCount aprova um inventário
+// @Summary Aprovar inventário
+// @Description Aprova um inventário com todos os itens contados. Cada diferença entre o contado e o esperado gera uma
+// @Description movimentação de ajuste no local (e no lote) do item, com o inventário como referência.
+// @Tags inventory-counts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do inventário"
+// @Success 200 {object} utils.Response{data=dto.ApiInventoryCount} "Inventário aprovado com sucesso"
+// @Failure 400 {object} utils.Response "Itens pendentes ou ajuste inválido"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Inventário não encontrado"
+// @Failure 409 {object} utils.Response "Inventário não está aberto"
+// @Router /inventory-counts/{id}/approve [post]
+func (h *InventoryCountHandler) ApproveCount(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	userID, exists := utils.GetUserIDFromContext(c)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Usuário não autenticado", "")
+		return
+	}
+
+	count, err := h.countService.ApproveCount(id, userID)
+	if err != nil {
+		h.sendCountError(c, err, "Erro ao aprovar inventário")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Inventário aprovado com sucesso", count, nil)
+}
+
+// CancelCount cancela um inventário aberto
+// @Summary Cancelar inventário
+// @Description Cancela um inventário aberto, sem ajustar o estoque
+// @Tags inventory-counts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do inventário"
+// @Success 200 {object} utils.Response{data=dto.ApiInventoryCount} "Inventário cancelado com sucesso"
+// @Failure 400 {object} utils.Response "ID inválido"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Inventário não encontrado"
+// @Failure 409 {object} utils.Response "Inventário não está aberto"
+// @Router /inventory-counts/{id}/cancel [post]
+func (h *InventoryCountHandler) CancelCount(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	count, err := h.countService.CancelCount(id)
+	if err != nil {
+		h.sendCountError(c, err, "Erro ao cancelar inventário")
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Inventário cancelado com sucesso", count, nil)
+}
+
+// GetVarianceReport retorna o relatório de diferenças do inventário
+// @Summary Relatório de diferenças do inventário
+// @Description Retorna as diferenças entre o contado e o esperado, valorizadas pelo custo dos produtos na abertura,
+// @Description com os itens ainda não apurados e os totais de sobras e faltas
+// @Tags inventory-counts
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do inventário"
+// @Success 200 {object} utils.Response{data=dto.ApiInventoryCountVarianceReport} "Relatório de diferenças gerado"
+// @Failure 400 {object} utils.Response "ID inválido"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Inventário não encontrado"
+// @Router /inventory-counts/{id}/variances [get]
+func (h *InventoryCountHandler) GetVarianceReport(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	report, err := h.countService.GetVarianceReport(id)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Inventário não encontrado", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao gerar relatório de diferenças", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Relatório de diferenças gerado", report, nil)
+}
+
+// sendCountError envia a resposta de erro das operações sobre um inventário
+func (h *InventoryCountHandler) sendCountError(c *gin.Context, err error, message string) {
+	if err == utils.ErrNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Inventário não encontrado", err.Error())
+	} else if err == service.ErrInventoryCountNotOpen {
+		utils.ErrorResponse(c, http.StatusConflict, "Inventário não está aberto", err.Error())
+	} else if validator.IsValidationError(err) {
+		utils.ValidationErrorResponse(c, "Dados inválidos", validator.GetValidationErrors(err))
+	} else {
+		utils.ErrorResponse(c, http.StatusBadRequest, message, err.Error())
+	}
+}
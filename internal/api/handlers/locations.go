@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"net/http"
+
+	"simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/service"
+	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/utils/path"
+	"simple-erp-service/internal/validator"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// LocationHandler gerencia as consultas de países, estados e cidades
+type LocationHandler struct {
+	locationService   *service.LocationService
+	postalCodeService *service.PostalCodeService
+}
+
+// NewLocationHandler cria um novo handler de dados geográficos
+func NewLocationHandler(db *gorm.DB) *LocationHandler {
+	locationRepo := repository.NewLocationRepository(db)
+	postalCodeRepo := repository.NewPostalCodeRepository(db)
+
+	// A consulta de CEPs usa somente a base local; um provedor remoto pode ser trocado aqui
+	postalCodeProvider := service.NewLocalPostalCodeProvider(postalCodeRepo)
+
+	return &LocationHandler{
+		locationService:   service.NewLocationService(locationRepo),
+		postalCodeService: service.NewPostalCodeService(postalCodeProvider, locationRepo, postalCodeRepo),
+	}
+}
+
+// GetCountries retorna os países cadastrados
+// @Summary Listar países
+// @Description Retorna os países cadastrados em ordem alfabética
+// @Tags locations
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} utils.Response{data=[]dto.ApiCountry} "Países encontrados"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 500 {object} utils.Response "Erro ao buscar países"
+// @Router /locations/countries [get]
+func (h *LocationHandler) GetCountries(c *gin.Context) {
+	countries, err := h.locationService.GetCountries()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar países", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Países encontrados", countries, nil)
+}
+
+// GetStates retorna os estados de um país
+// @Summary Listar estados do país
+// @Description Retorna os estados do país em ordem alfabética
+// @Tags locations
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do país"
+// @Success 200 {object} utils.Response{data=[]dto.ApiState} "Estados encontrados"
+// @Failure 400 {object} utils.Response "ID inválido"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "País não encontrado"
+// @Router /locations/countries/{id}/states [get]
+func (h *LocationHandler) GetStates(c *gin.Context) {
+	countryID, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	states, err := h.locationService.GetStates(countryID)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "País não encontrado", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar estados", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Estados encontrados", states, nil)
+}
+
+// GetCities retorna as cidades de um estado
+// @Summary Listar cidades do estado
+// @Description Retorna as cidades do estado em ordem alfabética, com busca pelo nome sem acentos
+// @Tags locations
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do estado"
+// @Param search query string false "Parte do nome da cidade"
+// @Param limit query int false "Quantidade máxima de cidades (1 a 1000)"
+// @Success 200 {object} utils.Response{data=[]dto.ApiCity} "Cidades encontradas"
+// @Failure 400 {object} utils.Response "Filtros inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Estado não encontrado"
+// @Router /locations/states/{id}/cities [get]
+func (h *LocationHandler) GetCities(c *gin.Context) {
+	stateID, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	var filters dto.InGetCitiesFilters
+	if err := utils.BindQueryOrSendErrorRes(c, &filters); err != nil {
+		return
+	}
+
+	cities, err := h.locationService.GetCities(stateID, filters)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Estado não encontrado", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar cidades", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Cidades encontradas", cities, nil)
+}
+
+// GetPostalCode retorna o endereço de um CEP
+// @Summary Consultar CEP
+// @Description Retorna o logradouro, o bairro e a cidade cadastrada do CEP, a partir da base local importada.
+// @Description city_id é nulo quando o município do CEP não está cadastrado.
+// @Tags locations
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param zipCode path string true "CEP, com ou sem máscara"
+// @Success 200 {object} utils.Response{data=dto.ApiPostalCode} "CEP encontrado"
+// @Failure 400 {object} utils.Response "CEP inválido"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "CEP não encontrado"
+// @Router /locations/postal-codes/{zipCode} [get]
+func (h *LocationHandler) GetPostalCode(c *gin.Context) {
+	address, err := h.postalCodeService.Lookup(c.Request.Context(), c.Param("zipCode"))
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "CEP não encontrado", err.Error())
+		} else if validator.IsValidationError(err) {
+			utils.ValidationErrorResponse(c, "Dados inválidos", validator.GetValidationErrors(err))
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao consultar CEP", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "CEP encontrado", address, nil)
+}
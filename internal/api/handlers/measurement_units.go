@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"net/http"
+
+	"simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/service"
+	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/utils/path"
+	"simple-erp-service/internal/validator"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// MeasurementUnitHandler gerencia as requisições de unidades de medida e conversões
+type MeasurementUnitHandler struct {
+	unitService *service.MeasurementUnitService
+}
+
+// NewMeasurementUnitHandler cria um novo handler de unidades de medida
+func NewMeasurementUnitHandler(db *gorm.DB) *MeasurementUnitHandler {
+	unitRepo := repository.NewMeasurementUnitRepository(db)
+	productRepo := repository.NewProductRepository(db)
+
+	return &MeasurementUnitHandler{
+		unitService: service.NewMeasurementUnitService(unitRepo, productRepo),
+	}
+}
+
+// GetUnits lista as unidades de medida
+// @Summary Listar unidades de medida
+// @Description Retorna todas as unidades de medida ordenadas pelo nome
+// @Tags measurement-units
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} utils.Response{data=[]dto.ApiMeasurementUnit} "Unidades encontradas"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 500 {object} utils.Response "Erro ao buscar unidades"
+// @Router /measurement-units [get]
+func (h *MeasurementUnitHandler) GetUnits(c *gin.Context) {
+	units, err := h.unitService.GetUnits()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar unidades", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Unidades encontradas", units, nil)
+}
+
+// GetConversions lista as conversões de unidades
+// @Summary Listar conversões de unidades
+// @Description Retorna as conversões globais ou, com productId, as conversões exclusivas do produto
+// @Tags measurement-units
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param productId query int false "ID do produto"
+// @Success 200 {object} utils.Response{data=[]dto.ApiUnitConversion} "Conversões encontradas"
+// @Failure 400 {object} utils.Response "Parâmetros inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Router /measurement-units/conversions [get]
+func (h *MeasurementUnitHandler) GetConversions(c *gin.Context) {
+	var filters dto.InGetUnitConversionsFilters
+	if err := utils.BindQueryOrSendErrorRes(c, &filters); err != nil {
+		return
+	}
+
+	conversions, err := h.unitService.GetConversions(filters)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar conversões", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Conversões encontradas", conversions, nil)
+}
+
+// CreateConversion cadastra uma conversão de unidades
+// @Summary Cadastrar conversão de unidades
+// @Description Cadastra quantas unidades de destino há em cada unidade de origem (ex: 1 kg = 1000 g). A conversão vale
+// @Description nos dois sentidos. Com product_id, vale só para o produto, deve envolver a unidade dele e prevalece sobre a global.
+// @Tags measurement-units
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body models.CreateUnitConversionRequest true "Dados da conversão"
+// @Success 201 {object} utils.Response{data=dto.ApiUnitConversion} "Conversão cadastrada com sucesso"
+// @Failure 400 {object} utils.Response "Dados inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Router /measurement-units/conversions [post]
+func (h *MeasurementUnitHandler) CreateConversion(c *gin.Context) {
+	var req models.CreateUnitConversionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, "Dados inválidos", err.Error())
+		return
+	}
+
+	conversion, err := h.unitService.CreateConversion(req)
+	if err != nil {
+		if validator.IsValidationError(err) {
+			utils.ValidationErrorResponse(c, "Dados inválidos", validator.GetValidationErrors(err))
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao cadastrar conversão", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Conversão cadastrada com sucesso", conversion, nil)
+}
+
+// UpdateConversion atualiza o fator de uma conversão de unidades
+// @Summary Atualizar conversão de unidades
+// @Description Atualiza o fator da conversão. Compras e vendas já registradas mantêm o fator usado no registro.
+// @Tags measurement-units
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID da conversão"
+// @Param request body models.UpdateUnitConversionRequest true "Novo fator"
+// @Success 200 {object} utils.Response{data=dto.ApiUnitConversion} "Conversão atualizada com sucesso"
+// @Failure 400 {object} utils.Response "Dados inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Conversão não encontrada"
+// @Router /measurement-units/conversions/{id} [put]
+func (h *MeasurementUnitHandler) UpdateConversion(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	var req models.UpdateUnitConversionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, "Dados inválidos", err.Error())
+		return
+	}
+
+	conversion, err := h.unitService.UpdateConversion(id, req)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Conversão não encontrada", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao atualizar conversão", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Conversão atualizada com sucesso", conversion, nil)
+}
+
+// DeleteConversion exclui uma conversão de unidades
+// @Summary Excluir conversão de unidades
+// @Description Exclui a conversão de unidades
+// @Tags measurement-units
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID da conversão"
+// @Success 200 {object} utils.Response "Conversão excluída com sucesso"
+// @Failure 400 {object} utils.Response "ID inválido"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Conversão não encontrada"
+// @Router /measurement-units/conversions/{id} [delete]
+func (h *MeasurementUnitHandler) DeleteConversion(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	if err := h.unitService.DeleteConversion(id); err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Conversão não encontrada", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao excluir conversão", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Conversão excluída com sucesso", nil, nil)
+}
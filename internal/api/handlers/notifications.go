@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"net/http"
+
+	"simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/service"
+	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/utils/path"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// NotificationHandler gerencia as requisições das notificações do usuário autenticado
+type NotificationHandler struct {
+	notificationService *service.NotificationService
+}
+
+// NewNotificationHandler cria um novo handler de notificações
+func NewNotificationHandler(db *gorm.DB) *NotificationHandler {
+	notificationRepo := repository.NewNotificationRepository(db)
+
+	return &NotificationHandler{
+		notificationService: service.NewNotificationService(notificationRepo),
+	}
+}
+
+// GetNotifications retorna as notificações do usuário autenticado
+// @Summary Listar notificações
+// @Description Retorna as notificações do usuário autenticado, das mais recentes para as mais antigas, com o total de não lidas
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "Número da página" default(1)
+// @Param limit query int false "Limite de itens por página" default(10)
+// @Param unreadOnly query bool false "Somente notificações não lidas"
+// @Success 200 {object} utils.Response{data=dto.ApiNotificationListPaginated} "Notificações encontradas"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 500 {object} utils.Response "Erro ao buscar notificações"
+// @Router /notifications [get]
+func (h *NotificationHandler) GetNotifications(c *gin.Context) {
+	pagination := utils.GetPaginationParams(c)
+
+	var filters dto.InGetNotificationsFilters
+	if err := utils.BindQueryOrSendErrorRes(c, &filters); err != nil {
+		return
+	}
+
+	userID, exists := utils.GetUserIDFromContext(c)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Usuário não autenticado", "")
+		return
+	}
+
+	notifications, err := h.notificationService.GetNotifications(userID, &pagination, filters)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar notificações", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Notificações encontradas", notifications, nil)
+}
+
+// MarkAsRead marca uma notificação como lida
+// @Summary Marcar notificação como lida
+// @Description Marca uma notificação do usuário autenticado como lida
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID da notificação"
+// @Success 200 {object} utils.Response "Notificação marcada como lida"
+// @Failure 400 {object} utils.Response "ID inválido"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Notificação não encontrada"
+// @Router /notifications/{id}/read [post]
+func (h *NotificationHandler) MarkAsRead(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	userID, exists := utils.GetUserIDFromContext(c)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Usuário não autenticado", "")
+		return
+	}
+
+	if err := h.notificationService.MarkAsRead(id, userID); err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Notificação não encontrada", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao marcar notificação como lida", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Notificação marcada como lida", nil, nil)
+}
+
+// MarkAllAsRead marca todas as notificações do usuário como lidas
+// @Summary Marcar todas as notificações como lidas
+// @Description Marca todas as notificações não lidas do usuário autenticado como lidas
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} utils.Response{data=int} "Quantidade de notificações marcadas"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 500 {object} utils.Response "Erro ao marcar notificações como lidas"
+// @Router /notifications/read-all [post]
+func (h *NotificationHandler) MarkAllAsRead(c *gin.Context) {
+	userID, exists := utils.GetUserIDFromContext(c)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Usuário não autenticado", "")
+		return
+	}
+
+	marked, err := h.notificationService.MarkAllAsRead(userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao marcar notificações como lidas", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Notificações marcadas como lidas", marked, nil)
+}
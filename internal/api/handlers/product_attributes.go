@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"net/http"
+
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/service"
+	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/utils/path"
+	"simple-erp-service/internal/validator"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ProductAttributeHandler gerencia as requisições dos atributos das variantes de produtos
+type ProductAttributeHandler struct {
+	variantService *service.ProductVariantService
+}
+
+// NewProductAttributeHandler cria um novo handler de atributos das variantes
+func NewProductAttributeHandler(db *gorm.DB) *ProductAttributeHandler {
+	productRepo := repository.NewProductRepository(db)
+	attributeRepo := repository.NewProductAttributeRepository(db)
+
+	return &ProductAttributeHandler{
+		variantService: service.NewProductVariantService(productRepo, attributeRepo),
+	}
+}
+
+// GetAttributes lista os atributos das variantes
+// @Summary Listar atributos de variantes
+// @Description Retorna os atributos usados nas grades de variantes (ex: tamanho, cor), com os valores na ordem de exibição
+// @Tags product-attributes
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} utils.Response{data=[]dto.ApiProductAttribute} "Atributos encontrados"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 500 {object} utils.Response "Erro ao buscar atributos"
+// @Router /product-attributes [get]
+func (h *ProductAttributeHandler) GetAttributes(c *gin.Context) {
+	attributes, err := h.variantService.GetAttributes()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar atributos", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Atributos encontrados", attributes, nil)
+}
+
+// CreateAttribute cadastra um atributo de variantes
+// @Summary Cadastrar atributo de variantes
+// @Description Cadastra um atributo com os seus valores. O código de cada valor compõe o SKU das variantes; sem código,
+// @Description é usado o próprio valor em maiúsculas, sem acentos e espaços.
+// @Tags product-attributes
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body models.CreateProductAttributeRequest true "Dados do atributo"
+// @Success 201 {object} utils.Response{data=dto.ApiProductAttribute} "Atributo cadastrado com sucesso"
+// @Failure 400 {object} utils.Response "Dados inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Router /product-attributes [post]
+func (h *ProductAttributeHandler) CreateAttribute(c *gin.Context) {
+	var req models.CreateProductAttributeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, "Dados inválidos", err.Error())
+		return
+	}
+
+	attribute, err := h.variantService.CreateAttribute(req)
+	if err != nil {
+		if validator.IsValidationError(err) {
+			utils.ValidationErrorResponse(c, "Dados inválidos", validator.GetValidationErrors(err))
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao cadastrar atributo", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Atributo cadastrado com sucesso", attribute, nil)
+}
+
+// AddAttributeValues inclui valores em um atributo de variantes
+// @Summary Incluir valores no atributo
+// @Description Inclui novos valores no atributo. Valores e códigos não podem se repetir no atributo.
+// @Tags product-attributes
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do atributo"
+// @Param request body models.AddProductAttributeValuesRequest true "Valores a incluir"
+// @Success 200 {object} utils.Response{data=dto.ApiProductAttribute} "Valores incluídos com sucesso"
+// @Failure 400 {object} utils.Response "Dados inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Atributo não encontrado"
+// @Router /product-attributes/{id}/values [post]
+func (h *ProductAttributeHandler) AddAttributeValues(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	var req models.AddProductAttributeValuesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, "Dados inválidos", err.Error())
+		return
+	}
+
+	attribute, err := h.variantService.AddAttributeValues(id, req)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Atributo não encontrado", err.Error())
+		} else if validator.IsValidationError(err) {
+			utils.ValidationErrorResponse(c, "Dados inválidos", validator.GetValidationErrors(err))
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao incluir valores", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Valores incluídos com sucesso", attribute, nil)
+}
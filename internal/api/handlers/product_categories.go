@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"net/http"
+
+	"simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/service"
+	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/utils/path"
+	"simple-erp-service/internal/validator"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ProductCategoryHandler gerencia as requisições da árvore de categorias de produtos
+type ProductCategoryHandler struct {
+	categoryService *service.ProductCategoryService
+}
+
+// NewProductCategoryHandler cria um novo handler de categorias de produtos
+func NewProductCategoryHandler(db *gorm.DB) *ProductCategoryHandler {
+	categoryRepo := repository.NewProductCategoryRepository(db)
+
+	return &ProductCategoryHandler{
+		categoryService: service.NewProductCategoryService(categoryRepo),
+	}
+}
+
+// GetTree retorna a árvore de categorias
+// @Summary Árvore de categorias
+// @Description Retorna a árvore completa de categorias de produtos. Cada nó informa os produtos da própria
+// @Description categoria (product_count) e os produtos somados de todas as subcategorias (total_product_count).
+// @Tags product-categories
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} utils.Response{data=[]dto.ApiProductCategoryNode} "Categorias encontradas"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 500 {object} utils.Response "Erro ao buscar categorias"
+// @Router /product-categories [get]
+func (h *ProductCategoryHandler) GetTree(c *gin.Context) {
+	tree, err := h.categoryService.GetTree()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar categorias", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Categorias encontradas", tree, nil)
+}
+
+// CreateCategory cria uma categoria
+// @Summary Criar categoria
+// @Description Cria uma categoria na raiz ou sob a categoria pai informada. O nome deve ser único no mesmo nível.
+// @Tags product-categories
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body models.CreateProductCategoryRequest true "Dados da categoria"
+// @Success 201 {object} utils.Response{data=dto.ApiProductCategory} "Categoria criada com sucesso"
+// @Failure 400 {object} utils.Response "Dados inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Router /product-categories [post]
+func (h *ProductCategoryHandler) CreateCategory(c *gin.Context) {
+	var req models.CreateProductCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, "Dados inválidos", err.Error())
+		return
+	}
+
+	category, err := h.categoryService.CreateCategory(req)
+	if err != nil {
+		if validator.IsValidationError(err) {
+			utils.ValidationErrorResponse(c, "Dados inválidos", validator.GetValidationErrors(err))
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao criar categoria", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Categoria criada com sucesso", category, nil)
+}
+
+// UpdateCategory atualiza uma categoria
+// @Summary Atualizar categoria
+// @Description Atualiza o nome e a descrição da categoria. Para trocar a categoria pai, use a operação de mover.
+// @Tags product-categories
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID da categoria"
+// @Param request body models.UpdateProductCategoryRequest true "Dados da categoria"
+// @Success 200 {object} utils.Response{data=dto.ApiProductCategory} "Categoria atualizada com sucesso"
+// @Failure 400 {object} utils.Response "Dados inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Categoria não encontrada"
+// @Router /product-categories/{id} [put]
+func (h *ProductCategoryHandler) UpdateCategory(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	var req models.UpdateProductCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, "Dados inválidos", err.Error())
+		return
+	}
+
+	category, err := h.categoryService.UpdateCategory(id, req)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Categoria não encontrada", err.Error())
+		} else if validator.IsValidationError(err) {
+			utils.ValidationErrorResponse(c, "Dados inválidos", validator.GetValidationErrors(err))
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao atualizar categoria", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Categoria atualizada com sucesso", category, nil)
+}
+
+// MoveCategory move uma categoria para outro pai
+// @Summary Mover categoria
+// @Description Move a categoria, com todas as subcategorias e produtos, para o novo pai (nulo torna a categoria raiz).
+// @Description A categoria não pode ser movida para dentro dela mesma ou de uma de suas subcategorias.
+// @Tags product-categories
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID da categoria"
+// @Param request body models.MoveProductCategoryRequest true "Novo pai"
+// @Success 200 {object} utils.Response{data=dto.ApiProductCategory} "Categoria movida com sucesso"
+// @Failure 400 {object} utils.Response "Dados inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Categoria não encontrada"
+// @Router /product-categories/{id}/move [post]
+func (h *ProductCategoryHandler) MoveCategory(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	var req models.MoveProductCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, "Dados inválidos", err.Error())
+		return
+	}
+
+	category, err := h.categoryService.MoveCategory(id, req)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Categoria não encontrada", err.Error())
+		} else if validator.IsValidationError(err) {
+			utils.ValidationErrorResponse(c, "Dados inválidos", validator.GetValidationErrors(err))
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao mover categoria", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Categoria movida com sucesso", category, nil)
+}
+
+// DeleteCategory exclui uma categoria
+// @Summary Excluir categoria
+// @Description Exclui a categoria. No modo block (padrão), a exclusão é recusada se houver produtos ou
+// @Description subcategorias; no modo reassign, eles passam para a categoria pai (ou para a raiz / sem categoria).
+// @Tags product-categories
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID da categoria"
+// @Param mode query string false "Modo de exclusão" Enums(block, reassign)
+// @Success 200 {object} utils.Response "Categoria excluída com sucesso"
+// @Failure 400 {object} utils.Response "Dados inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Categoria não encontrada"
+// @Failure 409 {object} utils.Response "A categoria possui produtos ou subcategorias"
+// @Router /product-categories/{id} [delete]
+func (h *ProductCategoryHandler) DeleteCategory(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	var options dto.InDeleteProductCategory
+	if err := utils.BindQueryOrSendErrorRes(c, &options); err != nil {
+		return
+	}
+
+	if err := h.categoryService.DeleteCategory(id, options.Mode); err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Categoria não encontrada", err.Error())
+		} else if err == service.ErrCategoryInUse {
+			utils.ErrorResponse(c, http.StatusConflict, "A categoria possui produtos ou subcategorias; use mode=reassign para movê-los para a categoria pai", err.Error())
+		} else if validator.IsValidationError(err) {
+			utils.ValidationErrorResponse(c, "Dados inválidos", validator.GetValidationErrors(err))
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao excluir categoria", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Categoria excluída com sucesso", nil, nil)
+}
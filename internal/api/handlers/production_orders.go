@@ -0,0 +1,231 @@
+package handlers
+
+import (
+	"net/http"
+
+	"simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/service"
+	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/utils/path"
+	"simple-erp-service/internal/validator"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ProductionOrderHandler gerencia as requisições relacionadas a ordens de produção
+type ProductionOrderHandler struct {
+	orderService *service.ProductionOrderService
+}
+
+// NewProductionOrderHandler cria um novo handler de ordens de produção
+func NewProductionOrderHandler(db *gorm.DB) *ProductionOrderHandler {
+	orderRepo := repository.NewProductionOrderRepository(db)
+	productRepo := repository.NewProductRepository(db)
+	componentRepo := repository.NewProductComponentRepository(db)
+	locationRepo := repository.NewStockLocationRepository(db)
+
+	return &ProductionOrderHandler{
+		orderService: service.NewProductionOrderService(orderRepo, productRepo, componentRepo, locationRepo),
+	}
+}
+
+// GetOrders retorna uma lista paginada de ordens de produção
+// @Summary Listar ordens de produção
+// @Description Retorna uma lista paginada de ordens de produção, com filtros por produto, situação e período
+// @Tags production-orders
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "Número da página" default(1)
+// @Param limit query int false "Limite de itens por página" default(10)
+// @Param sort query string false "Campo para ordenação" default(created_at)
+// @Param order query string false "Direção da ordenação (asc/desc)" default(desc)
+// @Param productId query int false "ID do produto fabricado"
+// @Param status query string false "Situação da ordem" Enums(pendente, concluida, cancelada)
+// @Param dateFrom query string false "Ordens abertas a partir de (AAAA-MM-DD)"
+// @Param dateTo query string false "Ordens abertas até (AAAA-MM-DD, inclusive)"
+// @Success 200 {object} utils.Response{data=dto.ApiProductionOrderListPaginated} "Ordens de produção encontradas"
+// @Failure 400 {object} utils.Response "Filtros inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 500 {object} utils.Response "Erro ao buscar ordens de produção"
+// @Router /production-orders [get]
+func (h *ProductionOrderHandler) GetOrders(c *gin.Context) {
+	pagination := utils.GetPaginationParams(c)
+
+	var filters dto.InGetProductionOrdersFilters
+	if err := utils.BindQueryOrSendErrorRes(c, &filters); err != nil {
+		return
+	}
+
+	orders, err := h.orderService.GetOrders(&pagination, filters)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar ordens de produção", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Ordens de produção encontradas", orders, nil)
+}
+
+// GetOrder retorna uma ordem de produção específica
+// @Summary Buscar ordem de produção
+// @Description Retorna uma ordem de produção com o consumo de cada componente
+// @Tags production-orders
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID da ordem de produção"
+// @Success 200 {object} utils.Response{data=dto.ApiProductionOrder} "Ordem de produção encontrada"
+// @Failure 400 {object} utils.Response "ID inválido"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Ordem de produção não encontrada"
+// @Router /production-orders/{id} [get]
+func (h *ProductionOrderHandler) GetOrder(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	order, err := h.orderService.GetOrderByID(id)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Ordem de produção não encontrada", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar ordem de produção", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Ordem de produção encontrada", order, nil)
+}
+
+// CreateOrder abre uma ordem de produção
+// @Summary Abrir ordem de produção
+// @Description Abre uma ordem de produção pendente para um produto fabricado. O consumo dos componentes é calculado
+// @Description pela ficha técnica atual do produto e não muda se a ficha for alterada depois.
+// @Tags production-orders
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body models.CreateProductionOrderRequest true "Produto e quantidade a produzir"
+// @Success 201 {object} utils.Response{data=dto.ApiProductionOrder} "Ordem de produção aberta com sucesso"
+// @Failure 400 {object} utils.Response "Dados inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Router /production-orders [post]
+func (h *ProductionOrderHandler) CreateOrder(c *gin.Context) {
+	var req models.CreateProductionOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, "Dados inválidos", err.Error())
+		return
+	}
+
+	userID, exists := utils.GetUserIDFromContext(c)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Usuário não autenticado", "")
+		return
+	}
+
+	order, err := h.orderService.CreateOrder(req, userID)
+	if err != nil {
+		if validator.IsValidationError(err) {
+			utils.ValidationErrorResponse(c, "Dados inválidos", validator.GetValidationErrors(err))
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao abrir ordem de produção", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Ordem de produção aberta com sucesso", order, nil)
+}
+
+// CompleteOrder conclui uma ordem de produção
+// @Summary Concluir ordem de produção
+// @Description Baixa o estoque dos componentes e dá entrada no produto fabricado, no local da ordem ou no local padrão.
+// @Description Falta de saldo de qualquer componente nesse local impede a conclusão. Componentes com controle de lotes
+// @Description saem por ordem de validade; produtos com controle de lotes exigem o lote produzido e produtos com
+// @Description número de série, um número por unidade.
+// @Tags production-orders
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID da ordem de produção"
+// @Param request body models.CompleteProductionOrderRequest false "Data da conclusão e observações"
+// @Success 200 {object} utils.Response{data=dto.ApiProductionOrder} "Ordem de produção concluída com sucesso"
+// @Failure 400 {object} utils.Response "Dados inválidos ou estoque insuficiente"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Ordem de produção não encontrada"
+// @Failure 409 {object} utils.Response "Ordem de produção não está pendente"
+// @Router /production-orders/{id}/complete [post]
+func (h *ProductionOrderHandler) CompleteOrder(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	var req models.CompleteProductionOrderRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.ValidationErrorResponse(c, "Dados inválidos", err.Error())
+			return
+		}
+	}
+
+	userID, exists := utils.GetUserIDFromContext(c)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Usuário não autenticado", "")
+		return
+	}
+
+	order, err := h.orderService.CompleteOrder(id, req, userID)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Ordem de produção não encontrada", err.Error())
+		} else if err == service.ErrProductionOrderNotPending {
+			utils.ErrorResponse(c, http.StatusConflict, "Ordem de produção não está pendente", err.Error())
+		} else if validator.IsValidationError(err) {
+			utils.ValidationErrorResponse(c, "Dados inválidos", validator.GetValidationErrors(err))
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao concluir ordem de produção", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Ordem de produção concluída com sucesso", order, nil)
+}
+
+// CancelOrder cancela uma ordem de produção pendente
+// @Summary Cancelar ordem de produção
+// @Description Cancela uma ordem de produção que ainda não foi concluída
+// @Tags production-orders
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID da ordem de produção"
+// @Success 200 {object} utils.Response{data=dto.ApiProductionOrder} "Ordem de produção cancelada com sucesso"
+// @Failure 400 {object} utils.Response "ID inválido"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Ordem de produção não encontrada"
+// @Failure 409 {object} utils.Response "Ordem de produção não está pendente"
+// @Router /production-orders/{id}/cancel [post]
+func (h *ProductionOrderHandler) CancelOrder(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	order, err := h.orderService.CancelOrder(id)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Ordem de produção não encontrada", err.Error())
+		} else if err == service.ErrProductionOrderNotPending {
+			utils.ErrorResponse(c, http.StatusConflict, "Ordem de produção não está pendente", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao cancelar ordem de produção", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Ordem de produção cancelada com sucesso", order, nil)
+}
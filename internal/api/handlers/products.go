@@ -0,0 +1,530 @@
+package handlers
+
+import (
+	"net/http"
+
+	"simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/service"
+	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/utils/path"
+	"simple-erp-service/internal/validator"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ProductHandler gerencia as requisições relacionadas a produtos
+type ProductHandler struct {
+	productService     *service.ProductService
+	catalogService     *service.SupplierCatalogService
+	unitService        *service.MeasurementUnitService
+	variantService     *service.ProductVariantService
+	compositionService *service.ProductCompositionService
+	locationService    *service.StockLocationService
+	lotService         *service.StockLotService
+}
+
+// NewProductHandler cria um novo handler de produtos
+func NewProductHandler(db *gorm.DB) *ProductHandler {
+	productRepo := repository.NewProductRepository(db)
+	supplierRepo := repository.NewSupplierRepository(db)
+	supplierProductRepo := repository.NewSupplierProductRepository(db)
+	unitRepo := repository.NewMeasurementUnitRepository(db)
+	attributeRepo := repository.NewProductAttributeRepository(db)
+	componentRepo := repository.NewProductComponentRepository(db)
+	locationRepo := repository.NewStockLocationRepository(db)
+	lotRepo := repository.NewStockLotRepository(db)
+	customerRepo := repository.NewCustomerRepository(db)
+	reservationRepo := repository.NewStockReservationRepository(db)
+
+	return &ProductHandler{
+		productService:     service.NewProductService(productRepo, componentRepo, reservationRepo),
+		catalogService:     service.NewSupplierCatalogService(supplierRepo, productRepo, supplierProductRepo),
+		unitService:        service.NewMeasurementUnitService(unitRepo, productRepo),
+		variantService:     service.NewProductVariantService(productRepo, attributeRepo),
+		compositionService: service.NewProductCompositionService(productRepo, componentRepo),
+		locationService:    service.NewStockLocationService(locationRepo, productRepo),
+		lotService:         service.NewStockLotService(lotRepo, productRepo, customerRepo),
+	}
+}
+
+// GetProducts retorna uma lista paginada de produtos
+// @Summary Listar produtos
+// @Description Retorna uma lista paginada de produtos, com busca sem acentos e filtros. O estoque disponível é o
+// @Description estoque atual menos as reservas das vendas pendentes.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "Número da página" default(1)
+// @Param limit query int false "Limite de itens por página" default(10)
+// @Param sort query string false "Campo para ordenação" default(created_at)
+// @Param order query string false "Direção da ordenação (asc/desc)" default(desc)
+// @Param search query string false "Busca em nome, SKU, código de barras e códigos dos fornecedores"
+// @Param categoryId query int false "ID da categoria"
+// @Param supplierId query int false "ID de um fornecedor do produto"
+// @Param isActive query bool false "Somente ativos (true) ou inativos (false)"
+// @Param lowStock query bool false "Somente produtos no estoque mínimo ou abaixo dele"
+// @Param parentId query int false "Somente as variantes do produto"
+// @Param hideVariants query bool false "Omite as variantes, listando só os produtos pai e os sem grade"
+// @Success 200 {object} utils.Response{data=dto.ApiProductListPaginated} "Produtos encontrados"
+// @Failure 400 {object} utils.Response "Filtros inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 500 {object} utils.Response "Erro ao buscar produtos"
+// @Router /products [get]
+func (h *ProductHandler) GetProducts(c *gin.Context) {
+	pagination := utils.GetPaginationParams(c)
+
+	var filters dto.InGetProductsFilters
+	if err := utils.BindQueryOrSendErrorRes(c, &filters); err != nil {
+		return
+	}
+
+	products, err := h.productService.GetProducts(&pagination, filters)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar produtos", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Produtos encontrados", products, nil)
+}
+
+// GetProduct retorna um produto específico
+// @Summary Buscar produto
+// @Description Retorna um produto específico pelo ID, com a quantidade reservada para vendas pendentes e o
+// @Description estoque disponível
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do produto"
+// @Success 200 {object} utils.Response{data=dto.ApiProduct} "Produto encontrado"
+// @Failure 400 {object} utils.Response "ID inválido"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Produto não encontrado"
+// @Router /products/{id} [get]
+func (h *ProductHandler) GetProduct(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	product, err := h.productService.GetProductByID(id)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Produto não encontrado", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar produto", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Produto encontrado", product, nil)
+}
+
+// CreateProduct cria um novo produto
+// @Summary Criar produto
+// @Description Cria um novo produto com estoque zerado. O estoque só muda por movimentações. O custo informado vale
+// @Description até a primeira entrada com custo; depois, o custo é o custo médio ponderado das entradas.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body models.CreateProductRequest true "Dados do produto"
+// @Success 201 {object} utils.Response{data=dto.ApiProduct} "Produto criado com sucesso"
+// @Failure 400 {object} utils.Response "Dados inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Router /products [post]
+func (h *ProductHandler) CreateProduct(c *gin.Context) {
+	var req models.CreateProductRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, "Dados inválidos", err.Error())
+		return
+	}
+
+	userID, exists := utils.GetUserIDFromContext(c)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Usuário não autenticado", "")
+		return
+	}
+
+	product, err := h.productService.CreateProduct(req, userID)
+	if err != nil {
+		if validator.IsValidationError(err) {
+			utils.ValidationErrorResponse(c, "Dados inválidos", validator.GetValidationErrors(err))
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao criar produto", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Produto criado com sucesso", product, nil)
+}
+
+// UpdateProduct atualiza um produto existente
+// @Summary Atualizar produto
+// @Description Atualiza um produto existente. O estoque atual não é alterado. Com estoque, o custo também não: vale
+// @Description o custo médio ponderado das entradas.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do produto"
+// @Param request body models.UpdateProductRequest true "Dados do produto"
+// @Success 200 {object} utils.Response{data=dto.ApiProduct} "Produto atualizado com sucesso"
+// @Failure 400 {object} utils.Response "Dados inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Produto não encontrado"
+// @Router /products/{id} [put]
+func (h *ProductHandler) UpdateProduct(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	var req models.UpdateProductRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, "Dados inválidos", err.Error())
+		return
+	}
+
+	product, err := h.productService.UpdateProduct(id, req)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Produto não encontrado", err.Error())
+		} else if validator.IsValidationError(err) {
+			utils.ValidationErrorResponse(c, "Dados inválidos", validator.GetValidationErrors(err))
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao atualizar produto", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Produto atualizado com sucesso", product, nil)
+}
+
+// DeleteProduct exclui um produto
+// @Summary Excluir produto
+// @Description Exclui um produto. Produtos com variantes só podem ser excluídos depois das variantes.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do produto"
+// @Success 200 {object} utils.Response "Produto excluído com sucesso"
+// @Failure 400 {object} utils.Response "ID inválido"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Produto não encontrado"
+// @Failure 409 {object} utils.Response "O produto possui variantes ou é componente de outros produtos"
+// @Router /products/{id} [delete]
+func (h *ProductHandler) DeleteProduct(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	if err := h.productService.DeleteProduct(id); err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Produto não encontrado", err.Error())
+		} else if err == service.ErrProductHasVariants {
+			utils.ErrorResponse(c, http.StatusConflict, "O produto possui variantes; exclua as variantes antes", err.Error())
+		} else if err == service.ErrProductIsComponent {
+			utils.ErrorResponse(c, http.StatusConflict, "O produto é componente de kits ou produtos fabricados; remova-o das composições antes", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao excluir produto", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Produto excluído com sucesso", nil, nil)
+}
+
+// CompareSuppliers compara os fornecedores do produto pelo preço
+// @Summary Comparar fornecedores do produto
+// @Description Lista os fornecedores do produto do menor para o maior último preço, convertido para a unidade do produto.
+// @Description Fornecedores sem preço ficam no final.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do produto"
+// @Success 200 {object} utils.Response{data=dto.ApiSupplierPriceComparison} "Fornecedores comparados"
+// @Failure 400 {object} utils.Response "ID inválido"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Produto não encontrado"
+// @Router /products/{id}/suppliers [get]
+func (h *ProductHandler) CompareSuppliers(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	comparison, err := h.catalogService.CompareSuppliers(id)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Produto não encontrado", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao comparar fornecedores", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Fornecedores comparados", comparison, nil)
+}
+
+// GetProductUnits lista as unidades em que o produto pode ser comprado ou vendido
+// @Summary Unidades do produto
+// @Description Lista a unidade do produto, em que o estoque é mantido, e as unidades com conversão para ela,
+// @Description exclusiva do produto ou global, com quantas unidades do produto há em cada uma.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do produto"
+// @Success 200 {object} utils.Response{data=[]dto.ApiProductUnit} "Unidades encontradas"
+// @Failure 400 {object} utils.Response "ID inválido"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Produto não encontrado"
+// @Router /products/{id}/units [get]
+func (h *ProductHandler) GetProductUnits(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	units, err := h.unitService.GetProductUnits(id)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Produto não encontrado", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar unidades do produto", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Unidades encontradas", units, nil)
+}
+
+// GetVariants lista as variantes do produto
+// @Summary Variantes do produto
+// @Description Lista as variantes (grade) do produto, com os valores dos atributos, preços e estoque de cada uma
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do produto"
+// @Success 200 {object} utils.Response{data=[]dto.ApiProduct} "Variantes encontradas"
+// @Failure 400 {object} utils.Response "ID inválido"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Produto não encontrado"
+// @Router /products/{id}/variants [get]
+func (h *ProductHandler) GetVariants(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	variants, err := h.variantService.GetVariants(id)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Produto não encontrado", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar variantes", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Variantes encontradas", variants, nil)
+}
+
+// GenerateVariants gera a grade de variantes do produto
+// @Summary Gerar grade de variantes
+// @Description Gera uma variante para cada combinação dos valores informados (ex: tamanhos P, M, G × cores azul, preta),
+// @Description com SKU formado pelo SKU do produto e pelos códigos dos valores. Combinações existentes são mantidas.
+// @Description As variantes herdam categoria, unidade e preços do produto; código de barras e preço próprio são
+// @Description definidos depois, na atualização de cada variante.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do produto"
+// @Param request body models.GenerateProductVariantsRequest true "Atributos e valores da grade"
+// @Success 201 {object} utils.Response{data=[]dto.ApiProduct} "Variantes geradas com sucesso"
+// @Failure 400 {object} utils.Response "Dados inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Produto não encontrado"
+// @Router /products/{id}/variants [post]
+func (h *ProductHandler) GenerateVariants(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	var req models.GenerateProductVariantsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, "Dados inválidos", err.Error())
+		return
+	}
+
+	userID, exists := utils.GetUserIDFromContext(c)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Usuário não autenticado", "")
+		return
+	}
+
+	variants, err := h.variantService.GenerateVariants(id, req, userID)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Produto não encontrado", err.Error())
+		} else if validator.IsValidationError(err) {
+			utils.ValidationErrorResponse(c, "Dados inválidos", validator.GetValidationErrors(err))
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao gerar variantes", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Variantes geradas com sucesso", variants, nil)
+}
+
+// GetComposition retorna a composição do produto
+// @Summary Composição do produto
+// @Description Retorna os componentes do kit ou a ficha técnica do produto fabricado, com o estoque de cada componente
+// @Description e quantas unidades do produto podem ser montadas ou produzidas com ele
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do produto"
+// @Success 200 {object} utils.Response{data=dto.ApiProductComposition} "Composição encontrada"
+// @Failure 400 {object} utils.Response "ID inválido"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Produto não encontrado"
+// @Router /products/{id}/components [get]
+func (h *ProductHandler) GetComposition(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	composition, err := h.compositionService.GetComposition(id)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Produto não encontrado", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar composição", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Composição encontrada", composition, nil)
+}
+
+// SetComposition define a composição do produto
+// @Summary Definir composição do produto
+// @Description Substitui os componentes do kit ou a ficha técnica do produto fabricado. A venda de um kit baixa o
+// @Description estoque dos componentes; o produto fabricado consome os componentes nas ordens de produção.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do produto"
+// @Param request body models.SetProductComponentsRequest true "Componentes e quantidades por unidade do produto"
+// @Success 200 {object} utils.Response{data=dto.ApiProductComposition} "Composição atualizada com sucesso"
+// @Failure 400 {object} utils.Response "Dados inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Produto não encontrado"
+// @Router /products/{id}/components [put]
+func (h *ProductHandler) SetComposition(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	var req models.SetProductComponentsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, "Dados inválidos", err.Error())
+		return
+	}
+
+	composition, err := h.compositionService.SetComposition(id, req)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Produto não encontrado", err.Error())
+		} else if validator.IsValidationError(err) {
+			utils.ValidationErrorResponse(c, "Dados inválidos", validator.GetValidationErrors(err))
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao atualizar composição", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Composição atualizada com sucesso", composition, nil)
+}
+
+// GetProductLocations retorna os saldos do produto por local de estoque
+// @Summary Saldos do produto por local
+// @Description Retorna os locais de estoque em que o produto tem saldo e as quantidades, na unidade do produto.
+// @Description A soma dos saldos é o estoque atual do produto.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do produto"
+// @Success 200 {object} utils.Response{data=[]dto.ApiStockBalance} "Saldos encontrados"
+// @Failure 400 {object} utils.Response "ID inválido"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Produto não encontrado"
+// @Router /products/{id}/locations [get]
+func (h *ProductHandler) GetProductLocations(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	balances, err := h.locationService.GetProductBalances(id)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Produto não encontrado", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar saldos do produto", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Saldos encontrados", balances, nil)
+}
+
+// GetProductLots lista os lotes do produto
+// @Summary Lotes do produto
+// @Description Retorna os lotes do produto por ordem de validade, com os saldos de cada lote por local de estoque
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do produto"
+// @Success 200 {object} utils.Response{data=[]dto.ApiStockLot} "Lotes encontrados"
+// @Failure 400 {object} utils.Response "ID inválido"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Produto não encontrado"
+// @Router /products/{id}/lots [get]
+func (h *ProductHandler) GetProductLots(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	lots, err := h.lotService.GetProductLots(id)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Produto não encontrado", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar lotes do produto", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Lotes encontrados", lots, nil)
+}
@@ -0,0 +1,445 @@
+package handlers
+
+import (
+	"net/http"
+
+	"simple-erp-service/config"
+	"simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/service"
+	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/utils/path"
+	"simple-erp-service/internal/validator"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// PurchaseHandler gerencia as requisições relacionadas a compras
+type PurchaseHandler struct {
+	purchaseService      *service.PurchaseService
+	replenishmentService *service.ReplenishmentService
+}
+
+// NewPurchaseHandler cria um novo handler de compras
+func NewPurchaseHandler(db *gorm.DB, documentsCfg config.DocumentsConfig) *PurchaseHandler {
+	purchaseRepo := repository.NewPurchaseRepository(db)
+	supplierRepo := repository.NewSupplierRepository(db)
+	productRepo := repository.NewProductRepository(db)
+	supplierProductRepo := repository.NewSupplierProductRepository(db)
+	documentRepo := repository.NewDocumentRepository(db)
+	unitRepo := repository.NewMeasurementUnitRepository(db)
+	locationRepo := repository.NewStockLocationRepository(db)
+	replenishmentRepo := repository.NewReplenishmentRepository(db)
+	reservationRepo := repository.NewStockReservationRepository(db)
+
+	purchaseService := service.NewPurchaseService(purchaseRepo, supplierRepo, productRepo, supplierProductRepo, documentRepo, unitRepo, locationRepo, documentsCfg)
+	return &PurchaseHandler{
+		purchaseService:      purchaseService,
+		replenishmentService: service.NewReplenishmentService(replenishmentRepo, reservationRepo, locationRepo, purchaseService),
+	}
+}
+
+// GetPurchases retorna uma lista paginada de compras
+// @Summary Listar compras
+// @Description Retorna uma lista paginada de compras, com filtros por fornecedor, situação e período
+// @Tags purchases
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "Número da página" default(1)
+// @Param limit query int false "Limite de itens por página" default(10)
+// @Param sort query string false "Campo para ordenação" default(created_at)
+// @Param order query string false "Direção da ordenação (asc/desc)" default(desc)
+// @Param supplierId query int false "ID do fornecedor"
+// @Param status query string false "Situação da compra" Enums(rascunho, pendente, recebido, cancelado)
+// @Param dateFrom query string false "Compras a partir de (AAAA-MM-DD)"
+// @Param dateTo query string false "Compras até (AAAA-MM-DD, inclusive)"
+// @Success 200 {object} utils.Response{data=dto.ApiPurchaseListPaginated} "Compras encontradas"
+// @Failure 400 {object} utils.Response "Filtros inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 500 {object} utils.Response "Erro ao buscar compras"
+// @Router /purchases [get]
+func (h *PurchaseHandler) GetPurchases(c *gin.Context) {
+	pagination := utils.GetPaginationParams(c)
+
+	var filters dto.InGetPurchasesFilters
+	if err := utils.BindQueryOrSendErrorRes(c, &filters); err != nil {
+		return
+	}
+
+	purchases, err := h.purchaseService.GetPurchases(&pagination, filters)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar compras", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Compras encontradas", purchases, nil)
+}
+
+// GetPurchase retorna uma compra específica
+// @Summary Buscar compra
+// @Description Retorna uma compra com os itens
+// @Tags purchases
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID da compra"
+// @Success 200 {object} utils.Response{data=dto.ApiPurchase} "Compra encontrada"
+// @Failure 400 {object} utils.Response "ID inválido"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Compra não encontrada"
+// @Router /purchases/{id} [get]
+func (h *PurchaseHandler) GetPurchase(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	purchase, err := h.purchaseService.GetPurchaseByID(id)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Compra não encontrada", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar compra", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Compra encontrada", purchase, nil)
+}
+
+// CreatePurchase cria um pedido de compra
+// @Summary Criar pedido de compra
+// @Description Cria um pedido de compra pendente. Cada item pode ser informado pelo produto ou pelo código do fornecedor;
+// @Description com o código do fornecedor, quantidade e preço são informados na embalagem do fornecedor e convertidos
+// @Description para a unidade do produto. Sem preço, o item usa o último preço pago ao fornecedor.
+// @Tags purchases
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body models.CreatePurchaseRequest true "Dados do pedido de compra"
+// @Success 201 {object} utils.Response{data=dto.ApiPurchase} "Compra criada com sucesso"
+// @Failure 400 {object} utils.Response "Dados inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Router /purchases [post]
+func (h *PurchaseHandler) CreatePurchase(c *gin.Context) {
+	var req models.CreatePurchaseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, "Dados inválidos", err.Error())
+		return
+	}
+
+	userID, exists := utils.GetUserIDFromContext(c)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Usuário não autenticado", "")
+		return
+	}
+
+	purchase, err := h.purchaseService.CreatePurchase(req, userID)
+	if err != nil {
+		if validator.IsValidationError(err) {
+			utils.ValidationErrorResponse(c, "Dados inválidos", validator.GetValidationErrors(err))
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao criar compra", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Compra criada com sucesso", purchase, nil)
+}
+
+// ReceivePurchase recebe as mercadorias de uma compra
+// @Summary Receber compra
+// @Description Registra a entrada no estoque das quantidades recebidas, no local informado, no local do pedido ou no
+// @Description local padrão, e atualiza o último preço pago no catálogo do fornecedor. Itens não informados são
+// @Description recebidos conforme o pedido. Produtos com controle de lotes exigem os lotes recebidos, com a validade.
+// @Description Produtos com número de série exigem um número por unidade recebida. O frete e os outros custos de
+// @Description aquisição são rateados entre os itens pelo valor recebido e recalculam, com o preço, o custo médio
+// @Description ponderado dos produtos.
+// @Tags purchases
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID da compra"
+// @Param request body models.ReceivePurchaseRequest false "Quantidades e preços recebidos"
+// @Success 200 {object} utils.Response{data=dto.ApiPurchase} "Compra recebida com sucesso"
+// @Failure 400 {object} utils.Response "Dados inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Compra não encontrada"
+// @Failure 409 {object} utils.Response "Compra não está pendente"
+// @Router /purchases/{id}/receive [post]
+func (h *PurchaseHandler) ReceivePurchase(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	var req models.ReceivePurchaseRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.ValidationErrorResponse(c, "Dados inválidos", err.Error())
+			return
+		}
+	}
+
+	userID, exists := utils.GetUserIDFromContext(c)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Usuário não autenticado", "")
+		return
+	}
+
+	purchase, err := h.purchaseService.ReceivePurchase(id, req, userID)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Compra não encontrada", err.Error())
+		} else if err == service.ErrPurchaseNotPending {
+			utils.ErrorResponse(c, http.StatusConflict, "Compra não está pendente", err.Error())
+		} else if validator.IsValidationError(err) {
+			utils.ValidationErrorResponse(c, "Dados inválidos", validator.GetValidationErrors(err))
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao receber compra", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Compra recebida com sucesso", purchase, nil)
+}
+
+// ConfirmPurchase confirma um pedido de compra em rascunho
+// @Summary Confirmar pedido de compra
+// @Description Confirma um pedido em rascunho, gerado pelas sugestões de reposição, que passa a pendente com a data de
+// @Description hoje. A data prevista de entrega é adiada pelo tempo em que o pedido ficou em rascunho. Com o bloqueio
+// @Description configurado, fornecedores com documentos obrigatórios vencidos não podem ter pedidos confirmados.
+// @Tags purchases
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID da compra"
+// @Success 200 {object} utils.Response{data=dto.ApiPurchase} "Compra confirmada com sucesso"
+// @Failure 400 {object} utils.Response "ID inválido ou fornecedor com documentos vencidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Compra não encontrada"
+// @Failure 409 {object} utils.Response "Compra não é um rascunho"
+// @Router /purchases/{id}/confirm [post]
+func (h *PurchaseHandler) ConfirmPurchase(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	purchase, err := h.purchaseService.ConfirmPurchase(id)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Compra não encontrada", err.Error())
+		} else if err == service.ErrPurchaseNotDraft {
+			utils.ErrorResponse(c, http.StatusConflict, "Compra não é um rascunho", err.Error())
+		} else if validator.IsValidationError(err) {
+			utils.ValidationErrorResponse(c, "Dados inválidos", validator.GetValidationErrors(err))
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao confirmar compra", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Compra confirmada com sucesso", purchase, nil)
+}
+
+// CancelPurchase cancela uma compra pendente
+// @Summary Cancelar compra
+// @Description Cancela uma compra pendente ou em rascunho, que ainda não foi recebida
+// @Tags purchases
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID da compra"
+// @Success 200 {object} utils.Response{data=dto.ApiPurchase} "Compra cancelada com sucesso"
+// @Failure 400 {object} utils.Response "ID inválido"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Compra não encontrada"
+// @Failure 409 {object} utils.Response "Compra não está pendente"
+// @Router /purchases/{id}/cancel [post]
+func (h *PurchaseHandler) CancelPurchase(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	purchase, err := h.purchaseService.CancelPurchase(id)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Compra não encontrada", err.Error())
+		} else if err == service.ErrPurchaseNotPending {
+			utils.ErrorResponse(c, http.StatusConflict, "Compra não está pendente", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao cancelar compra", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Compra cancelada com sucesso", purchase, nil)
+}
+
+// GetReturns retorna as devoluções de uma compra
+// @Summary Listar devoluções da compra
+// @Description Retorna as devoluções ao fornecedor registradas para a compra
+// @Tags purchases
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID da compra"
+// @Success 200 {object} utils.Response{data=[]dto.ApiPurchaseReturn} "Devoluções encontradas"
+// @Failure 400 {object} utils.Response "ID inválido"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Compra não encontrada"
+// @Router /purchases/{id}/returns [get]
+func (h *PurchaseHandler) GetReturns(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	returns, err := h.purchaseService.GetReturns(id)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Compra não encontrada", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar devoluções", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Devoluções encontradas", returns, nil)
+}
+
+// CreateReturn registra uma devolução ao fornecedor
+// @Summary Devolver itens ao fornecedor
+// @Description Registra a devolução de itens recebidos, com a saída do estoque. Cada item pode ser devolvido até a
+// @Description quantidade recebida menos as devoluções anteriores. Nos produtos com controle de lotes, sai o lote
+// @Description informado ou, sem ele, os lotes por ordem de validade. Nos produtos com número de série, informe os
+// @Description números devolvidos.
+// @Tags purchases
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID da compra"
+// @Param request body models.CreatePurchaseReturnRequest true "Itens devolvidos e motivo"
+// @Success 201 {object} utils.Response{data=dto.ApiPurchaseReturn} "Devolução registrada com sucesso"
+// @Failure 400 {object} utils.Response "Dados inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Compra não encontrada"
+// @Failure 409 {object} utils.Response "Compra ainda não recebida"
+// @Router /purchases/{id}/returns [post]
+func (h *PurchaseHandler) CreateReturn(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	var req models.CreatePurchaseReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, "Dados inválidos", err.Error())
+		return
+	}
+
+	userID, exists := utils.GetUserIDFromContext(c)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Usuário não autenticado", "")
+		return
+	}
+
+	purchaseReturn, err := h.purchaseService.ReturnPurchaseItems(id, req, userID)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Compra não encontrada", err.Error())
+		} else if err == service.ErrPurchaseNotReceived {
+			utils.ErrorResponse(c, http.StatusConflict, "Compra ainda não recebida", err.Error())
+		} else if validator.IsValidationError(err) {
+			utils.ValidationErrorResponse(c, "Dados inválidos", validator.GetValidationErrors(err))
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao registrar devolução", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Devolução registrada com sucesso", purchaseReturn, nil)
+}
+
+// GetReorderSuggestions retorna as sugestões de reposição de estoque
+// @Summary Sugestões de reposição
+// @Description Lista os produtos no ponto de reposição ou abaixo dele, agrupados pelo fornecedor preferencial (sem ele,
+// @Description o da última compra recebida). O ponto de reposição é o estoque mínimo mais a média diária de vendas da
+// @Description janela multiplicada pelo prazo de entrega do fornecedor. A posição do estoque é o estoque atual menos as
+// @Description reservas das vendas pendentes mais o que falta receber dos pedidos pendentes e em rascunho. A quantidade
+// @Description sugerida leva a posição até o estoque máximo (sem ele, até o ponto de reposição mais as vendas de uma
+// @Description janela), em embalagens inteiras do fornecedor.
+// @Tags purchases
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param days query int false "Janela da média de vendas, em dias" default(30)
+// @Param categoryId query int false "ID da categoria"
+// @Param supplierId query int false "ID do fornecedor preferencial"
+// @Success 200 {object} utils.Response{data=dto.ApiReorderSuggestions} "Sugestões de reposição geradas"
+// @Failure 400 {object} utils.Response "Filtros inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 500 {object} utils.Response "Erro ao gerar sugestões de reposição"
+// @Router /purchases/reorder-suggestions [get]
+func (h *PurchaseHandler) GetReorderSuggestions(c *gin.Context) {
+	var filters dto.InReorderSuggestionsFilters
+	if err := utils.BindQueryOrSendErrorRes(c, &filters); err != nil {
+		return
+	}
+
+	suggestions, err := h.replenishmentService.GetReorderSuggestions(filters)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao gerar sugestões de reposição", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Sugestões de reposição geradas", suggestions, nil)
+}
+
+// CreateReorderDrafts gera pedidos de compra em rascunho pelas sugestões de reposição
+// @Summary Gerar pedidos de reposição
+// @Description Gera um pedido de compra em rascunho para cada fornecedor das sugestões de reposição, com as quantidades
+// @Description sugeridas e o último preço pago (sem ele, o preço de custo). Os rascunhos contam como quantidade a receber
+// @Description nas próximas sugestões e devem ser confirmados para seguir como pedidos pendentes. Produtos sem
+// @Description fornecedor e fornecedores que não podem receber pedidos ficam de fora, com o motivo.
+// @Tags purchases
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body models.CreateReorderDraftsRequest false "Filtros das sugestões"
+// @Success 201 {object} utils.Response{data=dto.ApiReorderDrafts} "Pedidos de reposição gerados"
+// @Failure 400 {object} utils.Response "Dados inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Router /purchases/reorder-suggestions/drafts [post]
+func (h *PurchaseHandler) CreateReorderDrafts(c *gin.Context) {
+	var req models.CreateReorderDraftsRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.ValidationErrorResponse(c, "Dados inválidos", err.Error())
+			return
+		}
+	}
+
+	userID, exists := utils.GetUserIDFromContext(c)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Usuário não autenticado", "")
+		return
+	}
+
+	drafts, err := h.replenishmentService.CreateReorderDrafts(req, userID)
+	if err != nil {
+		if validator.IsValidationError(err) {
+			utils.ValidationErrorResponse(c, "Dados inválidos", validator.GetValidationErrors(err))
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao gerar pedidos de reposição", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Pedidos de reposição gerados", drafts, nil)
+}
@@ -0,0 +1,347 @@
+package handlers
+
+import (
+	"net/http"
+
+	"simple-erp-service/config"
+	"simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/service"
+	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/utils/path"
+	"simple-erp-service/internal/validator"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// SaleHandler gerencia as requisições relacionadas a vendas
+type SaleHandler struct {
+	saleService        *service.SaleService
+	lotService         *service.StockLotService
+	reservationService *service.StockReservationService
+}
+
+// NewSaleHandler cria um novo handler de vendas
+func NewSaleHandler(db *gorm.DB, inventoryCfg config.InventoryConfig, creditCfg config.CreditConfig) *SaleHandler {
+	lotRepo := repository.NewStockLotRepository(db)
+	productRepo := repository.NewProductRepository(db)
+	customerRepo := repository.NewCustomerRepository(db)
+	reservationRepo := repository.NewStockReservationRepository(db)
+	saleRepo := repository.NewSaleRepository(db)
+	unitRepo := repository.NewMeasurementUnitRepository(db)
+	locationRepo := repository.NewStockLocationRepository(db)
+
+	return &SaleHandler{
+		saleService:        service.NewSaleService(saleRepo, customerRepo, productRepo, unitRepo, locationRepo, lotRepo, creditCfg, inventoryCfg),
+		lotService:         service.NewStockLotService(lotRepo, productRepo, customerRepo),
+		reservationService: service.NewStockReservationService(reservationRepo, inventoryCfg),
+	}
+}
+
+// GetSales retorna uma lista paginada de vendas
+// @Summary Listar vendas
+// @Description Retorna uma lista paginada de vendas, com filtros por cliente, situação e período
+// @Tags sales
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "Número da página" default(1)
+// @Param limit query int false "Limite de itens por página" default(10)
+// @Param sort query string false "Campo para ordenação" default(created_at)
+// @Param order query string false "Direção da ordenação (asc/desc)" default(desc)
+// @Param customerId query int false "ID do cliente"
+// @Param status query string false "Situação da venda" Enums(pendente, faturado, pago, cancelado)
+// @Param dateFrom query string false "Vendas a partir de (AAAA-MM-DD)"
+// @Param dateTo query string false "Vendas até (AAAA-MM-DD, inclusive)"
+// @Success 200 {object} utils.Response{data=dto.ApiSaleListPaginated} "Vendas encontradas"
+// @Failure 400 {object} utils.Response "Filtros inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 500 {object} utils.Response "Erro ao buscar vendas"
+// @Router /sales [get]
+func (h *SaleHandler) GetSales(c *gin.Context) {
+	pagination := utils.GetPaginationParams(c)
+
+	var filters dto.InGetSalesFilters
+	if err := utils.BindQueryOrSendErrorRes(c, &filters); err != nil {
+		return
+	}
+
+	sales, err := h.saleService.GetSales(&pagination, filters)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar vendas", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Vendas encontradas", sales, nil)
+}
+
+// GetSale retorna uma venda específica
+// @Summary Buscar venda
+// @Description Retorna uma venda com os itens e, depois do faturamento, os lotes entregues em cada item
+// @Tags sales
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID da venda"
+// @Success 200 {object} utils.Response{data=dto.ApiSale} "Venda encontrada"
+// @Failure 400 {object} utils.Response "ID inválido"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Venda não encontrada"
+// @Router /sales/{id} [get]
+func (h *SaleHandler) GetSale(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	sale, err := h.saleService.GetSaleByID(id)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Venda não encontrada", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar venda", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Venda encontrada", sale, nil)
+}
+
+// CreateSale cria uma venda
+// @Summary Criar venda
+// @Description Cria uma venda pendente e reserva o estoque dos itens no local informado ou no local padrão. Os kits
+// @Description reservam os componentes. Itens em outra unidade são convertidos para a unidade do produto, e itens sem
+// @Description preço usam o preço de venda do produto. O estoque disponível (saldo menos as reservas das outras
+// @Description vendas) deve cobrir os itens. Vendas a prazo passam pela análise de crédito do cliente: são recusadas
+// @Description para clientes bloqueados e, com limite excedido ou títulos vencidos, recusadas ou enviadas para
+// @Description aprovação do gerente, conforme CREDIT_EXCEEDED_ACTION.
+// @Tags sales
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param request body models.CreateSaleRequest true "Dados da venda"
+// @Success 201 {object} utils.Response{data=dto.ApiSale} "Venda criada com sucesso"
+// @Failure 400 {object} utils.Response "Dados inválidos ou estoque insuficiente"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Router /sales [post]
+func (h *SaleHandler) CreateSale(c *gin.Context) {
+	var req models.CreateSaleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, "Dados inválidos", err.Error())
+		return
+	}
+
+	userID, exists := utils.GetUserIDFromContext(c)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Usuário não autenticado", "")
+		return
+	}
+
+	sale, err := h.saleService.CreateSale(req, userID)
+	if err != nil {
+		if validator.IsValidationError(err) {
+			utils.ValidationErrorResponse(c, "Dados inválidos", validator.GetValidationErrors(err))
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao criar venda", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Venda criada com sucesso", sale, nil)
+}
+
+// InvoiceSale fatura uma venda pendente
+// @Summary Faturar venda
+// @Description Registra a saída do estoque dos itens no local da venda ou no local padrão e baixa as reservas da
+// @Description venda. Os kits baixam os componentes. Produtos com controle de lotes saem dos lotes por ordem de
+// @Description validade (FEFO). Produtos com número de série exigem um número por unidade vendida, informado no
+// @Description item. O custo das mercadorias vendidas fica registrado em cada item, pelo custo médio. Vendas a prazo
+// @Description exigem o crédito aprovado e geram o título a receber, vencendo no prazo de pagamento do cliente.
+// @Tags sales
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID da venda"
+// @Param request body models.InvoiceSaleRequest false "Números de série entregues nos itens"
+// @Success 200 {object} utils.Response{data=dto.ApiSale} "Venda faturada com sucesso"
+// @Failure 400 {object} utils.Response "ID inválido ou estoque insuficiente"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Venda não encontrada"
+// @Failure 409 {object} utils.Response "Venda não está pendente ou aguarda aprovação do crédito"
+// @Router /sales/{id}/invoice [post]
+func (h *SaleHandler) InvoiceSale(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	var req models.InvoiceSaleRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.ValidationErrorResponse(c, "Dados inválidos", err.Error())
+			return
+		}
+	}
+
+	userID, exists := utils.GetUserIDFromContext(c)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Usuário não autenticado", "")
+		return
+	}
+
+	sale, err := h.saleService.InvoiceSale(id, req, userID)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Venda não encontrada", err.Error())
+		} else if err == service.ErrSaleNotPending {
+			utils.ErrorResponse(c, http.StatusConflict, "Venda não está pendente", err.Error())
+		} else if err == service.ErrSaleCreditNotApproved {
+			utils.ErrorResponse(c, http.StatusConflict, "Crédito da venda não aprovado", err.Error())
+		} else if validator.IsValidationError(err) {
+			utils.ValidationErrorResponse(c, "Dados inválidos", validator.GetValidationErrors(err))
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao faturar venda", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Venda faturada com sucesso", sale, nil)
+}
+
+// ApproveSaleCredit aprova o crédito de uma venda a prazo
+// @Summary Aprovar crédito da venda
+// @Description Aprova uma venda a prazo pendente que aguarda aprovação por exceder o limite de crédito ou por
+// @Description títulos vencidos do cliente, liberando o faturamento
+// @Tags sales
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID da venda"
+// @Success 200 {object} utils.Response{data=dto.ApiSale} "Crédito aprovado com sucesso"
+// @Failure 400 {object} utils.Response "ID inválido"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Venda não encontrada"
+// @Failure 409 {object} utils.Response "Venda não está pendente ou não aguarda aprovação"
+// @Router /sales/{id}/credit/approve [post]
+func (h *SaleHandler) ApproveSaleCredit(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	userID, exists := utils.GetUserIDFromContext(c)
+	if !exists {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Usuário não autenticado", "")
+		return
+	}
+
+	sale, err := h.saleService.ApproveSaleCredit(id, userID)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Venda não encontrada", err.Error())
+		} else if err == service.ErrSaleNotPending || err == service.ErrSaleNoApprovalRequired {
+			utils.ErrorResponse(c, http.StatusConflict, "Venda não aguarda aprovação do crédito", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao aprovar crédito", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Crédito aprovado com sucesso", sale, nil)
+}
+
+// CancelSale cancela uma venda pendente
+// @Summary Cancelar venda
+// @Description Cancela uma venda pendente e libera as reservas de estoque dos itens
+// @Tags sales
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID da venda"
+// @Success 200 {object} utils.Response{data=dto.ApiSale} "Venda cancelada com sucesso"
+// @Failure 400 {object} utils.Response "ID inválido"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Venda não encontrada"
+// @Failure 409 {object} utils.Response "Venda não está pendente"
+// @Router /sales/{id}/cancel [post]
+func (h *SaleHandler) CancelSale(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	sale, err := h.saleService.CancelSale(id)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Venda não encontrada", err.Error())
+		} else if err == service.ErrSaleNotPending {
+			utils.ErrorResponse(c, http.StatusConflict, "Venda não está pendente", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao cancelar venda", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Venda cancelada com sucesso", sale, nil)
+}
+
+// GetSaleLots lista os lotes entregues na venda
+// @Summary Lotes da venda
+// @Description Retorna os lotes entregues em cada item da venda, com a validade e o local de saída. Itens de
+// @Description produtos sem controle de lotes não aparecem.
+// @Tags sales
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID da venda"
+// @Success 200 {object} utils.Response{data=[]dto.ApiSaleLot} "Lotes encontrados"
+// @Failure 400 {object} utils.Response "ID inválido"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 500 {object} utils.Response "Erro ao buscar lotes"
+// @Router /sales/{id}/lots [get]
+func (h *SaleHandler) GetSaleLots(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	lots, err := h.lotService.GetSaleLots(id)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar lotes", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Lotes encontrados", lots, nil)
+}
+
+// GetSaleReservations lista as reservas de estoque da venda
+// @Summary Reservas de estoque da venda
+// @Description Retorna as reservas de estoque dos itens da venda, com o local e a situação: ativa enquanto a venda
+// @Description está pendente, baixada no faturamento, liberada no cancelamento ou expirada. Os kits reservam os
+// @Description componentes.
+// @Tags sales
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID da venda"
+// @Success 200 {object} utils.Response{data=[]dto.ApiStockReservation} "Reservas encontradas"
+// @Failure 400 {object} utils.Response "ID inválido"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 500 {object} utils.Response "Erro ao buscar reservas"
+// @Router /sales/{id}/reservations [get]
+func (h *SaleHandler) GetSaleReservations(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	reservations, err := h.reservationService.GetSaleReservations(id)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar reservas", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Reservas encontradas", reservations, nil)
+}
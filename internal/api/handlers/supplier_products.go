@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"net/http"
+
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/service"
+	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/utils/path"
+	"simple-erp-service/internal/validator"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// SupplierCatalogHandler gerencia as requisições do catálogo de produtos dos fornecedores
+type SupplierCatalogHandler struct {
+	catalogService *service.SupplierCatalogService
+}
+
+// NewSupplierCatalogHandler cria um novo handler do catálogo dos fornecedores
+func NewSupplierCatalogHandler(db *gorm.DB) *SupplierCatalogHandler {
+	supplierRepo := repository.NewSupplierRepository(db)
+	productRepo := repository.NewProductRepository(db)
+	supplierProductRepo := repository.NewSupplierProductRepository(db)
+
+	return &SupplierCatalogHandler{
+		catalogService: service.NewSupplierCatalogService(supplierRepo, productRepo, supplierProductRepo),
+	}
+}
+
+// GetCatalog retorna o catálogo de produtos do fornecedor
+// @Summary Listar catálogo do fornecedor
+// @Description Retorna os produtos do fornecedor com código, embalagem, prazo de entrega e último preço
+// @Tags suppliers
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do fornecedor"
+// @Success 200 {object} utils.Response{data=[]dto.ApiSupplierProduct} "Catálogo encontrado"
+// @Failure 400 {object} utils.Response "ID inválido"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Fornecedor não encontrado"
+// @Router /suppliers/{id}/products [get]
+func (h *SupplierCatalogHandler) GetCatalog(c *gin.Context) {
+	supplierID, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	catalog, err := h.catalogService.GetCatalog(supplierID)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Fornecedor não encontrado", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar catálogo do fornecedor", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Catálogo encontrado", catalog, nil)
+}
+
+// CreateCatalogItem adiciona um produto ao catálogo do fornecedor
+// @Summary Adicionar produto ao catálogo do fornecedor
+// @Description Vincula um produto ao fornecedor com o código do fornecedor, a embalagem (quantidade de unidades
+// @Description do produto por embalagem), o prazo de entrega e o preço por embalagem
+// @Tags suppliers
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do fornecedor"
+// @Param request body models.SupplierProductRequest true "Dados do item do catálogo"
+// @Success 201 {object} utils.Response{data=dto.ApiSupplierProduct} "Produto adicionado ao catálogo"
+// @Failure 400 {object} utils.Response "Dados inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Fornecedor não encontrado"
+// @Router /suppliers/{id}/products [post]
+func (h *SupplierCatalogHandler) CreateCatalogItem(c *gin.Context) {
+	supplierID, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	var req models.SupplierProductRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, "Dados inválidos", err.Error())
+		return
+	}
+
+	item, err := h.catalogService.CreateCatalogItem(supplierID, req)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Fornecedor não encontrado", err.Error())
+		} else if validator.IsValidationError(err) {
+			utils.ValidationErrorResponse(c, "Dados inválidos", validator.GetValidationErrors(err))
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao adicionar produto ao catálogo", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Produto adicionado ao catálogo", item, nil)
+}
+
+// UpdateCatalogItem atualiza um item do catálogo do fornecedor
+// @Summary Atualizar item do catálogo do fornecedor
+// @Description Atualiza um item do catálogo. O último preço só é substituído quando informado.
+// @Tags suppliers
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do fornecedor"
+// @Param itemId path int true "ID do item do catálogo"
+// @Param request body models.SupplierProductRequest true "Dados do item do catálogo"
+// @Success 200 {object} utils.Response{data=dto.ApiSupplierProduct} "Item do catálogo atualizado"
+// @Failure 400 {object} utils.Response "Dados inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Item não encontrado"
+// @Router /suppliers/{id}/products/{itemId} [put]
+func (h *SupplierCatalogHandler) UpdateCatalogItem(c *gin.Context) {
+	supplierID, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+	itemID, err := childIDFromPath(c, "itemId")
+	if err != nil {
+		return
+	}
+
+	var req models.SupplierProductRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ValidationErrorResponse(c, "Dados inválidos", err.Error())
+		return
+	}
+
+	item, err := h.catalogService.UpdateCatalogItem(supplierID, itemID, req)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Item não encontrado", err.Error())
+		} else if validator.IsValidationError(err) {
+			utils.ValidationErrorResponse(c, "Dados inválidos", validator.GetValidationErrors(err))
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao atualizar item do catálogo", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Item do catálogo atualizado", item, nil)
+}
+
+// DeleteCatalogItem remove um item do catálogo do fornecedor
+// @Summary Remover item do catálogo do fornecedor
+// @Description Remove o produto do catálogo do fornecedor
+// @Tags suppliers
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do fornecedor"
+// @Param itemId path int true "ID do item do catálogo"
+// @Success 200 {object} utils.Response "Item removido do catálogo"
+// @Failure 400 {object} utils.Response "ID inválido"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Item não encontrado"
+// @Router /suppliers/{id}/products/{itemId} [delete]
+func (h *SupplierCatalogHandler) DeleteCatalogItem(c *gin.Context) {
+	supplierID, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+	itemID, err := childIDFromPath(c, "itemId")
+	if err != nil {
+		return
+	}
+
+	if err := h.catalogService.DeleteCatalogItem(supplierID, itemID); err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Item não encontrado", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao remover item do catálogo", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Item removido do catálogo", nil, nil)
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"net/http"
+
+	"simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/service"
+	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/utils/path"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// SupplierScorecardHandler gerencia as requisições do desempenho dos fornecedores
+type SupplierScorecardHandler struct {
+	scorecardService *service.SupplierScorecardService
+}
+
+// NewSupplierScorecardHandler cria um novo handler de scorecard de fornecedores
+func NewSupplierScorecardHandler(db *gorm.DB) *SupplierScorecardHandler {
+	supplierRepo := repository.NewSupplierRepository(db)
+	scorecardRepo := repository.NewSupplierScorecardRepository(db)
+
+	return &SupplierScorecardHandler{
+		scorecardService: service.NewSupplierScorecardService(supplierRepo, scorecardRepo),
+	}
+}
+
+// GetLeaderboard retorna o ranking dos fornecedores
+// @Summary Ranking de fornecedores
+// @Description Classifica os fornecedores pelos indicadores das compras recebidas no período: pontualidade em relação à
+// @Description data prevista, quantidade atendida, variação de preço em relação ao pedido e devoluções.
+// @Description A nota (0 a 100) pondera pontualidade (35), atendimento (30), preço (20) e devoluções (15).
+// @Tags suppliers
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param dateFrom query string false "Compras e devoluções a partir de (AAAA-MM-DD)"
+// @Param dateTo query string false "Compras e devoluções até (AAAA-MM-DD, inclusive)"
+// @Param minPurchases query int false "Mínimo de compras recebidas para participar do ranking"
+// @Param sortBy query string false "Critério de ordenação" Enums(score, on_time_rate, fill_rate, price_variance, returns) default(score)
+// @Param limit query int false "Quantidade de fornecedores"
+// @Success 200 {object} utils.Response{data=dto.ApiSupplierLeaderboard} "Ranking de fornecedores gerado"
+// @Failure 400 {object} utils.Response "Filtros inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 500 {object} utils.Response "Erro ao gerar ranking de fornecedores"
+// @Router /suppliers/scorecards [get]
+func (h *SupplierScorecardHandler) GetLeaderboard(c *gin.Context) {
+	var filters dto.InSupplierScorecardFilters
+	if err := utils.BindQueryOrSendErrorRes(c, &filters); err != nil {
+		return
+	}
+
+	leaderboard, err := h.scorecardService.GetLeaderboard(filters)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao gerar ranking de fornecedores", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Ranking de fornecedores gerado", leaderboard, nil)
+}
+
+// GetScorecard retorna o scorecard de um fornecedor
+// @Summary Scorecard do fornecedor
+// @Description Retorna os indicadores de desempenho do fornecedor nas compras recebidas e devoluções do período
+// @Tags suppliers
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path int true "ID do fornecedor"
+// @Param dateFrom query string false "Compras e devoluções a partir de (AAAA-MM-DD)"
+// @Param dateTo query string false "Compras e devoluções até (AAAA-MM-DD, inclusive)"
+// @Success 200 {object} utils.Response{data=dto.ApiSupplierScorecard} "Scorecard gerado"
+// @Failure 400 {object} utils.Response "Dados inválidos"
+// @Failure 401 {object} utils.Response "Não autorizado"
+// @Failure 404 {object} utils.Response "Fornecedor não encontrado"
+// @Router /suppliers/{id}/scorecard [get]
+func (h *SupplierScorecardHandler) GetScorecard(c *gin.Context) {
+	id, err := path.IdFromPathParamOrSendError(c)
+	if err != nil {
+		return
+	}
+
+	var filters dto.InSupplierScorecardFilters
+	if err := utils.BindQueryOrSendErrorRes(c, &filters); err != nil {
+		return
+	}
+
+	scorecard, err := h.scorecardService.GetScorecard(id, filters)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			utils.ErrorResponse(c, http.StatusNotFound, "Fornecedor não encontrado", err.Error())
+		} else {
+			utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao gerar scorecard do fornecedor", err.Error())
+		}
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Scorecard gerado", scorecard, nil)
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"simple-erp-service/internal/data-structure/dto"
 	"simple-erp-service/internal/data-structure/models"
 	"simple-erp-service/internal/repository"
 	"simple-erp-service/internal/service"
@@ -30,7 +31,7 @@ func NewSupplierHandler(db *gorm.DB) *SupplierHandler {
 
 // GetSuppliers retorna uma lista paginada de fornecedores
 // @Summary Listar fornecedores
-// @Description Retorna uma lista paginada de fornecedores
+// @Description Retorna uma lista paginada de fornecedores, com busca sem acentos e filtros
 // @Tags suppliers
 // @Accept json
 // @Produce json
@@ -39,14 +40,28 @@ func NewSupplierHandler(db *gorm.DB) *SupplierHandler {
 // @Param limit query int false "Limite de itens por página" default(10)
 // @Param sort query string false "Campo para ordenação" default(id)
 // @Param order query string false "Direção da ordenação (asc/desc)" default(asc)
+// @Param search query string false "Busca em nome, razão social, documento e contatos"
+// @Param personType query string false "Tipo de pessoa (F/J)"
+// @Param isActive query bool false "Somente ativos (true) ou inativos (false)"
+// @Param cityId query int false "ID da cidade de algum endereço"
+// @Param stateId query int false "ID do estado de algum endereço"
+// @Param uf query string false "UF de algum endereço (ex: SP)"
+// @Param createdFrom query string false "Criado a partir de (AAAA-MM-DD)"
+// @Param createdTo query string false "Criado até (AAAA-MM-DD, inclusive)"
 // @Success 200 {object} utils.Response "Fornecedores encontrados"
+// @Failure 400 {object} utils.Response "Filtros inválidos"
 // @Failure 401 {object} utils.Response "Não autorizado"
 // @Failure 500 {object} utils.Response "Erro ao buscar fornecedores"
 // @Router /suppliers [get]
 func (h *SupplierHandler) GetSuppliers(c *gin.Context) {
 	pagination := utils.GetPaginationParams(c)
 
-	suppliers, err := h.supplierService.GetSuppliers(&pagination)
+	var filters dto.InGetPartiesFilters
+	if err := utils.BindQueryOrSendErrorRes(c, &filters); err != nil {
+		return
+	}
+
+	suppliers, err := h.supplierService.GetSuppliers(&pagination, filters)
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Erro ao buscar fornecedores", err.Error())
 		return
@@ -110,7 +125,7 @@ func (h *SupplierHandler) CreateSupplier(c *gin.Context) {
 	supplier, err := h.supplierService.CreateSupplier(req)
 	if err != nil {
 		if validator.IsValidationError(err) {
-			utils.ValidationErrorResponse(c, "Dados inválidos", err.Error())
+			utils.ValidationErrorResponse(c, "Dados inválidos", validator.GetValidationErrors(err))
 		} else {
 			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao criar fornecedor", err.Error())
 		}
@@ -152,7 +167,7 @@ func (h *SupplierHandler) UpdateSupplier(c *gin.Context) {
 		if err == utils.ErrNotFound {
 			utils.ErrorResponse(c, http.StatusNotFound, "Fornecedor não encontrado", err.Error())
 		} else if validator.IsValidationError(err) {
-			utils.ValidationErrorResponse(c, "Dados inválidos", err.Error())
+			utils.ValidationErrorResponse(c, "Dados inválidos", validator.GetValidationErrors(err))
 		} else {
 			utils.ErrorResponse(c, http.StatusBadRequest, "Erro ao atualizar fornecedor", err.Error())
 		}
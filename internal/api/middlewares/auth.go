@@ -3,6 +3,7 @@ package middlewares
 import (
 	"log" // Adicionar log para depuração
 	"net/http"
+	"strconv"
 	"strings"
 
 	"simple-erp-service/config"
@@ -56,6 +57,10 @@ func AuthMiddleware(cfg *config.Config) gin.HandlerFunc {
 		c.Set("roleID", claims.RoleID)
 		c.Set("role", claims.Role)
 		c.Set("permissions", claims.Permissions)
+		if claims.ImpersonatorID != nil {
+			c.Set("impersonatorID", *claims.ImpersonatorID)
+			c.Header("X-Impersonator-ID", strconv.FormatUint(uint64(*claims.ImpersonatorID), 10))
+		}
 
 		log.Printf("Middleware: Token validado com sucesso para UserID: %d", claims.UserID)
 		c.Next()
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/utils"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -57,3 +58,44 @@ func LoggerMiddleware(db *gorm.DB) gin.HandlerFunc {
 		}
 	}
 }
+
+// ImpersonationAuditMiddleware registra toda requisição feita durante uma personificação,
+// guardando tanto o usuário personificado quanto o usuário que o está personificando
+func ImpersonationAuditMiddleware(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		startTime := time.Now()
+
+		c.Next()
+
+		// O AuthMiddleware só define o impersonatorID quando o token é de personificação
+		impersonatorID, isImpersonating := utils.GetImpersonatorIDFromContext(c)
+		if !isImpersonating {
+			return
+		}
+		userID, _ := utils.GetUserIDFromContext(c)
+
+		log := models.SystemLog{
+			UserID:         &userID,
+			ImpersonatorID: &impersonatorID,
+			Action:         c.Request.Method + " " + c.Request.URL.Path,
+			EntityType:     "impersonation",
+			IPAddress:      c.ClientIP(),
+			Details: map[string]interface{}{
+				"status":          c.Writer.Status(),
+				"latency_ms":      time.Since(startTime).Milliseconds(),
+				"user_agent":      c.Request.UserAgent(),
+				"query":           c.Request.URL.RawQuery,
+				"impersonator_id": impersonatorID,
+			},
+		}
+
+		if entityID := c.Param("id"); entityID != "" {
+			log.EntityID = entityID
+		}
+
+		// Salvar log de forma assíncrona
+		go func(log models.SystemLog) {
+			db.Create(&log)
+		}(log)
+	}
+}
@@ -24,6 +24,10 @@ func SetupAuthRoutes(router *gin.RouterGroup, db *gorm.DB, cfg *config.Config) {
 		{
 			protected.POST("/logout", authHandler.Logout)
 			protected.GET("/me", authHandler.GetMe)
+
+			// Personificação (suporte)
+			protected.POST("/impersonate/stop", authHandler.StopImpersonation)
+			protected.POST("/impersonate/:id", middlewares.RequirePermission("users.impersonate"), authHandler.Impersonate)
 		}
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"simple-erp-service/config"
 	"simple-erp-service/internal/api/handlers"
 	"simple-erp-service/internal/api/middlewares"
+	"simple-erp-service/internal/data-structure/models"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -12,18 +13,57 @@ import (
 // SetupCustomersRoutes configura as rotas de customers
 func SetupCustomersRoutes(router *gin.RouterGroup, db *gorm.DB) {
 	customerHandler := handlers.NewCustomerHandler(db)
+	addressHandler := handlers.NewAddressHandler(db, models.OwnerCustomer)
+	contactHandler := handlers.NewContactHandler(db, models.OwnerCustomer)
+	documentHandler := handlers.NewDocumentHandler(db, models.OwnerCustomer)
+	importHandler := handlers.NewImportHandler(db, models.OwnerCustomer)
 
 	// Obter configuração para middleware de autenticação
 	cfg, _ := config.Load()
+	creditHandler := handlers.NewCustomerCreditHandler(db, cfg.Credit)
+	lgpdHandler := handlers.NewCustomerLGPDHandler(db, cfg.LGPD)
 
 	// Grupo de rotas de usuários (todas protegidas)
 	customers := router.Group("/customers")
 	customers.Use(middlewares.AuthMiddleware(cfg))
 	{
 		customers.GET("", middlewares.RequirePermission("customers.view"), customerHandler.GetCustomers)
+		customers.GET("/duplicates", middlewares.RequirePermission("customers.merge"), customerHandler.GetDuplicateCustomers)
 		customers.GET("/:id", middlewares.RequirePermission("customers.view"), customerHandler.GetCustomer)
 		customers.POST("", middlewares.RequirePermission("customers.create"), customerHandler.CreateCustomer)
 		customers.PUT("/:id", middlewares.RequirePermission("customers.edit"), customerHandler.UpdateCustomer)
 		customers.DELETE("/:id", middlewares.RequirePermission("customers.delete"), customerHandler.DeleteCustomer)
+		customers.POST("/:id/merge", middlewares.RequirePermission("customers.merge"), customerHandler.MergeCustomer)
+
+		// Crédito e extrato financeiro
+		customers.GET("/:id/statement", middlewares.RequirePermission("customers.view"), creditHandler.GetStatement)
+		customers.PUT("/:id/credit", middlewares.RequirePermission("customers.credit"), creditHandler.UpdateCredit)
+		customers.POST("/:id/credit/check", middlewares.RequirePermission("customers.view"), creditHandler.CheckCredit)
+
+		// Solicitações do titular dos dados (LGPD)
+		customers.GET("/:id/lgpd/export", middlewares.RequirePermission("customers.lgpd"), lgpdHandler.ExportCustomerData)
+		customers.POST("/:id/lgpd/anonymize", middlewares.RequirePermission("customers.lgpd"), lgpdHandler.AnonymizeCustomer)
+
+		// Importação em lote (CSV/XLSX)
+		customers.POST("/import/preview", middlewares.RequirePermission("customers.create"), importHandler.PreviewImport)
+		customers.POST("/import", middlewares.RequirePermission("customers.create"), importHandler.Import)
+
+		// Endereços
+		customers.GET("/:id/addresses", middlewares.RequirePermission("customers.view"), addressHandler.GetAddresses)
+		customers.POST("/:id/addresses", middlewares.RequirePermission("customers.edit"), addressHandler.CreateAddress)
+		customers.PUT("/:id/addresses/:addressId", middlewares.RequirePermission("customers.edit"), addressHandler.UpdateAddress)
+		customers.DELETE("/:id/addresses/:addressId", middlewares.RequirePermission("customers.edit"), addressHandler.DeleteAddress)
+
+		// Contatos
+		customers.GET("/:id/contacts", middlewares.RequirePermission("customers.view"), contactHandler.GetContacts)
+		customers.POST("/:id/contacts", middlewares.RequirePermission("customers.edit"), contactHandler.CreateContact)
+		customers.PUT("/:id/contacts/:contactId", middlewares.RequirePermission("customers.edit"), contactHandler.UpdateContact)
+		customers.DELETE("/:id/contacts/:contactId", middlewares.RequirePermission("customers.edit"), contactHandler.DeleteContact)
+
+		// Documentos
+		customers.GET("/:id/documents", middlewares.RequirePermission("customers.view"), documentHandler.GetDocuments)
+		customers.POST("/:id/documents", middlewares.RequirePermission("customers.edit"), documentHandler.CreateDocument)
+		customers.PUT("/:id/documents/:documentId", middlewares.RequirePermission("customers.edit"), documentHandler.UpdateDocument)
+		customers.DELETE("/:id/documents/:documentId", middlewares.RequirePermission("customers.edit"), documentHandler.DeleteDocument)
 	}
 }
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"simple-erp-service/config"
+	"simple-erp-service/internal/api/handlers"
+	"simple-erp-service/internal/api/middlewares"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// SetupDocumentRoutes configura as rotas de consulta dos documentos de clientes e fornecedores
+func SetupDocumentRoutes(router *gin.RouterGroup, db *gorm.DB) {
+	// Obter configuração para middleware de autenticação
+	cfg, _ := config.Load()
+	expiryHandler := handlers.NewDocumentExpiryHandler(db, cfg.Documents)
+
+	documents := router.Group("/documents")
+	documents.Use(middlewares.AuthMiddleware(cfg))
+	{
+		documents.GET("/expiring", middlewares.RequirePermission("documents.view"), expiryHandler.GetExpiringDocuments)
+	}
+}
@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"simple-erp-service/config"
+	"simple-erp-service/internal/api/handlers"
+	"simple-erp-service/internal/api/middlewares"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// SetupImportRoutes configura as rotas de acompanhamento das importações em lote.
+// O envio das planilhas fica nas rotas de clientes e fornecedores.
+func SetupImportRoutes(router *gin.RouterGroup, db *gorm.DB) {
+	importHandler := handlers.NewImportHandler(db, "")
+
+	// Obter configuração para middleware de autenticação
+	cfg, _ := config.Load()
+
+	// Grupo de rotas de importações (todas protegidas)
+	imports := router.Group("/imports")
+	imports.Use(middlewares.AuthMiddleware(cfg))
+	{
+		imports.GET("/:id", importHandler.GetImportJob)
+	}
+}
@@ -1,11 +1,46 @@
 package routes
 
 import (
+	"simple-erp-service/config"
+	"simple-erp-service/internal/api/handlers"
+	"simple-erp-service/internal/api/middlewares"
+
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
 // SetupInventoryRoutes configura as rotas de inventory
 func SetupInventoryRoutes(router *gin.RouterGroup, db *gorm.DB) {
-	// TODO: Implementar rotas de inventory
+	inventoryHandler := handlers.NewInventoryHandler(db)
+
+	// Obter configuração para middleware de autenticação
+	cfg, _ := config.Load()
+
+	// Grupo de rotas de estoque (todas protegidas)
+	inventory := router.Group("/inventory")
+	inventory.Use(middlewares.AuthMiddleware(cfg))
+	{
+		// Locais de estoque (depósitos e endereços) e saldos por local
+		inventory.GET("/locations", middlewares.RequirePermission("stock_locations.view"), inventoryHandler.GetLocations)
+		inventory.GET("/locations/:id", middlewares.RequirePermission("stock_locations.view"), inventoryHandler.GetLocation)
+		inventory.POST("/locations", middlewares.RequirePermission("stock_locations.edit"), inventoryHandler.CreateLocation)
+		inventory.PUT("/locations/:id", middlewares.RequirePermission("stock_locations.edit"), inventoryHandler.UpdateLocation)
+		inventory.GET("/locations/:id/balances", middlewares.RequirePermission("product_location.view"), inventoryHandler.GetLocationBalances)
+
+		// Transferências entre locais
+		inventory.GET("/transfers", middlewares.RequirePermission("inventory.view"), inventoryHandler.GetTransfers)
+		inventory.POST("/transfers", middlewares.RequirePermission("inventory.edit"), inventoryHandler.CreateTransfer)
+
+		// Lotes, vencimentos e rastreabilidade
+		inventory.GET("/lots/expiring", middlewares.RequirePermission("inventory.reports"), inventoryHandler.GetExpiringLots)
+		inventory.GET("/lots/:id", middlewares.RequirePermission("inventory.view"), inventoryHandler.GetLot)
+		inventory.GET("/lots/:id/traceability", middlewares.RequirePermission("inventory.reports"), inventoryHandler.GetLotTraceability)
+
+		// Números de série, histórico e garantia
+		inventory.GET("/serials", middlewares.RequirePermission("inventory.view"), inventoryHandler.GetSerialNumbers)
+		inventory.GET("/serials/:id", middlewares.RequirePermission("inventory.view"), inventoryHandler.GetSerialNumber)
+
+		// Valorização do estoque pelo custo médio
+		inventory.GET("/valuation", middlewares.RequirePermission("inventory.reports"), inventoryHandler.GetValuation)
+	}
 }
@@ -0,0 +1,32 @@
+package routes
+
+import (
+	"simple-erp-service/config"
+	"simple-erp-service/internal/api/handlers"
+	"simple-erp-service/internal/api/middlewares"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// SetupInventoryCountsRoutes configura as rotas de inventários
+func SetupInventoryCountsRoutes(router *gin.RouterGroup, db *gorm.DB) {
+	countHandler := handlers.NewInventoryCountHandler(db)
+
+	// Obter configuração para middleware de autenticação
+	cfg, _ := config.Load()
+
+	// Grupo de rotas de inventários (todas protegidas)
+	counts := router.Group("/inventory-counts")
+	counts.Use(middlewares.AuthMiddleware(cfg))
+	{
+		counts.GET("", middlewares.RequirePermission("inventory_counts.view"), countHandler.GetCounts)
+		counts.GET("/:id", middlewares.RequirePermission("inventory_counts.view"), countHandler.GetCount)
+		counts.GET("/:id/variances", middlewares.RequirePermission("inventory.reports"), countHandler.GetVarianceReport)
+		counts.POST("", middlewares.RequirePermission("inventory_counts.manage"), countHandler.CreateCount)
+		counts.POST("/:id/counts", middlewares.RequirePermission("inventory_counts.count"), countHandler.SubmitCounts)
+		counts.POST("/:id/recount", middlewares.RequirePermission("inventory_counts.manage"), countHandler.RecountItems)
+		counts.POST("/:id/approve", middlewares.RequirePermission("inventory_counts.manage"), countHandler.ApproveCount)
+		counts.POST("/:id/cancel", middlewares.RequirePermission("inventory_counts.manage"), countHandler.CancelCount)
+	}
+}
@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"simple-erp-service/config"
+	"simple-erp-service/internal/api/handlers"
+	"simple-erp-service/internal/api/middlewares"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// SetupLocationRoutes configura as rotas de consulta de países, estados, cidades e CEPs
+func SetupLocationRoutes(router *gin.RouterGroup, db *gorm.DB) {
+	locationHandler := handlers.NewLocationHandler(db)
+
+	// Obter configuração para middleware de autenticação
+	cfg, _ := config.Load()
+
+	// Dados de referência usados nos formulários de endereço: basta estar autenticado
+	locations := router.Group("/locations")
+	locations.Use(middlewares.AuthMiddleware(cfg))
+	{
+		locations.GET("/countries", locationHandler.GetCountries)
+		locations.GET("/countries/:id/states", locationHandler.GetStates)
+		locations.GET("/states/:id/cities", locationHandler.GetCities)
+		locations.GET("/postal-codes/:zipCode", locationHandler.GetPostalCode)
+	}
+}
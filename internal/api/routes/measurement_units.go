@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"simple-erp-service/config"
+	"simple-erp-service/internal/api/handlers"
+	"simple-erp-service/internal/api/middlewares"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// SetupMeasurementUnitRoutes configura as rotas de unidades de medida e conversões
+func SetupMeasurementUnitRoutes(router *gin.RouterGroup, db *gorm.DB) {
+	unitHandler := handlers.NewMeasurementUnitHandler(db)
+
+	// Obter configuração para middleware de autenticação
+	cfg, _ := config.Load()
+
+	// Grupo de rotas de unidades de medida (todas protegidas)
+	units := router.Group("/measurement-units")
+	units.Use(middlewares.AuthMiddleware(cfg))
+	{
+		units.GET("", middlewares.RequirePermission("products.view"), unitHandler.GetUnits)
+		units.GET("/conversions", middlewares.RequirePermission("products.view"), unitHandler.GetConversions)
+		units.POST("/conversions", middlewares.RequirePermission("units.edit"), unitHandler.CreateConversion)
+		units.PUT("/conversions/:id", middlewares.RequirePermission("units.edit"), unitHandler.UpdateConversion)
+		units.DELETE("/conversions/:id", middlewares.RequirePermission("units.edit"), unitHandler.DeleteConversion)
+	}
+}
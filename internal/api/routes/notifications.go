@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"simple-erp-service/config"
+	"simple-erp-service/internal/api/handlers"
+	"simple-erp-service/internal/api/middlewares"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// SetupNotificationRoutes configura as rotas das notificações do usuário autenticado
+func SetupNotificationRoutes(router *gin.RouterGroup, db *gorm.DB) {
+	notificationHandler := handlers.NewNotificationHandler(db)
+
+	// Obter configuração para middleware de autenticação
+	cfg, _ := config.Load()
+
+	// Cada usuário acessa somente as próprias notificações: basta estar autenticado
+	notifications := router.Group("/notifications")
+	notifications.Use(middlewares.AuthMiddleware(cfg))
+	{
+		notifications.GET("", notificationHandler.GetNotifications)
+		notifications.POST("/read-all", notificationHandler.MarkAllAsRead)
+		notifications.POST("/:id/read", notificationHandler.MarkAsRead)
+	}
+}
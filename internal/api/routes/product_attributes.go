@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"simple-erp-service/config"
+	"simple-erp-service/internal/api/handlers"
+	"simple-erp-service/internal/api/middlewares"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// SetupProductAttributeRoutes configura as rotas dos atributos das variantes de produtos
+func SetupProductAttributeRoutes(router *gin.RouterGroup, db *gorm.DB) {
+	attributeHandler := handlers.NewProductAttributeHandler(db)
+
+	// Obter configuração para middleware de autenticação
+	cfg, _ := config.Load()
+
+	// Grupo de rotas de atributos (todas protegidas)
+	attributes := router.Group("/product-attributes")
+	attributes.Use(middlewares.AuthMiddleware(cfg))
+	{
+		attributes.GET("", middlewares.RequirePermission("products.view"), attributeHandler.GetAttributes)
+		attributes.POST("", middlewares.RequirePermission("products.create"), attributeHandler.CreateAttribute)
+		attributes.POST("/:id/values", middlewares.RequirePermission("products.edit"), attributeHandler.AddAttributeValues)
+	}
+}
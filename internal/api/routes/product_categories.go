@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"simple-erp-service/config"
+	"simple-erp-service/internal/api/handlers"
+	"simple-erp-service/internal/api/middlewares"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// SetupProductCategoryRoutes configura as rotas da árvore de categorias de produtos
+func SetupProductCategoryRoutes(router *gin.RouterGroup, db *gorm.DB) {
+	categoryHandler := handlers.NewProductCategoryHandler(db)
+
+	// Obter configuração para middleware de autenticação
+	cfg, _ := config.Load()
+
+	// Grupo de rotas de categorias (todas protegidas)
+	categories := router.Group("/product-categories")
+	categories.Use(middlewares.AuthMiddleware(cfg))
+	{
+		categories.GET("", middlewares.RequirePermission("products.view"), categoryHandler.GetTree)
+		categories.POST("", middlewares.RequirePermission("product_categories.edit"), categoryHandler.CreateCategory)
+		categories.PUT("/:id", middlewares.RequirePermission("product_categories.edit"), categoryHandler.UpdateCategory)
+		categories.POST("/:id/move", middlewares.RequirePermission("product_categories.edit"), categoryHandler.MoveCategory)
+		categories.DELETE("/:id", middlewares.RequirePermission("product_categories.edit"), categoryHandler.DeleteCategory)
+	}
+}
@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"simple-erp-service/config"
+	"simple-erp-service/internal/api/handlers"
+	"simple-erp-service/internal/api/middlewares"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// SetupProductionOrdersRoutes configura as rotas de ordens de produção
+func SetupProductionOrdersRoutes(router *gin.RouterGroup, db *gorm.DB) {
+	orderHandler := handlers.NewProductionOrderHandler(db)
+
+	// Obter configuração para middleware de autenticação
+	cfg, _ := config.Load()
+
+	// Grupo de rotas de ordens de produção (todas protegidas)
+	orders := router.Group("/production-orders")
+	orders.Use(middlewares.AuthMiddleware(cfg))
+	{
+		orders.GET("", middlewares.RequirePermission("production_orders.view"), orderHandler.GetOrders)
+		orders.GET("/:id", middlewares.RequirePermission("production_orders.view"), orderHandler.GetOrder)
+		orders.POST("", middlewares.RequirePermission("production_orders.manage"), orderHandler.CreateOrder)
+		orders.POST("/:id/complete", middlewares.RequirePermission("production_orders.manage"), orderHandler.CompleteOrder)
+		orders.POST("/:id/cancel", middlewares.RequirePermission("production_orders.manage"), orderHandler.CancelOrder)
+	}
+}
@@ -1,11 +1,43 @@
 package routes
 
 import (
+	"simple-erp-service/config"
+	"simple-erp-service/internal/api/handlers"
+	"simple-erp-service/internal/api/middlewares"
+
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
 // SetupProductsRoutes configura as rotas de products
 func SetupProductsRoutes(router *gin.RouterGroup, db *gorm.DB) {
-	// TODO: Implementar rotas de products
+	productHandler := handlers.NewProductHandler(db)
+
+	// Obter configuração para middleware de autenticação
+	cfg, _ := config.Load()
+
+	// Grupo de rotas de produtos (todas protegidas)
+	products := router.Group("/products")
+	products.Use(middlewares.AuthMiddleware(cfg))
+	{
+		products.GET("", middlewares.RequirePermission("products.view"), productHandler.GetProducts)
+		products.GET("/:id", middlewares.RequirePermission("products.view"), productHandler.GetProduct)
+		products.POST("", middlewares.RequirePermission("products.create"), productHandler.CreateProduct)
+		products.PUT("/:id", middlewares.RequirePermission("products.edit"), productHandler.UpdateProduct)
+		products.DELETE("/:id", middlewares.RequirePermission("products.delete"), productHandler.DeleteProduct)
+		products.GET("/:id/units", middlewares.RequirePermission("products.view"), productHandler.GetProductUnits)
+		products.GET("/:id/locations", middlewares.RequirePermission("product_location.view"), productHandler.GetProductLocations)
+		products.GET("/:id/lots", middlewares.RequirePermission("inventory.view"), productHandler.GetProductLots)
+
+		// Grade de variantes do produto
+		products.GET("/:id/variants", middlewares.RequirePermission("products.view"), productHandler.GetVariants)
+		products.POST("/:id/variants", middlewares.RequirePermission("products.create"), productHandler.GenerateVariants)
+
+		// Composição dos kits e ficha técnica dos produtos fabricados
+		products.GET("/:id/components", middlewares.RequirePermission("products.view"), productHandler.GetComposition)
+		products.PUT("/:id/components", middlewares.RequirePermission("products.edit"), productHandler.SetComposition)
+
+		// Comparação de preços entre os fornecedores do produto
+		products.GET("/:id/suppliers", middlewares.RequirePermission("supplier_codes.view"), productHandler.CompareSuppliers)
+	}
 }
@@ -1,11 +1,37 @@
 package routes
 
 import (
+	"simple-erp-service/config"
+	"simple-erp-service/internal/api/handlers"
+	"simple-erp-service/internal/api/middlewares"
+
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
 // SetupPurchasesRoutes configura as rotas de purchases
 func SetupPurchasesRoutes(router *gin.RouterGroup, db *gorm.DB) {
-	// TODO: Implementar rotas de purchases
+	// Obter configuração para middleware de autenticação
+	cfg, _ := config.Load()
+	purchaseHandler := handlers.NewPurchaseHandler(db, cfg.Documents)
+
+	// Grupo de rotas de compras (todas protegidas)
+	purchases := router.Group("/purchases")
+	purchases.Use(middlewares.AuthMiddleware(cfg))
+	{
+		purchases.GET("", middlewares.RequirePermission("purchases.view"), purchaseHandler.GetPurchases)
+		purchases.GET("/:id", middlewares.RequirePermission("purchases.view"), purchaseHandler.GetPurchase)
+		purchases.POST("", middlewares.RequirePermission("purchases.create"), purchaseHandler.CreatePurchase)
+		purchases.POST("/:id/receive", middlewares.RequirePermission("purchases.receive"), purchaseHandler.ReceivePurchase)
+		purchases.POST("/:id/confirm", middlewares.RequirePermission("purchases.edit"), purchaseHandler.ConfirmPurchase)
+		purchases.POST("/:id/cancel", middlewares.RequirePermission("purchases.edit"), purchaseHandler.CancelPurchase)
+
+		// Reposição de estoque: sugestões e pedidos em rascunho
+		purchases.GET("/reorder-suggestions", middlewares.RequirePermission("purchases.view"), purchaseHandler.GetReorderSuggestions)
+		purchases.POST("/reorder-suggestions/drafts", middlewares.RequirePermission("purchases.create"), purchaseHandler.CreateReorderDrafts)
+
+		// Devoluções ao fornecedor
+		purchases.GET("/:id/returns", middlewares.RequirePermission("purchases.view"), purchaseHandler.GetReturns)
+		purchases.POST("/:id/returns", middlewares.RequirePermission("purchases.return"), purchaseHandler.CreateReturn)
+	}
 }
@@ -1,11 +1,35 @@
 package routes
 
 import (
+	"simple-erp-service/config"
+	"simple-erp-service/internal/api/handlers"
+	"simple-erp-service/internal/api/middlewares"
+
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
 // SetupSalesRoutes configura as rotas de sales
 func SetupSalesRoutes(router *gin.RouterGroup, db *gorm.DB) {
-	// TODO: Implementar rotas de sales
+	// Obter configuração para middleware de autenticação
+	cfg, _ := config.Load()
+	saleHandler := handlers.NewSaleHandler(db, cfg.Inventory, cfg.Credit)
+
+	// Grupo de rotas de vendas (todas protegidas)
+	sales := router.Group("/sales")
+	sales.Use(middlewares.AuthMiddleware(cfg))
+	{
+		sales.GET("", middlewares.RequirePermission("sales.view"), saleHandler.GetSales)
+		sales.GET("/:id", middlewares.RequirePermission("sales.view"), saleHandler.GetSale)
+		sales.POST("", middlewares.RequirePermission("sales.create"), saleHandler.CreateSale)
+		sales.POST("/:id/invoice", middlewares.RequirePermission("sales.edit"), saleHandler.InvoiceSale)
+		sales.POST("/:id/credit/approve", middlewares.RequirePermission("finance.credit_approve"), saleHandler.ApproveSaleCredit)
+		sales.POST("/:id/cancel", middlewares.RequirePermission("sales.edit"), saleHandler.CancelSale)
+
+		// Rastreabilidade: lotes entregues na venda
+		sales.GET("/:id/lots", middlewares.RequirePermission("sales.view"), saleHandler.GetSaleLots)
+
+		// Reservas de estoque da venda
+		sales.GET("/:id/reservations", middlewares.RequirePermission("sales.view"), saleHandler.GetSaleReservations)
+	}
 }
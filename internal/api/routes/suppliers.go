@@ -5,12 +5,19 @@ import (
 	"simple-erp-service/config"
 	"simple-erp-service/internal/api/handlers"
 	"simple-erp-service/internal/api/middlewares"
+	"simple-erp-service/internal/data-structure/models"
 	"gorm.io/gorm"
 )
 
 // SetupSupplierRoutes configura as rotas de fornecedores
 func SetupSupplierRoutes(router *gin.RouterGroup, db *gorm.DB) {
 	supplierHandler := handlers.NewSupplierHandler(db)
+	addressHandler := handlers.NewAddressHandler(db, models.OwnerSupplier)
+	contactHandler := handlers.NewContactHandler(db, models.OwnerSupplier)
+	documentHandler := handlers.NewDocumentHandler(db, models.OwnerSupplier)
+	importHandler := handlers.NewImportHandler(db, models.OwnerSupplier)
+	catalogHandler := handlers.NewSupplierCatalogHandler(db)
+	scorecardHandler := handlers.NewSupplierScorecardHandler(db)
 
 	// Obter configuração para middleware de autenticação
 	cfg, _ := config.Load()
@@ -20,9 +27,39 @@ func SetupSupplierRoutes(router *gin.RouterGroup, db *gorm.DB) {
 	suppliers.Use(middlewares.AuthMiddleware(cfg))
 	{
 		suppliers.GET("", middlewares.RequirePermission("suppliers.view"), supplierHandler.GetSuppliers)
+		suppliers.GET("/scorecards", middlewares.RequirePermission("purchases.reports"), scorecardHandler.GetLeaderboard)
 		suppliers.GET("/:id", middlewares.RequirePermission("suppliers.view"), supplierHandler.GetSupplier)
+		suppliers.GET("/:id/scorecard", middlewares.RequirePermission("purchases.reports"), scorecardHandler.GetScorecard)
 		suppliers.POST("", middlewares.RequirePermission("suppliers.create"), supplierHandler.CreateSupplier)
 		suppliers.PUT("/:id", middlewares.RequirePermission("suppliers.edit"), supplierHandler.UpdateSupplier)
 		suppliers.DELETE("/:id", middlewares.RequirePermission("suppliers.delete"), supplierHandler.DeleteSupplier)
+
+		// Importação em lote (CSV/XLSX)
+		suppliers.POST("/import/preview", middlewares.RequirePermission("suppliers.create"), importHandler.PreviewImport)
+		suppliers.POST("/import", middlewares.RequirePermission("suppliers.create"), importHandler.Import)
+
+		// Endereços
+		suppliers.GET("/:id/addresses", middlewares.RequirePermission("suppliers.view"), addressHandler.GetAddresses)
+		suppliers.POST("/:id/addresses", middlewares.RequirePermission("suppliers.edit"), addressHandler.CreateAddress)
+		suppliers.PUT("/:id/addresses/:addressId", middlewares.RequirePermission("suppliers.edit"), addressHandler.UpdateAddress)
+		suppliers.DELETE("/:id/addresses/:addressId", middlewares.RequirePermission("suppliers.edit"), addressHandler.DeleteAddress)
+
+		// Contatos
+		suppliers.GET("/:id/contacts", middlewares.RequirePermission("suppliers.view"), contactHandler.GetContacts)
+		suppliers.POST("/:id/contacts", middlewares.RequirePermission("suppliers.edit"), contactHandler.CreateContact)
+		suppliers.PUT("/:id/contacts/:contactId", middlewares.RequirePermission("suppliers.edit"), contactHandler.UpdateContact)
+		suppliers.DELETE("/:id/contacts/:contactId", middlewares.RequirePermission("suppliers.edit"), contactHandler.DeleteContact)
+
+		// Documentos
+		suppliers.GET("/:id/documents", middlewares.RequirePermission("suppliers.view"), documentHandler.GetDocuments)
+		suppliers.POST("/:id/documents", middlewares.RequirePermission("suppliers.edit"), documentHandler.CreateDocument)
+		suppliers.PUT("/:id/documents/:documentId", middlewares.RequirePermission("suppliers.edit"), documentHandler.UpdateDocument)
+		suppliers.DELETE("/:id/documents/:documentId", middlewares.RequirePermission("suppliers.edit"), documentHandler.DeleteDocument)
+
+		// Catálogo de produtos do fornecedor
+		suppliers.GET("/:id/products", middlewares.RequirePermission("supplier_codes.view"), catalogHandler.GetCatalog)
+		suppliers.POST("/:id/products", middlewares.RequirePermission("supplier_codes.edit"), catalogHandler.CreateCatalogItem)
+		suppliers.PUT("/:id/products/:itemId", middlewares.RequirePermission("supplier_codes.edit"), catalogHandler.UpdateCatalogItem)
+		suppliers.DELETE("/:id/products/:itemId", middlewares.RequirePermission("supplier_codes.edit"), catalogHandler.DeleteCatalogItem)
 	}
 }
\ No newline at end of file
@@ -11,7 +11,10 @@ import (
 	"time"
 
 	"simple-erp-service/config"
+	"simple-erp-service/internal/api/middlewares"
 	"simple-erp-service/internal/api/routes"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/service"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -37,7 +40,7 @@ func NewServer(cfg *config.Config, db *gorm.DB) *Server {
 
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
 		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		ExposeHeaders:    []string{"Content-Length", "X-Impersonator-ID"},
 		AllowCredentials: true, // <--- Isso deve permanecer TRUE
 		MaxAge:           12 * time.Hour,
 	}))
@@ -57,6 +60,9 @@ func (s *Server) Run() error {
 	// Configurar rotas
 	s.setupRoutes()
 
+	// Iniciar rotinas em segundo plano
+	stopJobs := s.startJobs()
+
 	// Configurar servidor HTTP
 	srv := &http.Server{
 		Addr:         fmt.Sprintf(":%s", s.cfg.Server.Port),
@@ -82,6 +88,7 @@ func (s *Server) Run() error {
 	// Aguardar sinal de interrupção
 	<-quit
 	log.Println("Desligando servidor...")
+	close(stopJobs)
 
 	// Contexto com timeout para desligamento gracioso
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -100,19 +107,61 @@ func (s *Server) Run() error {
 func (s *Server) setupRoutes() {
 	// Grupo de rotas da API
 	api := s.router.Group("/api")
+	api.Use(middlewares.ImpersonationAuditMiddleware(s.db))
 
 	// Configurar rotas para cada módulo
 	routes.SetupAuthRoutes(api, s.db, s.cfg)
 	routes.SetupUserRoutes(api, s.db)
 	routes.SetupRoleRoutes(api, s.db)
 	routes.SetupProductsRoutes(api, s.db)
+	routes.SetupProductCategoryRoutes(api, s.db)
+	routes.SetupProductAttributeRoutes(api, s.db)
+	routes.SetupMeasurementUnitRoutes(api, s.db)
 	routes.SetupInventoryRoutes(api, s.db)
+	routes.SetupProductionOrdersRoutes(api, s.db)
+	routes.SetupInventoryCountsRoutes(api, s.db)
 	routes.SetupCustomersRoutes(api, s.db)
 	routes.SetupSupplierRoutes(api, s.db)
+	routes.SetupImportRoutes(api, s.db)
 	routes.SetupSalesRoutes(api, s.db)
 	routes.SetupPurchasesRoutes(api, s.db)
 	routes.SetupFinancialRoutes(api, s.db)
 	routes.SetupDashboardRoutes(api, s.db)
 	routes.SetupSystemRoutes(api, s.db)
 	routes.SetupPermissionRoutes(api, s.db)
+	routes.SetupLocationRoutes(api, s.db)
+	routes.SetupDocumentRoutes(api, s.db)
+	routes.SetupNotificationRoutes(api, s.db)
+}
+
+// startJobs inicia as rotinas periódicas em segundo plano. Fechar o canal retornado encerra as rotinas.
+func (s *Server) startJobs() chan struct{} {
+	stop := make(chan struct{})
+
+	// Importações que estavam em andamento quando o servidor parou não serão retomadas
+	importService := service.NewImportService(
+		repository.NewImportJobRepository(s.db),
+		repository.NewCustomerRepository(s.db),
+		repository.NewSupplierRepository(s.db),
+	)
+	if failed, err := importService.FailInterruptedJobs(); err != nil {
+		log.Printf("Erro ao encerrar as importações interrompidas: %v", err)
+	} else if failed > 0 {
+		log.Printf("%d importação(ões) interrompida(s) marcada(s) como falha", failed)
+	}
+
+	// Alertas de vencimento de documentos de clientes e fornecedores
+	documentExpiryService := service.NewDocumentExpiryService(
+		repository.NewDocumentRepository(s.db),
+		repository.NewUserRepository(s.db),
+		repository.NewNotificationRepository(s.db),
+		s.cfg.Documents,
+	)
+	go documentExpiryService.RunDocumentExpiryAlerts(stop)
+
+	// Expiração das reservas de estoque das vendas pendentes
+	reservationService := service.NewStockReservationService(repository.NewStockReservationRepository(s.db), s.cfg.Inventory)
+	go reservationService.RunReservationExpiry(stop)
+
+	return stop
 }
@@ -2,6 +2,7 @@ package dto
 
 import (
 	"simple-erp-service/internal/data-structure/models"
+	"strings"
 	"time"
 )
 
@@ -29,9 +30,19 @@ type ApiCustomerDetail struct {
 	DocumentNumber string `json:"document_number"`
 	CompanyName    string `json:"company_name"`
 	IsActive       bool   `json:"is_active"`
+	Notes          string `json:"notes"`
 
-	Addresses *[]models.Address `json:"addresses"`
-	Contacts  *[]models.Contact `json:"contacts"`
+	CreditLimit     float64 `json:"credit_limit"`
+	PaymentTermDays int     `json:"payment_term_days"`
+	CreditBlocked   bool    `json:"credit_blocked"`
+
+	AnonymizedAt *time.Time `json:"anonymized_at"` // Preenchido quando os dados pessoais foram anonimizados (LGPD)
+
+	PrimaryAddress *ApiAddress   `json:"primary_address"`
+	PrimaryContact *ApiContact   `json:"primary_contact"`
+	Addresses      []ApiAddress  `json:"addresses"`
+	Contacts       []ApiContact  `json:"contacts"`
+	Documents      []ApiDocument `json:"documents"`
 
 	CreatedAt time.Time `json:"created_at"`
 	CreatedBy *ApiUser  `json:"created_by"`
@@ -66,7 +77,7 @@ func ApiCustomerFromModel(c models.Customer) ApiCustomer {
 
 // ApiCustomerDetailFromModel converte um Customer para ApiCustomerDetail
 func ApiCustomerDetailFromModel(c models.Customer) ApiCustomerDetail {
-	return ApiCustomerDetail{
+	dto := ApiCustomerDetail{
 		ID:             c.ID,
 		FirstName:      c.FirstName,
 		LastName:       c.LastName,
@@ -74,7 +85,73 @@ func ApiCustomerDetailFromModel(c models.Customer) ApiCustomerDetail {
 		DocumentNumber: c.DocumentNumber,
 		CompanyName:    c.CompanyName,
 		IsActive:       c.IsActive,
+		Notes:          c.Notes,
 		CreatedAt:      c.CreatedAt,
 		UpdatedAt:      c.UpdatedAt,
+
+		CreditLimit:     c.CreditLimit,
+		PaymentTermDays: c.PaymentTermDays,
+		CreditBlocked:   c.CreditBlocked,
+
+		AnonymizedAt: c.AnonymizedAt,
+	}
+
+	// Endereços e contatos principais são retornados em destaque
+	dto.Addresses, dto.PrimaryAddress = ApiAddressesFromModel(c.Addresses)
+	dto.Contacts, dto.PrimaryContact = ApiContactsFromModel(c.Contacts)
+	dto.Documents = ApiDocumentsFromModel(c.Documents)
+
+	if c.CreatedBy != nil {
+		createdBy := ApiUserFromModel(*c.CreatedBy)
+		dto.CreatedBy = &createdBy
+	}
+	if c.UpdatedBy != nil {
+		updatedBy := ApiUserFromModel(*c.UpdatedBy)
+		dto.UpdatedBy = &updatedBy
+	}
+
+	return dto
+}
+
+// ApiCustomerDuplicate representa um par de clientes possivelmente duplicados.
+// Score é a média ponderada das notas de nome, contatos e endereços (0 a 1).
+type ApiCustomerDuplicate struct {
+	Customer     ApiCustomer `json:"customer"`
+	Duplicate    ApiCustomer `json:"duplicate"`
+	Score        float64     `json:"score"`
+	NameScore    float64     `json:"name_score"`
+	ContactScore float64     `json:"contact_score"`
+	AddressScore float64     `json:"address_score"`
+}
+
+// ApiCustomerMergeResult representa o resultado da mesclagem de um cliente duplicado
+type ApiCustomerMergeResult struct {
+	Customer          ApiCustomerDetail `json:"customer"`
+	MergedCustomerID  uint              `json:"merged_customer_id"`
+	MovedAddresses    int64             `json:"moved_addresses"`
+	MovedContacts     int64             `json:"moved_contacts"`
+	MovedDocuments    int64             `json:"moved_documents"`
+	MovedSales        int64             `json:"moved_sales"`
+	MovedTransactions int64             `json:"moved_transactions"`
+	MovedLotSales     int64             `json:"moved_lot_sales"` // Lotes entregues nas vendas, para a rastreabilidade
+	MovedSerials      int64             `json:"moved_serials"`   // Números de série vendidos, com o histórico
+}
+
+// InGetCustomerDuplicates representa os parâmetros da busca de clientes duplicados
+type InGetCustomerDuplicates struct {
+	MinScore float64 `form:"minScore" binding:"omitempty,gt=0,lte=1"` // Nota mínima do par (padrão 0.5)
+	Limit    int     `form:"limit" binding:"omitempty,min=1,max=200"` // Quantidade máxima de pares (padrão 50)
+}
+
+// InCustomerDataExport representa os parâmetros da exportação dos dados do cliente (LGPD)
+type InCustomerDataExport struct {
+	Format string `form:"format" binding:"omitempty,oneof=json zip"` // json (padrão) ou zip
+}
+
+// CustomerDisplayName retorna a razão social do cliente ou, na falta dela, o nome completo
+func CustomerDisplayName(c models.Customer) string {
+	if c.CompanyName != "" {
+		return c.CompanyName
 	}
+	return strings.TrimSpace(c.FirstName + " " + c.LastName)
 }
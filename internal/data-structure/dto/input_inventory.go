@@ -0,0 +1,47 @@
+package dto
+
+import "time"
+
+// InGetStockLocationsFilters representa os parâmetros de filtro da listagem de locais de estoque
+type InGetStockLocationsFilters struct {
+	IsActive *bool `form:"isActive"` // Opcional: somente ativos ou inativos
+	ParentID *uint `form:"parentId"` // Opcional: somente os endereços do depósito
+}
+
+// InGetStockTransfersFilters representa os parâmetros de filtro da listagem de transferências de estoque
+type InGetStockTransfersFilters struct {
+	ProductID  uint      `form:"productId"`                                      // Opcional: somente transferências do produto
+	LocationID uint      `form:"locationId"`                                     // Opcional: transferências com origem ou destino no local
+	DateFrom   time.Time `form:"dateFrom" time_format:"2006-01-02" time_utc:"1"` // Opcional: transferências a partir desta data
+	DateTo     time.Time `form:"dateTo" time_format:"2006-01-02" time_utc:"1"`   // Opcional: transferências até esta data (inclusive)
+}
+
+// InGetExpiringLotsFilters representa os parâmetros da listagem de lotes vencidos e a vencer
+type InGetExpiringLotsFilters struct {
+	Days       *int `form:"days" binding:"omitempty,gte=0,lte=365"` // Opcional: vencendo nos próximos N dias (padrão: 30)
+	LocationID uint `form:"locationId"`                             // Opcional: somente os saldos no local
+}
+
+// InGetSerialNumbersFilters representa os parâmetros de filtro da consulta de números de série
+type InGetSerialNumbersFilters struct {
+	Serial     string `form:"serial"`                                                        // Opcional: número de série (busca parcial, sem diferenciar maiúsculas)
+	ProductID  uint   `form:"productId"`                                                     // Opcional: somente do produto
+	Status     string `form:"status" binding:"omitempty,oneof=em_estoque vendido devolvido"` // Opcional: somente na situação
+	LocationID uint   `form:"locationId"`                                                    // Opcional: somente em estoque no local
+	CustomerID uint   `form:"customerId"`                                                    // Opcional: somente vendidos ao cliente
+}
+
+// InGetInventoryCountsFilters representa os parâmetros de filtro da listagem de inventários
+type InGetInventoryCountsFilters struct {
+	Status     string    `form:"status" binding:"omitempty,oneof=aberto aprovado cancelado"` // Opcional: situação do inventário
+	LocationID uint      `form:"locationId"`                                                 // Opcional: somente inventários do local
+	DateFrom   time.Time `form:"dateFrom" time_format:"2006-01-02" time_utc:"1"`             // Opcional: inventários abertos a partir desta data
+	DateTo     time.Time `form:"dateTo" time_format:"2006-01-02" time_utc:"1"`               // Opcional: inventários abertos até esta data (inclusive)
+}
+
+// InGetInventoryValuationFilters representa os parâmetros do relatório de valorização do estoque
+type InGetInventoryValuationFilters struct {
+	Date       time.Time `form:"date" time_format:"2006-01-02" time_utc:"1"` // Opcional: posição no fim deste dia (padrão: hoje)
+	CategoryID uint      `form:"categoryId"`                                 // Opcional: somente a categoria e as subcategorias
+	LocationID uint      `form:"locationId"`                                 // Opcional: somente o local e os seus endereços
+}
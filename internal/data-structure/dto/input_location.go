@@ -0,0 +1,7 @@
+package dto
+
+// InGetCitiesFilters representa os parâmetros da busca de cidades de um estado
+type InGetCitiesFilters struct {
+	Search string `form:"search"`                                   // Opcional: parte do nome da cidade (sem acentos)
+	Limit  int    `form:"limit" binding:"omitempty,gte=1,lte=1000"` // Opcional: quantidade máxima de cidades (padrão: todas)
+}
@@ -0,0 +1,6 @@
+package dto
+
+// InGetNotificationsFilters representa os parâmetros da listagem de notificações do usuário
+type InGetNotificationsFilters struct {
+	UnreadOnly bool `form:"unreadOnly"` // Opcional: somente notificações não lidas
+}
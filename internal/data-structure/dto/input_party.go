@@ -0,0 +1,22 @@
+package dto
+
+import "time"
+
+// InGetPartiesFilters representa os parâmetros de busca e filtro das listagens de clientes e fornecedores
+type InGetPartiesFilters struct {
+	Search      string    `form:"search"`                                            // Busca livre em nome, razão social, documento e contatos (sem acentos)
+	PersonType  string    `form:"personType" binding:"omitempty,oneof=F J"`          // F: Física, J: Jurídica
+	IsActive    *bool     `form:"isActive"`                                          // Opcional: somente ativos ou inativos
+	CityID      uint      `form:"cityId"`                                            // Opcional: possui endereço na cidade
+	StateID     uint      `form:"stateId"`                                           // Opcional: possui endereço no estado
+	UF          string    `form:"uf" binding:"omitempty,len=2"`                      // Opcional: possui endereço na UF (ex: SP)
+	CreatedFrom time.Time `form:"createdFrom" time_format:"2006-01-02" time_utc:"1"` // Opcional: criado a partir desta data
+	CreatedTo   time.Time `form:"createdTo" time_format:"2006-01-02" time_utc:"1"`   // Opcional: criado até esta data (inclusive)
+}
+
+// InGetExpiringDocumentsFilters representa os parâmetros da listagem de documentos vencidos e a vencer
+type InGetExpiringDocumentsFilters struct {
+	Days          *int   `form:"days" binding:"omitempty,gte=0,lte=365"`                // Opcional: vencendo nos próximos N dias (padrão: DOCUMENT_EXPIRY_WARNING_DAYS)
+	OwnerType     string `form:"ownerType" binding:"omitempty,oneof=customer supplier"` // Opcional: somente de clientes ou de fornecedores
+	MandatoryOnly bool   `form:"mandatoryOnly"`                                         // Opcional: somente documentos obrigatórios
+}
@@ -0,0 +1,33 @@
+package dto
+
+import "time"
+
+// InGetProductsFilters representa os parâmetros de busca e filtro da listagem de produtos
+type InGetProductsFilters struct {
+	Search     string `form:"search"`     // Busca livre em nome, SKU, código de barras e códigos dos fornecedores (sem acentos)
+	CategoryID uint   `form:"categoryId"` // Opcional: somente produtos da categoria
+	SupplierID uint   `form:"supplierId"` // Opcional: somente produtos do catálogo do fornecedor
+	IsActive   *bool  `form:"isActive"`   // Opcional: somente ativos ou inativos
+	LowStock   bool   `form:"lowStock"`   // Opcional: somente produtos no estoque mínimo ou abaixo dele
+
+	ParentID     uint `form:"parentId"`     // Opcional: somente as variantes do produto
+	HideVariants bool `form:"hideVariants"` // Opcional: omite as variantes, listando só os produtos pai e os sem grade
+}
+
+// InDeleteProductCategory representa as opções de exclusão de uma categoria de produto
+type InDeleteProductCategory struct {
+	Mode string `form:"mode" binding:"omitempty,oneof=block reassign"` // block (padrão): recusa se houver produtos ou subcategorias; reassign: move-os para a categoria pai
+}
+
+// InGetUnitConversionsFilters representa os filtros da listagem de conversões de unidades
+type InGetUnitConversionsFilters struct {
+	ProductID *uint `form:"productId"` // Opcional: conversões exclusivas do produto; sem ele, as conversões globais
+}
+
+// InGetProductionOrdersFilters representa os parâmetros de filtro da listagem de ordens de produção
+type InGetProductionOrdersFilters struct {
+	ProductID uint      `form:"productId"`                                                     // Opcional: somente ordens do produto
+	Status    string    `form:"status" binding:"omitempty,oneof=pendente concluida cancelada"` // Opcional: situação da ordem
+	DateFrom  time.Time `form:"dateFrom" time_format:"2006-01-02" time_utc:"1"`                // Opcional: ordens abertas a partir desta data
+	DateTo    time.Time `form:"dateTo" time_format:"2006-01-02" time_utc:"1"`                  // Opcional: ordens abertas até esta data (inclusive)
+}
@@ -0,0 +1,28 @@
+package dto
+
+import "time"
+
+// InGetPurchasesFilters representa os parâmetros de filtro da listagem de compras
+type InGetPurchasesFilters struct {
+	SupplierID uint      `form:"supplierId"`                                                            // Opcional: somente compras do fornecedor
+	Status     string    `form:"status" binding:"omitempty,oneof=rascunho pendente recebido cancelado"` // Opcional: situação da compra
+	DateFrom   time.Time `form:"dateFrom" time_format:"2006-01-02" time_utc:"1"`                        // Opcional: compras a partir desta data
+	DateTo     time.Time `form:"dateTo" time_format:"2006-01-02" time_utc:"1"`                          // Opcional: compras até esta data (inclusive)
+}
+
+// InSupplierScorecardFilters representa os parâmetros do scorecard e do ranking de fornecedores.
+// O período considera a data das compras e a data das devoluções.
+type InSupplierScorecardFilters struct {
+	DateFrom     time.Time `form:"dateFrom" time_format:"2006-01-02" time_utc:"1"`                                       // Opcional: a partir desta data
+	DateTo       time.Time `form:"dateTo" time_format:"2006-01-02" time_utc:"1"`                                         // Opcional: até esta data (inclusive)
+	MinPurchases int       `form:"minPurchases" binding:"omitempty,gte=0"`                                               // Ranking: mínimo de compras recebidas para participar
+	SortBy       string    `form:"sortBy" binding:"omitempty,oneof=score on_time_rate fill_rate price_variance returns"` // Ranking: critério de ordenação (padrão: score)
+	Limit        int       `form:"limit" binding:"omitempty,gte=1"`                                                      // Ranking: quantidade de fornecedores retornados
+}
+
+// InReorderSuggestionsFilters representa os parâmetros das sugestões de reposição
+type InReorderSuggestionsFilters struct {
+	Days       int  `form:"days" binding:"omitempty,gte=1,lte=365"` // Opcional: janela da média de vendas (padrão: 30 dias)
+	CategoryID uint `form:"categoryId"`                             // Opcional: somente produtos da categoria
+	SupplierID uint `form:"supplierId"`                             // Opcional: somente os produtos do fornecedor preferencial
+}
@@ -0,0 +1,11 @@
+package dto
+
+import "time"
+
+// InGetSalesFilters representa os parâmetros de filtro da listagem de vendas
+type InGetSalesFilters struct {
+	CustomerID uint      `form:"customerId"`                                                        // Opcional: somente vendas do cliente
+	Status     string    `form:"status" binding:"omitempty,oneof=pendente faturado pago cancelado"` // Opcional: situação da venda
+	DateFrom   time.Time `form:"dateFrom" time_format:"2006-01-02" time_utc:"1"`                    // Opcional: vendas a partir desta data
+	DateTo     time.Time `form:"dateTo" time_format:"2006-01-02" time_utc:"1"`                      // Opcional: vendas até esta data (inclusive)
+}
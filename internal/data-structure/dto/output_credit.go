@@ -0,0 +1,57 @@
+package dto
+
+import "time"
+
+// ApiCustomerCredit representa o perfil financeiro de um cliente
+type ApiCustomerCredit struct {
+	CustomerID        uint    `json:"customer_id"`
+	CreditLimit       float64 `json:"credit_limit"`
+	PaymentTermDays   int     `json:"payment_term_days"`
+	CreditBlocked     bool    `json:"credit_blocked"`
+	CreditBlockReason string  `json:"credit_block_reason"`
+}
+
+// ApiOpenReceivable representa um título a receber em aberto no extrato do cliente
+type ApiOpenReceivable struct {
+	TransactionID uint      `json:"transaction_id"`
+	Code          string    `json:"code"`
+	Date          time.Time `json:"date"`
+	DueDate       time.Time `json:"due_date"`
+	Amount        float64   `json:"amount"`
+	Paid          float64   `json:"paid"`
+	Balance       float64   `json:"balance"`
+	DaysOverdue   int       `json:"days_overdue"`
+}
+
+// ApiAging representa o saldo em aberto agrupado por faixa de atraso
+type ApiAging struct {
+	Current    float64 `json:"current"` // A vencer
+	Days1To30  float64 `json:"days_1_30"`
+	Days31To60 float64 `json:"days_31_60"`
+	Days61To90 float64 `json:"days_61_90"`
+	Over90     float64 `json:"over_90"`
+}
+
+// ApiCustomerStatement representa o extrato financeiro do cliente: exposição, crédito disponível e aging
+type ApiCustomerStatement struct {
+	ApiCustomerCredit
+	Exposure           float64             `json:"exposure"`             // Saldo total em aberto, com as vendas a prazo não faturadas
+	PendingCreditSales float64             `json:"pending_credit_sales"` // Vendas a prazo não faturadas, aprovadas ou aguardando aprovação
+	AvailableCredit    float64             `json:"available_credit"`
+	OverdueBalance     float64             `json:"overdue_balance"`
+	MaxDaysOverdue     int                 `json:"max_days_overdue"`
+	Aging              ApiAging            `json:"aging"`
+	Receivables        []ApiOpenReceivable `json:"receivables"`
+	GeneratedAt        time.Time           `json:"generated_at"`
+}
+
+// ApiCreditDecision representa o resultado da análise de crédito de uma venda a prazo
+type ApiCreditDecision struct {
+	Status          string   `json:"status"` // approved, approval_required ou rejected
+	Reasons         []string `json:"reasons"`
+	Amount          float64  `json:"amount"`
+	Exposure        float64  `json:"exposure"`
+	CreditLimit     float64  `json:"credit_limit"`
+	AvailableCredit float64  `json:"available_credit"`
+	MaxDaysOverdue  int      `json:"max_days_overdue"`
+}
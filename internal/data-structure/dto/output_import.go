@@ -0,0 +1,80 @@
+package dto
+
+import (
+	"simple-erp-service/internal/data-structure/models"
+	"time"
+)
+
+// ApiImportField descreve um campo de destino disponível no mapeamento de colunas
+type ApiImportField struct {
+	Key      string `json:"key"`
+	Label    string `json:"label"`
+	Required bool   `json:"required"`
+}
+
+// ApiImportPreview representa a etapa de mapeamento: cabeçalhos da planilha, amostra das linhas
+// e a sugestão de mapeamento campo -> coluna feita pelos nomes dos cabeçalhos
+type ApiImportPreview struct {
+	FileName         string            `json:"file_name"`
+	Headers          []string          `json:"headers"`
+	SampleRows       [][]string        `json:"sample_rows"`
+	TotalRows        int               `json:"total_rows"`
+	Fields           []ApiImportField  `json:"fields"`
+	SuggestedMapping map[string]string `json:"suggested_mapping"`
+}
+
+// ApiImportReport representa o resultado de uma importação em modo de simulação (dry-run)
+type ApiImportReport struct {
+	TotalRows   int                     `json:"total_rows"`
+	ValidRows   int                     `json:"valid_rows"`
+	InvalidRows int                     `json:"invalid_rows"`
+	RowErrors   []models.ImportRowError `json:"row_errors"`
+}
+
+// ApiImportJob representa o andamento de uma importação em segundo plano
+type ApiImportJob struct {
+	ID            uint                    `json:"id"`
+	Entity        string                  `json:"entity"`
+	FileName      string                  `json:"file_name"`
+	Status        string                  `json:"status"`
+	TotalRows     int                     `json:"total_rows"`
+	ProcessedRows int                     `json:"processed_rows"`
+	InsertedRows  int                     `json:"inserted_rows"`
+	FailedRows    int                     `json:"failed_rows"`
+	Progress      int                     `json:"progress"` // Percentual de linhas processadas
+	RowErrors     []models.ImportRowError `json:"row_errors"`
+	Message       string                  `json:"message,omitempty"`
+	StartedAt     *time.Time              `json:"started_at"`
+	FinishedAt    *time.Time              `json:"finished_at"`
+	CreatedAt     time.Time               `json:"created_at"`
+}
+
+// ApiImportJobFromModel converte um job de importação para DTO
+func ApiImportJobFromModel(job models.ImportJob) ApiImportJob {
+	progress := 100
+	if job.TotalRows > 0 {
+		progress = job.ProcessedRows * 100 / job.TotalRows
+	}
+
+	rowErrors := job.RowErrors
+	if rowErrors == nil {
+		rowErrors = []models.ImportRowError{}
+	}
+
+	return ApiImportJob{
+		ID:            job.ID,
+		Entity:        job.Entity,
+		FileName:      job.FileName,
+		Status:        job.Status,
+		TotalRows:     job.TotalRows,
+		ProcessedRows: job.ProcessedRows,
+		InsertedRows:  job.InsertedRows,
+		FailedRows:    job.FailedRows,
+		Progress:      progress,
+		RowErrors:     rowErrors,
+		Message:       job.Message,
+		StartedAt:     job.StartedAt,
+		FinishedAt:    job.FinishedAt,
+		CreatedAt:     job.CreatedAt,
+	}
+}
@@ -0,0 +1,466 @@
+package dto
+
+import (
+	"simple-erp-service/internal/data-structure/models"
+	"time"
+)
+
+// ApiStockLocation representa um local de estoque para exibição
+type ApiStockLocation struct {
+	ID         uint      `json:"id"`
+	Code       string    `json:"code"`
+	Name       string    `json:"name"`
+	ParentID   *uint     `json:"parent_id"` // Depósito do endereço
+	ParentCode string    `json:"parent_code"`
+	IsDefault  bool      `json:"is_default"`
+	IsActive   bool      `json:"is_active"`
+	Notes      string    `json:"notes"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// ApiStockBalance representa o saldo de um produto em um local de estoque
+type ApiStockBalance struct {
+	ProductID    uint      `json:"product_id"`
+	ProductSKU   string    `json:"product_sku"`
+	ProductName  string    `json:"product_name"`
+	LocationID   uint      `json:"location_id"`
+	LocationCode string    `json:"location_code"`
+	LocationName string    `json:"location_name"`
+	Quantity     float64   `json:"quantity"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// ApiStockTransfer representa uma transferência de estoque entre locais para exibição
+type ApiStockTransfer struct {
+	ID               uint      `json:"id"`
+	ProductID        uint      `json:"product_id"`
+	ProductSKU       string    `json:"product_sku"`
+	ProductName      string    `json:"product_name"`
+	FromLocationID   uint      `json:"from_location_id"`
+	FromLocationCode string    `json:"from_location_code"`
+	ToLocationID     uint      `json:"to_location_id"`
+	ToLocationCode   string    `json:"to_location_code"`
+	Quantity         float64   `json:"quantity"`
+	LotID            *uint     `json:"lot_id"`
+	LotCode          string    `json:"lot_code"`
+	Notes            string    `json:"notes"`
+	CreatedBy        *uint     `json:"created_by"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// ApiStockTransferListPaginated representa uma lista paginada de transferências de estoque
+type ApiStockTransferListPaginated struct {
+	Transfers  []ApiStockTransfer `json:"data"`
+	Pagination ApiPagination      `json:"pagination"`
+}
+
+// ApiStockLocationFromModel converte um StockLocation para ApiStockLocation
+func ApiStockLocationFromModel(l models.StockLocation) ApiStockLocation {
+	dto := ApiStockLocation{
+		ID:        l.ID,
+		Code:      l.Code,
+		Name:      l.Name,
+		ParentID:  l.ParentID,
+		IsDefault: l.IsDefault,
+		IsActive:  l.IsActive,
+		Notes:     l.Notes,
+		CreatedAt: l.CreatedAt,
+		UpdatedAt: l.UpdatedAt,
+	}
+	if l.Parent != nil {
+		dto.ParentCode = l.Parent.Code
+	}
+	return dto
+}
+
+// ApiStockBalanceFromModel converte um StockBalance para ApiStockBalance
+func ApiStockBalanceFromModel(b models.StockBalance) ApiStockBalance {
+	dto := ApiStockBalance{
+		ProductID:  b.ProductID,
+		LocationID: b.LocationID,
+		Quantity:   b.Quantity,
+		UpdatedAt:  b.UpdatedAt,
+	}
+	if b.Product != nil {
+		dto.ProductSKU = b.Product.SKU
+		dto.ProductName = b.Product.Name
+	}
+	if b.Location != nil {
+		dto.LocationCode = b.Location.Code
+		dto.LocationName = b.Location.Name
+	}
+	return dto
+}
+
+// ApiStockTransferFromModel converte um StockTransfer para ApiStockTransfer
+func ApiStockTransferFromModel(t models.StockTransfer) ApiStockTransfer {
+	dto := ApiStockTransfer{
+		ID:             t.ID,
+		ProductID:      t.ProductID,
+		FromLocationID: t.FromLocationID,
+		ToLocationID:   t.ToLocationID,
+		Quantity:       t.Quantity,
+		LotID:          t.LotID,
+		Notes:          t.Notes,
+		CreatedBy:      t.CreatedByID,
+		CreatedAt:      t.CreatedAt,
+	}
+	if t.Product != nil {
+		dto.ProductSKU = t.Product.SKU
+		dto.ProductName = t.Product.Name
+	}
+	if t.FromLocation != nil {
+		dto.FromLocationCode = t.FromLocation.Code
+	}
+	if t.ToLocation != nil {
+		dto.ToLocationCode = t.ToLocation.Code
+	}
+	if t.Lot != nil {
+		dto.LotCode = t.Lot.Code
+	}
+	return dto
+}
+
+// ApiStockLot representa um lote com os saldos por local para exibição
+type ApiStockLot struct {
+	ID                uint                 `json:"id"`
+	ProductID         uint                 `json:"product_id"`
+	ProductSKU        string               `json:"product_sku"`
+	ProductName       string               `json:"product_name"`
+	Code              string               `json:"code"`
+	ManufacturedAt    *time.Time           `json:"manufactured_at"`
+	ExpiresAt         *time.Time           `json:"expires_at"`
+	SupplierID        *uint                `json:"supplier_id"`
+	PurchaseID        *uint                `json:"purchase_id"`
+	ProductionOrderID *uint                `json:"production_order_id"`
+	Quantity          float64              `json:"quantity"` // Soma dos saldos nos locais
+	Balances          []ApiStockLotBalance `json:"balances"`
+	CreatedAt         time.Time            `json:"created_at"`
+}
+
+// ApiStockLotBalance representa o saldo de um lote em um local de estoque
+type ApiStockLotBalance struct {
+	LocationID   uint      `json:"location_id"`
+	LocationCode string    `json:"location_code"`
+	LocationName string    `json:"location_name"`
+	Quantity     float64   `json:"quantity"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// ApiExpiringLot representa o saldo de um lote vencido ou a vencer em um local.
+// DaysRemaining é negativo para lotes vencidos.
+type ApiExpiringLot struct {
+	LotID         uint      `json:"lot_id"`
+	Code          string    `json:"code"`
+	ProductID     uint      `json:"product_id"`
+	ProductSKU    string    `json:"product_sku"`
+	ProductName   string    `json:"product_name"`
+	LocationID    uint      `json:"location_id"`
+	LocationCode  string    `json:"location_code"`
+	Quantity      float64   `json:"quantity"`
+	ExpiresAt     time.Time `json:"expires_at"`
+	DaysRemaining int       `json:"days_remaining"`
+	Expired       bool      `json:"expired"`
+}
+
+// ApiLotCustomer representa a quantidade de um lote vendida a um cliente
+type ApiLotCustomer struct {
+	CustomerID   *uint     `json:"customer_id"` // Nulo: vendas sem cliente identificado
+	CustomerName string    `json:"customer_name"`
+	Quantity     float64   `json:"quantity"`
+	Sales        int64     `json:"sales"`
+	FirstSale    time.Time `json:"first_sale"`
+	LastSale     time.Time `json:"last_sale"`
+}
+
+// ApiLotSale representa uma venda em que o lote foi entregue
+type ApiLotSale struct {
+	SaleID       uint      `json:"sale_id"`
+	SaleItemID   uint      `json:"sale_item_id"`
+	SaleDate     time.Time `json:"sale_date"`
+	CustomerID   *uint     `json:"customer_id"`
+	LocationID   uint      `json:"location_id"`
+	LocationCode string    `json:"location_code"`
+	Quantity     float64   `json:"quantity"`
+}
+
+// ApiLotTraceability representa a rastreabilidade de um lote: os clientes que o receberam e as vendas
+type ApiLotTraceability struct {
+	Lot       ApiStockLot      `json:"lot"`
+	Customers []ApiLotCustomer `json:"customers"`
+	Sales     []ApiLotSale     `json:"sales"`
+}
+
+// ApiSaleLot representa a quantidade de um lote entregue em um item de venda
+type ApiSaleLot struct {
+	SaleItemID   uint       `json:"sale_item_id"`
+	ProductID    uint       `json:"product_id"`
+	ProductSKU   string     `json:"product_sku"`
+	ProductName  string     `json:"product_name"`
+	LotID        uint       `json:"lot_id"`
+	LotCode      string     `json:"lot_code"`
+	ExpiresAt    *time.Time `json:"expires_at"`
+	LocationID   uint       `json:"location_id"`
+	LocationCode string     `json:"location_code"`
+	Quantity     float64    `json:"quantity"`
+}
+
+// ApiStockLotFromModel converte um StockLot para ApiStockLot, incluindo os saldos carregados
+func ApiStockLotFromModel(l models.StockLot) ApiStockLot {
+	dto := ApiStockLot{
+		ID:                l.ID,
+		ProductID:         l.ProductID,
+		Code:              l.Code,
+		ManufacturedAt:    l.ManufacturedAt,
+		ExpiresAt:         l.ExpiresAt,
+		SupplierID:        l.SupplierID,
+		PurchaseID:        l.PurchaseID,
+		ProductionOrderID: l.ProductionOrderID,
+		Balances:          make([]ApiStockLotBalance, 0, len(l.Balances)),
+		CreatedAt:         l.CreatedAt,
+	}
+	if l.Product != nil {
+		dto.ProductSKU = l.Product.SKU
+		dto.ProductName = l.Product.Name
+	}
+	for _, balance := range l.Balances {
+		apiBalance := ApiStockLotBalance{
+			LocationID: balance.LocationID,
+			Quantity:   balance.Quantity,
+			UpdatedAt:  balance.UpdatedAt,
+		}
+		if balance.Location != nil {
+			apiBalance.LocationCode = balance.Location.Code
+			apiBalance.LocationName = balance.Location.Name
+		}
+		dto.Quantity += balance.Quantity
+		dto.Balances = append(dto.Balances, apiBalance)
+	}
+	return dto
+}
+
+// ApiLotSaleFromModel converte um SaleItemLot para ApiLotSale
+func ApiLotSaleFromModel(a models.SaleItemLot) ApiLotSale {
+	dto := ApiLotSale{
+		SaleID:     a.SaleID,
+		SaleItemID: a.SaleItemID,
+		SaleDate:   a.SaleDate,
+		CustomerID: a.CustomerID,
+		LocationID: a.LocationID,
+		Quantity:   a.Quantity,
+	}
+	if a.Location != nil {
+		dto.LocationCode = a.Location.Code
+	}
+	return dto
+}
+
+// ApiSaleLotFromModel converte um SaleItemLot para ApiSaleLot
+func ApiSaleLotFromModel(a models.SaleItemLot) ApiSaleLot {
+	dto := ApiSaleLot{
+		SaleItemID: a.SaleItemID,
+		ProductID:  a.ProductID,
+		LotID:      a.LotID,
+		LocationID: a.LocationID,
+		Quantity:   a.Quantity,
+	}
+	if a.Product != nil {
+		dto.ProductSKU = a.Product.SKU
+		dto.ProductName = a.Product.Name
+	}
+	if a.Lot != nil {
+		dto.LotCode = a.Lot.Code
+		dto.ExpiresAt = a.Lot.ExpiresAt
+	}
+	if a.Location != nil {
+		dto.LocationCode = a.Location.Code
+	}
+	return dto
+}
+
+// ApiStockReservation representa a quantidade de um produto reservada para um item de venda
+type ApiStockReservation struct {
+	ID           uint       `json:"id"`
+	SaleItemID   uint       `json:"sale_item_id"`
+	ProductID    uint       `json:"product_id"`
+	ProductSKU   string     `json:"product_sku"`
+	ProductName  string     `json:"product_name"`
+	LocationID   uint       `json:"location_id"`
+	LocationCode string     `json:"location_code"`
+	Quantity     float64    `json:"quantity"`
+	Status       string     `json:"status"`
+	ExpiresAt    *time.Time `json:"expires_at"`
+	ClosedAt     *time.Time `json:"closed_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// ApiStockReservationFromModel converte um StockReservation para ApiStockReservation. Reservas ativas já
+// vencidas, ainda não baixadas pela rotina de expiração, aparecem como expiradas.
+func ApiStockReservationFromModel(r models.StockReservation, now time.Time) ApiStockReservation {
+	dto := ApiStockReservation{
+		ID:         r.ID,
+		SaleItemID: r.SaleItemID,
+		ProductID:  r.ProductID,
+		LocationID: r.LocationID,
+		Quantity:   r.Quantity,
+		Status:     r.Status,
+		ExpiresAt:  r.ExpiresAt,
+		ClosedAt:   r.ClosedAt,
+		CreatedAt:  r.CreatedAt,
+	}
+	if r.Status == models.ReservationStatusActive && r.ExpiresAt != nil && !r.ExpiresAt.After(now) {
+		dto.Status = models.ReservationStatusExpired
+	}
+	if r.Product != nil {
+		dto.ProductSKU = r.Product.SKU
+		dto.ProductName = r.Product.Name
+	}
+	if r.Location != nil {
+		dto.LocationCode = r.Location.Code
+	}
+	return dto
+}
+
+// ApiSerialNumber representa um número de série com a situação atual e a garantia para exibição
+type ApiSerialNumber struct {
+	ID                uint                   `json:"id"`
+	ProductID         uint                   `json:"product_id"`
+	ProductSKU        string                 `json:"product_sku"`
+	ProductName       string                 `json:"product_name"`
+	Serial            string                 `json:"serial"`
+	Status            string                 `json:"status"` // 'em_estoque', 'vendido', 'devolvido'
+	LocationID        *uint                  `json:"location_id"`
+	LocationCode      string                 `json:"location_code"`
+	SupplierID        *uint                  `json:"supplier_id"`
+	PurchaseID        *uint                  `json:"purchase_id"`
+	ProductionOrderID *uint                  `json:"production_order_id"`
+	ReceivedAt        *time.Time             `json:"received_at"`
+	SaleID            *uint                  `json:"sale_id"`
+	CustomerID        *uint                  `json:"customer_id"`
+	SoldAt            *time.Time             `json:"sold_at"`
+	WarrantyMonths    int                    `json:"warranty_months"`
+	WarrantyUntil     *time.Time             `json:"warranty_until"`  // Último dia da garantia, nos vendidos
+	WarrantyStatus    string                 `json:"warranty_status"` // 'nao_vendido', 'sem_garantia', 'em_garantia', 'expirada'
+	Events            []ApiSerialNumberEvent `json:"events,omitempty"`
+}
+
+// ApiSerialNumberEvent representa um evento do histórico de um número de série
+type ApiSerialNumberEvent struct {
+	ID            uint      `json:"id"`
+	EventType     string    `json:"event_type"`
+	ReferenceType string    `json:"reference_type"`
+	ReferenceID   *uint     `json:"reference_id"`
+	LocationID    *uint     `json:"location_id"`
+	LocationCode  string    `json:"location_code"`
+	SupplierID    *uint     `json:"supplier_id"`
+	SupplierName  string    `json:"supplier_name"`
+	CustomerID    *uint     `json:"customer_id"`
+	CustomerName  string    `json:"customer_name"`
+	Notes         string    `json:"notes"`
+	CreatedBy     *uint     `json:"created_by"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// ApiSerialNumberListPaginated representa uma lista paginada de números de série
+type ApiSerialNumberListPaginated struct {
+	SerialNumbers []ApiSerialNumber `json:"data"`
+	Pagination    ApiPagination     `json:"pagination"`
+}
+
+// ApiSerialNumberFromModel converte um SerialNumber para ApiSerialNumber, sem o histórico e sem a garantia
+func ApiSerialNumberFromModel(s models.SerialNumber) ApiSerialNumber {
+	dto := ApiSerialNumber{
+		ID:                s.ID,
+		ProductID:         s.ProductID,
+		Serial:            s.Serial,
+		Status:            s.Status,
+		LocationID:        s.LocationID,
+		SupplierID:        s.SupplierID,
+		PurchaseID:        s.PurchaseID,
+		ProductionOrderID: s.ProductionOrderID,
+		ReceivedAt:        s.ReceivedAt,
+		SaleID:            s.SaleID,
+		CustomerID:        s.CustomerID,
+		SoldAt:            s.SoldAt,
+	}
+	if s.Product != nil {
+		dto.ProductSKU = s.Product.SKU
+		dto.ProductName = s.Product.Name
+		dto.WarrantyMonths = s.Product.WarrantyMonths
+	}
+	if s.Location != nil {
+		dto.LocationCode = s.Location.Code
+	}
+	return dto
+}
+
+// ApiSerialNumberEventFromModel converte um SerialNumberEvent para ApiSerialNumberEvent, sem os nomes do
+// fornecedor e do cliente
+func ApiSerialNumberEventFromModel(e models.SerialNumberEvent) ApiSerialNumberEvent {
+	dto := ApiSerialNumberEvent{
+		ID:            e.ID,
+		EventType:     e.EventType,
+		ReferenceType: e.ReferenceType,
+		ReferenceID:   e.ReferenceID,
+		LocationID:    e.LocationID,
+		SupplierID:    e.SupplierID,
+		CustomerID:    e.CustomerID,
+		Notes:         e.Notes,
+		CreatedBy:     e.CreatedByID,
+		CreatedAt:     e.CreatedAt,
+	}
+	if e.Location != nil {
+		dto.LocationCode = e.Location.Code
+	}
+	return dto
+}
+
+// ApiInventoryValuationRow representa o valor do estoque de uma categoria em um local. Produtos sem categoria
+// e movimentações anteriores aos locais de estoque ficam com a categoria ou o local nulos.
+type ApiInventoryValuationRow struct {
+	CategoryID   *uint   `json:"category_id"`
+	CategoryName string  `json:"category_name"`
+	LocationID   *uint   `json:"location_id"`
+	LocationCode string  `json:"location_code"`
+	LocationName string  `json:"location_name"`
+	ProductCount int64   `json:"product_count"`
+	Value        float64 `json:"value"`
+}
+
+// ApiInventoryValuationTotal representa o valor do estoque de uma categoria ou de um local
+type ApiInventoryValuationTotal struct {
+	ID    *uint   `json:"id"`
+	Name  string  `json:"name"`
+	Value float64 `json:"value"`
+}
+
+// ApiInventoryValuation representa o valor do estoque pelo custo médio no fim do dia informado, por
+// categoria e local
+type ApiInventoryValuation struct {
+	Date       time.Time                    `json:"date"`
+	TotalValue float64                      `json:"total_value"`
+	Rows       []ApiInventoryValuationRow   `json:"rows"`
+	ByCategory []ApiInventoryValuationTotal `json:"by_category"`
+	ByLocation []ApiInventoryValuationTotal `json:"by_location"`
+}
+
+// ApiInventoryValuationRowFromModel converte um InventoryValuationRow para ApiInventoryValuationRow
+func ApiInventoryValuationRowFromModel(r models.InventoryValuationRow) ApiInventoryValuationRow {
+	row := ApiInventoryValuationRow{
+		CategoryID:   r.CategoryID,
+		LocationID:   r.LocationID,
+		ProductCount: r.ProductCount,
+		Value:        r.Value,
+	}
+	if r.CategoryName != nil {
+		row.CategoryName = *r.CategoryName
+	}
+	if r.LocationCode != nil {
+		row.LocationCode = *r.LocationCode
+	}
+	if r.LocationName != nil {
+		row.LocationName = *r.LocationName
+	}
+	return row
+}
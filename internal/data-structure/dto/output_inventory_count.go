@@ -0,0 +1,177 @@
+package dto
+
+import (
+	"simple-erp-service/internal/data-structure/models"
+	"time"
+)
+
+// ApiInventoryCount representa um inventário para exibição
+type ApiInventoryCount struct {
+	ID           uint                    `json:"id"`
+	Description  string                  `json:"description"`
+	LocationID   *uint                   `json:"location_id"`
+	LocationCode string                  `json:"location_code"`
+	CategoryID   *uint                   `json:"category_id"`
+	CategoryName string                  `json:"category_name"`
+	Blind        bool                    `json:"blind"`
+	Status       string                  `json:"status"`
+	Notes        string                  `json:"notes"`
+	ApprovedAt   *time.Time              `json:"approved_at"`
+	ApprovedBy   *uint                   `json:"approved_by"`
+	CreatedBy    *uint                   `json:"created_by"`
+	CreatedAt    time.Time               `json:"created_at"`
+	UpdatedAt    time.Time               `json:"updated_at"`
+	Items        []ApiInventoryCountItem `json:"items,omitempty"`
+}
+
+// ApiInventoryCountItem representa um item do inventário. No inventário cego aberto, as quantidades
+// esperadas e contadas não são exibidas.
+type ApiInventoryCountItem struct {
+	ID               uint                     `json:"id"`
+	ProductID        uint                     `json:"product_id"`
+	ProductSKU       string                   `json:"product_sku"`
+	ProductName      string                   `json:"product_name"`
+	LocationID       uint                     `json:"location_id"`
+	LocationCode     string                   `json:"location_code"`
+	LotID            *uint                    `json:"lot_id"`
+	LotCode          string                   `json:"lot_code"`
+	ExpectedQuantity *float64                 `json:"expected_quantity"`
+	CountedQuantity  *float64                 `json:"counted_quantity"`
+	Round            int                      `json:"round"`
+	Status           string                   `json:"status"`
+	Entries          []ApiInventoryCountEntry `json:"entries,omitempty"`
+}
+
+// ApiInventoryCountEntry representa uma contagem informada para um item do inventário
+type ApiInventoryCountEntry struct {
+	Round     int       `json:"round"`
+	Quantity  *float64  `json:"quantity"`
+	CountedBy *uint     `json:"counted_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ApiInventoryCountListPaginated representa uma lista paginada de inventários
+type ApiInventoryCountListPaginated struct {
+	Counts     []ApiInventoryCount `json:"data"`
+	Pagination ApiPagination       `json:"pagination"`
+}
+
+// ApiInventoryCountVariance representa a diferença de um item do inventário, valorizada pelo custo
+type ApiInventoryCountVariance struct {
+	ItemID           uint     `json:"item_id"`
+	ProductID        uint     `json:"product_id"`
+	ProductSKU       string   `json:"product_sku"`
+	ProductName      string   `json:"product_name"`
+	LocationID       uint     `json:"location_id"`
+	LocationCode     string   `json:"location_code"`
+	LotID            *uint    `json:"lot_id"`
+	LotCode          string   `json:"lot_code"`
+	Status           string   `json:"status"`
+	ExpectedQuantity float64  `json:"expected_quantity"`
+	CountedQuantity  *float64 `json:"counted_quantity"`
+	Variance         float64  `json:"variance"` // Contado menos esperado: negativa nas faltas
+	UnitCost         float64  `json:"unit_cost"`
+	VarianceValue    float64  `json:"variance_value"`
+}
+
+// ApiInventoryCountVarianceReport representa o relatório de diferenças de um inventário. Os totais
+// consideram somente os itens já apurados.
+type ApiInventoryCountVarianceReport struct {
+	InventoryCountID uint                        `json:"inventory_count_id"`
+	Status           string                      `json:"status"`
+	TotalItems       int                         `json:"total_items"`
+	CountedItems     int                         `json:"counted_items"`
+	DivergentItems   int                         `json:"divergent_items"`
+	SurplusValue     float64                     `json:"surplus_value"`  // Valor das sobras
+	ShortageValue    float64                     `json:"shortage_value"` // Valor das faltas, positivo
+	NetValue         float64                     `json:"net_value"`      // Sobras menos faltas
+	Items            []ApiInventoryCountVariance `json:"items"`          // Itens com diferença ou ainda não apurados
+}
+
+// ApiInventoryCountFromModel converte um InventoryCount para ApiInventoryCount. No inventário cego aberto, as
+// quantidades dos itens são omitidas.
+func ApiInventoryCountFromModel(c models.InventoryCount) ApiInventoryCount {
+	dto := ApiInventoryCount{
+		ID:          c.ID,
+		Description: c.Description,
+		LocationID:  c.LocationID,
+		CategoryID:  c.CategoryID,
+		Blind:       c.Blind,
+		Status:      c.Status,
+		Notes:       c.Notes,
+		ApprovedAt:  c.ApprovedAt,
+		ApprovedBy:  c.ApprovedByID,
+		CreatedBy:   c.CreatedByID,
+		CreatedAt:   c.CreatedAt,
+		UpdatedAt:   c.UpdatedAt,
+	}
+	if c.Location != nil {
+		dto.LocationCode = c.Location.Code
+	}
+	if c.Category != nil {
+		dto.CategoryName = c.Category.Name
+	}
+
+	hideQuantities := c.Blind && c.Status == models.InventoryCountStatusOpen
+	for _, item := range c.Items {
+		apiItem := ApiInventoryCountItem{
+			ID:         item.ID,
+			ProductID:  item.ProductID,
+			LocationID: item.LocationID,
+			LotID:      item.LotID,
+			Round:      item.Round,
+			Status:     item.Status,
+		}
+		setInventoryCountItemNames(item, &apiItem.ProductSKU, &apiItem.ProductName, &apiItem.LocationCode, &apiItem.LotCode)
+		if !hideQuantities {
+			expected := item.ExpectedQuantity
+			apiItem.ExpectedQuantity = &expected
+			apiItem.CountedQuantity = item.CountedQuantity
+		}
+		for _, entry := range item.Entries {
+			apiEntry := ApiInventoryCountEntry{
+				Round:     entry.Round,
+				CountedBy: entry.CountedByID,
+				CreatedAt: entry.CreatedAt,
+			}
+			if !hideQuantities {
+				quantity := entry.Quantity
+				apiEntry.Quantity = &quantity
+			}
+			apiItem.Entries = append(apiItem.Entries, apiEntry)
+		}
+		dto.Items = append(dto.Items, apiItem)
+	}
+
+	return dto
+}
+
+// ApiInventoryCountVarianceFromModel converte um InventoryCountItem para ApiInventoryCountVariance
+func ApiInventoryCountVarianceFromModel(i models.InventoryCountItem) ApiInventoryCountVariance {
+	dto := ApiInventoryCountVariance{
+		ItemID:           i.ID,
+		ProductID:        i.ProductID,
+		LocationID:       i.LocationID,
+		LotID:            i.LotID,
+		Status:           i.Status,
+		ExpectedQuantity: i.ExpectedQuantity,
+		CountedQuantity:  i.CountedQuantity,
+		UnitCost:         i.UnitCost,
+	}
+	setInventoryCountItemNames(i, &dto.ProductSKU, &dto.ProductName, &dto.LocationCode, &dto.LotCode)
+	return dto
+}
+
+// setInventoryCountItemNames preenche o SKU e o nome do produto e os códigos do local e do lote do item
+func setInventoryCountItemNames(i models.InventoryCountItem, sku, name, locationCode, lotCode *string) {
+	if i.Product != nil {
+		*sku = i.Product.SKU
+		*name = i.Product.Name
+	}
+	if i.Location != nil {
+		*locationCode = i.Location.Code
+	}
+	if i.Lot != nil {
+		*lotCode = i.Lot.Code
+	}
+}
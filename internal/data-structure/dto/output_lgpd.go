@@ -0,0 +1,63 @@
+package dto
+
+import (
+	"simple-erp-service/internal/data-structure/models"
+	"time"
+)
+
+// ApiAuditEntry representa um registro do log de auditoria
+type ApiAuditEntry struct {
+	ID             uint                   `json:"id"`
+	Action         string                 `json:"action"`
+	EntityType     string                 `json:"entity_type"`
+	EntityID       string                 `json:"entity_id"`
+	UserID         *uint                  `json:"user_id"`
+	ImpersonatorID *uint                  `json:"impersonator_id,omitempty"`
+	IPAddress      string                 `json:"ip_address"`
+	Details        map[string]interface{} `json:"details"`
+	CreatedAt      time.Time              `json:"created_at"`
+}
+
+// ApiCustomerDataExport representa todos os dados mantidos sobre um cliente (portabilidade/acesso LGPD)
+type ApiCustomerDataExport struct {
+	GeneratedAt  time.Time            `json:"generated_at"`
+	Customer     ApiCustomerDetail    `json:"customer"`
+	Sales        []models.Sale        `json:"sales"`
+	Transactions []models.Transaction `json:"transactions"`
+	AuditEntries []ApiAuditEntry      `json:"audit_entries"`
+}
+
+// ApiCustomerAnonymization representa o resultado da anonimização de um cliente.
+// Quando DocumentRetained é verdadeiro o CPF/CNPJ foi mantido por causa da guarda obrigatória
+// dos registros fiscais e poderá ser removido anonimizando o cliente novamente após RetainedUntil.
+type ApiCustomerAnonymization struct {
+	CustomerID       uint       `json:"customer_id"`
+	AnonymizedAt     time.Time  `json:"anonymized_at"`
+	DocumentRetained bool       `json:"document_retained"`
+	RetainedUntil    *time.Time `json:"retained_until"`
+	RemovedAddresses int64      `json:"removed_addresses"`
+	RemovedContacts  int64      `json:"removed_contacts"`
+	RemovedDocuments int64      `json:"removed_documents"`
+
+	// Registros de auditoria dos quais os dados pessoais foram retirados
+	RedactedAuditEntries int64 `json:"redacted_audit_entries"`
+	// Textos livres limpos fora do cadastro: jobs de importação, vendas e eventos de números de série
+	RedactedImportJobs  int64 `json:"redacted_import_jobs"`
+	ClearedSaleNotes    int64 `json:"cleared_sale_notes"`
+	ClearedSerialEvents int64 `json:"cleared_serial_events"`
+}
+
+// ApiAuditEntryFromModel converte um SystemLog para ApiAuditEntry
+func ApiAuditEntryFromModel(l models.SystemLog) ApiAuditEntry {
+	return ApiAuditEntry{
+		ID:             l.ID,
+		Action:         l.Action,
+		EntityType:     l.EntityType,
+		EntityID:       l.EntityID,
+		UserID:         l.UserID,
+		ImpersonatorID: l.ImpersonatorID,
+		IPAddress:      l.IPAddress,
+		Details:        l.Details,
+		CreatedAt:      l.CreatedAt,
+	}
+}
@@ -0,0 +1,73 @@
+package dto
+
+import "simple-erp-service/internal/data-structure/models"
+
+// ApiCountry representa um país para exibição
+type ApiCountry struct {
+	ID        uint   `json:"id"`
+	Name      string `json:"name"`
+	BacenCode string `json:"bacen_code"`
+	PhoneCode string `json:"phone_code"`
+}
+
+// ApiState representa um estado para exibição
+type ApiState struct {
+	ID        uint   `json:"id"`
+	Name      string `json:"name"`
+	UF        string `json:"uf"`
+	IBGECode  string `json:"ibge_code"`
+	CountryID uint   `json:"country_id"`
+}
+
+// ApiCity representa uma cidade para exibição
+type ApiCity struct {
+	ID       uint   `json:"id"`
+	Name     string `json:"name"`
+	IBGECode string `json:"ibge_code"`
+	StateID  uint   `json:"state_id"`
+}
+
+// ApiCountryFromModel converte um Country para ApiCountry
+func ApiCountryFromModel(c models.Country) ApiCountry {
+	return ApiCountry{
+		ID:        c.ID,
+		Name:      c.Name,
+		BacenCode: c.BacenCode,
+		PhoneCode: c.PhoneCode,
+	}
+}
+
+// ApiStateFromModel converte um State para ApiState
+func ApiStateFromModel(s models.State) ApiState {
+	return ApiState{
+		ID:        s.ID,
+		Name:      s.Name,
+		UF:        s.UF,
+		IBGECode:  s.IBGECode,
+		CountryID: s.CountryID,
+	}
+}
+
+// ApiCityFromModel converte um City para ApiCity
+func ApiCityFromModel(c models.City) ApiCity {
+	return ApiCity{
+		ID:       c.ID,
+		Name:     c.Name,
+		IBGECode: c.IBGECode,
+		StateID:  c.StateID,
+	}
+}
+
+// ApiPostalCode representa o endereço de um CEP para preenchimento de models.Address.
+// CityID é nulo quando o município do CEP não está cadastrado.
+type ApiPostalCode struct {
+	ZipCode      string `json:"zip_code"`
+	Street       string `json:"street"`
+	Neighborhood string `json:"neighborhood"`
+	Complement   string `json:"complement"`
+	CityID       *uint  `json:"city_id"`
+	CityName     string `json:"city_name"`
+	CityIBGECode string `json:"city_ibge_code"`
+	StateID      *uint  `json:"state_id"`
+	UF           string `json:"uf"`
+}
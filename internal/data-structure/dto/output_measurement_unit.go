@@ -0,0 +1,69 @@
+package dto
+
+import (
+	"simple-erp-service/internal/data-structure/models"
+	"time"
+)
+
+// ApiMeasurementUnit representa uma unidade de medida para exibição
+type ApiMeasurementUnit struct {
+	ID           uint   `json:"id"`
+	Name         string `json:"name"`
+	Abbreviation string `json:"abbreviation"`
+}
+
+// ApiUnitConversion representa uma conversão de unidades para exibição: 1 unidade de origem equivale a
+// Factor unidades de destino
+type ApiUnitConversion struct {
+	ID                   uint      `json:"id"`
+	FromUnitID           uint      `json:"from_unit_id"`
+	FromUnitAbbreviation string    `json:"from_unit_abbreviation"`
+	ToUnitID             uint      `json:"to_unit_id"`
+	ToUnitAbbreviation   string    `json:"to_unit_abbreviation"`
+	Factor               float64   `json:"factor"`
+	ProductID            *uint     `json:"product_id"` // Nulo nas conversões globais
+	CreatedAt            time.Time `json:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at"`
+}
+
+// ApiProductUnit representa uma unidade em que o produto pode ser comprado ou vendido
+type ApiProductUnit struct {
+	UnitID       uint    `json:"unit_id"`
+	Name         string  `json:"name"`
+	Abbreviation string  `json:"abbreviation"`
+	Factor       float64 `json:"factor"`        // Unidades do produto em cada unidade
+	IsBase       bool    `json:"is_base"`       // Unidade do produto, em que o estoque é mantido
+	IsExclusive  bool    `json:"is_exclusive"`  // Conversão exclusiva do produto
+	ConversionID *uint   `json:"conversion_id"` // Conversão usada; nula na unidade do produto
+}
+
+// ApiMeasurementUnitFromModel converte um MeasurementUnit para ApiMeasurementUnit
+func ApiMeasurementUnitFromModel(u models.MeasurementUnit) ApiMeasurementUnit {
+	return ApiMeasurementUnit{
+		ID:           u.ID,
+		Name:         u.Name,
+		Abbreviation: u.Abbreviation,
+	}
+}
+
+// ApiUnitConversionFromModel converte um UnitConversion para ApiUnitConversion
+func ApiUnitConversionFromModel(c models.UnitConversion) ApiUnitConversion {
+	dto := ApiUnitConversion{
+		ID:         c.ID,
+		FromUnitID: c.FromUnitID,
+		ToUnitID:   c.ToUnitID,
+		Factor:     c.Factor,
+		ProductID:  c.ProductID,
+		CreatedAt:  c.CreatedAt,
+		UpdatedAt:  c.UpdatedAt,
+	}
+
+	if c.FromUnit != nil {
+		dto.FromUnitAbbreviation = c.FromUnit.Abbreviation
+	}
+	if c.ToUnit != nil {
+		dto.ToUnitAbbreviation = c.ToUnit.Abbreviation
+	}
+
+	return dto
+}
@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"simple-erp-service/internal/data-structure/models"
+	"time"
+)
+
+// ApiNotification representa uma notificação para exibição
+type ApiNotification struct {
+	ID            uint       `json:"id"`
+	Type          string     `json:"type"`
+	Title         string     `json:"title"`
+	Message       string     `json:"message"`
+	ReferenceType string     `json:"reference_type"`
+	ReferenceID   *uint      `json:"reference_id"`
+	ReadAt        *time.Time `json:"read_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// ApiNotificationListPaginated representa uma lista paginada de notificações com o total de não lidas
+type ApiNotificationListPaginated struct {
+	Notifications []ApiNotification `json:"data"`
+	Unread        int64             `json:"unread"`
+	Pagination    ApiPagination     `json:"pagination"`
+}
+
+// ApiNotificationFromModel converte um Notification para ApiNotification
+func ApiNotificationFromModel(n models.Notification) ApiNotification {
+	return ApiNotification{
+		ID:            n.ID,
+		Type:          n.Type,
+		Title:         n.Title,
+		Message:       n.Message,
+		ReferenceType: n.ReferenceType,
+		ReferenceID:   n.ReferenceID,
+		ReadAt:        n.ReadAt,
+		CreatedAt:     n.CreatedAt,
+	}
+}
@@ -0,0 +1,142 @@
+package dto
+
+import (
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/utils/brdoc"
+	"time"
+)
+
+// ApiAddress representa os dados de um endereço para exibição
+type ApiAddress struct {
+	ID           uint   `json:"id"`
+	Street       string `json:"street"`
+	Number       string `json:"number"`
+	Complement   string `json:"complement"`
+	Neighborhood string `json:"neighborhood"`
+	ZipCode      string `json:"zip_code"`
+	CityID       uint   `json:"city_id"`
+	CityName     string `json:"city_name,omitempty"`
+	UF           string `json:"uf,omitempty"`
+	IsPrimary    bool   `json:"is_primary"`
+}
+
+// ApiContact representa os dados de um contato para exibição
+type ApiContact struct {
+	ID        uint   `json:"id"`
+	Type      string `json:"type"`
+	Contact   string `json:"contact"`
+	Name      string `json:"name"`
+	IsPrimary bool   `json:"is_primary"`
+}
+
+// ApiDocument representa os dados de um documento para exibição
+type ApiDocument struct {
+	ID           uint       `json:"id"`
+	Type         string     `json:"type"`
+	Number       string     `json:"number"`
+	Validate     *time.Time `json:"validate"`
+	EmissionDate *time.Time `json:"emission_date"`
+	Department   string     `json:"department"`
+	StateID      *uint      `json:"state_id"`
+	UF           string     `json:"uf,omitempty"`
+	IsMandatory  bool       `json:"is_mandatory"`
+}
+
+// ApiExpiringDocument representa um documento vencido ou a vencer com o seu dono.
+// DaysRemaining é negativo para documentos vencidos.
+type ApiExpiringDocument struct {
+	ID            uint      `json:"id"`
+	Type          string    `json:"type"`
+	Number        string    `json:"number"`
+	Validate      time.Time `json:"validate"`
+	DaysRemaining int       `json:"days_remaining"`
+	Expired       bool      `json:"expired"`
+	IsMandatory   bool      `json:"is_mandatory"`
+	OwnerType     string    `json:"owner_type"` // customer ou supplier
+	OwnerID       uint      `json:"owner_id"`
+	OwnerName     string    `json:"owner_name"`
+}
+
+// ApiAddressFromModel converte um Address para ApiAddress
+func ApiAddressFromModel(a models.Address) ApiAddress {
+	return ApiAddress{
+		ID:           a.ID,
+		Street:       a.Street,
+		Number:       a.Number,
+		Complement:   a.Complement,
+		Neighborhood: a.Neighborhood,
+		ZipCode:      brdoc.FormatCEP(a.ZipCode),
+		CityID:       a.CityID,
+		CityName:     a.City.Name,
+		UF:           a.City.State.UF,
+		IsPrimary:    a.IsPrimary,
+	}
+}
+
+// ApiContactFromModel converte um Contact para ApiContact
+func ApiContactFromModel(c models.Contact) ApiContact {
+	return ApiContact{
+		ID:        c.ID,
+		Type:      c.Type,
+		Contact:   c.Contact,
+		Name:      c.Name,
+		IsPrimary: c.IsPrimary,
+	}
+}
+
+// ApiDocumentFromModel converte um Document para ApiDocument
+func ApiDocumentFromModel(d models.Document) ApiDocument {
+	dto := ApiDocument{
+		ID:           d.ID,
+		Type:         d.Type,
+		Number:       d.Number,
+		Validate:     d.Validate,
+		EmissionDate: d.EmissionDate,
+		Department:   d.Department,
+		StateID:      d.StateID,
+		IsMandatory:  d.IsMandatory,
+	}
+
+	if d.State != nil {
+		dto.UF = d.State.UF
+	}
+
+	return dto
+}
+
+// ApiAddressesFromModel converte uma lista de Address, retornando também o endereço principal
+func ApiAddressesFromModel(addresses []models.Address) ([]ApiAddress, *ApiAddress) {
+	var primary *ApiAddress
+	result := make([]ApiAddress, 0, len(addresses))
+	for _, address := range addresses {
+		result = append(result, ApiAddressFromModel(address))
+		if address.IsPrimary && primary == nil {
+			p := result[len(result)-1]
+			primary = &p
+		}
+	}
+	return result, primary
+}
+
+// ApiContactsFromModel converte uma lista de Contact, retornando também o contato principal
+func ApiContactsFromModel(contacts []models.Contact) ([]ApiContact, *ApiContact) {
+	var primary *ApiContact
+	result := make([]ApiContact, 0, len(contacts))
+	for _, contact := range contacts {
+		result = append(result, ApiContactFromModel(contact))
+		if contact.IsPrimary && primary == nil {
+			p := result[len(result)-1]
+			primary = &p
+		}
+	}
+	return result, primary
+}
+
+// ApiDocumentsFromModel converte uma lista de Document
+func ApiDocumentsFromModel(documents []models.Document) []ApiDocument {
+	result := make([]ApiDocument, 0, len(documents))
+	for _, document := range documents {
+		result = append(result, ApiDocumentFromModel(document))
+	}
+	return result
+}
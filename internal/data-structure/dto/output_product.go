@@ -0,0 +1,144 @@
+package dto
+
+import (
+	"simple-erp-service/internal/data-structure/models"
+	"time"
+)
+
+// ApiProduct representa os dados de produto para exibição
+type ApiProduct struct {
+	ID               uint      `json:"id"`
+	SKU              string    `json:"sku"`
+	Barcode          *string   `json:"barcode"`
+	Name             string    `json:"name"`
+	Description      string    `json:"description"`
+	CategoryID       *uint     `json:"category_id"`
+	CategoryName     string    `json:"category_name"`
+	UnitID           *uint     `json:"unit_id"`
+	UnitAbbreviation string    `json:"unit_abbreviation"`
+	CostPrice        float64   `json:"cost_price"`
+	SellingPrice     float64   `json:"selling_price"`
+	MinStock         float64   `json:"min_stock"`
+	MaxStock         *float64  `json:"max_stock"`
+	CurrentStock     float64   `json:"current_stock"`
+	IsActive         bool      `json:"is_active"`
+	Kind             string    `json:"kind"` // 'simples', 'kit', 'fabricado'
+	TracksLots       bool      `json:"tracks_lots"`
+	TracksSerials    bool      `json:"tracks_serials"`
+	WarrantyMonths   int       `json:"warranty_months"` // Garantia a partir da venda, nos produtos com número de série
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+
+	// Variantes (grade)
+	ParentID      *uint                    `json:"parent_id"`            // Produto pai, nas variantes
+	PriceOverride bool                     `json:"price_override"`       // Variante com preço próprio
+	VariantCount  int64                    `json:"variant_count"`        // Quantidade de variantes, no produto pai
+	TotalStock    float64                  `json:"total_stock"`          // Estoque próprio somado ao das variantes; nos kits, quantidade que pode ser montada
+	Attributes    []ApiProductVariantValue `json:"attributes,omitempty"` // Valores dos atributos, nas variantes
+
+	// Reservas das vendas pendentes
+	ReservedStock  float64 `json:"reserved_stock"`  // Quantidade reservada para vendas pendentes
+	AvailableStock float64 `json:"available_stock"` // Estoque atual menos o reservado; nos kits, quantidade que pode ser montada
+}
+
+// ApiProductVariantValue representa o valor de um atributo da variante
+type ApiProductVariantValue struct {
+	AttributeID   uint   `json:"attribute_id"`
+	AttributeName string `json:"attribute_name"`
+	ValueID       uint   `json:"value_id"`
+	Value         string `json:"value"`
+}
+
+// ApiProductAttribute representa um atributo das variantes com os seus valores
+type ApiProductAttribute struct {
+	ID     uint                       `json:"id"`
+	Name   string                     `json:"name"`
+	Values []ApiProductAttributeValue `json:"values"`
+}
+
+// ApiProductAttributeValue representa um valor do atributo
+type ApiProductAttributeValue struct {
+	ID       uint   `json:"id"`
+	Value    string `json:"value"`
+	Code     string `json:"code"`
+	Position int    `json:"position"`
+}
+
+// ApiProductListPaginated representa uma lista paginada de produtos
+type ApiProductListPaginated struct {
+	Products   []ApiProduct  `json:"data"`
+	Pagination ApiPagination `json:"pagination"`
+}
+
+// ApiProductFromModel converte um Product para ApiProduct
+func ApiProductFromModel(p models.Product) ApiProduct {
+	dto := ApiProduct{
+		ID:           p.ID,
+		SKU:          p.SKU,
+		Barcode:      p.Barcode,
+		Name:         p.Name,
+		Description:  p.Description,
+		CategoryID:   p.CategoryID,
+		UnitID:       p.UnitID,
+		CostPrice:    p.CostPrice,
+		SellingPrice: p.SellingPrice,
+		MinStock:     p.MinStock,
+		MaxStock:     p.MaxStock,
+		CurrentStock: p.CurrentStock,
+		IsActive:     p.IsActive,
+		Kind:         p.Kind,
+		TracksLots:   p.TracksLots,
+		CreatedAt:    p.CreatedAt,
+		UpdatedAt:    p.UpdatedAt,
+
+		TracksSerials:  p.TracksSerials,
+		WarrantyMonths: p.WarrantyMonths,
+
+		ParentID:      p.ParentID,
+		PriceOverride: p.PriceOverride,
+		TotalStock:    p.CurrentStock,
+
+		AvailableStock: p.CurrentStock,
+	}
+
+	if p.Category != nil {
+		dto.CategoryName = p.Category.Name
+	}
+	if p.Unit != nil {
+		dto.UnitAbbreviation = p.Unit.Abbreviation
+	}
+	for _, value := range p.VariantValues {
+		if value.AttributeValue == nil {
+			continue
+		}
+		apiValue := ApiProductVariantValue{
+			AttributeID: value.AttributeID,
+			ValueID:     value.AttributeValueID,
+			Value:       value.AttributeValue.Value,
+		}
+		if value.AttributeValue.Attribute != nil {
+			apiValue.AttributeName = value.AttributeValue.Attribute.Name
+		}
+		dto.Attributes = append(dto.Attributes, apiValue)
+	}
+
+	return dto
+}
+
+// ApiProductAttributeFromModel converte um ProductAttribute para ApiProductAttribute, com os valores carregados
+func ApiProductAttributeFromModel(a models.ProductAttribute) ApiProductAttribute {
+	dto := ApiProductAttribute{
+		ID:     a.ID,
+		Name:   a.Name,
+		Values: make([]ApiProductAttributeValue, 0, len(a.Values)),
+	}
+	for _, value := range a.Values {
+		dto.Values = append(dto.Values, ApiProductAttributeValue{
+			ID:       value.ID,
+			Value:    value.Value,
+			Code:     value.Code,
+			Position: value.Position,
+		})
+	}
+	return dto
+}
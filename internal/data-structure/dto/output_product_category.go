@@ -0,0 +1,72 @@
+package dto
+
+import "simple-erp-service/internal/data-structure/models"
+
+// ApiProductCategory representa uma categoria de produto para exibição
+type ApiProductCategory struct {
+	ID          uint   `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	ParentID    *uint  `json:"parent_id"`
+}
+
+// ApiProductCategoryNode representa uma categoria da árvore com as subcategorias.
+// TotalProductCount inclui os produtos de todas as subcategorias.
+type ApiProductCategoryNode struct {
+	ID                uint                     `json:"id"`
+	Name              string                   `json:"name"`
+	Description       string                   `json:"description"`
+	ParentID          *uint                    `json:"parent_id"`
+	Depth             int                      `json:"depth"`
+	ProductCount      int64                    `json:"product_count"`
+	TotalProductCount int64                    `json:"total_product_count"`
+	Children          []ApiProductCategoryNode `json:"children"`
+}
+
+// ApiProductCategoryFromModel converte um ProductCategory para ApiProductCategory
+func ApiProductCategoryFromModel(c models.ProductCategory) ApiProductCategory {
+	return ApiProductCategory{
+		ID:          c.ID,
+		Name:        c.Name,
+		Description: c.Description,
+		ParentID:    c.ParentID,
+	}
+}
+
+// ApiProductCategoryTreeFromNodes monta a árvore a partir dos nós ordenados por profundidade, como
+// retornados pela consulta recursiva. Os filhos mantêm a ordem dos nós (por nome).
+func ApiProductCategoryTreeFromNodes(nodes []models.ProductCategoryNode) []ApiProductCategoryNode {
+	children := make(map[uint][]models.ProductCategoryNode)
+	roots := make([]models.ProductCategoryNode, 0)
+	for _, node := range nodes {
+		if node.Depth == 0 || node.ParentID == nil {
+			roots = append(roots, node)
+		} else {
+			children[*node.ParentID] = append(children[*node.ParentID], node)
+		}
+	}
+
+	var build func(node models.ProductCategoryNode) ApiProductCategoryNode
+	build = func(node models.ProductCategoryNode) ApiProductCategoryNode {
+		result := ApiProductCategoryNode{
+			ID:                node.ID,
+			Name:              node.Name,
+			Description:       node.Description,
+			ParentID:          node.ParentID,
+			Depth:             node.Depth,
+			ProductCount:      node.ProductCount,
+			TotalProductCount: node.TotalProductCount,
+			Children:          make([]ApiProductCategoryNode, 0, len(children[node.ID])),
+		}
+		for _, child := range children[node.ID] {
+			result.Children = append(result.Children, build(child))
+		}
+		return result
+	}
+
+	tree := make([]ApiProductCategoryNode, 0, len(roots))
+	for _, root := range roots {
+		tree = append(tree, build(root))
+	}
+	return tree
+}
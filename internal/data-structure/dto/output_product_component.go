@@ -0,0 +1,96 @@
+package dto
+
+import (
+	"simple-erp-service/internal/data-structure/models"
+	"time"
+)
+
+// ApiProductComposition representa a composição de um kit ou a ficha técnica de um produto fabricado
+type ApiProductComposition struct {
+	ProductID  uint                  `json:"product_id"`
+	Kind       string                `json:"kind"`
+	Available  float64               `json:"available"` // Unidades que podem ser montadas ou produzidas com o estoque atual
+	Components []ApiProductComponent `json:"components"`
+}
+
+// ApiProductComponent representa um componente da composição com o seu estoque
+type ApiProductComponent struct {
+	ComponentID      uint    `json:"component_id"`
+	SKU              string  `json:"sku"`
+	Name             string  `json:"name"`
+	UnitAbbreviation string  `json:"unit_abbreviation"`
+	Quantity         float64 `json:"quantity"` // Por unidade do produto, na unidade do componente
+	CurrentStock     float64 `json:"current_stock"`
+	Available        float64 `json:"available"` // Unidades do produto que o estoque do componente atende
+}
+
+// ApiProductionOrder representa uma ordem de produção para exibição
+type ApiProductionOrder struct {
+	ID          uint                          `json:"id"`
+	ProductID   uint                          `json:"product_id"`
+	ProductSKU  string                        `json:"product_sku"`
+	ProductName string                        `json:"product_name"`
+	Quantity    float64                       `json:"quantity"`
+	Status      string                        `json:"status"`
+	CompletedAt *time.Time                    `json:"completed_at"`
+	Notes       string                        `json:"notes"`
+	LocationID  *uint                         `json:"location_id"`
+	CreatedBy   *uint                         `json:"created_by"`
+	CreatedAt   time.Time                     `json:"created_at"`
+	UpdatedAt   time.Time                     `json:"updated_at"`
+	Components  []ApiProductionOrderComponent `json:"components,omitempty"`
+	LotID       *uint                         `json:"lot_id"`
+	LotCode     string                        `json:"lot_code"`
+}
+
+// ApiProductionOrderComponent representa a quantidade de um componente consumida pela ordem
+type ApiProductionOrderComponent struct {
+	ComponentID uint    `json:"component_id"`
+	SKU         string  `json:"sku"`
+	Name        string  `json:"name"`
+	Quantity    float64 `json:"quantity"`
+}
+
+// ApiProductionOrderListPaginated representa uma lista paginada de ordens de produção
+type ApiProductionOrderListPaginated struct {
+	Orders     []ApiProductionOrder `json:"data"`
+	Pagination ApiPagination        `json:"pagination"`
+}
+
+// ApiProductionOrderFromModel converte um ProductionOrder para ApiProductionOrder
+func ApiProductionOrderFromModel(o models.ProductionOrder) ApiProductionOrder {
+	dto := ApiProductionOrder{
+		ID:          o.ID,
+		ProductID:   o.ProductID,
+		Quantity:    o.Quantity,
+		Status:      o.Status,
+		CompletedAt: o.CompletedAt,
+		Notes:       o.Notes,
+		LocationID:  o.LocationID,
+		LotID:       o.LotID,
+		CreatedBy:   o.CreatedByID,
+		CreatedAt:   o.CreatedAt,
+		UpdatedAt:   o.UpdatedAt,
+	}
+
+	if o.Product != nil {
+		dto.ProductSKU = o.Product.SKU
+		dto.ProductName = o.Product.Name
+	}
+	if o.Lot != nil {
+		dto.LotCode = o.Lot.Code
+	}
+	for _, component := range o.Components {
+		apiComponent := ApiProductionOrderComponent{
+			ComponentID: component.ComponentID,
+			Quantity:    component.Quantity,
+		}
+		if component.Component != nil {
+			apiComponent.SKU = component.Component.SKU
+			apiComponent.Name = component.Component.Name
+		}
+		dto.Components = append(dto.Components, apiComponent)
+	}
+
+	return dto
+}
@@ -0,0 +1,171 @@
+package dto
+
+import (
+	"simple-erp-service/internal/data-structure/models"
+	"time"
+)
+
+// ApiPurchaseItem representa um item de compra para exibição
+type ApiPurchaseItem struct {
+	ID                uint     `json:"id"`
+	ProductID         uint     `json:"product_id"`
+	ProductSKU        string   `json:"product_sku"`
+	ProductName       string   `json:"product_name"`
+	SupplierCode      string   `json:"supplier_code"`
+	PackSize          int      `json:"pack_size"`
+	UnitID            *uint    `json:"unit_id"`           // Unidade informada no pedido, quando diferente da unidade do produto
+	UnitAbbreviation  string   `json:"unit_abbreviation"` // Abreviação da unidade informada no pedido
+	UnitQuantity      float64  `json:"unit_quantity"`     // Quantidade na unidade ou embalagem do pedido
+	ConversionFactor  float64  `json:"conversion_factor"` // Unidades do produto em cada unidade do pedido
+	Quantity          float64  `json:"quantity"`          // Na unidade do produto
+	UnitPrice         float64  `json:"unit_price"`
+	TotalAmount       float64  `json:"total_amount"`
+	ReceivedQuantity  float64  `json:"received_quantity"`
+	ReceivedUnitPrice *float64 `json:"received_unit_price"`
+	LandedUnitCost    *float64 `json:"landed_unit_cost"` // Preço recebido mais o rateio do frete e dos outros custos
+	ReturnedQuantity  float64  `json:"returned_quantity"`
+}
+
+// ApiPurchase representa os dados de compra para exibição
+type ApiPurchase struct {
+	ID           uint              `json:"id"`
+	SupplierID   *uint             `json:"supplier_id"`
+	SupplierName string            `json:"supplier_name"`
+	PurchaseDate time.Time         `json:"purchase_date"`
+	ExpectedDate *time.Time        `json:"expected_date"`
+	ReceivedAt   *time.Time        `json:"received_at"`
+	TotalAmount  float64           `json:"total_amount"`
+	Status       string            `json:"status"`
+	Notes        string            `json:"notes"`
+	LocationID   *uint             `json:"location_id"` // Local de recebimento; nulo: local padrão
+	LocationCode string            `json:"location_code"`
+	CreatedBy    *uint             `json:"created_by"`
+	CreatedAt    time.Time         `json:"created_at"`
+	UpdatedAt    time.Time         `json:"updated_at"`
+	Items        []ApiPurchaseItem `json:"items,omitempty"`
+
+	// Custos de aquisição informados no recebimento
+	FreightAmount float64 `json:"freight_amount"`
+	OtherCosts    float64 `json:"other_costs"`
+}
+
+// ApiPurchaseReturnItem representa um item devolvido ao fornecedor
+type ApiPurchaseReturnItem struct {
+	PurchaseItemID uint    `json:"purchase_item_id"`
+	ProductID      uint    `json:"product_id"`
+	ProductSKU     string  `json:"product_sku"`
+	ProductName    string  `json:"product_name"`
+	Quantity       float64 `json:"quantity"`
+	LotID          *uint   `json:"lot_id"`
+	LotCode        string  `json:"lot_code"`
+}
+
+// ApiPurchaseReturn representa uma devolução ao fornecedor para exibição
+type ApiPurchaseReturn struct {
+	ID         uint                    `json:"id"`
+	PurchaseID uint                    `json:"purchase_id"`
+	SupplierID *uint                   `json:"supplier_id"`
+	ReturnDate time.Time               `json:"return_date"`
+	Reason     string                  `json:"reason"`
+	LocationID *uint                   `json:"location_id"`
+	CreatedBy  *uint                   `json:"created_by"`
+	CreatedAt  time.Time               `json:"created_at"`
+	Items      []ApiPurchaseReturnItem `json:"items"`
+}
+
+// ApiPurchaseListPaginated representa uma lista paginada de compras
+type ApiPurchaseListPaginated struct {
+	Purchases  []ApiPurchase `json:"data"`
+	Pagination ApiPagination `json:"pagination"`
+}
+
+// ApiPurchaseFromModel converte um Purchase para ApiPurchase, incluindo os itens carregados
+func ApiPurchaseFromModel(p models.Purchase) ApiPurchase {
+	dto := ApiPurchase{
+		ID:           p.ID,
+		SupplierID:   p.SupplierID,
+		PurchaseDate: p.PurchaseDate,
+		ExpectedDate: p.ExpectedDate,
+		ReceivedAt:   p.ReceivedAt,
+		TotalAmount:  p.TotalAmount,
+		Status:       p.Status,
+		Notes:        p.Notes,
+		LocationID:   p.LocationID,
+		CreatedBy:    p.CreatedByID,
+		CreatedAt:    p.CreatedAt,
+		UpdatedAt:    p.UpdatedAt,
+
+		FreightAmount: p.FreightAmount,
+		OtherCosts:    p.OtherCosts,
+	}
+
+	if p.Supplier != nil {
+		dto.SupplierName = SupplierDisplayName(*p.Supplier)
+	}
+	if p.Location != nil {
+		dto.LocationCode = p.Location.Code
+	}
+
+	for _, item := range p.Items {
+		apiItem := ApiPurchaseItem{
+			ID:                item.ID,
+			ProductID:         item.ProductID,
+			SupplierCode:      item.SupplierCode,
+			PackSize:          item.PackSize,
+			UnitID:            item.UnitID,
+			UnitQuantity:      item.UnitQuantity,
+			ConversionFactor:  item.ConversionFactor,
+			Quantity:          item.Quantity,
+			UnitPrice:         item.UnitPrice,
+			TotalAmount:       item.TotalAmount,
+			ReceivedQuantity:  item.ReceivedQuantity,
+			ReceivedUnitPrice: item.ReceivedUnitPrice,
+			LandedUnitCost:    item.LandedUnitCost,
+			ReturnedQuantity:  item.ReturnedQuantity,
+		}
+		if item.Product != nil {
+			apiItem.ProductSKU = item.Product.SKU
+			apiItem.ProductName = item.Product.Name
+		}
+		if item.Unit != nil {
+			apiItem.UnitAbbreviation = item.Unit.Abbreviation
+		}
+		dto.Items = append(dto.Items, apiItem)
+	}
+
+	return dto
+}
+
+// ApiPurchaseReturnFromModel converte um PurchaseReturn para ApiPurchaseReturn
+func ApiPurchaseReturnFromModel(r models.PurchaseReturn) ApiPurchaseReturn {
+	dto := ApiPurchaseReturn{
+		ID:         r.ID,
+		PurchaseID: r.PurchaseID,
+		SupplierID: r.SupplierID,
+		ReturnDate: r.ReturnDate,
+		Reason:     r.Reason,
+		LocationID: r.LocationID,
+		CreatedBy:  r.CreatedByID,
+		CreatedAt:  r.CreatedAt,
+		Items:      make([]ApiPurchaseReturnItem, 0, len(r.Items)),
+	}
+
+	for _, item := range r.Items {
+		apiItem := ApiPurchaseReturnItem{
+			PurchaseItemID: item.PurchaseItemID,
+			ProductID:      item.ProductID,
+			Quantity:       item.Quantity,
+			LotID:          item.LotID,
+		}
+		if item.Product != nil {
+			apiItem.ProductSKU = item.Product.SKU
+			apiItem.ProductName = item.Product.Name
+		}
+		if item.Lot != nil {
+			apiItem.LotCode = item.Lot.Code
+		}
+		dto.Items = append(dto.Items, apiItem)
+	}
+
+	return dto
+}
@@ -0,0 +1,58 @@
+package dto
+
+import "time"
+
+// ApiReorderSuggestion representa a sugestão de compra de um produto no ponto de reposição ou abaixo dele.
+// Quantidades na unidade do produto.
+type ApiReorderSuggestion struct {
+	ProductID         uint     `json:"product_id"`
+	ProductSKU        string   `json:"product_sku"`
+	ProductName       string   `json:"product_name"`
+	UnitAbbreviation  string   `json:"unit_abbreviation"`
+	CurrentStock      float64  `json:"current_stock"`
+	ReservedStock     float64  `json:"reserved_stock"`     // Reservado para vendas pendentes
+	OpenQuantity      float64  `json:"open_quantity"`      // A receber nos pedidos pendentes e em rascunho
+	StockPosition     float64  `json:"stock_position"`     // Estoque atual menos o reservado mais o a receber
+	MinStock          float64  `json:"min_stock"`          // Estoque de segurança
+	MaxStock          *float64 `json:"max_stock"`          // Estoque máximo; nulo: o ponto de reposição mais as vendas da janela
+	DailySales        float64  `json:"daily_sales"`        // Média diária de vendas na janela
+	LeadTimeDays      int      `json:"lead_time_days"`     // Prazo de entrega do fornecedor
+	ReorderPoint      float64  `json:"reorder_point"`      // Estoque mínimo mais as vendas esperadas no prazo de entrega
+	TargetStock       float64  `json:"target_stock"`       // Estoque a atingir com a compra
+	SuggestedQuantity float64  `json:"suggested_quantity"` // Arredondada para a embalagem do fornecedor
+	SupplierCode      string   `json:"supplier_code"`
+	PackSize          int      `json:"pack_size"`
+	Packs             float64  `json:"packs"`      // Quantidade sugerida na embalagem do fornecedor
+	UnitPrice         float64  `json:"unit_price"` // Último preço pago ao fornecedor ou, sem ele, o preço de custo
+	TotalAmount       float64  `json:"total_amount"`
+}
+
+// ApiReorderSupplierGroup representa as sugestões de reposição de um fornecedor preferencial. Produtos sem
+// fornecedor no catálogo ficam em um grupo sem fornecedor.
+type ApiReorderSupplierGroup struct {
+	SupplierID   *uint                  `json:"supplier_id"`
+	SupplierName string                 `json:"supplier_name"`
+	LeadTimeDays int                    `json:"lead_time_days"` // Maior prazo de entrega dos itens
+	TotalAmount  float64                `json:"total_amount"`
+	Items        []ApiReorderSuggestion `json:"items"`
+}
+
+// ApiReorderSuggestions representa as sugestões de reposição agrupadas por fornecedor
+type ApiReorderSuggestions struct {
+	Days        int                       `json:"days"` // Janela da média de vendas
+	GeneratedAt time.Time                 `json:"generated_at"`
+	Groups      []ApiReorderSupplierGroup `json:"groups"`
+}
+
+// ApiReorderSkippedGroup representa um grupo de sugestões que não virou pedido de compra, com o motivo
+type ApiReorderSkippedGroup struct {
+	SupplierID   *uint  `json:"supplier_id"`
+	SupplierName string `json:"supplier_name"`
+	Reason       string `json:"reason"`
+}
+
+// ApiReorderDrafts representa os pedidos de compra em rascunho gerados pelas sugestões de reposição
+type ApiReorderDrafts struct {
+	Purchases []ApiPurchase            `json:"purchases"`
+	Skipped   []ApiReorderSkippedGroup `json:"skipped"`
+}
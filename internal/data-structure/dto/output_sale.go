@@ -0,0 +1,121 @@
+package dto
+
+import (
+	"simple-erp-service/internal/data-structure/models"
+	"time"
+)
+
+// ApiSaleItem representa um item de venda para exibição
+type ApiSaleItem struct {
+	ID               uint    `json:"id"`
+	ProductID        uint    `json:"product_id"`
+	ProductSKU       string  `json:"product_sku"`
+	ProductName      string  `json:"product_name"`
+	UnitID           *uint   `json:"unit_id"`           // Unidade informada na venda, quando diferente da unidade do produto
+	UnitAbbreviation string  `json:"unit_abbreviation"` // Abreviação da unidade informada na venda
+	UnitQuantity     float64 `json:"unit_quantity"`     // Quantidade na unidade da venda
+	ConversionFactor float64 `json:"conversion_factor"` // Unidades do produto em cada unidade da venda
+	Quantity         float64 `json:"quantity"`          // Na unidade do produto
+	UnitPrice        float64 `json:"unit_price"`        // Na unidade do produto
+	DiscountPercent  float64 `json:"discount_percent"`
+	DiscountAmount   float64 `json:"discount_amount"`
+	TotalAmount      float64 `json:"total_amount"`
+
+	// Custo das mercadorias vendidas, registrado no faturamento pelo custo médio na saída do estoque
+	UnitCost   float64 `json:"unit_cost"`
+	CostAmount float64 `json:"cost_amount"`
+
+	// Lotes entregues no faturamento, por ordem de validade (FEFO), nos produtos com controle de lotes
+	Lots []ApiSaleLot `json:"lots,omitempty"`
+}
+
+// ApiSale representa os dados de venda para exibição
+type ApiSale struct {
+	ID                 uint          `json:"id"`
+	Code               string        `json:"code"`
+	CustomerID         *uint         `json:"customer_id"`
+	CustomerName       string        `json:"customer_name"`
+	SaleDate           time.Time     `json:"sale_date"`
+	Subtotal           float64       `json:"subtotal"`
+	DiscountAmount     float64       `json:"discount_amount"`
+	TaxAmount          float64       `json:"tax_amount"`
+	TotalAmount        float64       `json:"total_amount"`
+	FinalAmount        float64       `json:"final_amount"`
+	Status             string        `json:"status"`
+	InvoicedAt         *time.Time    `json:"invoiced_at"`
+	IsCredit           bool          `json:"is_credit"`
+	CreditStatus       string        `json:"credit_status"` // approved, approval_required; vazio nas vendas à vista
+	CreditApprovedByID *uint         `json:"credit_approved_by"`
+	Notes              string        `json:"notes"`
+	LocationID         *uint         `json:"location_id"` // Local de saída; nulo: local padrão
+	LocationCode       string        `json:"location_code"`
+	CreatedBy          *uint         `json:"created_by"`
+	CreatedAt          time.Time     `json:"created_at"`
+	UpdatedAt          time.Time     `json:"updated_at"`
+	Items              []ApiSaleItem `json:"items,omitempty"`
+}
+
+// ApiSaleListPaginated representa uma lista paginada de vendas
+type ApiSaleListPaginated struct {
+	Sales      []ApiSale     `json:"data"`
+	Pagination ApiPagination `json:"pagination"`
+}
+
+// ApiSaleFromModel converte um Sale para ApiSale, incluindo os itens carregados
+func ApiSaleFromModel(s models.Sale) ApiSale {
+	dto := ApiSale{
+		ID:                 s.ID,
+		Code:               s.Code,
+		CustomerID:         s.CustomerID,
+		SaleDate:           s.SaleDate,
+		Subtotal:           s.Subtotal,
+		DiscountAmount:     s.DiscountAmount,
+		TaxAmount:          s.TaxAmount,
+		TotalAmount:        s.TotalAmount,
+		FinalAmount:        s.FinalAmount,
+		Status:             s.Status,
+		InvoicedAt:         s.InvoicedAt,
+		IsCredit:           s.IsCredit,
+		CreditStatus:       s.CreditStatus,
+		CreditApprovedByID: s.CreditApprovedByID,
+		Notes:              s.Notes,
+		LocationID:         s.LocationID,
+		CreatedBy:          s.CreatedByID,
+		CreatedAt:          s.CreatedAt,
+		UpdatedAt:          s.UpdatedAt,
+	}
+
+	if s.Customer != nil {
+		dto.CustomerName = CustomerDisplayName(*s.Customer)
+	}
+	if s.Location != nil {
+		dto.LocationCode = s.Location.Code
+	}
+
+	for _, item := range s.Items {
+		apiItem := ApiSaleItem{
+			ID:               item.ID,
+			ProductID:        item.ProductID,
+			UnitID:           item.UnitID,
+			UnitQuantity:     item.UnitQuantity,
+			ConversionFactor: item.ConversionFactor,
+			Quantity:         item.Quantity,
+			UnitPrice:        item.UnitPrice,
+			DiscountPercent:  item.DiscountPercent,
+			DiscountAmount:   item.DiscountAmount,
+			TotalAmount:      item.TotalAmount,
+			UnitCost:         item.UnitCost,
+			CostAmount:       item.CostAmount,
+		}
+		if item.Product != nil {
+			apiItem.ProductSKU = item.Product.SKU
+			apiItem.ProductName = item.Product.Name
+		}
+		if item.Unit != nil {
+			apiItem.UnitAbbreviation = item.Unit.Abbreviation
+		}
+		dto.Items = append(dto.Items, apiItem)
+	}
+
+	return dto
+}
@@ -30,8 +30,11 @@ type ApiSupplierDetail struct {
 	CompanyName    string `json:"company_name"`
 	IsActive       bool   `json:"is_active"`
 
-	Addresses *[]models.Address `json:"addresses"`
-	Contacts  *[]models.Contact `json:"contacts"`
+	PrimaryAddress *ApiAddress   `json:"primary_address"`
+	PrimaryContact *ApiContact   `json:"primary_contact"`
+	Addresses      []ApiAddress  `json:"addresses"`
+	Contacts       []ApiContact  `json:"contacts"`
+	Documents      []ApiDocument `json:"documents"`
 
 	CreatedAt time.Time `json:"created_at"`
 	CreatedBy *ApiUser  `json:"created_by"`
@@ -66,7 +69,7 @@ func ApiSupplierFromModel(s models.Supplier) ApiSupplier {
 
 // ApiSupplierDetailFromModel converte um Supplier para ApiSupplierDetail
 func ApiSupplierDetailFromModel(s models.Supplier) ApiSupplierDetail {
-	return ApiSupplierDetail{
+	dto := ApiSupplierDetail{
 		ID:             s.ID,
 		FirstName:      s.FirstName,
 		LastName:       s.LastName,
@@ -77,4 +80,20 @@ func ApiSupplierDetailFromModel(s models.Supplier) ApiSupplierDetail {
 		CreatedAt:      s.CreatedAt,
 		UpdatedAt:      s.UpdatedAt,
 	}
+
+	// Endereços e contatos principais são retornados em destaque
+	dto.Addresses, dto.PrimaryAddress = ApiAddressesFromModel(s.Addresses)
+	dto.Contacts, dto.PrimaryContact = ApiContactsFromModel(s.Contacts)
+	dto.Documents = ApiDocumentsFromModel(s.Documents)
+
+	if s.CreatedBy != nil {
+		createdBy := ApiUserFromModel(*s.CreatedBy)
+		dto.CreatedBy = &createdBy
+	}
+	if s.UpdatedBy != nil {
+		updatedBy := ApiUserFromModel(*s.UpdatedBy)
+		dto.UpdatedBy = &updatedBy
+	}
+
+	return dto
 }
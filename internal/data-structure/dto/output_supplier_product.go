@@ -0,0 +1,76 @@
+package dto
+
+import (
+	"simple-erp-service/internal/data-structure/models"
+	"strings"
+	"time"
+)
+
+// ApiSupplierProduct representa um item do catálogo do fornecedor para exibição
+type ApiSupplierProduct struct {
+	ID             uint       `json:"id"`
+	SupplierID     uint       `json:"supplier_id"`
+	SupplierName   string     `json:"supplier_name"`
+	ProductID      uint       `json:"product_id"`
+	ProductSKU     string     `json:"product_sku"`
+	ProductName    string     `json:"product_name"`
+	SupplierCode   string     `json:"supplier_code"`
+	PackSize       int        `json:"pack_size"`
+	PackUnit       string     `json:"pack_unit"`
+	LeadTimeDays   int        `json:"lead_time_days"`
+	LastPrice      *float64   `json:"last_price"` // Por embalagem do fornecedor
+	UnitPrice      *float64   `json:"unit_price"` // Convertido para a unidade do produto
+	LastPurchaseAt *time.Time `json:"last_purchase_at"`
+	LastPurchaseID *uint      `json:"last_purchase_id"`
+	IsPreferred    bool       `json:"is_preferred"`
+	Notes          string     `json:"notes"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// ApiSupplierPriceComparison representa a comparação dos fornecedores de um produto pelo preço na
+// unidade do produto. Os fornecedores vêm do menor para o maior preço; os sem preço ficam no final.
+type ApiSupplierPriceComparison struct {
+	ProductID      uint                 `json:"product_id"`
+	ProductSKU     string               `json:"product_sku"`
+	ProductName    string               `json:"product_name"`
+	BestSupplierID *uint                `json:"best_supplier_id"`
+	Suppliers      []ApiSupplierProduct `json:"suppliers"`
+}
+
+// ApiSupplierProductFromModel converte um SupplierProduct para ApiSupplierProduct
+func ApiSupplierProductFromModel(sp models.SupplierProduct) ApiSupplierProduct {
+	dto := ApiSupplierProduct{
+		ID:             sp.ID,
+		SupplierID:     sp.SupplierID,
+		ProductID:      sp.ProductID,
+		SupplierCode:   sp.SupplierCode,
+		PackSize:       sp.PackSize,
+		PackUnit:       sp.PackUnit,
+		LeadTimeDays:   sp.LeadTimeDays,
+		LastPrice:      sp.LastPrice,
+		UnitPrice:      sp.UnitPrice(),
+		LastPurchaseAt: sp.LastPurchaseAt,
+		LastPurchaseID: sp.LastPurchaseID,
+		IsPreferred:    sp.IsPreferred,
+		Notes:          sp.Notes,
+		UpdatedAt:      sp.UpdatedAt,
+	}
+
+	if sp.Supplier != nil {
+		dto.SupplierName = SupplierDisplayName(*sp.Supplier)
+	}
+	if sp.Product != nil {
+		dto.ProductSKU = sp.Product.SKU
+		dto.ProductName = sp.Product.Name
+	}
+
+	return dto
+}
+
+// SupplierDisplayName retorna a razão social do fornecedor ou, na falta dela, o nome completo
+func SupplierDisplayName(s models.Supplier) string {
+	if s.CompanyName != "" {
+		return s.CompanyName
+	}
+	return strings.TrimSpace(s.FirstName + " " + s.LastName)
+}
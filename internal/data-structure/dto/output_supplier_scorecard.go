@@ -0,0 +1,33 @@
+package dto
+
+import "time"
+
+// ApiSupplierScorecard representa os indicadores de desempenho de um fornecedor no período.
+// As taxas ficam entre 0 e 1 e são nulas quando não há dados para calculá-las.
+type ApiSupplierScorecard struct {
+	Rank         int    `json:"rank,omitempty"`
+	SupplierID   uint   `json:"supplier_id"`
+	SupplierName string `json:"supplier_name"`
+
+	Purchases        int64    `json:"purchases"`          // Compras recebidas
+	OnTimeRate       *float64 `json:"on_time_rate"`       // Recebidas até a data prevista / recebidas com data prevista
+	FillRate         *float64 `json:"fill_rate"`          // Quantidade recebida / quantidade pedida
+	PriceVariance    *float64 `json:"price_variance"`     // Variação do preço efetivo sobre o preço do pedido (0.05 = 5% acima)
+	Returns          int64    `json:"returns"`            // Devoluções ao fornecedor
+	ReturnRate       *float64 `json:"return_rate"`        // Quantidade devolvida / quantidade recebida
+	AvgLeadTimeDays  *float64 `json:"avg_lead_time_days"` // Média de dias entre o pedido e o recebimento
+	OrderedQuantity  float64  `json:"ordered_quantity"`
+	ReceivedQuantity float64  `json:"received_quantity"`
+	ReturnedQuantity float64  `json:"returned_quantity"`
+
+	Score float64 `json:"score"` // Nota ponderada de 0 a 100
+}
+
+// ApiSupplierLeaderboard representa o ranking de fornecedores no período
+type ApiSupplierLeaderboard struct {
+	DateFrom    *time.Time             `json:"date_from"`
+	DateTo      *time.Time             `json:"date_to"`
+	SortBy      string                 `json:"sort_by"`
+	Suppliers   []ApiSupplierScorecard `json:"suppliers"`
+	GeneratedAt time.Time              `json:"generated_at"`
+}
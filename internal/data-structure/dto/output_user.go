@@ -48,7 +48,7 @@ func ApiUserFromModel(u models.User) ApiUser {
 	}
 
 	// Adicionar o nome do perfil se estiver carregado
-	if u.Role.ID != 0 {
+	if u.Role != nil && u.Role.ID != 0 {
 		dto.Role = u.Role.Name
 	}
 
@@ -75,7 +75,7 @@ func ApiUserDetailFromModel(u models.User) ApiUserDetail {
 	}
 
 	// Adicionar o perfil se estiver carregado
-	if u.Role.ID != 0 {
+	if u.Role != nil && u.Role.ID != 0 {
 		dto.Role = ApiRoleDetailFromModel(*u.Role)
 	}
 
@@ -83,9 +83,17 @@ func ApiUserDetailFromModel(u models.User) ApiUserDetail {
 }
 
 type LoginSuccessResponse struct {
-	User ApiUserDetail `json:"user"`
+	User         ApiUserDetail `json:"user"`
+	Impersonator *ApiUser      `json:"impersonator,omitempty"` // Presente apenas durante uma personificação
 }
 
 type RefreshTokenSuccessResponse struct {
 	User ApiUserDetail `json:"user"`
 }
+
+// ImpersonationSuccessResponse representa a resposta do início de uma personificação
+type ImpersonationSuccessResponse struct {
+	User         ApiUserDetail `json:"user"`
+	Impersonator ApiUser       `json:"impersonator"`
+	ExpiresAt    string        `json:"expires_at"`
+}
@@ -50,9 +50,11 @@ type Address struct {
 	Street       string `gorm:"not null" json:"street"`
 	Number       string `json:"number"`
 	Neighborhood string `gorm:"not null" json:"neighborhood"`
+	Complement   string `gorm:"size:100" json:"complement"`
 	ZipCode      string `gorm:"not null;size:8" json:"zip_code"` // CEP com 8 dígitos
 	CityID       uint   `gorm:"not null" json:"city_id"`         // Relacionamento com City
 	City         City   `gorm:"foreignKey:CityID" json:"city"`   // Associação com a cidade
+	IsPrimary    bool   `gorm:"default:false" json:"is_primary"` // Endereço principal do cliente/fornecedor
 
 	CustomerID *uint     `gorm:"index" json:"customer_id,omitempty"`
 	Customer   *Customer `gorm:"foreignKey:CustomerID" json:"-"`
@@ -65,3 +67,25 @@ type Address struct {
 func (Address) TableName() string {
 	return "address"
 }
+
+// CreateAddressRequest representa os dados para criar um endereço de cliente ou fornecedor
+type CreateAddressRequest struct {
+	Street       string `json:"street" binding:"required"`
+	Number       string `json:"number"`
+	Complement   string `json:"complement" binding:"max=100"`
+	Neighborhood string `json:"neighborhood" binding:"required"`
+	ZipCode      string `json:"zip_code" binding:"required"`
+	CityID       uint   `json:"city_id" binding:"required"`
+	IsPrimary    bool   `json:"is_primary"`
+}
+
+// UpdateAddressRequest representa os dados para atualizar um endereço
+type UpdateAddressRequest struct {
+	Street       string `json:"street" binding:"required"`
+	Number       string `json:"number"`
+	Complement   string `json:"complement" binding:"max=100"`
+	Neighborhood string `json:"neighborhood" binding:"required"`
+	ZipCode      string `json:"zip_code" binding:"required"`
+	CityID       uint   `json:"city_id" binding:"required"`
+	IsPrimary    bool   `json:"is_primary"`
+}
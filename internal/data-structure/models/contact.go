@@ -1,6 +1,8 @@
 package models
 
 import (
+	"simple-erp-service/internal/utils/fieldcrypt"
+
 	"gorm.io/gorm"
 )
 
@@ -13,12 +15,43 @@ type Contact struct {
 	SupplierID *uint     `gorm:"index" json:"supplier_id,omitempty"`
 	Supplier   *Supplier `gorm:"foreignKey:SupplierID" json:"-"`
 
-	Type    string `gorm:"not null" json:"type"`           // Tipo de Contato: email, Telefone, Celular
-	Contact string `gorm:"unique;not null" json:"contact"` // Contato em si o email, o telefone etc
-	Name    string `json:"name"`                           // Um Nome para contato se for o caso
+	Type         string  `gorm:"not null" json:"type"`                         // Tipo de Contato: email, Telefone, Celular
+	Contact      string  `gorm:"not null;serializer:encrypted" json:"contact"` // Contato em si o email, o telefone etc (criptografado)
+	ContactIndex *string `gorm:"size:64;uniqueIndex" json:"-"`                 // Índice cego do contato (ver fieldcrypt)
+	Name         string  `json:"name"`                                         // Um Nome para contato se for o caso
+	IsPrimary    bool    `gorm:"default:false" json:"is_primary"`              // Contato principal do cliente/fornecedor
 }
 
+// Tipos de contato aceitos
+const (
+	ContactTypeEmail  = "email"
+	ContactTypePhone  = "telefone"
+	ContactTypeMobile = "celular"
+)
+
 // TableName especifica o nome da tabela
 func (Contact) TableName() string {
 	return "contact"
 }
+
+// BeforeSave atualiza o índice cego do contato
+func (c *Contact) BeforeSave(tx *gorm.DB) (err error) {
+	c.ContactIndex, err = fieldcrypt.BlindIndex(c.Contact)
+	return err
+}
+
+// CreateContactRequest representa os dados para criar um contato de cliente ou fornecedor
+type CreateContactRequest struct {
+	Type      string `json:"type" binding:"required,oneof=email telefone celular"`
+	Contact   string `json:"contact" binding:"required"`
+	Name      string `json:"name"`
+	IsPrimary bool   `json:"is_primary"`
+}
+
+// UpdateContactRequest representa os dados para atualizar um contato
+type UpdateContactRequest struct {
+	Type      string `json:"type" binding:"required,oneof=email telefone celular"`
+	Contact   string `json:"contact" binding:"required"`
+	Name      string `json:"name"`
+	IsPrimary bool   `json:"is_primary"`
+}
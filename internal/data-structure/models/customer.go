@@ -1,6 +1,9 @@
 package models
 
 import (
+	"simple-erp-service/internal/utils/fieldcrypt"
+	"time"
+
 	"gorm.io/gorm"
 )
 
@@ -10,12 +13,24 @@ type Customer struct {
 
 	FirstName      string `gorm:"size:100" json:"first_name"`
 	LastName       string `gorm:"size:100" json:"last_name"`
-	PersonType     string `gorm:"default:F" json:"person_type"`          // F: Física, J: Jurídica
-	DocumentNumber string `gorm:"size:20;unique" json:"document_number"` // CPF ou CNPJ
+	PersonType     string `gorm:"default:F" json:"person_type"`                         // F: Física, J: Jurídica
+	DocumentNumber string `gorm:"not null;serializer:encrypted" json:"document_number"` // CPF ou CNPJ (criptografado)
 	CompanyName    string `gorm:"size:100" json:"company_name"`
 	IsActive       bool   `gorm:"default:true" json:"is_active"`
 	Notes          string `gorm:"size:255" json:"notes"`
 
+	// Índice cego do documento, usado nas buscas exatas e na unicidade (ver fieldcrypt)
+	DocumentNumberIndex *string `gorm:"size:64;uniqueIndex" json:"-"`
+
+	// Perfil financeiro. Limite zero significa que o cliente não possui crédito liberado.
+	CreditLimit       float64 `gorm:"type:decimal(15,2);default:0" json:"credit_limit"`
+	PaymentTermDays   int     `gorm:"default:0" json:"payment_term_days"`  // Prazo padrão de pagamento em dias
+	CreditBlocked     bool    `gorm:"default:false" json:"credit_blocked"` // Bloqueio manual de vendas a prazo
+	CreditBlockReason string  `gorm:"size:255" json:"credit_block_reason"`
+
+	// Preenchido quando os dados pessoais do titular foram anonimizados (LGPD)
+	AnonymizedAt *time.Time `json:"anonymized_at"`
+
 	CreatedByID *uint `gorm:"column:created_by" json:"created_by_id"`
 	CreatedBy   *User `gorm:"foreignKey:CreatedByID" json:"created_by,omitempty"`
 
@@ -34,6 +49,12 @@ func (Customer) TableName() string {
 	return "customers"
 }
 
+// BeforeSave atualiza o índice cego do documento
+func (c *Customer) BeforeSave(tx *gorm.DB) (err error) {
+	c.DocumentNumberIndex, err = fieldcrypt.BlindIndex(c.DocumentNumber)
+	return err
+}
+
 // CreateCustomerRequest representa os dados para criar um novo cliente
 // Especificar no Front um Estilo Passo a Passo para Gravar o Cliente no Primeiro Passo, e os Demais serem Update com os Demais dados.
 type CreateCustomerRequest struct {
@@ -54,4 +75,45 @@ type UpdateCustomerRequest struct {
 	CompanyName    string `json:"company_name" binding:"omitempty"`
 	IsActive       bool   `json:"is_active" binding:"required"`
 	Notes          string `json:"notes"`
+
+	// Relacionamentos O Front manda uma Lista de IDs válidos para cada entidade relacionada.
+	DocumentsIDs []uint `json:"documents_ids" binding:"omitempty"`
+	AdressesIDs  []uint `json:"adresses_ids" binding:"omitempty"`
+	ContactsIDs  []uint `json:"contacts_ids" binding:"omitempty"`
+}
+
+// UpdateCustomerCreditRequest representa os dados para atualizar o perfil financeiro de um cliente
+type UpdateCustomerCreditRequest struct {
+	CreditLimit       *float64 `json:"credit_limit" binding:"required,gte=0"`
+	PaymentTermDays   int      `json:"payment_term_days" binding:"gte=0,lte=365"`
+	CreditBlocked     bool     `json:"credit_blocked"`
+	CreditBlockReason string   `json:"credit_block_reason" binding:"max=255"`
+}
+
+// CreditCheckRequest representa o valor de uma venda a prazo a ser avaliada
+type CreditCheckRequest struct {
+	Amount float64 `json:"amount" binding:"required,gt=0"`
+}
+
+// MergeCustomerRequest representa os dados para mesclar um cliente duplicado no cliente da rota
+type MergeCustomerRequest struct {
+	DuplicateID uint `json:"duplicate_id" binding:"required"`
+}
+
+// CustomerDuplicateCandidate representa um par de clientes possivelmente duplicados e as notas de cada critério (0 a 1)
+type CustomerDuplicateCandidate struct {
+	CustomerID   uint
+	DuplicateID  uint
+	NameScore    float64
+	ContactScore float64
+	AddressScore float64
+}
+
+// DuplicateScoring define os pesos de cada critério, a nota mínima e a quantidade máxima de pares da busca de duplicados
+type DuplicateScoring struct {
+	NameWeight    float64
+	ContactWeight float64
+	AddressWeight float64
+	MinScore      float64
+	Limit         int
 }
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"simple-erp-service/internal/utils/fieldcrypt"
 	"time"
 
 	"gorm.io/gorm"
@@ -15,16 +16,60 @@ type Document struct {
 	SupplierID *uint     `gorm:"index" json:"supplier_id,omitempty"`
 	Supplier   *Supplier `gorm:"foreignKey:SupplierID" json:"-"`
 
-	Type         string     `gorm:"not null" json:"type"`            // Tipo de : CPF, RG, CNPJ, IE, IM, CNH etc.
-	Number       string     `gorm:"unique;not null" json:"number"`   // Número do o
-	Validate     *time.Time `json:"validate"`                        // Data de Validade (nullable)
-	EmissionDate *time.Time `json:"emission_date"`                   // Data de Emissão (nullable)
-	Department   string     `json:"department"`                      // Órgão Emissor
-	StateID      uint       `json:"state_id"`                        // FK para State (UF de Emissão)
-	State        State      `gorm:"foreignKey:StateID" json:"state"` // Associação com a UF
+	Type         string     `gorm:"not null" json:"type"`                        // Tipo de : CPF, RG, CNPJ, IE, IM, CNH, ALVARA etc.
+	Number       string     `gorm:"not null;serializer:encrypted" json:"number"` // Número do documento (criptografado)
+	NumberIndex  *string    `gorm:"size:64;uniqueIndex" json:"-"`                // Índice cego do número (ver fieldcrypt)
+	Validate     *time.Time `json:"validate"`                                    // Data de Validade (nullable)
+	EmissionDate *time.Time `json:"emission_date"`                               // Data de Emissão (nullable)
+	Department   string     `json:"department"`                                  // Órgão Emissor
+	StateID      *uint      `json:"state_id"`                                    // FK para State (UF de Emissão), opcional para CPF/CNPJ
+	State        *State     `gorm:"foreignKey:StateID" json:"state,omitempty"`   // Associação com a UF
+	IsMandatory  bool       `gorm:"default:false" json:"is_mandatory"`           // Documento obrigatório: vencido, pode bloquear compras do fornecedor
+}
+
+// Tipos de documento aceitos
+const (
+	DocumentTypeCPF  = "CPF"
+	DocumentTypeCNPJ = "CNPJ"
+	DocumentTypeRG   = "RG"
+	DocumentTypeIE   = "IE"
+	DocumentTypeIM   = "IM"
+	DocumentTypeCNH  = "CNH"
+
+	DocumentTypeAlvara      = "ALVARA"      // Alvará de funcionamento
+	DocumentTypeLicenca     = "LICENCA"     // Licenças sanitária, ambiental etc.
+	DocumentTypeCertificado = "CERTIFICADO" // Certidões e certificados (regularidade fiscal, ISO etc.)
+)
+
+// CreateDocumentRequest representa os dados para criar um documento de cliente ou fornecedor
+type CreateDocumentRequest struct {
+	Type         string     `json:"type" binding:"required,oneof=CPF CNPJ RG IE IM CNH ALVARA LICENCA CERTIFICADO"`
+	Number       string     `json:"number" binding:"required"`
+	Validate     *time.Time `json:"validate"`
+	EmissionDate *time.Time `json:"emission_date"`
+	Department   string     `json:"department"`
+	StateID      *uint      `json:"state_id"`
+	IsMandatory  bool       `json:"is_mandatory"`
+}
+
+// UpdateDocumentRequest representa os dados para atualizar um documento
+type UpdateDocumentRequest struct {
+	Type         string     `json:"type" binding:"required,oneof=CPF CNPJ RG IE IM CNH ALVARA LICENCA CERTIFICADO"`
+	Number       string     `json:"number" binding:"required"`
+	Validate     *time.Time `json:"validate"`
+	EmissionDate *time.Time `json:"emission_date"`
+	Department   string     `json:"department"`
+	StateID      *uint      `json:"state_id"`
+	IsMandatory  bool       `json:"is_mandatory"`
 }
 
 // TableName especifica o nome da tabela
 func (Document) TableName() string {
 	return "document"
 }
+
+// BeforeSave atualiza o índice cego do número
+func (d *Document) BeforeSave(tx *gorm.DB) (err error) {
+	d.NumberIndex, err = fieldcrypt.BlindIndex(d.Number)
+	return err
+}
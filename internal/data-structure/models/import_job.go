@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Situações de um job de importação
+const (
+	ImportStatusPending   = "pending"
+	ImportStatusRunning   = "running"
+	ImportStatusCompleted = "completed"
+	ImportStatusFailed    = "failed"
+)
+
+// ImportJob representa uma importação em lote de clientes ou fornecedores executada em segundo plano
+type ImportJob struct {
+	gorm.Model
+
+	Entity        string           `gorm:"size:20;not null;index" json:"entity"` // customer ou supplier (ver OwnerCustomer/OwnerSupplier)
+	FileName      string           `gorm:"size:255" json:"file_name"`
+	Status        string           `gorm:"size:20;not null;default:pending" json:"status"`
+	TotalRows     int              `json:"total_rows"`
+	ProcessedRows int              `json:"processed_rows"`
+	InsertedRows  int              `json:"inserted_rows"`
+	FailedRows    int              `json:"failed_rows"`
+	RowErrors     []ImportRowError `gorm:"type:jsonb;serializer:json" json:"row_errors"`
+	Message       string           `gorm:"size:255" json:"message"` // Motivo da falha geral do job
+	StartedAt     *time.Time       `json:"started_at"`
+	FinishedAt    *time.Time       `json:"finished_at"`
+
+	CreatedByID *uint `gorm:"column:created_by;index" json:"created_by_id"`
+	CreatedBy   *User `gorm:"foreignKey:CreatedByID" json:"created_by,omitempty"`
+}
+
+// TableName especifica o nome da tabela
+func (ImportJob) TableName() string {
+	return "import_jobs"
+}
+
+// ImportFieldError representa um erro de validação de um campo de uma linha importada
+type ImportFieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ImportRowError agrupa os erros de uma linha da planilha (a linha 1 é o cabeçalho)
+type ImportRowError struct {
+	Row    int                `json:"row"`
+	Errors []ImportFieldError `json:"errors"`
+}
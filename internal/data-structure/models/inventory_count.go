@@ -0,0 +1,123 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Situações de um inventário
+const (
+	InventoryCountStatusOpen      = "aberto"   // Em contagem
+	InventoryCountStatusApproved  = "aprovado" // Ajustes de estoque gerados
+	InventoryCountStatusCancelled = "cancelado"
+)
+
+// Situações de um item do inventário
+const (
+	CountItemStatusPending = "pendente"   // Ainda não contado
+	CountItemStatusCounted = "contado"    // As contagens da rodada atual coincidem
+	CountItemStatusRecount = "recontagem" // Contagens divergentes ou recontagem pedida: aguardando nova rodada
+)
+
+// InventoryCount representa um inventário (contagem física) de um local de estoque, de uma categoria de
+// produtos ou dos dois. Na abertura, os saldos esperados são copiados para os itens; na aprovação, as
+// diferenças entre o contado e o esperado geram movimentações de ajuste. No inventário cego, as quantidades
+// esperadas não são exibidas enquanto a contagem está aberta.
+type InventoryCount struct {
+	gorm.Model
+
+	Description  string               `gorm:"size:255" json:"description"`
+	LocationID   *uint                `gorm:"index" json:"location_id"` // Local inventariado, com os seus endereços; nulo: todos os locais ativos
+	Location     *StockLocation       `gorm:"foreignKey:LocationID" json:"location,omitempty"`
+	CategoryID   *uint                `json:"category_id"` // Categoria inventariada, com as subcategorias; nula: todas
+	Category     *ProductCategory     `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
+	Blind        bool                 `gorm:"default:false" json:"blind"`
+	Status       string               `gorm:"size:20;not null" json:"status"` // 'aberto', 'aprovado', 'cancelado'
+	Notes        string               `json:"notes"`
+	ApprovedAt   *time.Time           `json:"approved_at"`
+	ApprovedByID *uint                `gorm:"column:approved_by" json:"approved_by"`
+	CreatedByID  *uint                `gorm:"column:created_by" json:"created_by"`
+	CreatedBy    *User                `gorm:"foreignKey:CreatedByID" json:"created_by_user,omitempty"`
+	Items        []InventoryCountItem `gorm:"foreignKey:InventoryCountID" json:"items,omitempty"`
+}
+
+// TableName especifica o nome da tabela
+func (InventoryCount) TableName() string {
+	return "inventory_counts"
+}
+
+// InventoryCountItem representa um produto a contar em um local (e, nos produtos com controle de lotes, em
+// um lote), com o saldo esperado na abertura do inventário e a quantidade apurada
+type InventoryCountItem struct {
+	gorm.Model
+
+	InventoryCountID uint                  `gorm:"not null;index" json:"inventory_count_id"`
+	ProductID        uint                  `gorm:"not null;index" json:"product_id"`
+	Product          *Product              `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+	LocationID       uint                  `gorm:"not null" json:"location_id"`
+	Location         *StockLocation        `gorm:"foreignKey:LocationID" json:"location,omitempty"`
+	LotID            *uint                 `json:"lot_id"`
+	Lot              *StockLot             `gorm:"foreignKey:LotID" json:"lot,omitempty"`
+	ExpectedQuantity float64               `gorm:"type:decimal(15,4);not null" json:"expected_quantity"` // Saldo na abertura, na unidade do produto
+	UnitCost         float64               `gorm:"type:decimal(15,2);not null" json:"unit_cost"`         // Custo do produto na abertura, para valorizar as diferenças
+	CountedQuantity  *float64              `gorm:"type:decimal(15,4)" json:"counted_quantity"`           // Quantidade apurada; nula enquanto pendente ou em recontagem
+	Round            int                   `gorm:"not null;default:1" json:"round"`                      // Rodada de contagem atual
+	Status           string                `gorm:"size:20;not null" json:"status"`                       // 'pendente', 'contado', 'recontagem'
+	Entries          []InventoryCountEntry `gorm:"foreignKey:InventoryCountItemID" json:"entries,omitempty"`
+}
+
+// TableName especifica o nome da tabela
+func (InventoryCountItem) TableName() string {
+	return "inventory_count_items"
+}
+
+// Variance retorna a diferença entre a quantidade apurada e a esperada (zero enquanto não apurada)
+func (i InventoryCountItem) Variance() float64 {
+	if i.CountedQuantity == nil {
+		return 0
+	}
+	return *i.CountedQuantity - i.ExpectedQuantity
+}
+
+// InventoryCountEntry representa a quantidade informada por um contador para um item, em uma rodada
+type InventoryCountEntry struct {
+	gorm.Model
+
+	InventoryCountItemID uint    `gorm:"not null;index" json:"inventory_count_item_id"`
+	Round                int     `gorm:"not null" json:"round"`
+	Quantity             float64 `gorm:"type:decimal(15,4);not null" json:"quantity"`
+	CountedByID          *uint   `gorm:"column:counted_by" json:"counted_by"`
+	CountedBy            *User   `gorm:"foreignKey:CountedByID" json:"counted_by_user,omitempty"`
+}
+
+// TableName especifica o nome da tabela
+func (InventoryCountEntry) TableName() string {
+	return "inventory_count_entries"
+}
+
+// CreateInventoryCountRequest representa os dados para abrir um inventário. Informe o local, a categoria ou
+// os dois.
+type CreateInventoryCountRequest struct {
+	Description string `json:"description" binding:"max=255"`
+	LocationID  *uint  `json:"location_id"`
+	CategoryID  *uint  `json:"category_id"`
+	Blind       bool   `json:"blind"` // Oculta as quantidades esperadas durante a contagem
+	Notes       string `json:"notes"`
+}
+
+// SubmitInventoryCountRequest representa as quantidades contadas por um contador
+type SubmitInventoryCountRequest struct {
+	Counts []InventoryCountEntryRequest `json:"counts" binding:"required,min=1,dive"`
+}
+
+// InventoryCountEntryRequest representa a quantidade contada de um item do inventário
+type InventoryCountEntryRequest struct {
+	ItemID   uint    `json:"item_id" binding:"required"`
+	Quantity float64 `json:"quantity" binding:"gte=0"` // Na unidade do produto
+}
+
+// RecountInventoryCountRequest representa os itens do inventário que devem ser contados novamente
+type RecountInventoryCountRequest struct {
+	ItemIDs []uint `json:"item_ids" binding:"required,min=1"`
+}
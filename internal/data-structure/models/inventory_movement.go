@@ -2,24 +2,69 @@ package models
 
 import "gorm.io/gorm"
 
+// Tipos de movimentação de estoque
+const (
+	MovementTypeIn         = "entrada"
+	MovementTypeOut        = "saida"
+	MovementTypeAdjustment = "ajuste"
+)
+
+// Origens das movimentações de estoque
+const (
+	MovementReferenceSale       = "venda"
+	MovementReferencePurchase   = "compra"
+	MovementReferenceReturn     = "devolucao" // Devolução ao fornecedor
+	MovementReferenceAdjustment = "ajuste"
+	MovementReferenceProduction = "producao"      // Consumo de componentes e entrada de produtos fabricados
+	MovementReferenceTransfer   = "transferencia" // Transferência entre locais de estoque
+	MovementReferenceCount      = "inventario"    // Ajuste pela aprovação de um inventário (contagem física)
+	MovementReferenceOpening    = "saldo_inicial" // Estoque anterior aos locais, registrado no local padrão
+)
+
 // InventoryMovement representa uma movimentação de estoque
 type InventoryMovement struct {
 	gorm.Model
 
 	ProductID     uint     `json:"product_id"`
 	Product       *Product `gorm:"foreignKey:ProductID" json:"product,omitempty"`
-	Quantity      int      `gorm:"not null" json:"quantity"`
-	PreviousStock int      `gorm:"not null" json:"previous_stock"`
-	NewStock      int      `gorm:"not null" json:"new_stock"`
+	Quantity      float64  `gorm:"type:decimal(15,4);not null" json:"quantity"` // Variação do estoque na unidade base: negativa nas saídas
+	PreviousStock float64  `gorm:"type:decimal(15,4);not null" json:"previous_stock"`
+	NewStock      float64  `gorm:"type:decimal(15,4);not null" json:"new_stock"`
 	MovementType  string   `gorm:"size:20;not null" json:"movement_type"` // 'entrada', 'saida', 'ajuste'
-	ReferenceID   *uint    `json:"reference_id"`                          // ID da venda, compra ou ajuste
-	ReferenceType string   `gorm:"size:20" json:"reference_type"`         // 'venda', 'compra', 'ajuste'
+	ReferenceID   *uint    `json:"reference_id"`                          // ID da venda, compra, ordem de produção, transferência, inventário ou ajuste
+	ReferenceType string   `gorm:"size:20" json:"reference_type"`         // 'venda', 'compra', 'devolucao', 'producao', 'transferencia', 'inventario', 'ajuste', 'saldo_inicial'
 	Notes         string   `json:"notes"`
 	CreatedByID   *uint    `gorm:"column:created_by" json:"created_by"`
 	CreatedBy     *User    `gorm:"foreignKey:CreatedByID" json:"created_by_user,omitempty"`
+
+	// Local de estoque movimentado. PreviousStock e NewStock são o estoque total do produto; o saldo por
+	// local fica em StockBalance. Nulo nas movimentações anteriores aos locais de estoque.
+	LocationID *uint          `gorm:"index" json:"location_id"`
+	Location   *StockLocation `gorm:"foreignKey:LocationID" json:"location,omitempty"`
+	LotID      *uint          `gorm:"index" json:"lot_id"` // Lote movimentado, nos produtos com controle de lotes
+	Lot        *StockLot      `gorm:"foreignKey:LotID" json:"lot,omitempty"`
+
+	// Custos na unidade do produto. UnitCost é o custo da entrada nas compras (com frete e outros custos de
+	// aquisição) e na produção; nas demais movimentações, o custo médio do momento. AverageCost é o custo
+	// médio do produto depois da movimentação, usado na valorização do estoque em datas passadas. Nas
+	// movimentações anteriores ao custo médio, os dois são o preço de custo do produto na migração.
+	UnitCost    float64  `gorm:"type:decimal(15,4);not null;default:0" json:"unit_cost"`
+	AverageCost *float64 `gorm:"type:decimal(15,4)" json:"average_cost"`
 }
 
 // TableName especifica o nome da tabela
 func (InventoryMovement) TableName() string {
 	return "inventory_movements"
 }
+
+// InventoryValuationRow representa o valor do estoque de uma categoria em um local. Resultado de consulta;
+// não é uma tabela.
+type InventoryValuationRow struct {
+	CategoryID   *uint
+	CategoryName *string
+	LocationID   *uint
+	LocationCode *string
+	LocationName *string
+	ProductCount int64
+	Value        float64
+}
@@ -9,3 +9,40 @@ type Pagination struct {
 	TotalRows  int64  `json:"total_rows"`
 	TotalPages int    `json:"total_pages"`
 }
+
+// Tipos de dono de endereços, contatos e documentos
+const (
+	OwnerCustomer = "customer"
+	OwnerSupplier = "supplier"
+)
+
+// Owner identifica o cliente ou fornecedor ao qual um endereço, contato ou documento pertence
+type Owner struct {
+	Type string
+	ID   uint
+}
+
+// Column retorna a coluna de chave estrangeira correspondente ao tipo de dono
+func (o Owner) Column() string {
+	if o.Type == OwnerSupplier {
+		return "supplier_id"
+	}
+	return "customer_id"
+}
+
+// IDs retorna as chaves estrangeiras de cliente e fornecedor a serem gravadas no registro filho
+func (o Owner) IDs() (customerID *uint, supplierID *uint) {
+	id := o.ID
+	if o.Type == OwnerSupplier {
+		return nil, &id
+	}
+	return &id, nil
+}
+
+// Owns verifica se as chaves estrangeiras informadas pertencem a este dono
+func (o Owner) Owns(customerID *uint, supplierID *uint) bool {
+	if o.Type == OwnerSupplier {
+		return supplierID != nil && *supplierID == o.ID
+	}
+	return customerID != nil && *customerID == o.ID
+}
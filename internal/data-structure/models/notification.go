@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Tipos de notificação
+const (
+	NotificationTypeDocumentExpiring = "documento_a_vencer"
+	NotificationTypeDocumentExpired  = "documento_vencido"
+)
+
+// Notification representa uma notificação exibida no sistema para um usuário
+type Notification struct {
+	gorm.Model
+
+	UserID        uint       `gorm:"not null;uniqueIndex:idx_notifications_user_key" json:"user_id"`
+	User          *User      `gorm:"foreignKey:UserID" json:"-"`
+	Type          string     `gorm:"size:50;not null" json:"type"`
+	Title         string     `gorm:"size:150;not null" json:"title"`
+	Message       string     `gorm:"size:500" json:"message"`
+	ReferenceType string     `gorm:"size:50" json:"reference_type"` // Registro relacionado (ex: document)
+	ReferenceID   *uint      `json:"reference_id"`
+	ReadAt        *time.Time `json:"read_at"`
+
+	// Chave de deduplicação: a mesma ocorrência (ex: o vencimento de um documento em uma data) gera
+	// uma única notificação por usuário, mesmo que a rotina que a cria seja executada várias vezes
+	Key string `gorm:"size:150;not null;uniqueIndex:idx_notifications_user_key" json:"-"`
+}
+
+// TableName especifica o nome da tabela
+func (Notification) TableName() string {
+	return "notifications"
+}
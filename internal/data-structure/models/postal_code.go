@@ -0,0 +1,20 @@
+package models
+
+import "gorm.io/gorm"
+
+// PostalCode representa um CEP da base local de consulta, importada de arquivo
+type PostalCode struct {
+	gorm.Model
+
+	ZipCode      string `gorm:"size:8;not null;unique" json:"zip_code"` // CEP com 8 dígitos
+	Street       string `gorm:"size:255" json:"street"`                 // Vazio nos CEPs gerais de município
+	Neighborhood string `gorm:"size:100" json:"neighborhood"`
+	Complement   string `gorm:"size:100" json:"complement"` // Ex: "lado ímpar", "até 1000/1001"
+	CityID       uint   `gorm:"not null;index" json:"city_id"`
+	City         City   `gorm:"foreignKey:CityID" json:"city"`
+}
+
+// TableName especifica o nome da tabela
+func (PostalCode) TableName() string {
+	return "postal_codes"
+}
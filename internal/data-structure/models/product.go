@@ -2,29 +2,101 @@ package models
 
 import "gorm.io/gorm"
 
+// Tipos de produto
+const (
+	ProductKindSimple       = "simples"   // Produto com estoque próprio, comprado e vendido
+	ProductKindKit          = "kit"       // Combo sem estoque próprio: a venda baixa o estoque dos componentes
+	ProductKindManufactured = "fabricado" // Produzido a partir dos componentes por ordens de produção
+)
+
 // Product representa um produto
 type Product struct {
 	gorm.Model
 
 	SKU          string           `gorm:"size:50;unique" json:"sku"`
-	Barcode      string           `gorm:"size:50;unique" json:"barcode"`
+	Barcode      *string          `gorm:"size:50;unique" json:"barcode"` // Nulo quando não informado, para não conflitar na restrição de unicidade
 	Name         string           `gorm:"size:255;not null" json:"name"`
 	Description  string           `json:"description"`
 	CategoryID   *uint            `json:"category_id"`
 	Category     *ProductCategory `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
-	UnitID       *uint            `json:"unit_id"`
+	UnitID       *uint            `json:"unit_id"` // Unidade base: estoque, preços e movimentações ficam nela
 	Unit         *MeasurementUnit `gorm:"foreignKey:UnitID" json:"unit,omitempty"`
-	CostPrice    float64          `gorm:"type:decimal(15,2);not null" json:"cost_price"`
+	CostPrice    float64          `gorm:"type:decimal(15,4);not null" json:"cost_price"` // Custo médio ponderado, recalculado a cada entrada com custo
 	SellingPrice float64          `gorm:"type:decimal(15,2);not null" json:"selling_price"`
-	MinStock     int              `gorm:"default:0" json:"min_stock"`
-	MaxStock     *int             `json:"max_stock"`
-	CurrentStock int              `gorm:"default:0" json:"current_stock"`
+	MinStock     float64          `gorm:"type:decimal(15,4);default:0" json:"min_stock"`
+	MaxStock     *float64         `gorm:"type:decimal(15,4)" json:"max_stock"`
+	CurrentStock float64          `gorm:"type:decimal(15,4);default:0" json:"current_stock"` // Sempre na unidade base do produto (UnitID)
 	IsActive     bool             `gorm:"default:true" json:"is_active"`
+	Kind         string           `gorm:"size:20;not null;default:simples" json:"kind"` // 'simples', 'kit', 'fabricado'
+	TracksLots   bool             `gorm:"default:false" json:"tracks_lots"`             // Estoque controlado por lote e validade
 	CreatedByID  *uint            `gorm:"column:created_by" json:"created_by"`
 	CreatedBy    *User            `gorm:"foreignKey:CreatedByID" json:"created_by_user,omitempty"`
+
+	Suppliers []SupplierProduct `gorm:"foreignKey:ProductID" json:"-"` // Catálogo dos fornecedores do produto
+
+	// Variantes (grade): cada variante é um produto com SKU, código de barras, preço e estoque próprios,
+	// ligado ao produto pai. Sem PriceOverride, os preços da variante acompanham os do pai.
+	ParentID      *uint                 `gorm:"index" json:"parent_id"`
+	Parent        *Product              `gorm:"foreignKey:ParentID" json:"-"`
+	PriceOverride bool                  `gorm:"default:false" json:"price_override"`
+	VariantValues []ProductVariantValue `gorm:"foreignKey:ProductID" json:"-"`
+
+	Components []ProductComponent `gorm:"foreignKey:ProductID" json:"-"` // Composição dos kits e dos produtos fabricados
+
+	// Controle por número de série: cada unidade em estoque tem um número de série (SerialNumber). A garantia
+	// é contada a partir da data da venda.
+	TracksSerials  bool `gorm:"default:false" json:"tracks_serials"`
+	WarrantyMonths int  `gorm:"default:0" json:"warranty_months"`
+}
+
+// HasComposition informa se o tipo do produto usa composição (kit ou fabricado)
+func (p Product) HasComposition() bool {
+	return p.Kind == ProductKindKit || p.Kind == ProductKindManufactured
 }
 
 // TableName especifica o nome da tabela
 func (Product) TableName() string {
 	return "products"
 }
+
+// CreateProductRequest representa os dados para criar um novo produto
+type CreateProductRequest struct {
+	SKU          string   `json:"sku" binding:"required,max=50"`
+	Barcode      string   `json:"barcode" binding:"omitempty,max=50"`
+	Name         string   `json:"name" binding:"required,max=255"`
+	Description  string   `json:"description"`
+	CategoryID   *uint    `json:"category_id"`
+	UnitID       *uint    `json:"unit_id"`
+	CostPrice    float64  `json:"cost_price" binding:"gte=0"` // Custo inicial, até a primeira entrada com custo
+	SellingPrice float64  `json:"selling_price" binding:"gte=0"`
+	MinStock     float64  `json:"min_stock" binding:"gte=0"`
+	MaxStock     *float64 `json:"max_stock" binding:"omitempty,gte=0"`
+	Kind         string   `json:"kind" binding:"omitempty,oneof=simples kit fabricado"` // Padrão: simples
+	TracksLots   bool     `json:"tracks_lots"`
+
+	// Número de série por unidade e meses de garantia a partir da venda
+	TracksSerials  bool `json:"tracks_serials"`
+	WarrantyMonths int  `json:"warranty_months" binding:"gte=0,lte=120"`
+}
+
+// UpdateProductRequest representa os dados para atualizar um produto. O estoque atual não é editável:
+// ele só muda por movimentações de estoque.
+type UpdateProductRequest struct {
+	SKU          string   `json:"sku" binding:"required,max=50"`
+	Barcode      string   `json:"barcode" binding:"omitempty,max=50"`
+	Name         string   `json:"name" binding:"required,max=255"`
+	Description  string   `json:"description"`
+	CategoryID   *uint    `json:"category_id"`
+	UnitID       *uint    `json:"unit_id"`
+	CostPrice    float64  `json:"cost_price" binding:"gte=0"` // Ignorado com estoque: vale o custo médio das entradas
+	SellingPrice float64  `json:"selling_price" binding:"gte=0"`
+	MinStock     float64  `json:"min_stock" binding:"gte=0"`
+	MaxStock     *float64 `json:"max_stock" binding:"omitempty,gte=0"`
+	IsActive     bool     `json:"is_active"`
+	Kind         string   `json:"kind" binding:"omitempty,oneof=simples kit fabricado"` // Padrão: mantém o tipo atual
+	TracksLots   bool     `json:"tracks_lots"`
+
+	// Número de série por unidade e meses de garantia a partir da venda
+	TracksSerials  bool `json:"tracks_serials"`
+	WarrantyMonths int  `json:"warranty_months" binding:"gte=0,lte=120"`
+}
@@ -0,0 +1,83 @@
+package models
+
+import "gorm.io/gorm"
+
+// ProductAttribute representa um atributo que diferencia as variantes de um produto (ex: tamanho, cor)
+type ProductAttribute struct {
+	gorm.Model
+
+	Name   string                  `gorm:"size:50;not null;unique" json:"name"`
+	Values []ProductAttributeValue `gorm:"foreignKey:AttributeID" json:"values,omitempty"`
+}
+
+// TableName especifica o nome da tabela
+func (ProductAttribute) TableName() string {
+	return "product_attributes"
+}
+
+// ProductAttributeValue representa um valor possível do atributo (ex: M, Azul). O código compõe o SKU
+// das variantes geradas.
+type ProductAttributeValue struct {
+	gorm.Model
+
+	AttributeID uint              `gorm:"not null;uniqueIndex:idx_attribute_values_value;uniqueIndex:idx_attribute_values_code" json:"attribute_id"`
+	Attribute   *ProductAttribute `gorm:"foreignKey:AttributeID" json:"attribute,omitempty"`
+	Value       string            `gorm:"size:50;not null;uniqueIndex:idx_attribute_values_value" json:"value"`
+	Code        string            `gorm:"size:10;not null;uniqueIndex:idx_attribute_values_code" json:"code"`
+	Position    int               `gorm:"default:0" json:"position"` // Ordem de exibição (ex: P, M, G)
+}
+
+// TableName especifica o nome da tabela
+func (ProductAttributeValue) TableName() string {
+	return "product_attribute_values"
+}
+
+// ProductVariantValue liga a variante ao valor que ela tem em cada atributo
+type ProductVariantValue struct {
+	ProductID        uint                   `gorm:"primaryKey" json:"product_id"`
+	AttributeID      uint                   `gorm:"primaryKey" json:"attribute_id"`
+	AttributeValueID uint                   `gorm:"not null;index" json:"attribute_value_id"`
+	AttributeValue   *ProductAttributeValue `gorm:"foreignKey:AttributeValueID" json:"attribute_value,omitempty"`
+}
+
+// TableName especifica o nome da tabela
+func (ProductVariantValue) TableName() string {
+	return "product_variant_values"
+}
+
+// ProductVariantSummary representa a quantidade de variantes de um produto e o estoque somado delas
+type ProductVariantSummary struct {
+	ParentID uint
+	Variants int64
+	Stock    float64
+}
+
+// CreateProductAttributeRequest representa os dados para cadastrar um atributo com os seus valores
+type CreateProductAttributeRequest struct {
+	Name   string                               `json:"name" binding:"required,max=50"`
+	Values []CreateProductAttributeValueRequest `json:"values" binding:"omitempty,dive"`
+}
+
+// CreateProductAttributeValueRequest representa um valor do atributo
+type CreateProductAttributeValueRequest struct {
+	Value    string `json:"value" binding:"required,max=50"`
+	Code     string `json:"code" binding:"omitempty,max=10"` // Padrão: o valor em maiúsculas, sem acentos e espaços
+	Position *int   `json:"position"`                        // Padrão: após os valores existentes
+}
+
+// AddProductAttributeValuesRequest representa os valores a incluir em um atributo existente
+type AddProductAttributeValuesRequest struct {
+	Values []CreateProductAttributeValueRequest `json:"values" binding:"required,min=1,dive"`
+}
+
+// GenerateProductVariantsRequest representa a grade de variantes a gerar: todas as combinações dos
+// valores informados para cada atributo
+type GenerateProductVariantsRequest struct {
+	Attributes []GenerateProductVariantsAttribute `json:"attributes" binding:"required,min=1,dive"`
+}
+
+// GenerateProductVariantsAttribute representa um atributo da grade e os valores usados
+type GenerateProductVariantsAttribute struct {
+	AttributeID uint   `json:"attribute_id" binding:"required"`
+	ValueIDs    []uint `json:"value_ids" binding:"required,min=1"`
+}
@@ -18,3 +18,34 @@ type ProductCategory struct {
 func (ProductCategory) TableName() string {
 	return "product_categories"
 }
+
+// ProductCategoryNode representa uma categoria da árvore com a profundidade e as quantidades de produtos.
+// Resultado da consulta recursiva da árvore; não é uma tabela.
+type ProductCategoryNode struct {
+	ID                uint
+	ParentID          *uint
+	Name              string
+	Description       string
+	Depth             int   // Zero para as categorias raiz
+	ProductCount      int64 // Produtos da própria categoria
+	TotalProductCount int64 // Produtos da categoria e de todas as subcategorias
+}
+
+// CreateProductCategoryRequest representa os dados para criar uma categoria de produto
+type CreateProductCategoryRequest struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description"`
+	ParentID    *uint  `json:"parent_id"` // Nulo para criar uma categoria raiz
+}
+
+// UpdateProductCategoryRequest representa os dados para atualizar uma categoria de produto.
+// A categoria pai é alterada pela operação de mover.
+type UpdateProductCategoryRequest struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description"`
+}
+
+// MoveProductCategoryRequest representa o novo pai de uma categoria, que é movida com as subcategorias
+type MoveProductCategoryRequest struct {
+	ParentID *uint `json:"parent_id"` // Nulo para tornar a categoria raiz
+}
@@ -0,0 +1,36 @@
+package models
+
+import "gorm.io/gorm"
+
+// ProductComponent representa um componente da composição de um kit ou da ficha técnica de um produto
+// fabricado: quanto do componente, na unidade dele, é usado em cada unidade do produto
+type ProductComponent struct {
+	gorm.Model
+
+	ProductID   uint     `gorm:"not null;index" json:"product_id"`
+	ComponentID uint     `gorm:"not null;index" json:"component_id"`
+	Component   *Product `gorm:"foreignKey:ComponentID" json:"component,omitempty"`
+	Quantity    float64  `gorm:"type:decimal(15,4);not null" json:"quantity"`
+}
+
+// TableName especifica o nome da tabela
+func (ProductComponent) TableName() string {
+	return "product_components"
+}
+
+// KitAvailability representa quantas unidades do kit podem ser montadas com o estoque dos componentes
+type KitAvailability struct {
+	ProductID uint
+	Available float64
+}
+
+// SetProductComponentsRequest representa a composição completa do produto, que substitui a anterior
+type SetProductComponentsRequest struct {
+	Components []ProductComponentRequest `json:"components" binding:"omitempty,dive"` // Vazia: remove a composição
+}
+
+// ProductComponentRequest representa um componente e a quantidade usada por unidade do produto
+type ProductComponentRequest struct {
+	ComponentID uint    `json:"component_id" binding:"required"`
+	Quantity    float64 `json:"quantity" binding:"required,gt=0"`
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Situações de uma ordem de produção
+const (
+	ProductionStatusPending   = "pendente"
+	ProductionStatusCompleted = "concluida"
+	ProductionStatusCancelled = "cancelada"
+)
+
+// ProductionOrder representa uma ordem de produção de um produto fabricado. Os componentes são copiados
+// da ficha técnica na abertura da ordem, para que alterações posteriores não mudem o consumo.
+type ProductionOrder struct {
+	gorm.Model
+
+	ProductID   uint                       `gorm:"not null;index" json:"product_id"`
+	Product     *Product                   `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+	Quantity    float64                    `gorm:"type:decimal(15,4);not null" json:"quantity"` // Quantidade a produzir, na unidade do produto
+	Status      string                     `gorm:"size:20;not null" json:"status"`              // 'pendente', 'concluida', 'cancelada'
+	CompletedAt *time.Time                 `json:"completed_at"`
+	Notes       string                     `json:"notes"`
+	LocationID  *uint                      `json:"location_id"` // Local de onde saem os componentes e onde entra o produto
+	CreatedByID *uint                      `gorm:"column:created_by" json:"created_by"`
+	CreatedBy   *User                      `gorm:"foreignKey:CreatedByID" json:"created_by_user,omitempty"`
+	Components  []ProductionOrderComponent `gorm:"foreignKey:ProductionOrderID" json:"components,omitempty"`
+
+	// Lote produzido, nos produtos fabricados com controle de lotes
+	LotID *uint     `json:"lot_id"`
+	Lot   *StockLot `gorm:"foreignKey:LotID" json:"lot,omitempty"`
+}
+
+// TableName especifica o nome da tabela
+func (ProductionOrder) TableName() string {
+	return "production_orders"
+}
+
+// ProductionOrderComponent representa a quantidade total de um componente consumida pela ordem
+type ProductionOrderComponent struct {
+	gorm.Model
+
+	ProductionOrderID uint     `gorm:"not null;index" json:"production_order_id"`
+	ComponentID       uint     `gorm:"not null" json:"component_id"`
+	Component         *Product `gorm:"foreignKey:ComponentID" json:"component,omitempty"`
+	Quantity          float64  `gorm:"type:decimal(15,4);not null" json:"quantity"`
+}
+
+// TableName especifica o nome da tabela
+func (ProductionOrderComponent) TableName() string {
+	return "production_order_components"
+}
+
+// CreateProductionOrderRequest representa os dados para abrir uma ordem de produção
+type CreateProductionOrderRequest struct {
+	ProductID  uint    `json:"product_id" binding:"required"`
+	Quantity   float64 `json:"quantity" binding:"required,gt=0"`
+	LocationID *uint   `json:"location_id"` // Padrão: local padrão
+	Notes      string  `json:"notes"`
+}
+
+// CompleteProductionOrderRequest representa os dados da conclusão de uma ordem de produção
+type CompleteProductionOrderRequest struct {
+	CompletedAt *time.Time `json:"completed_at"` // Padrão: agora
+	Notes       string     `json:"notes"`
+
+	// Lote produzido, obrigatório quando o produto fabricado controla lotes. Sem data de fabricação, vale a
+	// data da conclusão.
+	Lot *StockLotRequest `json:"lot"`
+
+	// Números de série das unidades produzidas, obrigatórios quando o produto fabricado tem número de série
+	Serials []string `json:"serials" binding:"omitempty,dive,required,max=100"`
+}
@@ -6,6 +6,14 @@ import (
 	"gorm.io/gorm"
 )
 
+// Situações de uma compra
+const (
+	PurchaseStatusDraft     = "rascunho" // Sugestão de reposição ainda não confirmada
+	PurchaseStatusPending   = "pendente"
+	PurchaseStatusReceived  = "recebido"
+	PurchaseStatusCancelled = "cancelado"
+)
+
 // Purchase representa uma compra
 type Purchase struct {
 	gorm.Model
@@ -13,15 +21,74 @@ type Purchase struct {
 	SupplierID   *uint          `json:"supplier_id"`
 	Supplier     *Supplier      `gorm:"foreignKey:SupplierID" json:"supplier,omitempty"`
 	PurchaseDate time.Time      `json:"purchase_date"`
+	ExpectedDate *time.Time     `json:"expected_date"` // Previsão de entrega
+	ReceivedAt   *time.Time     `json:"received_at"`   // Data do recebimento
 	TotalAmount  float64        `gorm:"type:decimal(15,2);not null" json:"total_amount"`
-	Status       string         `gorm:"size:20;not null" json:"status"` // 'pendente', 'recebido', 'cancelado'
+	Status       string         `gorm:"size:20;not null" json:"status"` // 'rascunho', 'pendente', 'recebido', 'cancelado'
 	Notes        string         `json:"notes"`
 	CreatedByID  *uint          `gorm:"column:created_by" json:"created_by"`
 	CreatedBy    *User          `gorm:"foreignKey:CreatedByID" json:"created_by_user,omitempty"`
 	Items        []PurchaseItem `gorm:"foreignKey:PurchaseID" json:"items,omitempty"`
+
+	// Local de estoque em que a compra é recebida. Nulo: local padrão no recebimento.
+	LocationID *uint          `json:"location_id"`
+	Location   *StockLocation `gorm:"foreignKey:LocationID" json:"location,omitempty"`
+
+	// Custos de aquisição informados no recebimento, rateados entre os itens no custo de entrada
+	FreightAmount float64 `gorm:"type:decimal(15,2);default:0" json:"freight_amount"`
+	OtherCosts    float64 `gorm:"type:decimal(15,2);default:0" json:"other_costs"` // Seguro, impostos não recuperáveis, despesas de importação etc.
 }
 
 // TableName especifica o nome da tabela
 func (Purchase) TableName() string {
 	return "purchases"
 }
+
+// CreatePurchaseRequest representa os dados para criar um pedido de compra
+type CreatePurchaseRequest struct {
+	SupplierID   uint                        `json:"supplier_id" binding:"required"`
+	PurchaseDate *time.Time                  `json:"purchase_date"` // Padrão: agora
+	ExpectedDate *time.Time                  `json:"expected_date"` // Padrão: data da compra + prazo de entrega do catálogo
+	Notes        string                      `json:"notes"`
+	LocationID   *uint                       `json:"location_id"` // Local de recebimento. Padrão: local padrão
+	Items        []CreatePurchaseItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+// CreatePurchaseItemRequest representa um item do pedido de compra, identificado pelo produto ou pelo
+// código do fornecedor. Com o código do fornecedor, a quantidade e o preço são informados na embalagem
+// do fornecedor; com a unidade, na unidade informada. Nos dois casos eles são convertidos para a unidade
+// do produto.
+type CreatePurchaseItemRequest struct {
+	ProductID    uint     `json:"product_id" binding:"required_without=SupplierCode"`
+	SupplierCode string   `json:"supplier_code" binding:"required_without=ProductID,max=60"`
+	UnitID       *uint    `json:"unit_id" binding:"excluded_with=SupplierCode"` // Padrão: unidade do produto
+	Quantity     float64  `json:"quantity" binding:"required,gt=0"`
+	UnitPrice    *float64 `json:"unit_price" binding:"omitempty,gte=0"` // Padrão: último preço do catálogo
+}
+
+// ReceivePurchaseRequest representa os dados do recebimento de uma compra. Itens não informados são
+// recebidos integralmente pelo preço do pedido.
+type ReceivePurchaseRequest struct {
+	ReceivedAt *time.Time                   `json:"received_at"` // Padrão: agora
+	LocationID *uint                        `json:"location_id"` // Padrão: local do pedido
+	Items      []ReceivePurchaseItemRequest `json:"items" binding:"omitempty,dive"`
+	Notes      string                       `json:"notes"`
+
+	// Custos de aquisição, rateados entre os itens recebidos pelo valor de cada um
+	FreightAmount float64 `json:"freight_amount" binding:"gte=0"`
+	OtherCosts    float64 `json:"other_costs" binding:"gte=0"`
+}
+
+// ReceivePurchaseItemRequest representa a quantidade e o preço efetivamente recebidos de um item
+type ReceivePurchaseItemRequest struct {
+	ItemID    uint     `json:"item_id" binding:"required"`
+	Quantity  *float64 `json:"quantity" binding:"omitempty,gte=0"`   // Na unidade do produto
+	UnitPrice *float64 `json:"unit_price" binding:"omitempty,gte=0"` // Na unidade do produto
+
+	// Lotes recebidos, obrigatórios nos produtos com controle de lotes. A soma das quantidades dos lotes é a
+	// quantidade recebida.
+	Lots []ReceivePurchaseLotRequest `json:"lots" binding:"omitempty,dive"`
+
+	// Números de série recebidos, obrigatórios nos produtos com número de série: um por unidade recebida
+	Serials []string `json:"serials" binding:"omitempty,dive,required,max=100"`
+}
@@ -2,17 +2,31 @@ package models
 
 import "gorm.io/gorm"
 
-// PurchaseItem representa um item de compra
+// PurchaseItem representa um item de compra. Quantidades e preços ficam na unidade do produto; a quantidade
+// como foi pedida, na embalagem do fornecedor ou em outra unidade, fica em UnitQuantity.
 type PurchaseItem struct {
 	gorm.Model
 
-	PurchaseID  uint      `json:"purchase_id"`
-	Purchase    *Purchase `gorm:"foreignKey:PurchaseID" json:"-"`
-	ProductID   uint      `json:"product_id"`
-	Product     *Product  `gorm:"foreignKey:ProductID" json:"product,omitempty"`
-	Quantity    int       `gorm:"not null" json:"quantity"`
-	UnitPrice   float64   `gorm:"type:decimal(15,2);not null" json:"unit_price"`
-	TotalAmount float64   `gorm:"type:decimal(15,2);not null" json:"total_amount"`
+	PurchaseID   uint      `json:"purchase_id"`
+	Purchase     *Purchase `gorm:"foreignKey:PurchaseID" json:"-"`
+	ProductID    uint      `json:"product_id"`
+	Product      *Product  `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+	SupplierCode string    `gorm:"size:60" json:"supplier_code"`        // Código do fornecedor usado no pedido
+	PackSize     int       `gorm:"not null;default:1" json:"pack_size"` // Embalagem do fornecedor no momento do pedido
+	Quantity     float64   `gorm:"type:decimal(15,4);not null" json:"quantity"`
+	UnitPrice    float64   `gorm:"type:decimal(15,4);not null" json:"unit_price"` // Quatro casas para preservar o preço convertido da embalagem
+	TotalAmount  float64   `gorm:"type:decimal(15,2);not null" json:"total_amount"`
+
+	ReceivedQuantity  float64  `gorm:"type:decimal(15,4);default:0" json:"received_quantity"`
+	ReceivedUnitPrice *float64 `gorm:"type:decimal(15,4)" json:"received_unit_price"`
+	LandedUnitCost    *float64 `gorm:"type:decimal(15,4)" json:"landed_unit_cost"`            // Preço recebido mais o rateio do frete e dos outros custos
+	ReturnedQuantity  float64  `gorm:"type:decimal(15,4);default:0" json:"returned_quantity"` // Devolvido ao fornecedor
+
+	// Unidade em que o item foi pedido, quando diferente da unidade do produto
+	UnitID           *uint            `json:"unit_id"`
+	Unit             *MeasurementUnit `gorm:"foreignKey:UnitID" json:"unit,omitempty"`
+	UnitQuantity     float64          `gorm:"type:decimal(15,4);not null;default:0" json:"unit_quantity"`     // Quantidade na unidade ou embalagem do pedido
+	ConversionFactor float64          `gorm:"type:decimal(18,6);not null;default:1" json:"conversion_factor"` // Unidades do produto em cada unidade do pedido
 }
 
 // TableName especifica o nome da tabela
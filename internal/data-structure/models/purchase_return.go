@@ -0,0 +1,66 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PurchaseReturn representa uma devolução ao fornecedor de mercadorias recebidas em uma compra
+type PurchaseReturn struct {
+	gorm.Model
+
+	PurchaseID  uint                 `gorm:"not null;index" json:"purchase_id"`
+	Purchase    *Purchase            `gorm:"foreignKey:PurchaseID" json:"-"`
+	SupplierID  *uint                `gorm:"index" json:"supplier_id"`
+	ReturnDate  time.Time            `gorm:"not null" json:"return_date"`
+	Reason      string               `gorm:"size:255;not null" json:"reason"`
+	LocationID  *uint                `json:"location_id"` // Local de estoque de onde as mercadorias saíram
+	CreatedByID *uint                `gorm:"column:created_by" json:"created_by"`
+	CreatedBy   *User                `gorm:"foreignKey:CreatedByID" json:"created_by_user,omitempty"`
+	Items       []PurchaseReturnItem `gorm:"foreignKey:PurchaseReturnID" json:"items,omitempty"`
+}
+
+// TableName especifica o nome da tabela
+func (PurchaseReturn) TableName() string {
+	return "purchase_returns"
+}
+
+// PurchaseReturnItem representa a quantidade devolvida de um item da compra, na unidade do produto
+type PurchaseReturnItem struct {
+	gorm.Model
+
+	PurchaseReturnID uint     `gorm:"not null;index" json:"purchase_return_id"`
+	PurchaseItemID   uint     `gorm:"not null" json:"purchase_item_id"`
+	ProductID        uint     `gorm:"not null" json:"product_id"`
+	Product          *Product `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+	Quantity         float64  `gorm:"type:decimal(15,4);not null" json:"quantity"`
+
+	// Lote devolvido, quando informado. Sem ele, nos produtos com controle de lotes, a quantidade sai dos
+	// lotes por ordem de validade.
+	LotID *uint     `json:"lot_id"`
+	Lot   *StockLot `gorm:"foreignKey:LotID" json:"lot,omitempty"`
+}
+
+// TableName especifica o nome da tabela
+func (PurchaseReturnItem) TableName() string {
+	return "purchase_return_items"
+}
+
+// CreatePurchaseReturnRequest representa os dados de uma devolução ao fornecedor
+type CreatePurchaseReturnRequest struct {
+	ReturnDate *time.Time                        `json:"return_date"` // Padrão: agora
+	Reason     string                            `json:"reason" binding:"required,max=255"`
+	LocationID *uint                             `json:"location_id"` // Padrão: local em que a compra foi recebida
+	Items      []CreatePurchaseReturnItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+// CreatePurchaseReturnItemRequest representa a quantidade devolvida de um item da compra
+type CreatePurchaseReturnItemRequest struct {
+	ItemID   uint    `json:"item_id" binding:"required"`
+	Quantity float64 `json:"quantity" binding:"required,gt=0"` // Na unidade do produto
+	LotID    *uint   `json:"lot_id"`                           // Opcional: lote devolvido, nos produtos com controle de lotes
+
+	// Números de série devolvidos, obrigatórios nos produtos com número de série: um por unidade devolvida
+	Serials []string `json:"serials" binding:"omitempty,dive,required,max=100"`
+}
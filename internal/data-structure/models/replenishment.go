@@ -0,0 +1,17 @@
+package models
+
+// ProductQuantity representa uma quantidade somada por produto. Resultado de consulta; não é uma tabela.
+type ProductQuantity struct {
+	ProductID uint
+	Quantity  float64
+}
+
+// CreateReorderDraftsRequest representa os filtros das sugestões de reposição convertidas em pedidos de
+// compra em rascunho, um por fornecedor
+type CreateReorderDraftsRequest struct {
+	Days       int    `json:"days" binding:"omitempty,gte=1,lte=365"`    // Janela da média de vendas. Padrão: 30 dias
+	CategoryID *uint  `json:"category_id"`                               // Somente produtos da categoria
+	SupplierID *uint  `json:"supplier_id"`                               // Somente o pedido do fornecedor
+	ProductIDs []uint `json:"product_ids" binding:"omitempty,dive,gt=0"` // Somente os produtos informados
+	LocationID *uint  `json:"location_id"`                               // Local de recebimento dos pedidos. Padrão: local padrão
+}
@@ -6,6 +6,14 @@ import (
 	"gorm.io/gorm"
 )
 
+// Situações de uma venda
+const (
+	SaleStatusPending   = "pendente"
+	SaleStatusInvoiced  = "faturado" // Estoque baixado
+	SaleStatusPaid      = "pago"
+	SaleStatusCancelled = "cancelado"
+)
+
 // Sale representa uma venda
 type Sale struct {
 	gorm.Model
@@ -21,14 +29,65 @@ type Sale struct {
 	FinalAmount     float64        `gorm:"type:decimal(15,2);not null" json:"final_amount"`
 	PaymentMethodID *uint          `json:"payment_method_id"`
 	PaymentMethod   *PaymentMethod `gorm:"foreignKey:PaymentMethodID" json:"payment_method,omitempty"`
-	Status          string         `gorm:"size:20;not null" json:"status"` // 'pendente', 'pago', 'cancelado'
+	Status          string         `gorm:"size:20;not null" json:"status"` // 'pendente', 'faturado', 'pago', 'cancelado'
+	InvoicedAt      *time.Time     `json:"invoiced_at"`                    // Data do faturamento
 	Notes           string         `json:"notes"`
 	CreatedByID     *uint          `gorm:"column:created_by" json:"created_by"`
 	CreatedBy       *User          `gorm:"foreignKey:CreatedByID" json:"created_by_user,omitempty"`
 	Items           []SaleItem     `gorm:"foreignKey:SaleID" json:"items,omitempty"`
+
+	// Vendas a prazo passam pela análise de crédito (ver CreditService.AuthorizeCreditSale)
+	IsCredit           bool   `gorm:"default:false" json:"is_credit"`
+	CreditStatus       string `gorm:"size:20" json:"credit_status"`
+	CreditApprovedByID *uint  `json:"credit_approved_by_id"`
+	CreditApprovedBy   *User  `gorm:"foreignKey:CreditApprovedByID" json:"credit_approved_by,omitempty"`
+
+	// Local de estoque de onde saem os itens vendidos. Nulo: local padrão.
+	LocationID *uint          `json:"location_id"`
+	Location   *StockLocation `gorm:"foreignKey:LocationID" json:"location,omitempty"`
 }
 
+// Situações da análise de crédito de uma venda a prazo
+const (
+	CreditStatusApproved         = "approved"
+	CreditStatusApprovalRequired = "approval_required"
+	CreditStatusRejected         = "rejected"
+)
+
 // TableName especifica o nome da tabela
 func (Sale) TableName() string {
 	return "sales"
 }
+
+// CreateSaleRequest representa os dados para criar uma venda
+type CreateSaleRequest struct {
+	CustomerID     *uint                   `json:"customer_id"`
+	SaleDate       *time.Time              `json:"sale_date"` // Padrão: agora
+	Notes          string                  `json:"notes"`
+	LocationID     *uint                   `json:"location_id"`                     // Local de saída. Padrão: local padrão
+	DiscountAmount float64                 `json:"discount_amount" binding:"gte=0"` // Desconto no total da venda
+	IsCredit       bool                    `json:"is_credit"`                       // Venda a prazo: exige o cliente e passa pela análise de crédito
+	Items          []CreateSaleItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+// CreateSaleItemRequest representa um item da venda. Com a unidade, a quantidade e o preço são informados
+// na unidade informada e convertidos para a unidade do produto.
+type CreateSaleItemRequest struct {
+	ProductID       uint     `json:"product_id" binding:"required"`
+	UnitID          *uint    `json:"unit_id"` // Padrão: unidade do produto
+	Quantity        float64  `json:"quantity" binding:"required,gt=0"`
+	UnitPrice       *float64 `json:"unit_price" binding:"omitempty,gte=0"` // Padrão: preço de venda do produto
+	DiscountPercent float64  `json:"discount_percent" binding:"gte=0,lte=100"`
+}
+
+// InvoiceSaleRequest representa os dados do faturamento de uma venda
+type InvoiceSaleRequest struct {
+	Items []InvoiceSaleItemRequest `json:"items" binding:"omitempty,dive"`
+}
+
+// InvoiceSaleItemRequest representa os números de série entregues em um item da venda, obrigatórios nos
+// produtos com número de série: um por unidade vendida
+type InvoiceSaleItemRequest struct {
+	ItemID        uint     `json:"item_id" binding:"required"`
+	SerialNumbers []string `json:"serial_numbers" binding:"omitempty,dive,required,max=100"`
+}
@@ -2,7 +2,8 @@ package models
 
 import "gorm.io/gorm"
 
-// SaleItem representa um item de venda
+// SaleItem representa um item de venda. Quantidade e preço ficam na unidade do produto; a quantidade como
+// foi vendida, em qualquer unidade com conversão para a do produto, fica em UnitQuantity.
 type SaleItem struct {
 	gorm.Model
 
@@ -10,13 +11,27 @@ type SaleItem struct {
 	Sale            *Sale    `gorm:"foreignKey:SaleID" json:"-"`
 	ProductID       uint     `json:"product_id"`
 	Product         *Product `gorm:"foreignKey:ProductID" json:"product,omitempty"`
-	Quantity        int      `gorm:"not null" json:"quantity"`
+	Quantity        float64  `gorm:"type:decimal(15,4);not null" json:"quantity"`
 	UnitPrice       float64  `gorm:"type:decimal(15,2);not null" json:"unit_price"`
 	DiscountPercent float64  `gorm:"type:decimal(5,2);default:0" json:"discount_percent"`
 	DiscountAmount  float64  `gorm:"type:decimal(15,2);default:0" json:"discount_amount"`
 	TaxPercent      float64  `gorm:"type:decimal(5,2);default:0" json:"tax_percent"`
 	TaxAmount       float64  `gorm:"type:decimal(15,2);default:0" json:"tax_amount"`
 	TotalAmount     float64  `gorm:"type:decimal(15,2);not null" json:"total_amount"`
+
+	// Unidade em que o item foi vendido, quando diferente da unidade do produto
+	UnitID           *uint            `json:"unit_id"`
+	Unit             *MeasurementUnit `gorm:"foreignKey:UnitID" json:"unit,omitempty"`
+	UnitQuantity     float64          `gorm:"type:decimal(15,4);not null;default:0" json:"unit_quantity"`
+	ConversionFactor float64          `gorm:"type:decimal(18,6);not null;default:1" json:"conversion_factor"` // Unidades do produto em cada unidade vendida
+
+	// Números de série escolhidos no item, obrigatórios nos produtos com número de série: um por unidade
+	// vendida. Não é uma coluna: a venda fica registrada em cada número de série.
+	SerialNumbers []string `gorm:"-" json:"serial_numbers"`
+
+	// Custo das mercadorias vendidas, pelo custo médio na saída do estoque. Nos kits, a soma dos componentes.
+	UnitCost   float64 `gorm:"type:decimal(15,4);default:0" json:"unit_cost"`
+	CostAmount float64 `gorm:"type:decimal(15,2);default:0" json:"cost_amount"`
 }
 
 // TableName especifica o nome da tabela
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Situações de um número de série
+const (
+	SerialStatusInStock  = "em_estoque"
+	SerialStatusSold     = "vendido"
+	SerialStatusReturned = "devolvido" // Devolvido ao fornecedor
+)
+
+// Eventos do histórico de um número de série
+const (
+	SerialEventReceived    = "recebido"
+	SerialEventProduced    = "produzido"
+	SerialEventTransferred = "transferido"
+	SerialEventSold        = "vendido"
+	SerialEventReturned    = "devolvido"
+)
+
+// Situações da garantia de um número de série, contada a partir da venda
+const (
+	WarrantyStatusNotSold = "nao_vendido"
+	WarrantyStatusNone    = "sem_garantia"
+	WarrantyStatusActive  = "em_garantia"
+	WarrantyStatusExpired = "expirada"
+)
+
+// SerialNumber representa uma unidade de um produto controlado por número de série, com a situação atual.
+// O histórico completo fica em SerialNumberEvent.
+type SerialNumber struct {
+	gorm.Model
+
+	ProductID  uint           `gorm:"not null;uniqueIndex:idx_serial_numbers_product_serial" json:"product_id"`
+	Product    *Product       `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+	Serial     string         `gorm:"size:100;not null;uniqueIndex:idx_serial_numbers_product_serial;index" json:"serial"`
+	Status     string         `gorm:"size:20;not null;index" json:"status"` // 'em_estoque', 'vendido', 'devolvido'
+	LocationID *uint          `gorm:"index" json:"location_id"`             // Local em que está, quando em estoque
+	Location   *StockLocation `gorm:"foreignKey:LocationID" json:"location,omitempty"`
+
+	// Última entrada: compra do fornecedor ou ordem de produção
+	SupplierID        *uint      `json:"supplier_id"`
+	PurchaseID        *uint      `json:"purchase_id"`
+	ProductionOrderID *uint      `json:"production_order_id"`
+	ReceivedAt        *time.Time `json:"received_at"`
+
+	// Última venda; a garantia é contada a partir de SoldAt
+	SaleID     *uint      `gorm:"index" json:"sale_id"`
+	SaleItemID *uint      `json:"sale_item_id"`
+	CustomerID *uint      `gorm:"index" json:"customer_id"`
+	SoldAt     *time.Time `json:"sold_at"`
+
+	Events []SerialNumberEvent `gorm:"foreignKey:SerialNumberID" json:"events,omitempty"`
+}
+
+// TableName especifica o nome da tabela
+func (SerialNumber) TableName() string {
+	return "serial_numbers"
+}
+
+// SerialNumberEvent representa um evento do histórico de um número de série: entrada, transferência, venda
+// ou devolução, com a origem e o cliente ou fornecedor envolvido
+type SerialNumberEvent struct {
+	gorm.Model
+
+	SerialNumberID uint           `gorm:"not null;index" json:"serial_number_id"`
+	EventType      string         `gorm:"size:20;not null" json:"event_type"` // models.SerialEvent*
+	ReferenceType  string         `gorm:"size:20" json:"reference_type"`      // models.MovementReference*
+	ReferenceID    *uint          `json:"reference_id"`
+	LocationID     *uint          `json:"location_id"` // Local de destino nas entradas e transferências; de origem nas saídas
+	Location       *StockLocation `gorm:"foreignKey:LocationID" json:"location,omitempty"`
+	SupplierID     *uint          `json:"supplier_id"`
+	CustomerID     *uint          `json:"customer_id"`
+	Notes          string         `json:"notes"`
+	CreatedByID    *uint          `gorm:"column:created_by" json:"created_by"`
+}
+
+// TableName especifica o nome da tabela
+func (SerialNumberEvent) TableName() string {
+	return "serial_number_events"
+}
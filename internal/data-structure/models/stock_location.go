@@ -0,0 +1,100 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// StockLocation representa um local de estoque: um depósito ou, com ParentID, um endereço (prateleira,
+// corredor, box) dentro do depósito. O estoque de cada produto é mantido por local em StockBalance.
+type StockLocation struct {
+	gorm.Model
+
+	Code      string         `gorm:"size:20;not null;unique" json:"code"`
+	Name      string         `gorm:"size:100;not null" json:"name"`
+	ParentID  *uint          `gorm:"index" json:"parent_id"` // Depósito do endereço; nulo nos depósitos
+	Parent    *StockLocation `gorm:"foreignKey:ParentID" json:"parent,omitempty"`
+	IsDefault bool           `gorm:"default:false" json:"is_default"` // Local das movimentações sem local informado
+	IsActive  bool           `gorm:"default:true" json:"is_active"`
+	Notes     string         `json:"notes"`
+}
+
+// TableName especifica o nome da tabela
+func (StockLocation) TableName() string {
+	return "stock_locations"
+}
+
+// StockBalance representa o saldo de um produto em um local de estoque, na unidade do produto. O estoque
+// atual do produto (Product.CurrentStock) é a soma dos saldos em todos os locais.
+type StockBalance struct {
+	ProductID  uint           `gorm:"primaryKey;autoIncrement:false" json:"product_id"`
+	Product    *Product       `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+	LocationID uint           `gorm:"primaryKey;autoIncrement:false;index" json:"location_id"`
+	Location   *StockLocation `gorm:"foreignKey:LocationID" json:"location,omitempty"`
+	Quantity   float64        `gorm:"type:decimal(15,4);not null;default:0" json:"quantity"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+}
+
+// TableName especifica o nome da tabela
+func (StockBalance) TableName() string {
+	return "stock_balances"
+}
+
+// StockTransfer representa uma transferência de estoque de um produto entre dois locais
+type StockTransfer struct {
+	gorm.Model
+
+	ProductID      uint           `gorm:"not null;index" json:"product_id"`
+	Product        *Product       `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+	FromLocationID uint           `gorm:"not null;index" json:"from_location_id"`
+	FromLocation   *StockLocation `gorm:"foreignKey:FromLocationID" json:"from_location,omitempty"`
+	ToLocationID   uint           `gorm:"not null;index" json:"to_location_id"`
+	ToLocation     *StockLocation `gorm:"foreignKey:ToLocationID" json:"to_location,omitempty"`
+	Quantity       float64        `gorm:"type:decimal(15,4);not null" json:"quantity"` // Na unidade do produto
+	Notes          string         `json:"notes"`
+	CreatedByID    *uint          `gorm:"column:created_by" json:"created_by"`
+	CreatedBy      *User          `gorm:"foreignKey:CreatedByID" json:"created_by_user,omitempty"`
+
+	// Lote transferido, quando informado. Sem ele, nos produtos com controle de lotes, a quantidade sai
+	// dos lotes da origem por ordem de validade e entra nos mesmos lotes no destino.
+	LotID *uint     `json:"lot_id"`
+	Lot   *StockLot `gorm:"foreignKey:LotID" json:"lot,omitempty"`
+}
+
+// TableName especifica o nome da tabela
+func (StockTransfer) TableName() string {
+	return "stock_transfers"
+}
+
+// CreateStockLocationRequest representa os dados para criar um local de estoque
+type CreateStockLocationRequest struct {
+	Code      string `json:"code" binding:"required,max=20"`
+	Name      string `json:"name" binding:"required,max=100"`
+	ParentID  *uint  `json:"parent_id"` // Depósito do endereço; nulo para criar um depósito
+	IsDefault bool   `json:"is_default"`
+	Notes     string `json:"notes"`
+}
+
+// UpdateStockLocationRequest representa os dados para atualizar um local de estoque
+type UpdateStockLocationRequest struct {
+	Code      string `json:"code" binding:"required,max=20"`
+	Name      string `json:"name" binding:"required,max=100"`
+	ParentID  *uint  `json:"parent_id"`
+	IsDefault bool   `json:"is_default"`
+	IsActive  bool   `json:"is_active"`
+	Notes     string `json:"notes"`
+}
+
+// CreateStockTransferRequest representa os dados de uma transferência de estoque entre locais
+type CreateStockTransferRequest struct {
+	ProductID      uint    `json:"product_id" binding:"required"`
+	FromLocationID uint    `json:"from_location_id" binding:"required"`
+	ToLocationID   uint    `json:"to_location_id" binding:"required,nefield=FromLocationID"`
+	Quantity       float64 `json:"quantity" binding:"required,gt=0"` // Na unidade do produto
+	LotID          *uint   `json:"lot_id"`                           // Opcional: lote transferido, nos produtos com controle de lotes
+	Notes          string  `json:"notes"`
+
+	// Números de série transferidos, obrigatórios nos produtos com número de série: um por unidade
+	Serials []string `json:"serials" binding:"omitempty,dive,required,max=100"`
+}
@@ -0,0 +1,97 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// StockLot representa um lote de um produto com controle de lotes, com as datas de fabricação e validade.
+// O saldo do lote é mantido por local de estoque em StockLotBalance.
+type StockLot struct {
+	gorm.Model
+
+	ProductID         uint       `gorm:"not null;uniqueIndex:idx_stock_lots_product_code" json:"product_id"`
+	Product           *Product   `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+	Code              string     `gorm:"size:50;not null;uniqueIndex:idx_stock_lots_product_code" json:"code"` // Número do lote
+	ManufacturedAt    *time.Time `json:"manufactured_at"`
+	ExpiresAt         *time.Time `gorm:"index" json:"expires_at"`
+	SupplierID        *uint      `json:"supplier_id"`         // Fornecedor do lote, quando comprado
+	PurchaseID        *uint      `json:"purchase_id"`         // Compra em que o lote foi recebido pela primeira vez
+	ProductionOrderID *uint      `json:"production_order_id"` // Ordem de produção que fabricou o lote
+
+	Balances []StockLotBalance `gorm:"foreignKey:LotID" json:"balances,omitempty"`
+}
+
+// TableName especifica o nome da tabela
+func (StockLot) TableName() string {
+	return "stock_lots"
+}
+
+// IsExpired informa se o lote está vencido na data informada
+func (l StockLot) IsExpired(today time.Time) bool {
+	return l.ExpiresAt != nil && l.ExpiresAt.Before(today)
+}
+
+// StockLotBalance representa o saldo de um lote em um local de estoque, na unidade do produto. A soma dos
+// saldos dos lotes de um produto em um local é o saldo do produto no local (StockBalance).
+type StockLotBalance struct {
+	LotID      uint           `gorm:"primaryKey;autoIncrement:false" json:"lot_id"`
+	Lot        *StockLot      `gorm:"foreignKey:LotID" json:"lot,omitempty"`
+	LocationID uint           `gorm:"primaryKey;autoIncrement:false;index" json:"location_id"`
+	Location   *StockLocation `gorm:"foreignKey:LocationID" json:"location,omitempty"`
+	Quantity   float64        `gorm:"type:decimal(15,4);not null;default:0" json:"quantity"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+}
+
+// TableName especifica o nome da tabela
+func (StockLotBalance) TableName() string {
+	return "stock_lot_balances"
+}
+
+// SaleItemLot representa a quantidade de um lote entregue em um item de venda, para a rastreabilidade
+// lote → clientes e venda → lotes. O cliente e a data da venda são copiados da venda.
+type SaleItemLot struct {
+	gorm.Model
+
+	SaleID     uint           `gorm:"not null;index" json:"sale_id"`
+	SaleItemID uint           `gorm:"not null" json:"sale_item_id"`
+	SaleDate   time.Time      `json:"sale_date"`
+	CustomerID *uint          `gorm:"index" json:"customer_id"`
+	Customer   *Customer      `gorm:"foreignKey:CustomerID" json:"customer,omitempty"`
+	ProductID  uint           `gorm:"not null" json:"product_id"`
+	Product    *Product       `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+	LotID      uint           `gorm:"not null;index" json:"lot_id"`
+	Lot        *StockLot      `gorm:"foreignKey:LotID" json:"lot,omitempty"`
+	LocationID uint           `json:"location_id"`
+	Location   *StockLocation `gorm:"foreignKey:LocationID" json:"location,omitempty"`
+	Quantity   float64        `gorm:"type:decimal(15,4);not null" json:"quantity"`
+}
+
+// TableName especifica o nome da tabela
+func (SaleItemLot) TableName() string {
+	return "sale_item_lots"
+}
+
+// LotCustomerSummary representa as quantidades de um lote vendidas a um cliente. Resultado de consulta
+// agregada; não é uma tabela.
+type LotCustomerSummary struct {
+	CustomerID *uint
+	Quantity   float64
+	Sales      int64
+	FirstSale  time.Time
+	LastSale   time.Time
+}
+
+// StockLotRequest representa um lote informado na entrada de mercadorias
+type StockLotRequest struct {
+	Code           string     `json:"code" binding:"required,max=50"`
+	ManufacturedAt *time.Time `json:"manufactured_at"`
+	ExpiresAt      *time.Time `json:"expires_at"`
+}
+
+// ReceivePurchaseLotRequest representa a quantidade recebida de um lote em um item da compra
+type ReceivePurchaseLotRequest struct {
+	StockLotRequest
+	Quantity float64 `json:"quantity" binding:"required,gt=0"` // Na unidade do produto
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Situações de uma reserva de estoque
+const (
+	ReservationStatusActive   = "ativa"
+	ReservationStatusConsumed = "baixada"  // A venda foi faturada e o estoque saiu
+	ReservationStatusReleased = "liberada" // A venda foi cancelada ou alterada
+	ReservationStatusExpired  = "expirada"
+)
+
+// StockReservation representa a quantidade de um produto reservada para um item de uma venda pendente, no
+// local de estoque da venda. Os kits reservam os componentes. O estoque disponível é o saldo menos as
+// reservas ativas e não expiradas.
+type StockReservation struct {
+	gorm.Model
+
+	SaleID      uint           `gorm:"not null;index" json:"sale_id"`
+	SaleItemID  uint           `json:"sale_item_id"`
+	ProductID   uint           `gorm:"not null;index:idx_stock_reservations_product_location" json:"product_id"`
+	Product     *Product       `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+	LocationID  uint           `gorm:"not null;index:idx_stock_reservations_product_location" json:"location_id"`
+	Location    *StockLocation `gorm:"foreignKey:LocationID" json:"location,omitempty"`
+	Quantity    float64        `gorm:"type:decimal(15,4);not null" json:"quantity"` // Na unidade do produto
+	Status      string         `gorm:"size:20;not null;index" json:"status"`        // 'ativa', 'baixada', 'liberada', 'expirada'
+	ExpiresAt   *time.Time     `gorm:"index" json:"expires_at"`                     // Nula: vale até a venda ser faturada ou cancelada
+	ClosedAt    *time.Time     `json:"closed_at"`                                   // Baixa, liberação ou expiração
+	CreatedByID *uint          `gorm:"column:created_by" json:"created_by"`
+}
+
+// TableName especifica o nome da tabela
+func (StockReservation) TableName() string {
+	return "stock_reservations"
+}
+
+// ReservedStock representa a quantidade reservada de um produto. Resultado de consulta; não é uma tabela.
+type ReservedStock struct {
+	ProductID uint
+	Quantity  float64
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SupplierProduct representa um item do catálogo de um fornecedor: o código que o fornecedor usa para o
+// produto, a embalagem em que ele vende, o prazo de entrega e o último preço pago
+type SupplierProduct struct {
+	gorm.Model
+
+	SupplierID   uint      `gorm:"not null;uniqueIndex:idx_supplier_products_product;uniqueIndex:idx_supplier_products_code" json:"supplier_id"`
+	Supplier     *Supplier `gorm:"foreignKey:SupplierID" json:"supplier,omitempty"`
+	ProductID    uint      `gorm:"not null;uniqueIndex:idx_supplier_products_product" json:"product_id"`
+	Product      *Product  `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+	SupplierCode string    `gorm:"size:60;not null;uniqueIndex:idx_supplier_products_code" json:"supplier_code"`
+
+	// Embalagem do fornecedor: quantas unidades do produto vêm em cada unidade comprada (ex: caixa com 12)
+	PackSize int    `gorm:"not null;default:1" json:"pack_size"`
+	PackUnit string `gorm:"size:10" json:"pack_unit"` // Abreviação da unidade do fornecedor (ex: cx)
+
+	LeadTimeDays   int        `gorm:"default:0" json:"lead_time_days"`      // Prazo de entrega em dias
+	LastPrice      *float64   `gorm:"type:decimal(15,4)" json:"last_price"` // Último preço pago por embalagem
+	LastPurchaseAt *time.Time `json:"last_purchase_at"`                     // Data do último recebimento
+	LastPurchaseID *uint      `json:"last_purchase_id"`                     // Compra do último recebimento
+	IsPreferred    bool       `gorm:"default:false" json:"is_preferred"`    // Fornecedor preferencial do produto
+	Notes          string     `gorm:"size:255" json:"notes"`
+}
+
+// TableName especifica o nome da tabela
+func (SupplierProduct) TableName() string {
+	return "supplier_products"
+}
+
+// UnitPrice retorna o último preço convertido para a unidade do produto
+func (sp SupplierProduct) UnitPrice() *float64 {
+	if sp.LastPrice == nil || sp.PackSize <= 0 {
+		return nil
+	}
+	price := *sp.LastPrice / float64(sp.PackSize)
+	return &price
+}
+
+// SupplierProductRequest representa os dados para cadastrar ou atualizar um item do catálogo do fornecedor
+type SupplierProductRequest struct {
+	ProductID    uint     `json:"product_id" binding:"required"`
+	SupplierCode string   `json:"supplier_code" binding:"required,max=60"`
+	PackSize     int      `json:"pack_size" binding:"omitempty,gte=1"` // Padrão: 1
+	PackUnit     string   `json:"pack_unit" binding:"omitempty,max=10"`
+	LeadTimeDays int      `json:"lead_time_days" binding:"gte=0"`
+	LastPrice    *float64 `json:"last_price" binding:"omitempty,gte=0"` // Preço por embalagem
+	IsPreferred  bool     `json:"is_preferred"`
+	Notes        string   `json:"notes" binding:"omitempty,max=255"`
+}
@@ -0,0 +1,22 @@
+package models
+
+// SupplierPurchaseStats representa os totais das compras recebidas de um fornecedor no período,
+// usados no cálculo do scorecard
+type SupplierPurchaseStats struct {
+	SupplierID       uint
+	Purchases        int64   // Compras recebidas
+	WithDueDate      int64   // Compras recebidas com data prevista de entrega
+	OnTime           int64   // Compras recebidas até a data prevista
+	OrderedQuantity  float64 // Quantidade pedida
+	ReceivedQuantity float64 // Quantidade recebida
+	AgreedValue      float64 // Valor recebido pelo preço do pedido
+	PaidValue        float64 // Valor recebido pelo preço efetivo
+	AvgLeadTimeDays  float64 // Média de dias entre o pedido e o recebimento
+}
+
+// SupplierReturnStats representa as devoluções feitas a um fornecedor no período
+type SupplierReturnStats struct {
+	SupplierID       uint
+	Returns          int64   // Quantidade de devoluções
+	ReturnedQuantity float64 // Quantidade de unidades devolvidas
+}
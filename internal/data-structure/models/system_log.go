@@ -11,8 +11,12 @@ type SystemLog struct {
 	Action     string                 `gorm:"size:100;not null" json:"action"`
 	EntityType string                 `gorm:"size:50" json:"entity_type"`
 	EntityID   string                 `json:"entity_id"`
-	Details    map[string]interface{} `gorm:"type:jsonb" json:"details"`
+	Details    map[string]interface{} `gorm:"type:jsonb;serializer:json" json:"details"`
 	IPAddress  string                 `gorm:"size:45" json:"ip_address"`
+
+	// Preenchido quando a ação foi executada durante uma personificação
+	ImpersonatorID *uint `gorm:"index" json:"impersonator_id,omitempty"`
+	Impersonator   *User `gorm:"foreignKey:ImpersonatorID" json:"impersonator,omitempty"`
 }
 
 // TableName especifica o nome da tabela
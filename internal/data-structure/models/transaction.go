@@ -6,6 +6,16 @@ import (
 	"gorm.io/gorm"
 )
 
+// Tipos e situações de lançamentos financeiros
+const (
+	TransactionTypeReceivable = "receivable"
+	TransactionTypePayable    = "payable"
+
+	TransactionStatusPending       = "Pendente"
+	TransactionStatusPartiallyPaid = "Parcialmente Paga"
+	TransactionStatusSettled       = "Liquidada"
+)
+
 type Transaction struct {
 	gorm.Model
 
@@ -37,3 +47,18 @@ type Transaction struct {
 	// Relacionamento com pagamentos
 	Payments []Payment `gorm:"foreignKey:TransactionID" json:"payments"`
 }
+
+// OpenReceivable representa um título a receber em aberto, com o valor já pago calculado a partir dos pagamentos
+type OpenReceivable struct {
+	TransactionID uint
+	Code          string
+	Date          time.Time
+	DueDate       time.Time
+	Amount        float64 // Valor do título somado a juros e multa
+	Paid          float64
+}
+
+// Balance retorna o saldo em aberto do título
+func (r OpenReceivable) Balance() float64 {
+	return r.Amount - r.Paid
+}
@@ -0,0 +1,36 @@
+package models
+
+import "gorm.io/gorm"
+
+// UnitConversion define quantas unidades de destino equivalem a uma unidade de origem (ex: 1 kg = 1000 g).
+// Sem produto, a conversão é global e vale para todos os produtos; com produto, vale só para ele (ex: a
+// caixa com 12 unidades) e tem precedência sobre a global. As conversões valem nos dois sentidos.
+type UnitConversion struct {
+	gorm.Model
+
+	FromUnitID uint             `gorm:"not null;index" json:"from_unit_id"`
+	FromUnit   *MeasurementUnit `gorm:"foreignKey:FromUnitID" json:"from_unit,omitempty"`
+	ToUnitID   uint             `gorm:"not null;index" json:"to_unit_id"`
+	ToUnit     *MeasurementUnit `gorm:"foreignKey:ToUnitID" json:"to_unit,omitempty"`
+	Factor     float64          `gorm:"type:decimal(18,6);not null" json:"factor"` // Unidades de destino em cada unidade de origem
+	ProductID  *uint            `gorm:"index" json:"product_id"`                   // Nulo nas conversões globais
+	Product    *Product         `gorm:"foreignKey:ProductID" json:"-"`
+}
+
+// TableName especifica o nome da tabela
+func (UnitConversion) TableName() string {
+	return "unit_conversions"
+}
+
+// CreateUnitConversionRequest representa os dados para cadastrar uma conversão de unidades
+type CreateUnitConversionRequest struct {
+	FromUnitID uint    `json:"from_unit_id" binding:"required"`
+	ToUnitID   uint    `json:"to_unit_id" binding:"required,nefield=FromUnitID"`
+	Factor     float64 `json:"factor" binding:"required,gt=0"`
+	ProductID  *uint   `json:"product_id"` // Opcional: conversão exclusiva do produto
+}
+
+// UpdateUnitConversionRequest representa os dados para atualizar o fator de uma conversão de unidades
+type UpdateUnitConversionRequest struct {
+	Factor float64 `json:"factor" binding:"required,gt=0"`
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"errors"
+	"simple-erp-service/internal/data-structure/models"
+
+	"gorm.io/gorm"
+)
+
+// AddressRepository define as operações de acesso a dados para endereços de clientes e fornecedores
+type AddressRepository interface {
+	Repository
+	FindByOwner(owner models.Owner) ([]models.Address, error)
+	FindByIDAndOwner(id uint, owner models.Owner) (*models.Address, error)
+	FindByIDs(ids []uint) ([]models.Address, error)
+	Create(address *models.Address) error
+	Update(address *models.Address) error
+	Delete(id uint) error
+	CountByOwner(owner models.Owner) (int64, error)
+	ClearPrimary(owner models.Owner, exceptID uint) error
+	PromoteOldest(owner models.Owner) error
+	AssignToOwner(ids []uint, owner models.Owner) error
+	MoveToOwner(from, to models.Owner) (int64, error)
+	DeleteByOwner(owner models.Owner) (int64, error)
+}
+
+// GormAddressRepository implementa AddressRepository usando GORM
+type GormAddressRepository struct {
+	*BaseRepository
+}
+
+// NewAddressRepository cria um novo repository de endereços
+func NewAddressRepository(db *gorm.DB) AddressRepository {
+	return &GormAddressRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// FindByOwner retorna os endereços de um cliente ou fornecedor, com o principal primeiro
+func (r *GormAddressRepository) FindByOwner(owner models.Owner) ([]models.Address, error) {
+	var addresses []models.Address
+	err := r.GetDB().Preload("City.State").
+		Where(owner.Column()+" = ?", owner.ID).
+		Order("is_primary DESC, id ASC").
+		Find(&addresses).Error
+	if err != nil {
+		return nil, err
+	}
+	return addresses, nil
+}
+
+// FindByIDAndOwner busca um endereço pelo ID garantindo que pertence ao dono informado
+func (r *GormAddressRepository) FindByIDAndOwner(id uint, owner models.Owner) (*models.Address, error) {
+	var address models.Address
+	err := r.GetDB().Preload("City.State").
+		Where(owner.Column()+" = ?", owner.ID).
+		First(&address, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &address, nil
+}
+
+// FindByIDs busca endereços pelos IDs
+func (r *GormAddressRepository) FindByIDs(ids []uint) ([]models.Address, error) {
+	var addresses []models.Address
+	if err := r.GetDB().Where("id IN ?", ids).Find(&addresses).Error; err != nil {
+		return nil, err
+	}
+	return addresses, nil
+}
+
+// Create cria um novo endereço
+func (r *GormAddressRepository) Create(address *models.Address) error {
+	return r.GetDB().Omit("City").Create(address).Error
+}
+
+// Update atualiza um endereço existente
+func (r *GormAddressRepository) Update(address *models.Address) error {
+	return r.GetDB().Omit("City").Save(address).Error
+}
+
+// Delete exclui um endereço (soft delete)
+func (r *GormAddressRepository) Delete(id uint) error {
+	return r.GetDB().Delete(&models.Address{}, id).Error
+}
+
+// CountByOwner conta quantos endereços um cliente ou fornecedor possui
+func (r *GormAddressRepository) CountByOwner(owner models.Owner) (int64, error) {
+	var count int64
+	err := r.GetDB().Model(&models.Address{}).Where(owner.Column()+" = ?", owner.ID).Count(&count).Error
+	return count, err
+}
+
+// ClearPrimary desmarca o endereço principal do dono, exceto o endereço informado
+func (r *GormAddressRepository) ClearPrimary(owner models.Owner, exceptID uint) error {
+	return r.GetDB().Model(&models.Address{}).
+		Where(owner.Column()+" = ? AND id != ? AND is_primary = ?", owner.ID, exceptID, true).
+		Update("is_primary", false).Error
+}
+
+// PromoteOldest marca o endereço mais antigo como principal quando o dono não possui nenhum
+func (r *GormAddressRepository) PromoteOldest(owner models.Owner) error {
+	var count int64
+	if err := r.GetDB().Model(&models.Address{}).
+		Where(owner.Column()+" = ? AND is_primary = ?", owner.ID, true).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	var oldest models.Address
+	err := r.GetDB().Where(owner.Column()+" = ?", owner.ID).Order("id ASC").First(&oldest).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	return r.GetDB().Model(&oldest).Update("is_primary", true).Error
+}
+
+// AssignToOwner vincula endereços existentes ao cliente ou fornecedor
+func (r *GormAddressRepository) AssignToOwner(ids []uint, owner models.Owner) error {
+	return r.GetDB().Model(&models.Address{}).Where("id IN ?", ids).Update(owner.Column(), owner.ID).Error
+}
+
+// MoveToOwner transfere todos os endereços de um dono para outro, retornando quantos foram movidos
+func (r *GormAddressRepository) MoveToOwner(from, to models.Owner) (int64, error) {
+	customerID, supplierID := to.IDs()
+
+	// Os registros movidos não podem disputar o principal com os do novo dono
+	var existing int64
+	if err := r.GetDB().Model(&models.Address{}).Where(to.Column()+" = ?", to.ID).Count(&existing).Error; err != nil {
+		return 0, err
+	}
+	updates := map[string]interface{}{"customer_id": customerID, "supplier_id": supplierID}
+	if existing > 0 {
+		updates["is_primary"] = false
+	}
+
+	result := r.GetDB().Model(&models.Address{}).Where(from.Column()+" = ?", from.ID).Updates(updates)
+	return result.RowsAffected, result.Error
+}
+
+// DeleteByOwner exclui definitivamente (sem soft delete) todos os endereços de um dono, inclusive os já
+// excluídos logicamente, retornando quantos foram removidos
+func (r *GormAddressRepository) DeleteByOwner(owner models.Owner) (int64, error) {
+	result := r.GetDB().Unscoped().Where(owner.Column()+" = ?", owner.ID).Delete(&models.Address{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"errors"
+	"simple-erp-service/internal/data-structure/models"
+
+	"gorm.io/gorm"
+)
+
+// ContactRepository define as operações de acesso a dados para contatos de clientes e fornecedores
+type ContactRepository interface {
+	Repository
+	FindByOwner(owner models.Owner) ([]models.Contact, error)
+	FindByIDAndOwner(id uint, owner models.Owner) (*models.Contact, error)
+	FindByIDs(ids []uint) ([]models.Contact, error)
+	Create(contact *models.Contact) error
+	Update(contact *models.Contact) error
+	Delete(id uint) error
+	CountByOwner(owner models.Owner) (int64, error)
+	ClearPrimary(owner models.Owner, exceptID uint) error
+	PromoteOldest(owner models.Owner) error
+	AssignToOwner(ids []uint, owner models.Owner) error
+	MoveToOwner(from, to models.Owner) (int64, error)
+	DeleteByOwner(owner models.Owner) (int64, error)
+	ExistsByContactExcept(contact string, id uint) (bool, error)
+}
+
+// GormContactRepository implementa ContactRepository usando GORM
+type GormContactRepository struct {
+	*BaseRepository
+}
+
+// NewContactRepository cria um novo repository de contatos
+func NewContactRepository(db *gorm.DB) ContactRepository {
+	return &GormContactRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// FindByOwner retorna os contatos de um cliente ou fornecedor, com o principal primeiro
+func (r *GormContactRepository) FindByOwner(owner models.Owner) ([]models.Contact, error) {
+	var contacts []models.Contact
+	err := r.GetDB().
+		Where(owner.Column()+" = ?", owner.ID).
+		Order("is_primary DESC, id ASC").
+		Find(&contacts).Error
+	if err != nil {
+		return nil, err
+	}
+	return contacts, nil
+}
+
+// FindByIDAndOwner busca um contato pelo ID garantindo que pertence ao dono informado
+func (r *GormContactRepository) FindByIDAndOwner(id uint, owner models.Owner) (*models.Contact, error) {
+	var contact models.Contact
+	err := r.GetDB().
+		Where(owner.Column()+" = ?", owner.ID).
+		First(&contact, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &contact, nil
+}
+
+// FindByIDs busca contatos pelos IDs
+func (r *GormContactRepository) FindByIDs(ids []uint) ([]models.Contact, error) {
+	var contacts []models.Contact
+	if err := r.GetDB().Where("id IN ?", ids).Find(&contacts).Error; err != nil {
+		return nil, err
+	}
+	return contacts, nil
+}
+
+// Create cria um novo contato
+func (r *GormContactRepository) Create(contact *models.Contact) error {
+	return r.GetDB().Create(contact).Error
+}
+
+// Update atualiza um contato existente
+func (r *GormContactRepository) Update(contact *models.Contact) error {
+	return r.GetDB().Save(contact).Error
+}
+
+// Delete exclui um contato (soft delete)
+func (r *GormContactRepository) Delete(id uint) error {
+	return r.GetDB().Delete(&models.Contact{}, id).Error
+}
+
+// CountByOwner conta quantos contatos um cliente ou fornecedor possui
+func (r *GormContactRepository) CountByOwner(owner models.Owner) (int64, error) {
+	var count int64
+	err := r.GetDB().Model(&models.Contact{}).Where(owner.Column()+" = ?", owner.ID).Count(&count).Error
+	return count, err
+}
+
+// ClearPrimary desmarca o contato principal do dono, exceto o contato informado
+func (r *GormContactRepository) ClearPrimary(owner models.Owner, exceptID uint) error {
+	return r.GetDB().Model(&models.Contact{}).
+		Where(owner.Column()+" = ? AND id != ? AND is_primary = ?", owner.ID, exceptID, true).
+		Update("is_primary", false).Error
+}
+
+// PromoteOldest marca o contato mais antigo como principal quando o dono não possui nenhum
+func (r *GormContactRepository) PromoteOldest(owner models.Owner) error {
+	var count int64
+	if err := r.GetDB().Model(&models.Contact{}).
+		Where(owner.Column()+" = ? AND is_primary = ?", owner.ID, true).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	var oldest models.Contact
+	err := r.GetDB().Where(owner.Column()+" = ?", owner.ID).Order("id ASC").First(&oldest).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	return r.GetDB().Model(&oldest).Update("is_primary", true).Error
+}
+
+// AssignToOwner vincula contatos existentes ao cliente ou fornecedor
+func (r *GormContactRepository) AssignToOwner(ids []uint, owner models.Owner) error {
+	return r.GetDB().Model(&models.Contact{}).Where("id IN ?", ids).Update(owner.Column(), owner.ID).Error
+}
+
+// ExistsByContactExcept verifica se o contato já está cadastrado em outro registro
+func (r *GormContactRepository) ExistsByContactExcept(contact string, id uint) (bool, error) {
+	index, err := blindIndex(contact)
+	if err != nil {
+		return false, err
+	}
+
+	var count int64
+	err = r.GetDB().Model(&models.Contact{}).Where("contact_index = ? AND id != ?", index, id).Count(&count).Error
+	return count > 0, err
+}
+
+// MoveToOwner transfere todos os contatos de um dono para outro, retornando quantos foram movidos
+func (r *GormContactRepository) MoveToOwner(from, to models.Owner) (int64, error) {
+	customerID, supplierID := to.IDs()
+
+	// Os registros movidos não podem disputar o principal com os do novo dono
+	var existing int64
+	if err := r.GetDB().Model(&models.Contact{}).Where(to.Column()+" = ?", to.ID).Count(&existing).Error; err != nil {
+		return 0, err
+	}
+	updates := map[string]interface{}{"customer_id": customerID, "supplier_id": supplierID}
+	if existing > 0 {
+		updates["is_primary"] = false
+	}
+
+	result := r.GetDB().Model(&models.Contact{}).Where(from.Column()+" = ?", from.ID).Updates(updates)
+	return result.RowsAffected, result.Error
+}
+
+// DeleteByOwner exclui definitivamente (sem soft delete) todos os contatos de um dono, inclusive os já
+// excluídos logicamente, retornando quantos foram removidos
+func (r *GormContactRepository) DeleteByOwner(owner models.Owner) (int64, error) {
+	result := r.GetDB().Unscoped().Where(owner.Column()+" = ?", owner.ID).Delete(&models.Contact{})
+	return result.RowsAffected, result.Error
+}
@@ -1,20 +1,32 @@
 package repository
 
 import (
+	"database/sql"
 	"errors"
+	"simple-erp-service/internal/data-structure/dto"
 	"simple-erp-service/internal/data-structure/models"
 	"simple-erp-service/internal/utils"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // CustomerRepository define as operações de acesso a dados para clientes
 type CustomerRepository interface {
 	Repository
-	FindAll(pagination *models.Pagination) ([]models.Customer, error)
+	FindAll(pagination *models.Pagination, filters dto.InGetPartiesFilters) ([]models.Customer, error)
 	FindByID(id uint) (*models.Customer, error)
+	FindByIDWithRelations(id uint) (*models.Customer, error)
+	FindByIDs(ids []uint) ([]models.Customer, error)
+	LockByID(id uint) error
+	FindDuplicateCandidates(scoring models.DuplicateScoring) ([]models.CustomerDuplicateCandidate, error)
+	ReassignSalesAndTransactions(fromID, toID uint) (int64, int64, error)
+	FindSalesAndTransactions(customerID uint) ([]models.Sale, []models.Transaction, error)
+	LastFiscalActivity(customerID uint) (*time.Time, error)
 	FindByDocument(document string) (*models.Customer, error)
 	Create(customer *models.Customer) error
+	CreateBatch(customers []models.Customer) error
 	Update(customer *models.Customer) error
 	Delete(id uint) error
 	ExistsByDocument(document string) (bool, error)
@@ -35,11 +47,11 @@ func NewCustomerRepository(db *gorm.DB) CustomerRepository {
 	}
 }
 
-// FindAll retorna todos os clientes com paginação
-func (r *GormCustomerRepository) FindAll(pagination *models.Pagination) ([]models.Customer, error) {
+// FindAll retorna os clientes com paginação, aplicando a busca textual e os filtros informados
+func (r *GormCustomerRepository) FindAll(pagination *models.Pagination, filters dto.InGetPartiesFilters) ([]models.Customer, error) {
 	var customers []models.Customer
 
-	query := r.GetDB().Model(&models.Customer{})
+	query := r.GetDB().Model(&models.Customer{}).Scopes(PartyFilters(CustomerParty, filters))
 	query, err := utils.Paginate(&models.Customer{}, pagination, query)
 	if err != nil {
 		return nil, err
@@ -64,10 +76,35 @@ func (r *GormCustomerRepository) FindByID(id uint) (*models.Customer, error) {
 	return &customer, nil
 }
 
-// FindByDocument busca um cliente pelo documento
+// FindByIDWithRelations busca um cliente pelo ID carregando endereços, contatos, documentos e usuários de auditoria
+func (r *GormCustomerRepository) FindByIDWithRelations(id uint) (*models.Customer, error) {
+	var customer models.Customer
+	err := r.GetDB().
+		Preload("Addresses", func(db *gorm.DB) *gorm.DB { return db.Order("is_primary DESC, id ASC") }).
+		Preload("Addresses.City.State").
+		Preload("Contacts", func(db *gorm.DB) *gorm.DB { return db.Order("is_primary DESC, id ASC") }).
+		Preload("Documents.State").
+		Preload("CreatedBy").
+		Preload("UpdatedBy").
+		First(&customer, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &customer, nil
+}
+
+// FindByDocument busca um cliente pelo documento (busca exata pelo índice cego)
 func (r *GormCustomerRepository) FindByDocument(document string) (*models.Customer, error) {
+	index, err := blindIndex(document)
+	if err != nil {
+		return nil, err
+	}
+
 	var customer models.Customer
-	if err := r.GetDB().Where("document = ?", document).First(&customer).Error; err != nil {
+	if err := r.GetDB().Where("document_number_index = ?", index).First(&customer).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
 		}
@@ -81,6 +118,37 @@ func (r *GormCustomerRepository) Create(customer *models.Customer) error {
 	return r.GetDB().Create(customer).Error
 }
 
+// CreateBatch insere vários clientes em uma única transação
+func (r *GormCustomerRepository) CreateBatch(customers []models.Customer) error {
+	if len(customers) == 0 {
+		return nil
+	}
+	// O default:true de IsActive faz o GORM ignorar o valor false na inserção,
+	// por isso os inativos são guardados antes e atualizados em seguida
+	inactive := make([]int, 0)
+	for i, customer := range customers {
+		if !customer.IsActive {
+			inactive = append(inactive, i)
+		}
+	}
+
+	return r.GetDB().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&customers).Error; err != nil {
+			return err
+		}
+		if len(inactive) == 0 {
+			return nil
+		}
+
+		ids := make([]uint, 0, len(inactive))
+		for _, i := range inactive {
+			customers[i].IsActive = false
+			ids = append(ids, customers[i].ID)
+		}
+		return tx.Model(&models.Customer{}).Where("id IN ?", ids).Update("is_active", false).Error
+	})
+}
+
 // Update atualiza um cliente existente
 func (r *GormCustomerRepository) Update(customer *models.Customer) error {
 	return r.GetDB().Save(customer).Error
@@ -93,15 +161,25 @@ func (r *GormCustomerRepository) Delete(id uint) error {
 
 // ExistsByDocument verifica se existe um cliente com o documento especificado
 func (r *GormCustomerRepository) ExistsByDocument(document string) (bool, error) {
+	index, err := blindIndex(document)
+	if err != nil {
+		return false, err
+	}
+
 	var count int64
-	err := r.GetDB().Model(&models.Customer{}).Where("document = ?", document).Count(&count).Error
+	err = r.GetDB().Model(&models.Customer{}).Where("document_number_index = ?", index).Count(&count).Error
 	return count > 0, err
 }
 
 // ExistsByDocumentExcept verifica se existe um cliente com o documento especificado, exceto o cliente com o ID especificado
 func (r *GormCustomerRepository) ExistsByDocumentExcept(document string, id uint) (bool, error) {
+	index, err := blindIndex(document)
+	if err != nil {
+		return false, err
+	}
+
 	var count int64
-	err := r.GetDB().Model(&models.Customer{}).Where("document = ? AND id != ?", document, id).Count(&count).Error
+	err = r.GetDB().Model(&models.Customer{}).Where("document_number_index = ? AND id != ?", index, id).Count(&count).Error
 	return count > 0, err
 }
 
@@ -118,3 +196,155 @@ func (r *GormCustomerRepository) ExistsByEmailExcept(email string, id uint) (boo
 	err := r.GetDB().Model(&models.Customer{}).Where("email = ? AND id != ?", email, id).Count(&count).Error
 	return count > 0, err
 }
+
+// FindByIDs busca clientes pelos IDs
+func (r *GormCustomerRepository) FindByIDs(ids []uint) ([]models.Customer, error) {
+	var customers []models.Customer
+	if err := r.GetDB().Where("id IN ?", ids).Find(&customers).Error; err != nil {
+		return nil, err
+	}
+	return customers, nil
+}
+
+// LockByID bloqueia o cliente até o fim da transação, para que as análises de crédito concorrentes do
+// mesmo cliente sejam feitas uma de cada vez
+func (r *GormCustomerRepository) LockByID(id uint) error {
+	var ids []uint
+	return r.GetDB().Model(&models.Customer{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		Pluck("id", &ids).Error
+}
+
+// duplicateCandidatesQuery gera os pares de clientes com nomes parecidos (operador % do pg_trgm),
+// contatos iguais após normalização ou endereços no mesmo CEP e número, e calcula a nota de cada critério
+const duplicateCandidatesQuery = `
+WITH customer_names AS (
+	SELECT id, f_unaccent(first_name || ' ' || last_name) AS full_name
+	FROM customers WHERE deleted_at IS NULL
+),
+normalized_contacts AS (
+	SELECT customer_id, contact_index AS value
+	FROM contact WHERE deleted_at IS NULL AND customer_id IS NOT NULL AND contact_index IS NOT NULL
+),
+pairs AS (
+	SELECT a.id AS customer_id, b.id AS duplicate_id FROM customer_names a
+	JOIN customer_names b ON a.id < b.id AND a.full_name % b.full_name
+	UNION
+	SELECT LEAST(x.customer_id, y.customer_id), GREATEST(x.customer_id, y.customer_id) FROM normalized_contacts x
+	JOIN normalized_contacts y ON x.value = y.value AND x.customer_id <> y.customer_id
+	UNION
+	SELECT LEAST(x.customer_id, y.customer_id), GREATEST(x.customer_id, y.customer_id) FROM address x
+	JOIN address y ON x.zip_code = y.zip_code AND x.number = y.number AND x.customer_id <> y.customer_id
+	WHERE x.deleted_at IS NULL AND y.deleted_at IS NULL
+)
+SELECT * FROM (
+SELECT p.customer_id, p.duplicate_id,
+	similarity(a.full_name, b.full_name) AS name_score,
+	CASE WHEN EXISTS (
+		SELECT 1 FROM normalized_contacts x JOIN normalized_contacts y ON x.value = y.value
+		WHERE x.customer_id = p.customer_id AND y.customer_id = p.duplicate_id
+	) THEN 1 ELSE 0 END AS contact_score,
+	CASE
+		WHEN EXISTS (
+			SELECT 1 FROM address x JOIN address y ON x.zip_code = y.zip_code AND x.number = y.number
+			WHERE x.customer_id = p.customer_id AND y.customer_id = p.duplicate_id
+				AND x.deleted_at IS NULL AND y.deleted_at IS NULL
+		) THEN 1
+		WHEN EXISTS (
+			SELECT 1 FROM address x JOIN address y ON x.zip_code = y.zip_code
+			WHERE x.customer_id = p.customer_id AND y.customer_id = p.duplicate_id
+				AND x.deleted_at IS NULL AND y.deleted_at IS NULL
+		) THEN 0.5
+		ELSE 0
+	END AS address_score
+FROM pairs p
+JOIN customer_names a ON a.id = p.customer_id
+JOIN customer_names b ON b.id = p.duplicate_id
+) scored
+WHERE ? * name_score + ? * contact_score + ? * address_score >= ?
+ORDER BY ? * name_score + ? * contact_score + ? * address_score DESC, customer_id, duplicate_id
+LIMIT ?`
+
+// FindDuplicateCandidates retorna os pares de clientes possivelmente duplicados, do mais para o menos provável,
+// com as notas de cada critério. A nota final é a média ponderada pelos pesos informados.
+// Os contatos são gravados normalizados e criptografados, por isso são comparados pelo índice cego.
+func (r *GormCustomerRepository) FindDuplicateCandidates(scoring models.DuplicateScoring) ([]models.CustomerDuplicateCandidate, error) {
+	weights := []interface{}{scoring.NameWeight, scoring.ContactWeight, scoring.AddressWeight}
+	args := append(append(append(weights, scoring.MinScore), weights...), scoring.Limit)
+
+	var candidates []models.CustomerDuplicateCandidate
+	if err := r.GetDB().Raw(duplicateCandidatesQuery, args...).Scan(&candidates).Error; err != nil {
+		return nil, err
+	}
+	return candidates, nil
+}
+
+// ReassignSalesAndTransactions transfere as vendas e os lançamentos financeiros de um cliente para outro
+func (r *GormCustomerRepository) ReassignSalesAndTransactions(fromID, toID uint) (int64, int64, error) {
+	sales := r.GetDB().Model(&models.Sale{}).Where("customer_id = ?", fromID).Update("customer_id", toID)
+	if sales.Error != nil {
+		return 0, 0, sales.Error
+	}
+
+	transactions := r.GetDB().Model(&models.Transaction{}).Where("customer_id = ?", fromID).Update("customer_id", toID)
+	if transactions.Error != nil {
+		return 0, 0, transactions.Error
+	}
+
+	return sales.RowsAffected, transactions.RowsAffected, nil
+}
+
+// FindSalesAndTransactions retorna as vendas (com itens) e os lançamentos financeiros (com pagamentos)
+// de um cliente
+func (r *GormCustomerRepository) FindSalesAndTransactions(customerID uint) ([]models.Sale, []models.Transaction, error) {
+	sales := []models.Sale{}
+	transactions := []models.Transaction{}
+
+	err := r.GetDB().Preload("Items").
+		Where("customer_id = ?", customerID).
+		Order("sale_date ASC, id ASC").
+		Find(&sales).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = r.GetDB().Preload("Payments").
+		Where("customer_id = ?", customerID).
+		Order("date ASC, id ASC").
+		Find(&transactions).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return sales, transactions, nil
+}
+
+// LastFiscalActivity retorna a data da última venda ou lançamento financeiro do cliente, inclusive
+// os excluídos logicamente, ou nil quando não houver nenhum
+func (r *GormCustomerRepository) LastFiscalActivity(customerID uint) (*time.Time, error) {
+	var last *time.Time
+
+	sources := []struct {
+		model  interface{}
+		column string
+	}{
+		{&models.Sale{}, "sale_date"},
+		{&models.Transaction{}, "date"},
+	}
+	for _, source := range sources {
+		var date sql.NullTime
+		err := r.GetDB().Unscoped().Model(source.model).
+			Where("customer_id = ?", customerID).
+			Select("MAX(" + source.column + ")").
+			Row().Scan(&date)
+		if err != nil {
+			return nil, err
+		}
+		if date.Valid && (last == nil || date.Time.After(*last)) {
+			last = &date.Time
+		}
+	}
+
+	return last, nil
+}
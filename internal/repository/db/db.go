@@ -5,6 +5,7 @@ import (
 	"log"
 	"simple-erp-service/config"
 	"simple-erp-service/internal/repository/seeders"
+	"simple-erp-service/internal/utils/fieldcrypt"
 	"simple-erp-service/migrations"
 	"strings"
 
@@ -15,6 +16,11 @@ import (
 
 // InitDB inicializa a conexão com o banco de dados
 func InitDB(cfg *config.Config) (*gorm.DB, error) {
+	// As colunas com dados pessoais são criptografadas pelo serializer "encrypted"
+	if err := ConfigureEncryption(cfg.Crypto); err != nil {
+		return nil, err
+	}
+
 	db, err := gorm.Open(postgres.Open(cfg.Database.DSN()), &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Silent),
 	})
@@ -52,3 +58,13 @@ func InitDB(cfg *config.Config) (*gorm.DB, error) {
 
 	return db, nil
 }
+
+// ConfigureEncryption configura as chaves usadas pela criptografia de campos e pelos índices cegos
+func ConfigureEncryption(cfg config.CryptoConfig) error {
+	cipher, err := fieldcrypt.New(cfg.Keys, cfg.CurrentKeyID, cfg.BlindIndexKey)
+	if err != nil {
+		return err
+	}
+	fieldcrypt.Configure(cipher)
+	return nil
+}
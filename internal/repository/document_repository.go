@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"errors"
+	"simple-erp-service/internal/data-structure/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DocumentRepository define as operações de acesso a dados para documentos de clientes e fornecedores
+type DocumentRepository interface {
+	Repository
+	FindByOwner(owner models.Owner) ([]models.Document, error)
+	FindByIDAndOwner(id uint, owner models.Owner) (*models.Document, error)
+	FindByIDs(ids []uint) ([]models.Document, error)
+	Create(document *models.Document) error
+	Update(document *models.Document) error
+	Delete(id uint) error
+	AssignToOwner(ids []uint, owner models.Owner) error
+	MoveToOwner(from, to models.Owner) (int64, error)
+	DeleteByOwner(owner models.Owner, keepTypes ...string) (int64, error)
+	ExistsByNumberExcept(number string, id uint) (bool, error)
+	FindExpiring(before time.Time, ownerType string, mandatoryOnly bool) ([]models.Document, error)
+	FindExpiredMandatory(supplierID uint, before time.Time) ([]models.Document, error)
+}
+
+// GormDocumentRepository implementa DocumentRepository usando GORM
+type GormDocumentRepository struct {
+	*BaseRepository
+}
+
+// NewDocumentRepository cria um novo repository de documentos
+func NewDocumentRepository(db *gorm.DB) DocumentRepository {
+	return &GormDocumentRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// FindByOwner retorna os documentos de um cliente ou fornecedor
+func (r *GormDocumentRepository) FindByOwner(owner models.Owner) ([]models.Document, error) {
+	var documents []models.Document
+	err := r.GetDB().Preload("State").
+		Where(owner.Column()+" = ?", owner.ID).
+		Order("type ASC, id ASC").
+		Find(&documents).Error
+	if err != nil {
+		return nil, err
+	}
+	return documents, nil
+}
+
+// FindByIDAndOwner busca um documento pelo ID garantindo que pertence ao dono informado
+func (r *GormDocumentRepository) FindByIDAndOwner(id uint, owner models.Owner) (*models.Document, error) {
+	var document models.Document
+	err := r.GetDB().Preload("State").
+		Where(owner.Column()+" = ?", owner.ID).
+		First(&document, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &document, nil
+}
+
+// FindByIDs busca documentos pelos IDs
+func (r *GormDocumentRepository) FindByIDs(ids []uint) ([]models.Document, error) {
+	var documents []models.Document
+	if err := r.GetDB().Where("id IN ?", ids).Find(&documents).Error; err != nil {
+		return nil, err
+	}
+	return documents, nil
+}
+
+// Create cria um novo documento
+func (r *GormDocumentRepository) Create(document *models.Document) error {
+	return r.GetDB().Omit("State").Create(document).Error
+}
+
+// Update atualiza um documento existente
+func (r *GormDocumentRepository) Update(document *models.Document) error {
+	return r.GetDB().Omit("State").Save(document).Error
+}
+
+// Delete exclui um documento (soft delete)
+func (r *GormDocumentRepository) Delete(id uint) error {
+	return r.GetDB().Delete(&models.Document{}, id).Error
+}
+
+// AssignToOwner vincula documentos existentes ao cliente ou fornecedor
+func (r *GormDocumentRepository) AssignToOwner(ids []uint, owner models.Owner) error {
+	return r.GetDB().Model(&models.Document{}).Where("id IN ?", ids).Update(owner.Column(), owner.ID).Error
+}
+
+// ExistsByNumberExcept verifica se o número do documento já está cadastrado em outro registro
+func (r *GormDocumentRepository) ExistsByNumberExcept(number string, id uint) (bool, error) {
+	index, err := blindIndex(number)
+	if err != nil {
+		return false, err
+	}
+
+	var count int64
+	err = r.GetDB().Model(&models.Document{}).Where("number_index = ? AND id != ?", index, id).Count(&count).Error
+	return count > 0, err
+}
+
+// MoveToOwner transfere todos os documentos de um dono para outro, retornando quantos foram movidos
+func (r *GormDocumentRepository) MoveToOwner(from, to models.Owner) (int64, error) {
+	customerID, supplierID := to.IDs()
+	updates := map[string]interface{}{"customer_id": customerID, "supplier_id": supplierID}
+
+	result := r.GetDB().Model(&models.Document{}).Where(from.Column()+" = ?", from.ID).Updates(updates)
+	return result.RowsAffected, result.Error
+}
+
+// DeleteByOwner exclui definitivamente (sem soft delete) os documentos de um dono, exceto os dos tipos
+// informados, retornando quantos foram removidos
+func (r *GormDocumentRepository) DeleteByOwner(owner models.Owner, keepTypes ...string) (int64, error) {
+	query := r.GetDB().Unscoped().Where(owner.Column()+" = ?", owner.ID)
+	if len(keepTypes) > 0 {
+		query = query.Where("type NOT IN ?", keepTypes)
+	}
+	result := query.Delete(&models.Document{})
+	return result.RowsAffected, result.Error
+}
+
+// FindExpiring retorna os documentos com validade anterior à data informada (incluindo os já vencidos)
+// de clientes e fornecedores não excluídos, carregando o dono. ownerType vazio considera ambos.
+func (r *GormDocumentRepository) FindExpiring(before time.Time, ownerType string, mandatoryOnly bool) ([]models.Document, error) {
+	var documents []models.Document
+
+	query := r.GetDB().Preload("Customer").Preload("Supplier").
+		Where("document.validate IS NOT NULL AND document.validate < ?", before).
+		Where(`(document.customer_id IS NOT NULL AND EXISTS (
+			SELECT 1 FROM customers WHERE customers.id = document.customer_id AND customers.deleted_at IS NULL
+		)) OR (document.supplier_id IS NOT NULL AND EXISTS (
+			SELECT 1 FROM suppliers WHERE suppliers.id = document.supplier_id AND suppliers.deleted_at IS NULL
+		))`)
+	switch ownerType {
+	case models.OwnerCustomer:
+		query = query.Where("document.customer_id IS NOT NULL")
+	case models.OwnerSupplier:
+		query = query.Where("document.supplier_id IS NOT NULL")
+	}
+	if mandatoryOnly {
+		query = query.Where("document.is_mandatory")
+	}
+
+	err := query.Order("document.validate ASC, document.id ASC").Find(&documents).Error
+	return documents, err
+}
+
+// FindExpiredMandatory retorna os documentos obrigatórios do fornecedor com validade anterior à data informada
+func (r *GormDocumentRepository) FindExpiredMandatory(supplierID uint, before time.Time) ([]models.Document, error) {
+	var documents []models.Document
+	err := r.GetDB().
+		Where("supplier_id = ? AND is_mandatory AND validate IS NOT NULL AND validate < ?", supplierID, before).
+		Order("validate ASC").
+		Find(&documents).Error
+	return documents, err
+}
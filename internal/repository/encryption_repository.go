@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"simple-erp-service/internal/utils/fieldcrypt"
+
+	"gorm.io/gorm"
+)
+
+// EncryptedColumn descreve uma coluna gravada com o serializer "encrypted" e a coluna do seu índice cego
+type EncryptedColumn struct {
+	Table       string
+	Column      string
+	IndexColumn string
+}
+
+// EncryptedColumns lista as colunas criptografadas. Novos campos criptografados devem ser registrados aqui
+// para que o comando de recriptografia os inclua na rotação de chaves.
+var EncryptedColumns = []EncryptedColumn{
+	{Table: "customers", Column: "document_number", IndexColumn: "document_number_index"},
+	{Table: "document", Column: "number", IndexColumn: "number_index"},
+	{Table: "contact", Column: "contact", IndexColumn: "contact_index"},
+}
+
+// EncryptionRepository define as operações de manutenção das colunas criptografadas
+type EncryptionRepository interface {
+	Repository
+	Reencrypt(column EncryptedColumn, reindex bool, batchSize int) (int64, error)
+}
+
+// GormEncryptionRepository implementa EncryptionRepository usando GORM
+type GormEncryptionRepository struct {
+	*BaseRepository
+}
+
+// NewEncryptionRepository cria um novo repository de manutenção das colunas criptografadas
+func NewEncryptionRepository(db *gorm.DB) EncryptionRepository {
+	return &GormEncryptionRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// Reencrypt criptografa com a chave atual os valores em texto puro ou criptografados com chaves antigas e
+// preenche os índices cegos ausentes, em lotes e incluindo os registros excluídos logicamente.
+// Com reindex, todos os registros são processados para recalcular os índices após a troca da chave HMAC.
+// Retorna a quantidade de registros atualizados.
+func (r *GormEncryptionRepository) Reencrypt(column EncryptedColumn, reindex bool, batchSize int) (int64, error) {
+	c, err := fieldcrypt.Default()
+	if err != nil {
+		return 0, err
+	}
+
+	var updated int64
+	var lastID uint
+	for {
+		var rows []struct {
+			ID    uint
+			Value string
+		}
+		query := r.GetDB().Table(column.Table).
+			Select("id, "+column.Column+" AS value").
+			Where("id > ? AND "+column.Column+" <> ''", lastID)
+		if !reindex {
+			query = query.Where("("+column.Column+" NOT LIKE ? OR "+column.IndexColumn+" IS NULL)", c.CurrentPrefix()+"%")
+		}
+		if err := query.Order("id ASC").Limit(batchSize).Scan(&rows).Error; err != nil {
+			return updated, err
+		}
+		if len(rows) == 0 {
+			return updated, nil
+		}
+
+		err := r.GetDB().Transaction(func(tx *gorm.DB) error {
+			for _, row := range rows {
+				plaintext, err := c.Decrypt(row.Value)
+				if err != nil {
+					return err
+				}
+				encrypted, err := c.Encrypt(plaintext)
+				if err != nil {
+					return err
+				}
+				err = tx.Table(column.Table).Where("id = ?", row.ID).Updates(map[string]interface{}{
+					column.Column:      encrypted,
+					column.IndexColumn: c.BlindIndex(plaintext),
+				}).Error
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return updated, err
+		}
+
+		updated += int64(len(rows))
+		lastID = rows[len(rows)-1].ID
+		if len(rows) < batchSize {
+			return updated, nil
+		}
+	}
+}
+
+// blindIndex calcula o índice cego usado nas buscas exatas por colunas criptografadas
+func blindIndex(value string) (string, error) {
+	c, err := fieldcrypt.Default()
+	if err != nil {
+		return "", err
+	}
+	return c.BlindIndex(value), nil
+}
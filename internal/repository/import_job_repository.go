@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"errors"
+	"simple-erp-service/internal/data-structure/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ImportJobRepository define as operações de acesso a dados para jobs de importação
+type ImportJobRepository interface {
+	Repository
+	FindByID(id uint) (*models.ImportJob, error)
+	Create(job *models.ImportJob) error
+	Update(job *models.ImportJob) error
+	FindByRowErrorsContaining(terms []string) ([]models.ImportJob, error)
+	FailUnfinished(message string, finishedAt time.Time) (int64, error)
+}
+
+// GormImportJobRepository implementa ImportJobRepository usando GORM
+type GormImportJobRepository struct {
+	*BaseRepository
+}
+
+// NewImportJobRepository cria um novo repository de jobs de importação
+func NewImportJobRepository(db *gorm.DB) ImportJobRepository {
+	return &GormImportJobRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// FindByID busca um job de importação pelo ID
+func (r *GormImportJobRepository) FindByID(id uint) (*models.ImportJob, error) {
+	var job models.ImportJob
+	if err := r.GetDB().First(&job, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+// Create cria um novo job de importação
+func (r *GormImportJobRepository) Create(job *models.ImportJob) error {
+	return r.GetDB().Create(job).Error
+}
+
+// Update grava o progresso de um job de importação
+func (r *GormImportJobRepository) Update(job *models.ImportJob) error {
+	return r.GetDB().Omit("CreatedBy").Save(job).Error
+}
+
+// FindByRowErrorsContaining retorna os jobs de importação cujos erros por linha contêm algum dos termos
+// informados
+func (r *GormImportJobRepository) FindByRowErrorsContaining(terms []string) ([]models.ImportJob, error) {
+	jobs := []models.ImportJob{}
+	if len(terms) == 0 {
+		return jobs, nil
+	}
+	condition, args := ContainsAny("row_errors::text", terms)
+	if err := r.GetDB().Where(condition, args...).Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// FailUnfinished marca como falhos os jobs pendentes ou em andamento. Retorna quantos jobs foram alterados.
+func (r *GormImportJobRepository) FailUnfinished(message string, finishedAt time.Time) (int64, error) {
+	result := r.GetDB().Model(&models.ImportJob{}).
+		Where("status IN ?", []string{models.ImportStatusPending, models.ImportStatusRunning}).
+		Updates(map[string]interface{}{"status": models.ImportStatusFailed, "message": message, "finished_at": finishedAt})
+	return result.RowsAffected, result.Error
+}
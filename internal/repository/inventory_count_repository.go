@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"errors"
+	"simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// InventoryCountRepository define as operações de acesso a dados para inventários, os seus itens e as
+// contagens informadas
+type InventoryCountRepository interface {
+	Repository
+	FindAll(pagination *models.Pagination, filters dto.InGetInventoryCountsFilters) ([]models.InventoryCount, error)
+	FindByID(id uint) (*models.InventoryCount, error)
+	FindByIDForUpdate(id uint) (*models.InventoryCount, error)
+	FindExpectedItems(locationID, categoryID *uint) ([]models.InventoryCountItem, error)
+	Create(count *models.InventoryCount) error
+	Update(count *models.InventoryCount) error
+	FindItemsForUpdate(countID uint, itemIDs []uint) ([]models.InventoryCountItem, error)
+	UpdateItem(item *models.InventoryCountItem) error
+	FindRoundEntries(itemID uint, round int) ([]models.InventoryCountEntry, error)
+	SaveEntry(entry *models.InventoryCountEntry) error
+}
+
+// GormInventoryCountRepository implementa InventoryCountRepository usando GORM
+type GormInventoryCountRepository struct {
+	*BaseRepository
+}
+
+// NewInventoryCountRepository cria um novo repository de inventários
+func NewInventoryCountRepository(db *gorm.DB) InventoryCountRepository {
+	return &GormInventoryCountRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// FindAll retorna os inventários com paginação, aplicando os filtros informados
+func (r *GormInventoryCountRepository) FindAll(pagination *models.Pagination, filters dto.InGetInventoryCountsFilters) ([]models.InventoryCount, error) {
+	var counts []models.InventoryCount
+
+	query := r.GetDB().Model(&models.InventoryCount{}).Scopes(DateRange("inventory_counts.created_at", filters.DateFrom, filters.DateTo))
+	if filters.Status != "" {
+		query = query.Where("inventory_counts.status = ?", filters.Status)
+	}
+	if filters.LocationID != 0 {
+		query = query.Where("inventory_counts.location_id = ?", filters.LocationID)
+	}
+
+	query, err := utils.Paginate(&models.InventoryCount{}, pagination, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := query.Preload("Location").Preload("Category").Find(&counts).Error; err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// FindByID busca um inventário pelo ID, com os itens ordenados por local e produto e as contagens de cada item
+func (r *GormInventoryCountRepository) FindByID(id uint) (*models.InventoryCount, error) {
+	var count models.InventoryCount
+	err := r.GetDB().
+		Preload("Location").
+		Preload("Category").
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Items.Product").
+		Preload("Items.Location").
+		Preload("Items.Lot").
+		Preload("Items.Entries", func(db *gorm.DB) *gorm.DB { return db.Order("round ASC, id ASC") }).
+		First(&count, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &count, nil
+}
+
+// FindByIDForUpdate busca um inventário pelo ID, sem os itens, bloqueando-o até o fim da transação
+func (r *GormInventoryCountRepository) FindByIDForUpdate(id uint) (*models.InventoryCount, error) {
+	var count models.InventoryCount
+	if err := r.GetDB().Clauses(clause.Locking{Strength: "UPDATE"}).First(&count, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &count, nil
+}
+
+// FindExpectedItems retorna os saldos a inventariar, ordenados por local e produto: os saldos por local dos
+// produtos sem controle de lotes e os saldos dos lotes dos produtos com controle de lotes, nos locais ativos.
+// Com o local informado, considera o local e os seus endereços; com a categoria, os produtos da categoria e
+// das subcategorias. Produtos com número de série não são inventariados por quantidade.
+func (r *GormInventoryCountRepository) FindExpectedItems(locationID, categoryID *uint) ([]models.InventoryCountItem, error) {
+	db := r.GetDB()
+	scope := func(query *gorm.DB, productColumn, locationColumn string) *gorm.DB {
+		query = query.
+			Joins("JOIN products p ON p.id = " + productColumn + " AND p.deleted_at IS NULL AND NOT p.tracks_serials").
+			Joins("JOIN stock_locations l ON l.id = " + locationColumn + " AND l.deleted_at IS NULL AND l.is_active")
+		if locationID != nil {
+			query = query.Where("(l.id = ? OR l.parent_id = ?)", *locationID, *locationID)
+		}
+		if categoryID != nil {
+			query = query.Where(`p.category_id IN (
+				WITH RECURSIVE subtree AS (
+					SELECT id FROM product_categories WHERE id = ? AND deleted_at IS NULL
+					UNION
+					SELECT c.id FROM product_categories c
+					JOIN subtree ON c.parent_id = subtree.id
+					WHERE c.deleted_at IS NULL
+				)
+				SELECT id FROM subtree
+			)`, *categoryID)
+		}
+		return query
+	}
+
+	balances := scope(db.Table("stock_balances").
+		Select("stock_balances.product_id, stock_balances.location_id, NULL::bigint AS lot_id, stock_balances.quantity AS expected_quantity, p.cost_price AS unit_cost, l.code AS location_code, p.name AS product_name"),
+		"stock_balances.product_id", "stock_balances.location_id").
+		Where("NOT p.tracks_lots")
+	lotBalances := scope(db.Table("stock_lot_balances").
+		Select("lots.product_id, stock_lot_balances.location_id, stock_lot_balances.lot_id, stock_lot_balances.quantity AS expected_quantity, p.cost_price AS unit_cost, l.code AS location_code, p.name AS product_name").
+		Joins("JOIN stock_lots lots ON lots.id = stock_lot_balances.lot_id AND lots.deleted_at IS NULL"),
+		"lots.product_id", "stock_lot_balances.location_id").
+		Where("p.tracks_lots AND stock_lot_balances.quantity <> 0")
+
+	var items []models.InventoryCountItem
+	err := db.Raw("SELECT * FROM (? UNION ALL ?) expected ORDER BY location_code, product_name, product_id, lot_id", balances, lotBalances).
+		Scan(&items).Error
+	return items, err
+}
+
+// Create cria o inventário com os itens
+func (r *GormInventoryCountRepository) Create(count *models.InventoryCount) error {
+	return r.GetDB().Omit("Location", "Category", "CreatedBy", "Items.Product", "Items.Location", "Items.Lot", "Items.Entries").Create(count).Error
+}
+
+// Update atualiza os dados do inventário, sem alterar os itens
+func (r *GormInventoryCountRepository) Update(count *models.InventoryCount) error {
+	return r.GetDB().Omit(clause.Associations).Save(count).Error
+}
+
+// FindItemsForUpdate busca os itens informados do inventário (todos, sem IDs) bloqueando-os até o fim da
+// transação
+func (r *GormInventoryCountRepository) FindItemsForUpdate(countID uint, itemIDs []uint) ([]models.InventoryCountItem, error) {
+	var items []models.InventoryCountItem
+	query := r.GetDB().Clauses(clause.Locking{Strength: "UPDATE"}).Where("inventory_count_id = ?", countID)
+	if len(itemIDs) > 0 {
+		query = query.Where("id IN ?", itemIDs)
+	}
+	err := query.Order("id ASC").Find(&items).Error
+	return items, err
+}
+
+// UpdateItem atualiza um item do inventário
+func (r *GormInventoryCountRepository) UpdateItem(item *models.InventoryCountItem) error {
+	return r.GetDB().Omit(clause.Associations).Save(item).Error
+}
+
+// FindRoundEntries retorna as contagens do item na rodada
+func (r *GormInventoryCountRepository) FindRoundEntries(itemID uint, round int) ([]models.InventoryCountEntry, error) {
+	var entries []models.InventoryCountEntry
+	err := r.GetDB().Where("inventory_count_item_id = ? AND round = ?", itemID, round).Order("id ASC").Find(&entries).Error
+	return entries, err
+}
+
+// SaveEntry cria ou atualiza uma contagem
+func (r *GormInventoryCountRepository) SaveEntry(entry *models.InventoryCountEntry) error {
+	return r.GetDB().Omit(clause.Associations).Save(entry).Error
+}
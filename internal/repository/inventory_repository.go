@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"simple-erp-service/internal/data-structure/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// InventoryMovementRepository define as operações de acesso a dados para movimentações de estoque
+type InventoryMovementRepository interface {
+	Repository
+	Create(movement *models.InventoryMovement) error
+	FindValuation(asOf time.Time, categoryID, locationID uint) ([]models.InventoryValuationRow, error)
+}
+
+// GormInventoryMovementRepository implementa InventoryMovementRepository usando GORM
+type GormInventoryMovementRepository struct {
+	*BaseRepository
+}
+
+// NewInventoryMovementRepository cria um novo repository de movimentações de estoque
+func NewInventoryMovementRepository(db *gorm.DB) InventoryMovementRepository {
+	return &GormInventoryMovementRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// Create registra uma movimentação de estoque
+func (r *GormInventoryMovementRepository) Create(movement *models.InventoryMovement) error {
+	return r.GetDB().Create(movement).Error
+}
+
+// FindValuation retorna o valor do estoque antes da data informada, por categoria e local, reconstruído pelas
+// movimentações: o saldo de cada produto em cada local é a soma das movimentações, e o custo é o custo médio
+// da última movimentação do produto. As movimentações anteriores ao custo médio recebem o custo na migração.
+// Com o local informado, considera o local e os seus endereços; com a categoria, os produtos da categoria e
+// das subcategorias.
+func (r *GormInventoryMovementRepository) FindValuation(asOf time.Time, categoryID, locationID uint) ([]models.InventoryValuationRow, error) {
+	db := r.GetDB()
+	quantities := db.Model(&models.InventoryMovement{}).
+		Select("product_id, location_id, SUM(quantity) AS quantity").
+		Where("created_at < ?", asOf).
+		Group("product_id, location_id").
+		Having("SUM(quantity) <> 0")
+	costs := db.Model(&models.InventoryMovement{}).
+		Select("DISTINCT ON (product_id) product_id, average_cost").
+		Where("created_at < ?", asOf).
+		Order("product_id, created_at DESC, id DESC")
+
+	query := db.Table("(?) q", quantities).
+		Select(`p.category_id, cat.name AS category_name, q.location_id, l.code AS location_code, l.name AS location_name,
+			COUNT(DISTINCT q.product_id) AS product_count,
+			ROUND(SUM(q.quantity * cost.average_cost), 2) AS value`).
+		Joins("JOIN products p ON p.id = q.product_id").
+		Joins("LEFT JOIN (?) cost ON cost.product_id = q.product_id", costs).
+		Joins("LEFT JOIN product_categories cat ON cat.id = p.category_id").
+		Joins("LEFT JOIN stock_locations l ON l.id = q.location_id")
+	if locationID != 0 {
+		query = query.Where("(l.id = ? OR l.parent_id = ?)", locationID, locationID)
+	}
+	if categoryID != 0 {
+		query = query.Where(`p.category_id IN (
+			WITH RECURSIVE subtree AS (
+				SELECT id FROM product_categories WHERE id = ? AND deleted_at IS NULL
+				UNION
+				SELECT c.id FROM product_categories c
+				JOIN subtree ON c.parent_id = subtree.id
+				WHERE c.deleted_at IS NULL
+			)
+			SELECT id FROM subtree
+		)`, categoryID)
+	}
+
+	var rows []models.InventoryValuationRow
+	err := query.
+		Group("p.category_id, cat.name, q.location_id, l.code, l.name").
+		Order("cat.name ASC NULLS LAST, l.code ASC NULLS LAST").
+		Scan(&rows).Error
+	return rows, err
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"errors"
+	"simple-erp-service/internal/data-structure/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LocationRepository define as operações de acesso a dados geográficos (países, estados e cidades)
+type LocationRepository interface {
+	Repository
+	FindStateByID(id uint) (*models.State, error)
+	FindCityByID(id uint) (*models.City, error)
+	FindCityByIBGECode(code string) (*models.City, error)
+	FindCitiesByIBGECodes(codes []string) ([]models.City, error)
+	FindCountries() ([]models.Country, error)
+	FindCountryByID(id uint) (*models.Country, error)
+	FindStatesByCountry(countryID uint) ([]models.State, error)
+	FindCitiesByState(stateID uint, search string, limit int) ([]models.City, error)
+	FindAllStates() ([]models.State, error)
+	UpsertCities(cities []models.City, batchSize int) error
+}
+
+// GormLocationRepository implementa LocationRepository usando GORM
+type GormLocationRepository struct {
+	*BaseRepository
+}
+
+// NewLocationRepository cria um novo repository de dados geográficos
+func NewLocationRepository(db *gorm.DB) LocationRepository {
+	return &GormLocationRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// FindStateByID busca um estado pelo ID
+func (r *GormLocationRepository) FindStateByID(id uint) (*models.State, error) {
+	var state models.State
+	if err := r.GetDB().First(&state, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &state, nil
+}
+
+// FindCityByID busca uma cidade pelo ID, carregando o estado
+func (r *GormLocationRepository) FindCityByID(id uint) (*models.City, error) {
+	var city models.City
+	if err := r.GetDB().Preload("State").First(&city, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &city, nil
+}
+
+// FindCityByIBGECode busca uma cidade pelo código IBGE, carregando o estado
+func (r *GormLocationRepository) FindCityByIBGECode(code string) (*models.City, error) {
+	var city models.City
+	if err := r.GetDB().Preload("State").Where("ibge_code = ?", code).First(&city).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &city, nil
+}
+
+// FindCitiesByIBGECodes retorna as cidades com os códigos IBGE informados
+func (r *GormLocationRepository) FindCitiesByIBGECodes(codes []string) ([]models.City, error) {
+	var cities []models.City
+	if len(codes) == 0 {
+		return cities, nil
+	}
+	err := r.GetDB().Where("ibge_code IN ?", codes).Find(&cities).Error
+	return cities, err
+}
+
+// FindCountries retorna os países em ordem alfabética
+func (r *GormLocationRepository) FindCountries() ([]models.Country, error) {
+	var countries []models.Country
+	err := r.GetDB().Order("name ASC").Find(&countries).Error
+	return countries, err
+}
+
+// FindCountryByID busca um país pelo ID
+func (r *GormLocationRepository) FindCountryByID(id uint) (*models.Country, error) {
+	var country models.Country
+	if err := r.GetDB().First(&country, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &country, nil
+}
+
+// FindStatesByCountry retorna os estados do país em ordem alfabética
+func (r *GormLocationRepository) FindStatesByCountry(countryID uint) ([]models.State, error) {
+	var states []models.State
+	err := r.GetDB().Where("country_id = ?", countryID).Order("name ASC").Find(&states).Error
+	return states, err
+}
+
+// FindAllStates retorna todos os estados cadastrados
+func (r *GormLocationRepository) FindAllStates() ([]models.State, error) {
+	var states []models.State
+	err := r.GetDB().Order("id ASC").Find(&states).Error
+	return states, err
+}
+
+// FindCitiesByState retorna as cidades do estado em ordem alfabética, filtrando pelo nome sem
+// diferenciar acentos e maiúsculas. Limite zero retorna todas as cidades.
+func (r *GormLocationRepository) FindCitiesByState(stateID uint, search string, limit int) ([]models.City, error) {
+	var cities []models.City
+	query := r.GetDB().Where("state_id = ?", stateID).
+		Scopes(TextSearch(search, []string{"name"})).
+		Order("name ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	err := query.Find(&cities).Error
+	return cities, err
+}
+
+// UpsertCities insere as cidades em lotes ou, quando o código IBGE já existe, atualiza o nome e o estado.
+// Cidades excluídas logicamente que voltam a constar na tabela do IBGE são restauradas.
+func (r *GormLocationRepository) UpsertCities(cities []models.City, batchSize int) error {
+	return r.GetDB().Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "ibge_code"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "state_id", "updated_at", "deleted_at"}),
+	}).CreateInBatches(&cities, batchSize).Error
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"errors"
+	"simple-erp-service/internal/data-structure/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MeasurementUnitRepository define as operações de acesso a dados para unidades de medida e conversões
+type MeasurementUnitRepository interface {
+	Repository
+	FindUnits() ([]models.MeasurementUnit, error)
+	FindUnitByID(id uint) (*models.MeasurementUnit, error)
+	FindConversions(productID *uint) ([]models.UnitConversion, error)
+	FindConversionByID(id uint) (*models.UnitConversion, error)
+	FindConversionsForProduct(productID, unitID uint) ([]models.UnitConversion, error)
+	ExistsConversionExcept(productID *uint, unitA, unitB, id uint) (bool, error)
+	CreateConversion(conversion *models.UnitConversion) error
+	UpdateConversion(conversion *models.UnitConversion) error
+	DeleteConversion(id uint) error
+}
+
+// GormMeasurementUnitRepository implementa MeasurementUnitRepository usando GORM
+type GormMeasurementUnitRepository struct {
+	*BaseRepository
+}
+
+// NewMeasurementUnitRepository cria um novo repository de unidades de medida
+func NewMeasurementUnitRepository(db *gorm.DB) MeasurementUnitRepository {
+	return &GormMeasurementUnitRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// FindUnits retorna todas as unidades de medida ordenadas pelo nome
+func (r *GormMeasurementUnitRepository) FindUnits() ([]models.MeasurementUnit, error) {
+	var units []models.MeasurementUnit
+	err := r.GetDB().Order("name ASC").Find(&units).Error
+	return units, err
+}
+
+// FindUnitByID busca uma unidade de medida pelo ID
+func (r *GormMeasurementUnitRepository) FindUnitByID(id uint) (*models.MeasurementUnit, error) {
+	var unit models.MeasurementUnit
+	if err := r.GetDB().First(&unit, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &unit, nil
+}
+
+// FindConversions retorna as conversões exclusivas do produto ou, sem produto, as conversões globais
+func (r *GormMeasurementUnitRepository) FindConversions(productID *uint) ([]models.UnitConversion, error) {
+	var conversions []models.UnitConversion
+	query := r.GetDB().Preload("FromUnit").Preload("ToUnit")
+	if productID != nil {
+		query = query.Where("product_id = ?", *productID)
+	} else {
+		query = query.Where("product_id IS NULL")
+	}
+	err := query.Order("id ASC").Find(&conversions).Error
+	return conversions, err
+}
+
+// FindConversionByID busca uma conversão de unidades pelo ID, com as unidades
+func (r *GormMeasurementUnitRepository) FindConversionByID(id uint) (*models.UnitConversion, error) {
+	var conversion models.UnitConversion
+	if err := r.GetDB().Preload("FromUnit").Preload("ToUnit").First(&conversion, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &conversion, nil
+}
+
+// FindConversionsForProduct retorna as conversões, exclusivas do produto ou globais, que envolvem a
+// unidade informada em qualquer um dos sentidos. As conversões do produto vêm antes das globais.
+func (r *GormMeasurementUnitRepository) FindConversionsForProduct(productID, unitID uint) ([]models.UnitConversion, error) {
+	var conversions []models.UnitConversion
+	err := r.GetDB().Preload("FromUnit").Preload("ToUnit").
+		Where("(from_unit_id = ? OR to_unit_id = ?) AND (product_id = ? OR product_id IS NULL)", unitID, unitID, productID).
+		Order("product_id IS NULL, id ASC").
+		Find(&conversions).Error
+	return conversions, err
+}
+
+// ExistsConversionExcept verifica se já existe, no mesmo escopo (produto ou global), uma conversão entre as
+// duas unidades em qualquer sentido, exceto a conversão com o ID especificado
+func (r *GormMeasurementUnitRepository) ExistsConversionExcept(productID *uint, unitA, unitB, id uint) (bool, error) {
+	var count int64
+	err := r.GetDB().Model(&models.UnitConversion{}).
+		Where("((from_unit_id = ? AND to_unit_id = ?) OR (from_unit_id = ? AND to_unit_id = ?))", unitA, unitB, unitB, unitA).
+		Where("product_id IS NOT DISTINCT FROM ? AND id != ?", productID, id).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// CreateConversion cria uma conversão de unidades
+func (r *GormMeasurementUnitRepository) CreateConversion(conversion *models.UnitConversion) error {
+	return r.GetDB().Omit(clause.Associations).Create(conversion).Error
+}
+
+// UpdateConversion atualiza uma conversão de unidades
+func (r *GormMeasurementUnitRepository) UpdateConversion(conversion *models.UnitConversion) error {
+	return r.GetDB().Omit(clause.Associations).Save(conversion).Error
+}
+
+// DeleteConversion exclui uma conversão de unidades (soft delete)
+func (r *GormMeasurementUnitRepository) DeleteConversion(id uint) error {
+	return r.GetDB().Delete(&models.UnitConversion{}, id).Error
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/utils"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NotificationRepository define as operações de acesso a dados para as notificações dos usuários
+type NotificationRepository interface {
+	Repository
+	FindByUser(userID uint, pagination *models.Pagination, unreadOnly bool) ([]models.Notification, error)
+	CountUnread(userID uint) (int64, error)
+	MarkAsRead(id, userID uint, readAt time.Time) (int64, error)
+	MarkAllAsRead(userID uint, readAt time.Time) (int64, error)
+	CreateMissing(notifications []models.Notification) (int64, error)
+}
+
+// GormNotificationRepository implementa NotificationRepository usando GORM
+type GormNotificationRepository struct {
+	*BaseRepository
+}
+
+// NewNotificationRepository cria um novo repository de notificações
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &GormNotificationRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// FindByUser retorna as notificações do usuário com paginação
+func (r *GormNotificationRepository) FindByUser(userID uint, pagination *models.Pagination, unreadOnly bool) ([]models.Notification, error) {
+	var notifications []models.Notification
+
+	query := r.GetDB().Model(&models.Notification{}).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	query, err := utils.Paginate(&models.Notification{}, pagination, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := query.Find(&notifications).Error; err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+// CountUnread conta as notificações não lidas do usuário
+func (r *GormNotificationRepository) CountUnread(userID uint) (int64, error) {
+	var count int64
+	err := r.GetDB().Model(&models.Notification{}).Where("user_id = ? AND read_at IS NULL", userID).Count(&count).Error
+	return count, err
+}
+
+// MarkAsRead marca a notificação do usuário como lida, retornando quantos registros foram encontrados
+func (r *GormNotificationRepository) MarkAsRead(id, userID uint, readAt time.Time) (int64, error) {
+	result := r.GetDB().Model(&models.Notification{}).
+		Where("id = ? AND user_id = ?", id, userID).
+		Update("read_at", gorm.Expr("COALESCE(read_at, ?)", readAt))
+	return result.RowsAffected, result.Error
+}
+
+// MarkAllAsRead marca todas as notificações não lidas do usuário como lidas
+func (r *GormNotificationRepository) MarkAllAsRead(userID uint, readAt time.Time) (int64, error) {
+	result := r.GetDB().Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", readAt)
+	return result.RowsAffected, result.Error
+}
+
+// CreateMissing grava as notificações ignorando as que já existem para o mesmo usuário e chave,
+// retornando quantas foram criadas
+func (r *GormNotificationRepository) CreateMissing(notifications []models.Notification) (int64, error) {
+	if len(notifications) == 0 {
+		return 0, nil
+	}
+	result := r.GetDB().Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "key"}},
+		DoNothing: true,
+	}).Omit(clause.Associations).CreateInBatches(&notifications, 500)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"errors"
+	"simple-erp-service/internal/data-structure/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PostalCodeRepository define as operações de acesso à base local de CEPs
+type PostalCodeRepository interface {
+	Repository
+	FindByZipCode(zipCode string) (*models.PostalCode, error)
+	Upsert(postalCodes []models.PostalCode, batchSize int) error
+}
+
+// GormPostalCodeRepository implementa PostalCodeRepository usando GORM
+type GormPostalCodeRepository struct {
+	*BaseRepository
+}
+
+// NewPostalCodeRepository cria um novo repository da base de CEPs
+func NewPostalCodeRepository(db *gorm.DB) PostalCodeRepository {
+	return &GormPostalCodeRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// FindByZipCode busca um CEP (8 dígitos, sem máscara), carregando a cidade e o estado
+func (r *GormPostalCodeRepository) FindByZipCode(zipCode string) (*models.PostalCode, error) {
+	var postalCode models.PostalCode
+	if err := r.GetDB().Preload("City.State").Where("zip_code = ?", zipCode).First(&postalCode).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &postalCode, nil
+}
+
+// Upsert insere os CEPs em lotes ou, quando o CEP já existe, atualiza o endereço. CEPs excluídos
+// logicamente que voltam a constar na base são restaurados.
+func (r *GormPostalCodeRepository) Upsert(postalCodes []models.PostalCode, batchSize int) error {
+	return r.GetDB().Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "zip_code"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"street", "neighborhood", "complement", "city_id", "updated_at", "deleted_at",
+		}),
+	}).CreateInBatches(&postalCodes, batchSize).Error
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"errors"
+	"simple-erp-service/internal/data-structure/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ProductAttributeRepository define as operações de acesso a dados para os atributos das variantes
+type ProductAttributeRepository interface {
+	Repository
+	FindAll() ([]models.ProductAttribute, error)
+	FindByID(id uint) (*models.ProductAttribute, error)
+	FindByIDs(ids []uint) ([]models.ProductAttribute, error)
+	ExistsByNameExcept(name string, id uint) (bool, error)
+	Create(attribute *models.ProductAttribute) error
+	CreateValues(values []models.ProductAttributeValue) error
+}
+
+// GormProductAttributeRepository implementa ProductAttributeRepository usando GORM
+type GormProductAttributeRepository struct {
+	*BaseRepository
+}
+
+// NewProductAttributeRepository cria um novo repository de atributos das variantes
+func NewProductAttributeRepository(db *gorm.DB) ProductAttributeRepository {
+	return &GormProductAttributeRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// orderedValues carrega os valores do atributo na ordem de exibição
+func orderedValues(db *gorm.DB) *gorm.DB {
+	return db.Order("position ASC, id ASC")
+}
+
+// FindAll retorna os atributos ordenados pelo nome, com os valores
+func (r *GormProductAttributeRepository) FindAll() ([]models.ProductAttribute, error) {
+	var attributes []models.ProductAttribute
+	err := r.GetDB().Preload("Values", orderedValues).Order("name ASC").Find(&attributes).Error
+	return attributes, err
+}
+
+// FindByID busca um atributo pelo ID, com os valores
+func (r *GormProductAttributeRepository) FindByID(id uint) (*models.ProductAttribute, error) {
+	var attribute models.ProductAttribute
+	if err := r.GetDB().Preload("Values", orderedValues).First(&attribute, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &attribute, nil
+}
+
+// FindByIDs busca os atributos com os IDs informados, com os valores
+func (r *GormProductAttributeRepository) FindByIDs(ids []uint) ([]models.ProductAttribute, error) {
+	var attributes []models.ProductAttribute
+	if len(ids) == 0 {
+		return attributes, nil
+	}
+	err := r.GetDB().Preload("Values", orderedValues).Where("id IN ?", ids).Find(&attributes).Error
+	return attributes, err
+}
+
+// ExistsByNameExcept verifica se existe um atributo com o nome especificado, sem diferenciar maiúsculas,
+// exceto o atributo com o ID especificado
+func (r *GormProductAttributeRepository) ExistsByNameExcept(name string, id uint) (bool, error) {
+	var count int64
+	err := r.GetDB().Unscoped().Model(&models.ProductAttribute{}).Where("LOWER(name) = LOWER(?) AND id != ?", name, id).Count(&count).Error
+	return count > 0, err
+}
+
+// Create cria um atributo com os valores informados
+func (r *GormProductAttributeRepository) Create(attribute *models.ProductAttribute) error {
+	return r.GetDB().Create(attribute).Error
+}
+
+// CreateValues inclui valores em atributos existentes
+func (r *GormProductAttributeRepository) CreateValues(values []models.ProductAttributeValue) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return r.GetDB().Omit(clause.Associations).Create(&values).Error
+}
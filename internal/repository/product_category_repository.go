@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"errors"
+	"simple-erp-service/internal/data-structure/models"
+
+	"gorm.io/gorm"
+)
+
+// ProductCategoryRepository define as operações de acesso a dados para a árvore de categorias de produtos
+type ProductCategoryRepository interface {
+	Repository
+	FindTree() ([]models.ProductCategoryNode, error)
+	FindByID(id uint) (*models.ProductCategory, error)
+	ExistsByNameExcept(parentID *uint, name string, id uint) (bool, error)
+	IsDescendant(id, ancestorID uint) (bool, error)
+	CountChildren(id uint) (int64, error)
+	CountProducts(id uint) (int64, error)
+	Create(category *models.ProductCategory) error
+	Update(category *models.ProductCategory) error
+	Delete(id uint) error
+	FindChildNameConflicts(id uint, parentID *uint) ([]string, error)
+	ReassignChildren(id uint, parentID *uint) error
+	ReassignProducts(id uint, categoryID *uint) error
+}
+
+// GormProductCategoryRepository implementa ProductCategoryRepository usando GORM
+type GormProductCategoryRepository struct {
+	*BaseRepository
+}
+
+// NewProductCategoryRepository cria um novo repository de categorias de produtos
+func NewProductCategoryRepository(db *gorm.DB) ProductCategoryRepository {
+	return &GormProductCategoryRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// FindTree percorre a árvore de categorias com uma consulta recursiva, retornando cada categoria com a
+// profundidade e as quantidades de produtos (próprios e das subcategorias), ordenadas por profundidade e nome.
+// Categorias cujo pai foi excluído são tratadas como raiz; o caminho percorrido impede laços.
+func (r *GormProductCategoryRepository) FindTree() ([]models.ProductCategoryNode, error) {
+	var nodes []models.ProductCategoryNode
+	err := r.GetDB().Raw(`
+		WITH RECURSIVE tree AS (
+			SELECT c.id, c.parent_id, c.name, c.description, 0 AS depth, ARRAY[c.id] AS path
+			FROM product_categories c
+			WHERE c.deleted_at IS NULL
+				AND (c.parent_id IS NULL OR NOT EXISTS (
+					SELECT 1 FROM product_categories p WHERE p.id = c.parent_id AND p.deleted_at IS NULL
+				))
+			UNION ALL
+			SELECT c.id, c.parent_id, c.name, c.description, tree.depth + 1, tree.path || c.id
+			FROM product_categories c
+			JOIN tree ON c.parent_id = tree.id
+			WHERE c.deleted_at IS NULL AND NOT c.id = ANY(tree.path)
+		),
+		direct AS (
+			SELECT category_id, COUNT(*) AS total
+			FROM products
+			WHERE deleted_at IS NULL AND category_id IS NOT NULL
+			GROUP BY category_id
+		)
+		SELECT tree.id, tree.parent_id, tree.name, tree.description, tree.depth,
+			COALESCE(direct.total, 0) AS product_count,
+			(
+				SELECT COALESCE(SUM(d.total), 0)
+				FROM tree sub
+				JOIN direct d ON d.category_id = sub.id
+				WHERE tree.id = ANY(sub.path)
+			) AS total_product_count
+		FROM tree
+		LEFT JOIN direct ON direct.category_id = tree.id
+		ORDER BY tree.depth ASC, tree.name ASC, tree.id ASC
+	`).Scan(&nodes).Error
+	return nodes, err
+}
+
+// FindByID busca uma categoria pelo ID
+func (r *GormProductCategoryRepository) FindByID(id uint) (*models.ProductCategory, error) {
+	var category models.ProductCategory
+	if err := r.GetDB().First(&category, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &category, nil
+}
+
+// ExistsByNameExcept verifica se já existe uma categoria com o nome (sem diferenciar maiúsculas) sob o mesmo pai,
+// exceto a categoria com o ID especificado
+func (r *GormProductCategoryRepository) ExistsByNameExcept(parentID *uint, name string, id uint) (bool, error) {
+	query := r.GetDB().Model(&models.ProductCategory{}).Where("LOWER(name) = LOWER(?) AND id != ?", name, id)
+	if parentID == nil {
+		query = query.Where("parent_id IS NULL")
+	} else {
+		query = query.Where("parent_id = ?", *parentID)
+	}
+
+	var count int64
+	err := query.Count(&count).Error
+	return count > 0, err
+}
+
+// IsDescendant verifica se a categoria id é a própria ancestorID ou está em sua subárvore
+func (r *GormProductCategoryRepository) IsDescendant(id, ancestorID uint) (bool, error) {
+	var found bool
+	err := r.GetDB().Raw(`
+		WITH RECURSIVE subtree AS (
+			SELECT id FROM product_categories WHERE id = ? AND deleted_at IS NULL
+			UNION
+			SELECT c.id FROM product_categories c
+			JOIN subtree ON c.parent_id = subtree.id
+			WHERE c.deleted_at IS NULL
+		)
+		SELECT EXISTS (SELECT 1 FROM subtree WHERE id = ?)
+	`, ancestorID, id).Scan(&found).Error
+	return found, err
+}
+
+// CountChildren conta as subcategorias diretas da categoria
+func (r *GormProductCategoryRepository) CountChildren(id uint) (int64, error) {
+	var count int64
+	err := r.GetDB().Model(&models.ProductCategory{}).Where("parent_id = ?", id).Count(&count).Error
+	return count, err
+}
+
+// CountProducts conta os produtos vinculados diretamente à categoria
+func (r *GormProductCategoryRepository) CountProducts(id uint) (int64, error) {
+	var count int64
+	err := r.GetDB().Model(&models.Product{}).Where("category_id = ?", id).Count(&count).Error
+	return count, err
+}
+
+// Create cria uma nova categoria
+func (r *GormProductCategoryRepository) Create(category *models.ProductCategory) error {
+	return r.GetDB().Omit("Parent", "Children", "Products").Create(category).Error
+}
+
+// Update atualiza uma categoria existente
+func (r *GormProductCategoryRepository) Update(category *models.ProductCategory) error {
+	return r.GetDB().Omit("Parent", "Children", "Products").Save(category).Error
+}
+
+// Delete exclui uma categoria (soft delete)
+func (r *GormProductCategoryRepository) Delete(id uint) error {
+	return r.GetDB().Delete(&models.ProductCategory{}, id).Error
+}
+
+// FindChildNameConflicts retorna os nomes das subcategorias diretas da categoria que já existem entre os
+// filhos do pai informado (desconsiderando a própria categoria), o que impede movê-las para esse pai
+func (r *GormProductCategoryRepository) FindChildNameConflicts(id uint, parentID *uint) ([]string, error) {
+	var names []string
+	err := r.GetDB().Model(&models.ProductCategory{}).
+		Where(`parent_id = ? AND EXISTS (
+			SELECT 1 FROM product_categories sibling
+			WHERE sibling.deleted_at IS NULL AND sibling.id != ?
+				AND sibling.parent_id IS NOT DISTINCT FROM ?
+				AND LOWER(sibling.name) = LOWER(product_categories.name)
+		)`, id, id, parentID).
+		Order("name ASC").
+		Pluck("name", &names).Error
+	return names, err
+}
+
+// ReassignChildren move as subcategorias diretas da categoria para o pai informado
+func (r *GormProductCategoryRepository) ReassignChildren(id uint, parentID *uint) error {
+	return r.GetDB().Model(&models.ProductCategory{}).Where("parent_id = ?", id).Update("parent_id", parentID).Error
+}
+
+// ReassignProducts move os produtos da categoria para a categoria informada (nula deixa os produtos sem categoria)
+func (r *GormProductCategoryRepository) ReassignProducts(id uint, categoryID *uint) error {
+	return r.GetDB().Model(&models.Product{}).Where("category_id = ?", id).Update("category_id", categoryID).Error
+}
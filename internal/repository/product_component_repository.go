@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"simple-erp-service/internal/data-structure/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ProductComponentRepository define as operações de acesso a dados para a composição dos produtos
+type ProductComponentRepository interface {
+	Repository
+	FindByProduct(productID uint) ([]models.ProductComponent, error)
+	FindByProducts(productIDs []uint) ([]models.ProductComponent, error)
+	Replace(productID uint, components []models.ProductComponent) error
+	IsUsedBy(componentID, productID uint) (bool, error)
+	IsComponent(productID uint) (bool, error)
+	FindKitAvailability(productIDs []uint) ([]models.KitAvailability, error)
+}
+
+// GormProductComponentRepository implementa ProductComponentRepository usando GORM
+type GormProductComponentRepository struct {
+	*BaseRepository
+}
+
+// NewProductComponentRepository cria um novo repository da composição dos produtos
+func NewProductComponentRepository(db *gorm.DB) ProductComponentRepository {
+	return &GormProductComponentRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// FindByProduct retorna a composição do produto com os componentes e as suas unidades
+func (r *GormProductComponentRepository) FindByProduct(productID uint) ([]models.ProductComponent, error) {
+	var components []models.ProductComponent
+	err := r.GetDB().Preload("Component.Unit").
+		Where("product_id = ?", productID).
+		Order("id ASC").
+		Find(&components).Error
+	return components, err
+}
+
+// FindByProducts retorna a composição dos produtos informados, com os componentes
+func (r *GormProductComponentRepository) FindByProducts(productIDs []uint) ([]models.ProductComponent, error) {
+	var components []models.ProductComponent
+	if len(productIDs) == 0 {
+		return components, nil
+	}
+	err := r.GetDB().Preload("Component").
+		Where("product_id IN ?", productIDs).
+		Order("id ASC").
+		Find(&components).Error
+	return components, err
+}
+
+// Replace substitui a composição do produto. Os componentes anteriores são removidos definitivamente.
+func (r *GormProductComponentRepository) Replace(productID uint, components []models.ProductComponent) error {
+	return r.GetDB().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("product_id = ?", productID).Delete(&models.ProductComponent{}).Error; err != nil {
+			return err
+		}
+		if len(components) == 0 {
+			return nil
+		}
+		return tx.Omit(clause.Associations).Create(&components).Error
+	})
+}
+
+// IsUsedBy verifica se o componente é o próprio produto ou o usa, direta ou indiretamente, na sua
+// composição. Incluir o componente na composição do produto criaria um ciclo.
+func (r *GormProductComponentRepository) IsUsedBy(componentID, productID uint) (bool, error) {
+	var found bool
+	err := r.GetDB().Raw(`
+		WITH RECURSIVE parts AS (
+			SELECT ?::bigint AS id
+			UNION
+			SELECT pc.component_id FROM product_components pc
+			JOIN parts ON pc.product_id = parts.id
+			WHERE pc.deleted_at IS NULL
+		)
+		SELECT EXISTS (SELECT 1 FROM parts WHERE id = ?)
+	`, componentID, productID).Scan(&found).Error
+	return found, err
+}
+
+// IsComponent verifica se o produto faz parte da composição de algum outro produto
+func (r *GormProductComponentRepository) IsComponent(productID uint) (bool, error) {
+	var count int64
+	err := r.GetDB().Model(&models.ProductComponent{}).Where("component_id = ?", productID).Count(&count).Error
+	return count > 0, err
+}
+
+// FindKitAvailability calcula, para os produtos informados com composição, quantas unidades podem ser
+// montadas com o estoque disponível dos componentes (o estoque atual menos as reservas das vendas
+// pendentes), o menor resultado entre os componentes. Componentes excluídos contam como sem estoque.
+func (r *GormProductComponentRepository) FindKitAvailability(productIDs []uint) ([]models.KitAvailability, error) {
+	var availability []models.KitAvailability
+	if len(productIDs) == 0 {
+		return availability, nil
+	}
+	reserved := r.GetDB().Model(&models.StockReservation{}).
+		Scopes(activeReservations).
+		Select("product_id, SUM(quantity) AS quantity").
+		Group("product_id")
+	err := r.GetDB().Table("product_components pc").
+		Select("pc.product_id, GREATEST(MIN(FLOOR((COALESCE(c.current_stock, 0) - COALESCE(res.quantity, 0)) / pc.quantity)), 0) AS available").
+		Joins("LEFT JOIN products c ON c.id = pc.component_id AND c.deleted_at IS NULL").
+		Joins("LEFT JOIN (?) res ON res.product_id = pc.component_id", reserved).
+		Where("pc.product_id IN ? AND pc.deleted_at IS NULL", productIDs).
+		Group("pc.product_id").
+		Scan(&availability).Error
+	return availability, err
+}
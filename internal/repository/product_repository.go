@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"errors"
+	"simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ProductRepository define as operações de acesso a dados para produtos
+type ProductRepository interface {
+	Repository
+	FindAll(pagination *models.Pagination, filters dto.InGetProductsFilters) ([]models.Product, error)
+	FindByID(id uint) (*models.Product, error)
+	FindByIDs(ids []uint) ([]models.Product, error)
+	Create(product *models.Product) error
+	Update(product *models.Product) error
+	Delete(id uint) error
+	ExistsBySKUExcept(sku string, id uint) (bool, error)
+	ExistsByBarcodeExcept(barcode string, id uint) (bool, error)
+	CategoryExists(id uint) (bool, error)
+	UnitExists(id uint) (bool, error)
+	HasUnitConversions(id uint) (bool, error)
+	FindVariants(parentID uint) ([]models.Product, error)
+	FindVariantSummaries(parentIDs []uint) ([]models.ProductVariantSummary, error)
+	SyncVariants(parent *models.Product) error
+	AddStock(id uint, quantity float64, unitCost *float64) (float64, float64, error)
+}
+
+// GormProductRepository implementa ProductRepository usando GORM
+type GormProductRepository struct {
+	*BaseRepository
+}
+
+// NewProductRepository cria um novo repository de produtos
+func NewProductRepository(db *gorm.DB) ProductRepository {
+	return &GormProductRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// FindAll retorna os produtos com paginação, aplicando a busca textual e os filtros informados
+func (r *GormProductRepository) FindAll(pagination *models.Pagination, filters dto.InGetProductsFilters) ([]models.Product, error) {
+	var products []models.Product
+
+	query := r.GetDB().Model(&models.Product{}).Scopes(
+		TextSearch(filters.Search,
+			[]string{"products.name", "products.sku", "products.barcode"},
+			RelatedSearch{Table: "supplier_products", ForeignKey: "product_id", ParentKey: "products.id", Columns: []string{"supplier_code"}},
+		),
+	)
+	if filters.CategoryID != 0 {
+		query = query.Where("products.category_id = ?", filters.CategoryID)
+	}
+	if filters.SupplierID != 0 {
+		query = query.Where("EXISTS (SELECT 1 FROM supplier_products WHERE supplier_products.product_id = products.id"+
+			" AND supplier_products.supplier_id = ? AND supplier_products.deleted_at IS NULL)", filters.SupplierID)
+	}
+	if filters.IsActive != nil {
+		query = query.Where("products.is_active = ?", *filters.IsActive)
+	}
+	if filters.ParentID != 0 {
+		query = query.Where("products.parent_id = ?", filters.ParentID)
+	} else if filters.HideVariants {
+		query = query.Where("products.parent_id IS NULL")
+	}
+	if filters.LowStock {
+		query = query.Where("products.current_stock <= products.min_stock")
+	}
+
+	query, err := utils.Paginate(&models.Product{}, pagination, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := query.Preload("Category").Preload("Unit").Preload("VariantValues.AttributeValue.Attribute").Find(&products).Error; err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+// FindByID busca um produto pelo ID, carregando a categoria, a unidade e, nas variantes, os valores dos atributos
+func (r *GormProductRepository) FindByID(id uint) (*models.Product, error) {
+	var product models.Product
+	if err := r.GetDB().Preload("Category").Preload("Unit").Preload("VariantValues.AttributeValue.Attribute").First(&product, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &product, nil
+}
+
+// FindByIDs busca os produtos com os IDs informados
+func (r *GormProductRepository) FindByIDs(ids []uint) ([]models.Product, error) {
+	var products []models.Product
+	if len(ids) == 0 {
+		return products, nil
+	}
+	err := r.GetDB().Where("id IN ?", ids).Find(&products).Error
+	return products, err
+}
+
+// Create cria um novo produto
+func (r *GormProductRepository) Create(product *models.Product) error {
+	return r.GetDB().Create(product).Error
+}
+
+// Update atualiza um produto existente, sem alterar o estoque atual
+func (r *GormProductRepository) Update(product *models.Product) error {
+	return r.GetDB().Omit(clause.Associations, "current_stock").Save(product).Error
+}
+
+// Delete exclui um produto (soft delete)
+func (r *GormProductRepository) Delete(id uint) error {
+	return r.GetDB().Delete(&models.Product{}, id).Error
+}
+
+// ExistsBySKUExcept verifica se existe um produto com o SKU especificado, exceto o produto com o ID especificado
+func (r *GormProductRepository) ExistsBySKUExcept(sku string, id uint) (bool, error) {
+	var count int64
+	err := r.GetDB().Unscoped().Model(&models.Product{}).Where("sku = ? AND id != ?", sku, id).Count(&count).Error
+	return count > 0, err
+}
+
+// ExistsByBarcodeExcept verifica se existe um produto com o código de barras especificado, exceto o produto com o ID especificado
+func (r *GormProductRepository) ExistsByBarcodeExcept(barcode string, id uint) (bool, error) {
+	var count int64
+	err := r.GetDB().Unscoped().Model(&models.Product{}).Where("barcode = ? AND id != ?", barcode, id).Count(&count).Error
+	return count > 0, err
+}
+
+// CategoryExists verifica se a categoria de produto existe
+func (r *GormProductRepository) CategoryExists(id uint) (bool, error) {
+	var count int64
+	err := r.GetDB().Model(&models.ProductCategory{}).Where("id = ?", id).Count(&count).Error
+	return count > 0, err
+}
+
+// UnitExists verifica se a unidade de medida existe
+func (r *GormProductRepository) UnitExists(id uint) (bool, error) {
+	var count int64
+	err := r.GetDB().Model(&models.MeasurementUnit{}).Where("id = ?", id).Count(&count).Error
+	return count > 0, err
+}
+
+// HasUnitConversions verifica se o produto possui conversões de unidades exclusivas
+func (r *GormProductRepository) HasUnitConversions(id uint) (bool, error) {
+	var count int64
+	err := r.GetDB().Model(&models.UnitConversion{}).Where("product_id = ?", id).Count(&count).Error
+	return count > 0, err
+}
+
+// FindVariants retorna as variantes do produto com os valores dos atributos
+func (r *GormProductRepository) FindVariants(parentID uint) ([]models.Product, error) {
+	var variants []models.Product
+	err := r.GetDB().
+		Preload("Category").
+		Preload("Unit").
+		Preload("VariantValues.AttributeValue.Attribute").
+		Where("parent_id = ?", parentID).
+		Order("id ASC").
+		Find(&variants).Error
+	return variants, err
+}
+
+// FindVariantSummaries retorna, para os produtos informados que possuem variantes, a quantidade de
+// variantes e o estoque somado delas
+func (r *GormProductRepository) FindVariantSummaries(parentIDs []uint) ([]models.ProductVariantSummary, error) {
+	var summaries []models.ProductVariantSummary
+	if len(parentIDs) == 0 {
+		return summaries, nil
+	}
+	err := r.GetDB().Model(&models.Product{}).
+		Select("parent_id, COUNT(*) AS variants, COALESCE(SUM(current_stock), 0) AS stock").
+		Where("parent_id IN ?", parentIDs).
+		Group("parent_id").
+		Scan(&summaries).Error
+	return summaries, err
+}
+
+// SyncVariants copia para as variantes a categoria do produto pai e, nas variantes sem preço próprio,
+// os preços. O custo só é copiado para as variantes sem estoque: com estoque, vale o custo médio das
+// entradas de cada variante.
+func (r *GormProductRepository) SyncVariants(parent *models.Product) error {
+	err := r.GetDB().Model(&models.Product{}).
+		Where("parent_id = ?", parent.ID).
+		Update("category_id", parent.CategoryID).Error
+	if err != nil {
+		return err
+	}
+	err = r.GetDB().Model(&models.Product{}).
+		Where("parent_id = ? AND price_override = ?", parent.ID, false).
+		Update("selling_price", parent.SellingPrice).Error
+	if err != nil {
+		return err
+	}
+	return r.GetDB().Model(&models.Product{}).
+		Where("parent_id = ? AND price_override = ? AND current_stock = 0", parent.ID, false).
+		Update("cost_price", parent.CostPrice).Error
+}
+
+// AddStock soma a quantidade (negativa nas saídas) ao estoque atual do produto em um único UPDATE,
+// evitando que movimentações simultâneas se sobrescrevam. Com o custo unitário informado, nas entradas com
+// custo, recalcula no mesmo UPDATE o custo médio ponderado pelo estoque anterior; sem estoque anterior, o
+// custo médio passa a ser o custo da entrada. Retorna o novo estoque e o custo médio.
+func (r *GormProductRepository) AddStock(id uint, quantity float64, unitCost *float64) (float64, float64, error) {
+	columns := map[string]interface{}{"current_stock": gorm.Expr("current_stock + ?", quantity)}
+	if unitCost != nil && quantity > 0 {
+		columns["cost_price"] = gorm.Expr(
+			"CASE WHEN current_stock > 0 THEN ROUND((current_stock * cost_price + ? * ?) / (current_stock + ?), 4) ELSE ? END",
+			quantity, *unitCost, quantity, *unitCost,
+		)
+	}
+
+	var product models.Product
+	result := r.GetDB().Model(&product).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "current_stock"}, {Name: "cost_price"}}}).
+		Where("id = ?", id).
+		UpdateColumns(columns)
+	if result.Error != nil {
+		return 0, 0, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return 0, 0, utils.ErrNotFound
+	}
+	return product.CurrentStock, product.CostPrice, nil
+}
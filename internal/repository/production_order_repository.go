@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"errors"
+	"simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ProductionOrderRepository define as operações de acesso a dados para ordens de produção
+type ProductionOrderRepository interface {
+	Repository
+	FindAll(pagination *models.Pagination, filters dto.InGetProductionOrdersFilters) ([]models.ProductionOrder, error)
+	FindByID(id uint) (*models.ProductionOrder, error)
+	Create(order *models.ProductionOrder) error
+	Update(order *models.ProductionOrder) error
+}
+
+// GormProductionOrderRepository implementa ProductionOrderRepository usando GORM
+type GormProductionOrderRepository struct {
+	*BaseRepository
+}
+
+// NewProductionOrderRepository cria um novo repository de ordens de produção
+func NewProductionOrderRepository(db *gorm.DB) ProductionOrderRepository {
+	return &GormProductionOrderRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// FindAll retorna as ordens de produção com paginação, aplicando os filtros informados
+func (r *GormProductionOrderRepository) FindAll(pagination *models.Pagination, filters dto.InGetProductionOrdersFilters) ([]models.ProductionOrder, error) {
+	var orders []models.ProductionOrder
+
+	query := r.GetDB().Model(&models.ProductionOrder{}).Scopes(DateRange("production_orders.created_at", filters.DateFrom, filters.DateTo))
+	if filters.ProductID != 0 {
+		query = query.Where("production_orders.product_id = ?", filters.ProductID)
+	}
+	if filters.Status != "" {
+		query = query.Where("production_orders.status = ?", filters.Status)
+	}
+
+	query, err := utils.Paginate(&models.ProductionOrder{}, pagination, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := query.Preload("Product").Find(&orders).Error; err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+// FindByID busca uma ordem de produção pelo ID, com o produto e os componentes
+func (r *GormProductionOrderRepository) FindByID(id uint) (*models.ProductionOrder, error) {
+	var order models.ProductionOrder
+	err := r.GetDB().
+		Preload("Product").
+		Preload("Components", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Components.Component").
+		Preload("Lot").
+		First(&order, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &order, nil
+}
+
+// Create cria a ordem de produção com os componentes
+func (r *GormProductionOrderRepository) Create(order *models.ProductionOrder) error {
+	return r.GetDB().Omit("Product", "CreatedBy", "Components.Component").Create(order).Error
+}
+
+// Update atualiza os dados da ordem de produção, sem alterar os componentes
+func (r *GormProductionOrderRepository) Update(order *models.ProductionOrder) error {
+	return r.GetDB().Omit(clause.Associations).Save(order).Error
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"errors"
+	"simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PurchaseRepository define as operações de acesso a dados para compras
+type PurchaseRepository interface {
+	Repository
+	FindAll(pagination *models.Pagination, filters dto.InGetPurchasesFilters) ([]models.Purchase, error)
+	FindByID(id uint) (*models.Purchase, error)
+	Create(purchase *models.Purchase) error
+	Update(purchase *models.Purchase) error
+	UpdateItem(item *models.PurchaseItem) error
+	CreateReturn(purchaseReturn *models.PurchaseReturn) error
+	FindReturns(purchaseID uint) ([]models.PurchaseReturn, error)
+}
+
+// GormPurchaseRepository implementa PurchaseRepository usando GORM
+type GormPurchaseRepository struct {
+	*BaseRepository
+}
+
+// NewPurchaseRepository cria um novo repository de compras
+func NewPurchaseRepository(db *gorm.DB) PurchaseRepository {
+	return &GormPurchaseRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// FindAll retorna as compras com paginação, aplicando os filtros informados
+func (r *GormPurchaseRepository) FindAll(pagination *models.Pagination, filters dto.InGetPurchasesFilters) ([]models.Purchase, error) {
+	var purchases []models.Purchase
+
+	query := r.GetDB().Model(&models.Purchase{}).Scopes(DateRange("purchases.purchase_date", filters.DateFrom, filters.DateTo))
+	if filters.SupplierID != 0 {
+		query = query.Where("purchases.supplier_id = ?", filters.SupplierID)
+	}
+	if filters.Status != "" {
+		query = query.Where("purchases.status = ?", filters.Status)
+	}
+
+	query, err := utils.Paginate(&models.Purchase{}, pagination, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := query.Preload("Supplier").Find(&purchases).Error; err != nil {
+		return nil, err
+	}
+
+	return purchases, nil
+}
+
+// FindByID busca uma compra pelo ID, carregando o fornecedor e os itens com os produtos
+func (r *GormPurchaseRepository) FindByID(id uint) (*models.Purchase, error) {
+	var purchase models.Purchase
+	err := r.GetDB().
+		Preload("Supplier").
+		Preload("Location").
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Items.Product").
+		Preload("Items.Unit").
+		First(&purchase, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &purchase, nil
+}
+
+// Create cria uma nova compra junto com os itens
+func (r *GormPurchaseRepository) Create(purchase *models.Purchase) error {
+	return r.GetDB().Omit("Supplier", "Location", "CreatedBy", "Items.Product").Create(purchase).Error
+}
+
+// Update atualiza os dados da compra, sem alterar os itens
+func (r *GormPurchaseRepository) Update(purchase *models.Purchase) error {
+	return r.GetDB().Omit(clause.Associations).Save(purchase).Error
+}
+
+// UpdateItem atualiza um item da compra
+func (r *GormPurchaseRepository) UpdateItem(item *models.PurchaseItem) error {
+	return r.GetDB().Omit(clause.Associations).Save(item).Error
+}
+
+// CreateReturn registra uma devolução ao fornecedor junto com os itens devolvidos
+func (r *GormPurchaseRepository) CreateReturn(purchaseReturn *models.PurchaseReturn) error {
+	return r.GetDB().Omit("Purchase", "CreatedBy", "Items.Product", "Items.Lot").Create(purchaseReturn).Error
+}
+
+// FindReturns retorna as devoluções da compra, da mais recente para a mais antiga
+func (r *GormPurchaseRepository) FindReturns(purchaseID uint) ([]models.PurchaseReturn, error) {
+	var returns []models.PurchaseReturn
+	err := r.GetDB().
+		Preload("Items.Product").
+		Preload("Items.Lot").
+		Where("purchase_id = ?", purchaseID).
+		Order("return_date DESC, id DESC").
+		Find(&returns).Error
+	return returns, err
+}
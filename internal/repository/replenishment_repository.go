@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"simple-erp-service/internal/data-structure/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ReplenishmentRepository define as consultas das sugestões de reposição de estoque
+type ReplenishmentRepository interface {
+	Repository
+	FindCandidates(categoryID uint, productIDs []uint) ([]models.Product, error)
+	FindOpenPurchaseQuantities(productIDs []uint) ([]models.ProductQuantity, error)
+	FindSoldQuantities(productIDs []uint, since time.Time) ([]models.ProductQuantity, error)
+	FindCatalogEntries(productIDs []uint) ([]models.SupplierProduct, error)
+}
+
+// GormReplenishmentRepository implementa ReplenishmentRepository usando GORM
+type GormReplenishmentRepository struct {
+	*BaseRepository
+}
+
+// NewReplenishmentRepository cria um novo repository de reposição de estoque
+func NewReplenishmentRepository(db *gorm.DB) ReplenishmentRepository {
+	return &GormReplenishmentRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// FindCandidates retorna os produtos repostos por compra, com a unidade: produtos simples ativos, sem
+// variantes (o estoque das grades fica nas variantes). Com a categoria ou os IDs informados, somente esses
+// produtos.
+func (r *GormReplenishmentRepository) FindCandidates(categoryID uint, productIDs []uint) ([]models.Product, error) {
+	var products []models.Product
+	query := r.GetDB().Preload("Unit").
+		Where("products.is_active AND products.kind = ?", models.ProductKindSimple).
+		Where("NOT EXISTS (SELECT 1 FROM products variants WHERE variants.parent_id = products.id AND variants.deleted_at IS NULL)")
+	if categoryID != 0 {
+		query = query.Where("products.category_id = ?", categoryID)
+	}
+	if len(productIDs) > 0 {
+		query = query.Where("products.id IN ?", productIDs)
+	}
+	err := query.Order("products.name ASC, products.id ASC").Find(&products).Error
+	return products, err
+}
+
+// FindOpenPurchaseQuantities retorna a quantidade ainda não recebida de cada produto nos pedidos de compra
+// pendentes e em rascunho
+func (r *GormReplenishmentRepository) FindOpenPurchaseQuantities(productIDs []uint) ([]models.ProductQuantity, error) {
+	var quantities []models.ProductQuantity
+	if len(productIDs) == 0 {
+		return quantities, nil
+	}
+	err := r.GetDB().Table("purchase_items").
+		Select("purchase_items.product_id, SUM(purchase_items.quantity - purchase_items.received_quantity) AS quantity").
+		Joins("JOIN purchases ON purchases.id = purchase_items.purchase_id AND purchases.deleted_at IS NULL").
+		Where("purchase_items.deleted_at IS NULL AND purchase_items.product_id IN ?", productIDs).
+		Where("purchases.status IN ?", []string{models.PurchaseStatusDraft, models.PurchaseStatusPending}).
+		Group("purchase_items.product_id").
+		Scan(&quantities).Error
+	return quantities, err
+}
+
+// FindSoldQuantities retorna a quantidade vendida de cada produto desde a data informada, pelas saídas de
+// estoque das vendas. Os kits vendidos contam nos componentes.
+func (r *GormReplenishmentRepository) FindSoldQuantities(productIDs []uint, since time.Time) ([]models.ProductQuantity, error) {
+	var quantities []models.ProductQuantity
+	if len(productIDs) == 0 {
+		return quantities, nil
+	}
+	err := r.GetDB().Model(&models.InventoryMovement{}).
+		Select("product_id, -SUM(quantity) AS quantity").
+		Where("reference_type = ? AND product_id IN ? AND created_at >= ?", models.MovementReferenceSale, productIDs, since).
+		Group("product_id").
+		Scan(&quantities).Error
+	return quantities, err
+}
+
+// FindCatalogEntries retorna os itens do catálogo dos fornecedores ativos para os produtos, com os
+// fornecedores
+func (r *GormReplenishmentRepository) FindCatalogEntries(productIDs []uint) ([]models.SupplierProduct, error) {
+	var items []models.SupplierProduct
+	if len(productIDs) == 0 {
+		return items, nil
+	}
+	err := r.GetDB().Preload("Supplier").
+		Joins("JOIN suppliers ON suppliers.id = supplier_products.supplier_id AND suppliers.deleted_at IS NULL AND suppliers.is_active").
+		Where("supplier_products.product_id IN ?", productIDs).
+		Order("supplier_products.id ASC").
+		Find(&items).Error
+	return items, err
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"errors"
+	"simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SaleRepository define as operações de acesso a dados das vendas
+type SaleRepository interface {
+	Repository
+	FindAll(pagination *models.Pagination, filters dto.InGetSalesFilters) ([]models.Sale, error)
+	FindByID(id uint) (*models.Sale, error)
+	NextID() (uint, error)
+	Create(sale *models.Sale) error
+	Update(sale *models.Sale) error
+	UpdateItemCost(itemID uint, unitCost, costAmount float64) error
+	SumPendingCredit(customerID uint) (float64, error)
+	ClearCustomerNotes(customerID uint) (int64, error)
+}
+
+// GormSaleRepository implementa SaleRepository usando GORM
+type GormSaleRepository struct {
+	*BaseRepository
+}
+
+// NewSaleRepository cria um novo repository de vendas
+func NewSaleRepository(db *gorm.DB) SaleRepository {
+	return &GormSaleRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// FindAll retorna as vendas com paginação, aplicando os filtros informados
+func (r *GormSaleRepository) FindAll(pagination *models.Pagination, filters dto.InGetSalesFilters) ([]models.Sale, error) {
+	var sales []models.Sale
+
+	query := r.GetDB().Model(&models.Sale{}).Scopes(DateRange("sales.sale_date", filters.DateFrom, filters.DateTo))
+	if filters.CustomerID != 0 {
+		query = query.Where("sales.customer_id = ?", filters.CustomerID)
+	}
+	if filters.Status != "" {
+		query = query.Where("sales.status = ?", filters.Status)
+	}
+
+	query, err := utils.Paginate(&models.Sale{}, pagination, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := query.Preload("Customer").Find(&sales).Error; err != nil {
+		return nil, err
+	}
+
+	return sales, nil
+}
+
+// FindByID busca uma venda pelo ID, carregando o cliente e os itens com os produtos
+func (r *GormSaleRepository) FindByID(id uint) (*models.Sale, error) {
+	var sale models.Sale
+	err := r.GetDB().
+		Preload("Customer").
+		Preload("Location").
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Items.Product").
+		Preload("Items.Unit").
+		First(&sale, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &sale, nil
+}
+
+// NextID reserva o próximo ID da tabela de vendas, usado para numerar a venda antes de gravá-la
+func (r *GormSaleRepository) NextID() (uint, error) {
+	var id uint
+	err := r.GetDB().Raw("SELECT nextval(pg_get_serial_sequence('sales', 'id'))").Scan(&id).Error
+	return id, err
+}
+
+// Create cria uma nova venda junto com os itens
+func (r *GormSaleRepository) Create(sale *models.Sale) error {
+	return r.GetDB().Omit("Customer", "PaymentMethod", "Location", "CreatedBy", "CreditApprovedBy", "Items.Product", "Items.Unit").Create(sale).Error
+}
+
+// Update atualiza os dados da venda, sem alterar os itens
+func (r *GormSaleRepository) Update(sale *models.Sale) error {
+	return r.GetDB().Omit(clause.Associations).Save(sale).Error
+}
+
+// UpdateItemCost registra o custo das mercadorias vendidas no item da venda
+func (r *GormSaleRepository) UpdateItemCost(itemID uint, unitCost, costAmount float64) error {
+	return r.GetDB().Model(&models.SaleItem{}).
+		Where("id = ?", itemID).
+		Updates(map[string]interface{}{"unit_cost": unitCost, "cost_amount": costAmount}).Error
+}
+
+// SumPendingCredit retorna o valor das vendas a prazo do cliente, aprovadas ou aguardando aprovação, que ainda
+// não foram faturadas e, por isso, ainda não têm título a receber
+func (r *GormSaleRepository) SumPendingCredit(customerID uint) (float64, error) {
+	var total float64
+	err := r.GetDB().Model(&models.Sale{}).
+		Select("COALESCE(SUM(final_amount), 0)").
+		Where("customer_id = ? AND is_credit AND status = ? AND credit_status IN ?",
+			customerID, models.SaleStatusPending, []string{models.CreditStatusApproved, models.CreditStatusApprovalRequired}).
+		Scan(&total).Error
+	return total, err
+}
+
+// ClearCustomerNotes apaga as observações das vendas do cliente, inclusive as excluídas logicamente.
+// Retorna quantas vendas foram alteradas.
+func (r *GormSaleRepository) ClearCustomerNotes(customerID uint) (int64, error) {
+	result := r.GetDB().Unscoped().Model(&models.Sale{}).
+		Where("customer_id = ? AND notes <> ''", customerID).
+		UpdateColumn("notes", "")
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,229 @@
+package repository
+
+import (
+	"simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/utils/brdoc"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// UnaccentFunction é a função imutável criada nas migrações que envolve o unaccent do Postgres.
+// O unaccent nativo não é IMMUTABLE e por isso não pode ser usado em índices de expressão.
+const UnaccentFunction = "f_unaccent"
+
+// RelatedSearch descreve uma tabela filha (ex: contatos) cujas colunas também participam da busca textual
+type RelatedSearch struct {
+	Table      string   // Tabela filha (ex: contact)
+	ForeignKey string   // Coluna da tabela filha que aponta para a tabela pai (ex: customer_id)
+	ParentKey  string   // Coluna da tabela pai (ex: customers.id)
+	Columns    []string // Colunas da tabela filha pesquisadas
+}
+
+// UnaccentMatch retorna a condição de comparação sem acentos e sem diferenciar maiúsculas para a expressão informada
+func UnaccentMatch(expression string) string {
+	return UnaccentFunction + "(" + expression + ") ILIKE " + UnaccentFunction + "(?)"
+}
+
+// ContainsPattern monta o padrão de ILIKE "contém", escapando os curingas digitados pelo usuário
+func ContainsPattern(term string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return "%" + replacer.Replace(strings.TrimSpace(term)) + "%"
+}
+
+// ContainsAny monta a condição de ILIKE "contém" da expressão com qualquer um dos termos, e seus argumentos
+func ContainsAny(expression string, terms []string) (string, []interface{}) {
+	conditions := make([]string, 0, len(terms))
+	args := make([]interface{}, 0, len(terms))
+	for _, term := range terms {
+		conditions = append(conditions, expression+" ILIKE ?")
+		args = append(args, ContainsPattern(term))
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")", args
+}
+
+// TextSearch aplica a busca textual sem acentos (coberta pelos índices trigram) nas colunas da
+// tabela principal e, via EXISTS, nas colunas das tabelas relacionadas. Termos vazios não filtram nada.
+func TextSearch(term string, columns []string, related ...RelatedSearch) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if strings.TrimSpace(term) == "" {
+			return db
+		}
+		condition, args := textSearchCondition(term, columns, related)
+		return db.Where(condition, args...)
+	}
+}
+
+// textSearchCondition monta a condição OR da busca textual e seus argumentos
+func textSearchCondition(term string, columns []string, related []RelatedSearch) (string, []interface{}) {
+	pattern := ContainsPattern(term)
+	conditions := make([]string, 0, len(columns)+len(related))
+	args := make([]interface{}, 0, len(columns)+len(related))
+
+	for _, column := range columns {
+		conditions = append(conditions, UnaccentMatch(column))
+		args = append(args, pattern)
+	}
+
+	for _, rel := range related {
+		matches := make([]string, 0, len(rel.Columns))
+		for _, column := range rel.Columns {
+			matches = append(matches, UnaccentMatch(rel.Table+"."+column))
+			args = append(args, pattern)
+		}
+		conditions = append(conditions,
+			"EXISTS (SELECT 1 FROM "+rel.Table+
+				" WHERE "+rel.Table+"."+rel.ForeignKey+" = "+rel.ParentKey+
+				" AND "+rel.Table+".deleted_at IS NULL"+
+				" AND ("+strings.Join(matches, " OR ")+"))")
+	}
+
+	return "(" + strings.Join(conditions, " OR ") + ")", args
+}
+
+// DateRange filtra a coluna pelo intervalo de datas informado. As datas zeradas são ignoradas
+// e a data final é inclusiva (considera o dia inteiro).
+func DateRange(column string, from, to time.Time) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if !from.IsZero() {
+			db = db.Where(column+" >= ?", from)
+		}
+		if !to.IsZero() {
+			db = db.Where(column+" < ?", to.AddDate(0, 0, 1))
+		}
+		return db
+	}
+}
+
+// AddressLocation filtra registros que possuam ao menos um endereço na cidade e/ou estado informados.
+// O estado pode ser informado pelo ID ou pela sigla (UF).
+func AddressLocation(foreignKey, parentKey string, cityID, stateID uint, uf string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if cityID == 0 && stateID == 0 && uf == "" {
+			return db
+		}
+
+		sub := db.Session(&gorm.Session{NewDB: true}).
+			Table("address").
+			Select("1").
+			Joins("JOIN city ON city.id = address.city_id").
+			Where("address." + foreignKey + " = " + parentKey).
+			Where("address.deleted_at IS NULL")
+
+		if cityID != 0 {
+			sub = sub.Where("address.city_id = ?", cityID)
+		}
+		if stateID != 0 {
+			sub = sub.Where("city.state_id = ?", stateID)
+		}
+		if uf != "" {
+			sub = sub.Joins("JOIN state ON state.id = city.state_id").
+				Where("state.uf = ?", strings.ToUpper(uf))
+		}
+
+		return db.Where("EXISTS (?)", sub)
+	}
+}
+
+// PartyTable descreve a tabela principal de um cadastro (clientes ou fornecedores) para os filtros das listagens
+type PartyTable struct {
+	Table             string // Tabela principal (ex: customers)
+	ForeignKey        string // Coluna que aponta para a tabela principal nas tabelas filhas (ex: customer_id)
+	EncryptedDocument bool   // document_number criptografado: aceita somente a busca exata pelo índice cego
+}
+
+// Cadastros com listagem filtrada por PartyFilters
+var (
+	CustomerParty = PartyTable{Table: "customers", ForeignKey: "customer_id", EncryptedDocument: true}
+	SupplierParty = PartyTable{Table: "suppliers", ForeignKey: "supplier_id"}
+)
+
+// PartyFilters aplica os filtros comuns das listagens de clientes e fornecedores
+func PartyFilters(party PartyTable, filters dto.InGetPartiesFilters) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		table := party.Table
+		parentKey := table + ".id"
+
+		if term := strings.TrimSpace(filters.Search); term != "" {
+			columns := []string{table + ".first_name || ' ' || " + table + ".last_name", table + ".company_name"}
+			if !party.EncryptedDocument {
+				columns = append(columns, table+".document_number")
+			}
+			contactNames := RelatedSearch{Table: "contact", ForeignKey: party.ForeignKey, ParentKey: parentKey, Columns: []string{"name"}}
+			condition, args := textSearchCondition(term, columns, []RelatedSearch{contactNames})
+			conditions := []string{condition}
+
+			// Os contatos são criptografados: a busca é exata, pelo índice cego do termo normalizado como na gravação
+			contactIndexes, err := contactSearchIndexes(term)
+			if err != nil {
+				db.AddError(err)
+				return db
+			}
+			conditions = append(conditions, "EXISTS (SELECT 1 FROM contact WHERE contact."+party.ForeignKey+" = "+parentKey+
+				" AND contact.deleted_at IS NULL AND contact.contact_index IN ?)")
+			args = append(args, contactIndexes)
+
+			// Documentos são gravados sem máscara, então o termo também é buscado limpo
+			if cleaned := brdoc.Clean(term); isMaskedNumber(term) {
+				if party.EncryptedDocument {
+					index, err := blindIndex(cleaned)
+					if err != nil {
+						db.AddError(err)
+						return db
+					}
+					conditions = append(conditions, table+".document_number_index = ?")
+					args = append(args, index)
+				} else if cleaned != term {
+					conditions = append(conditions, UnaccentMatch(table+".document_number"))
+					args = append(args, ContainsPattern(cleaned))
+				}
+			}
+
+			db = db.Where("("+strings.Join(conditions, " OR ")+")", args...)
+		}
+
+		if filters.PersonType != "" {
+			db = db.Where(table+".person_type = ?", filters.PersonType)
+		}
+		if filters.IsActive != nil {
+			db = db.Where(table+".is_active = ?", *filters.IsActive)
+		}
+
+		db = AddressLocation(party.ForeignKey, parentKey, filters.CityID, filters.StateID, filters.UF)(db)
+		return DateRange(table+".created_at", filters.CreatedFrom, filters.CreatedTo)(db)
+	}
+}
+
+// contactSearchIndexes retorna os índices cegos do termo normalizado como email e, se parecer um número, como telefone
+func contactSearchIndexes(term string) ([]string, error) {
+	values := []string{strings.ToLower(term)}
+	if phone := brdoc.NormalizePhone(term); phone != "" && isMaskedNumber(term) && phone != values[0] {
+		values = append(values, phone)
+	}
+
+	indexes := make([]string, 0, len(values))
+	for _, value := range values {
+		index, err := blindIndex(value)
+		if err != nil {
+			return nil, err
+		}
+		indexes = append(indexes, index)
+	}
+	return indexes, nil
+}
+
+// isMaskedNumber informa se o termo parece um documento ou telefone digitado com máscara
+// (somente dígitos, letras e os separadores . - / ( ) e espaço, com ao menos um dígito)
+func isMaskedNumber(term string) bool {
+	if !strings.ContainsAny(term, "0123456789") {
+		return false
+	}
+	for _, r := range term {
+		isAlnum := (r >= '0' && r <= '9') || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+		if !isAlnum && !strings.ContainsRune(".-/() ", r) {
+			return false
+		}
+	}
+	return true
+}
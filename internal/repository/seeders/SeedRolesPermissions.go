@@ -35,6 +35,10 @@ func SeedRolesPermissions(db *gorm.DB) {
 			{Permission: "sales.reports", Description: "Gerar relatórios de vendas", Module: "sales"},
 			// Novas permissões para módulos de vendas (ex: clientes, planos de pagamento)
 			{Permission: "customers.view", Description: "Visualizar clientes", Module: "sales.cadastros"},
+			{Permission: "customers.merge", Description: "Buscar e mesclar clientes duplicados", Module: "customers"},
+			{Permission: "customers.lgpd", Description: "Exportar e anonimizar dados de clientes (LGPD)", Module: "customers"},
+			{Permission: "documents.view", Description: "Visualizar documentos vencidos e a vencer de clientes e fornecedores", Module: "documents"},
+			{Permission: "documents.alerts", Description: "Receber alertas de vencimento de documentos", Module: "documents"},
 			{Permission: "payment_plans.view", Description: "Visualizar planos de pagamento", Module: "sales.cadastros"},
 			{Permission: "orders.view", Description: "Visualizar pedidos de vendas", Module: "sales"},
 
@@ -44,10 +48,28 @@ func SeedRolesPermissions(db *gorm.DB) {
 			{Permission: "inventory.edit", Description: "Editar itens do estoque", Module: "inventory"},
 			{Permission: "inventory.delete", Description: "Remover itens do estoque", Module: "inventory"},
 			{Permission: "inventory.reports", Description: "Gerar relatórios de estoque", Module: "inventory"},
+			{Permission: "purchases.view", Description: "Visualizar compras", Module: "inventory"},
+			{Permission: "purchases.create", Description: "Criar pedidos de compra", Module: "inventory"},
+			{Permission: "purchases.edit", Description: "Editar e cancelar pedidos de compra", Module: "inventory"},
+			{Permission: "purchases.receive", Description: "Receber compras no estoque", Module: "inventory"},
+			{Permission: "purchases.return", Description: "Registrar devoluções ao fornecedor", Module: "inventory"},
+			{Permission: "purchases.reports", Description: "Visualizar o desempenho e o ranking de fornecedores", Module: "inventory"},
+			{Permission: "production_orders.view", Description: "Visualizar ordens de produção", Module: "inventory"},
+			{Permission: "production_orders.manage", Description: "Abrir, concluir e cancelar ordens de produção", Module: "inventory"},
+			{Permission: "inventory_counts.view", Description: "Visualizar inventários", Module: "inventory"},
+			{Permission: "inventory_counts.count", Description: "Registrar contagens de inventário", Module: "inventory"},
+			{Permission: "inventory_counts.manage", Description: "Abrir, aprovar e cancelar inventários", Module: "inventory"},
 			// Novas permissões para módulos de estoque (ex: produtos, fornecedores, locais)
 			{Permission: "products.view", Description: "Visualizar produtos", Module: "inventory.cadastros"},
+			{Permission: "products.create", Description: "Cadastrar produtos", Module: "inventory.cadastros"},
+			{Permission: "products.edit", Description: "Editar produtos", Module: "inventory.cadastros"},
+			{Permission: "products.delete", Description: "Excluir produtos", Module: "inventory.cadastros"},
+			{Permission: "product_categories.edit", Description: "Criar, mover e excluir categorias de produtos", Module: "inventory.cadastros"},
+			{Permission: "units.edit", Description: "Gerenciar conversões de unidades de medida", Module: "inventory.cadastros"},
 			{Permission: "supplier_codes.view", Description: "Visualizar códigos por fornecedor", Module: "inventory.cadastros"},
+			{Permission: "supplier_codes.edit", Description: "Editar o catálogo de produtos dos fornecedores", Module: "inventory.cadastros"},
 			{Permission: "stock_locations.view", Description: "Visualizar locais de estoque", Module: "inventory.cadastros"},
+			{Permission: "stock_locations.edit", Description: "Cadastrar e editar locais de estoque", Module: "inventory.cadastros"},
 			{Permission: "product_location.view", Description: "Visualizar localização de produtos", Module: "inventory.cadastros"},
 			{Permission: "prices_promotions.view", Description: "Visualizar preços e promoções", Module: "inventory.cadastros"},
 			{Permission: "taxation.view", Description: "Visualizar tributação", Module: "inventory.cadastros"},
@@ -58,6 +80,8 @@ func SeedRolesPermissions(db *gorm.DB) {
 			{Permission: "finance.edit", Description: "Editar transações financeiras", Module: "finance"},
 			{Permission: "finance.delete", Description: "Excluir transações financeiras", Module: "finance"},
 			{Permission: "finance.reports", Description: "Gerar relatórios financeiros", Module: "finance"},
+			{Permission: "customers.credit", Description: "Definir limite de crédito e bloqueio de clientes", Module: "finance"},
+			{Permission: "finance.credit_approve", Description: "Aprovar vendas a prazo acima do limite de crédito", Module: "finance"},
 			// Novas permissões Financeiro granular (receber boleto, ver pendências)
 			{Permission: "finance.receive_boleto", Description: "Permissão para receber boleto financeiro", Module: "finance.contas_a_receber"},
 			{Permission: "finance.view_pendencies", Description: "Visualizar pendências financeiras", Module: "finance.contas_a_receber"},
@@ -79,6 +103,7 @@ func SeedRolesPermissions(db *gorm.DB) {
 			{Permission: "users.create", Description: "Criar usuários", Module: "users"},
 			{Permission: "users.edit", Description: "Editar usuários", Module: "users"},
 			{Permission: "users.delete", Description: "Excluir usuários", Module: "users"},
+			{Permission: "users.impersonate", Description: "Personificar usuários para suporte", Module: "users"},
 
 			// Permissões
 			{Permission: "permissions.view", Description: "Visualizar Permissões", Module: "permissions"},
@@ -138,6 +163,8 @@ func SeedRolesPermissions(db *gorm.DB) {
 			// Adicionar permissões de dashboard específicas do gerente
 			assignPermissionToRole(tx, managerRole.ID, "dashboard.manager.view")
 			assignPermissionToRole(tx, managerRole.ID, "dashboard.view_default")
+			assignPermissionToRole(tx, managerRole.ID, "documents.alerts")
+			assignPermissionToRole(tx, managerRole.ID, "finance.credit_approve")
 		}
 
 		// VENDAS: Todas as permissões do módulo 'sales' + dashboards de vendas/default + financeiro granular
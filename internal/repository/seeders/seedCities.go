@@ -3,26 +3,29 @@ package seeders
 import (
 	"log"
 
-	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/service"
+	"simple-erp-service/internal/utils/ibge"
 
 	"gorm.io/gorm"
 )
 
+// SeedCities importa os municípios do conjunto embutido no binário, sem acessar a rede. Para atualizar a
+// tabela direto do IBGE, use o comando cmd/import-ibge (-api ou -file com a planilha da DTB).
 func SeedCities(db *gorm.DB) {
-	var sp models.State
-	if err := db.Where("uf = ?", "SP").First(&sp).Error; err != nil {
-		log.Fatal("Estado de São Paulo não encontrado, execute SeedStates primeiro!")
+	municipalities, err := ibge.Embedded()
+	if err != nil {
+		log.Printf("Erro ao ler os municípios embutidos: %v", err)
+		return
 	}
 
-	cities := []models.City{
-		{Name: "São Paulo", IBGECode: "3550308", StateID: sp.ID},
-		{Name: "Campinas", IBGECode: "3509502", StateID: sp.ID},
-		{Name: "Santos", IBGECode: "3548500", StateID: sp.ID},
+	locationService := service.NewLocationService(repository.NewLocationRepository(db))
+	result, err := locationService.ImportMunicipalities(municipalities)
+	if err != nil {
+		log.Printf("Erro ao inserir cidades: %v", err)
+		return
 	}
-
-	for _, city := range cities {
-		if err := db.Where("ibge_code = ?", city.IBGECode).FirstOrCreate(&city).Error; err != nil {
-			log.Printf("Erro ao inserir cidade %s: %v", city.Name, err)
-		}
+	if len(result.MissingUFs) > 0 {
+		log.Printf("%d cidades ignoradas: estados não encontrados (códigos IBGE %v), execute SeedStates primeiro!", result.Skipped, result.MissingUFs)
 	}
 }
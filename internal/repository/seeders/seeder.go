@@ -10,7 +10,7 @@ func RunAll(db *gorm.DB) {
 	SeedCities(db)
 
 	SeedRolesPermissions(db)
-	//SeedMeasurementUnit(db)
+	SeedMeasurementUnit(db)
 	//SeedPaymentMethod(db)
-	//SeedProductCategory(db)
+	SeedProductCategory(db)
 }
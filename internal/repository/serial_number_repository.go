@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"errors"
+	"simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/utils"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SerialNumberRepository define as operações de acesso a dados para números de série e o seu histórico
+type SerialNumberRepository interface {
+	Repository
+	FindAll(pagination *models.Pagination, filters dto.InGetSerialNumbersFilters) ([]models.SerialNumber, error)
+	FindByID(id uint) (*models.SerialNumber, error)
+	FindByProductAndSerialsForUpdate(productID uint, serials []string) ([]models.SerialNumber, error)
+	Create(serial *models.SerialNumber) error
+	Update(serial *models.SerialNumber) error
+	CreateEvents(events []models.SerialNumberEvent) error
+	ReassignCustomer(fromID, toID uint) (int64, error)
+	ClearCustomerEventNotes(customerID uint) (int64, error)
+}
+
+// GormSerialNumberRepository implementa SerialNumberRepository usando GORM
+type GormSerialNumberRepository struct {
+	*BaseRepository
+}
+
+// NewSerialNumberRepository cria um novo repository de números de série
+func NewSerialNumberRepository(db *gorm.DB) SerialNumberRepository {
+	return &GormSerialNumberRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// FindAll retorna uma lista paginada e filtrada de números de série, com o produto e o local
+func (r *GormSerialNumberRepository) FindAll(pagination *models.Pagination, filters dto.InGetSerialNumbersFilters) ([]models.SerialNumber, error) {
+	var serials []models.SerialNumber
+
+	query := r.GetDB().Model(&models.SerialNumber{})
+	if filters.Serial != "" {
+		query = query.Where("serial ILIKE ?", "%"+filters.Serial+"%")
+	}
+	if filters.ProductID != 0 {
+		query = query.Where("product_id = ?", filters.ProductID)
+	}
+	if filters.Status != "" {
+		query = query.Where("status = ?", filters.Status)
+	}
+	if filters.LocationID != 0 {
+		query = query.Where("status = ? AND location_id = ?", models.SerialStatusInStock, filters.LocationID)
+	}
+	if filters.CustomerID != 0 {
+		query = query.Where("customer_id = ?", filters.CustomerID)
+	}
+
+	query, err := utils.Paginate(&models.SerialNumber{}, pagination, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := query.Preload("Product").Preload("Location").Find(&serials).Error; err != nil {
+		return nil, err
+	}
+
+	return serials, nil
+}
+
+// FindByID busca um número de série pelo ID, com o produto, o local e o histórico em ordem cronológica
+func (r *GormSerialNumberRepository) FindByID(id uint) (*models.SerialNumber, error) {
+	var serial models.SerialNumber
+	err := r.GetDB().
+		Preload("Product").
+		Preload("Location").
+		Preload("Events", func(db *gorm.DB) *gorm.DB { return db.Order("created_at ASC, id ASC") }).
+		Preload("Events.Location").
+		First(&serial, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &serial, nil
+}
+
+// FindByProductAndSerialsForUpdate busca os números de série informados do produto, bloqueando-os até o fim
+// da transação
+func (r *GormSerialNumberRepository) FindByProductAndSerialsForUpdate(productID uint, serials []string) ([]models.SerialNumber, error) {
+	var found []models.SerialNumber
+	if len(serials) == 0 {
+		return found, nil
+	}
+	err := r.GetDB().
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("product_id = ? AND serial IN ?", productID, serials).
+		Find(&found).Error
+	return found, err
+}
+
+// Create cria um número de série
+func (r *GormSerialNumberRepository) Create(serial *models.SerialNumber) error {
+	return r.GetDB().Omit(clause.Associations).Create(serial).Error
+}
+
+// Update atualiza um número de série
+func (r *GormSerialNumberRepository) Update(serial *models.SerialNumber) error {
+	return r.GetDB().Omit(clause.Associations).Save(serial).Error
+}
+
+// CreateEvents registra eventos no histórico dos números de série
+func (r *GormSerialNumberRepository) CreateEvents(events []models.SerialNumberEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return r.GetDB().Omit(clause.Associations).Create(&events).Error
+}
+
+// ReassignCustomer transfere para outro cliente os números de série vendidos ao cliente e os eventos do
+// histórico, na mesclagem de clientes duplicados. Retorna quantos números de série foram transferidos.
+func (r *GormSerialNumberRepository) ReassignCustomer(fromID, toID uint) (int64, error) {
+	result := r.GetDB().Model(&models.SerialNumber{}).Where("customer_id = ?", fromID).Update("customer_id", toID)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	err := r.GetDB().Model(&models.SerialNumberEvent{}).Where("customer_id = ?", fromID).Update("customer_id", toID).Error
+	return result.RowsAffected, err
+}
+
+// ClearCustomerEventNotes apaga as observações dos eventos do histórico que envolvem o cliente (vendas e
+// devoluções). Retorna quantos eventos foram alterados.
+func (r *GormSerialNumberRepository) ClearCustomerEventNotes(customerID uint) (int64, error) {
+	result := r.GetDB().Unscoped().Model(&models.SerialNumberEvent{}).
+		Where("customer_id = ? AND notes <> ''", customerID).
+		UpdateColumn("notes", "")
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"errors"
+	"simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+// StockLocationRepository define as operações de acesso a dados para locais de estoque, saldos por local e
+// transferências entre locais
+type StockLocationRepository interface {
+	Repository
+	FindAll(filters dto.InGetStockLocationsFilters) ([]models.StockLocation, error)
+	FindByID(id uint) (*models.StockLocation, error)
+	FindDefault() (*models.StockLocation, error)
+	ExistsByCodeExcept(code string, id uint) (bool, error)
+	HasChildren(id uint) (bool, error)
+	HasBalance(id uint) (bool, error)
+	Create(location *models.StockLocation) error
+	Update(location *models.StockLocation) error
+	AddBalance(productID, locationID uint, quantity float64) (float64, error)
+	FindBalance(productID, locationID uint) (float64, error)
+	FindBalancesByProduct(productID uint) ([]models.StockBalance, error)
+	FindBalancesByLocation(locationID uint) ([]models.StockBalance, error)
+	FindTransfers(pagination *models.Pagination, filters dto.InGetStockTransfersFilters) ([]models.StockTransfer, error)
+	FindTransferByID(id uint) (*models.StockTransfer, error)
+	CreateTransfer(transfer *models.StockTransfer) error
+}
+
+// GormStockLocationRepository implementa StockLocationRepository usando GORM
+type GormStockLocationRepository struct {
+	*BaseRepository
+}
+
+// NewStockLocationRepository cria um novo repository de locais de estoque
+func NewStockLocationRepository(db *gorm.DB) StockLocationRepository {
+	return &GormStockLocationRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// FindAll retorna os locais de estoque ordenados pelo código, com o depósito dos endereços
+func (r *GormStockLocationRepository) FindAll(filters dto.InGetStockLocationsFilters) ([]models.StockLocation, error) {
+	var locations []models.StockLocation
+
+	query := r.GetDB().Preload("Parent")
+	if filters.IsActive != nil {
+		query = query.Where("is_active = ?", *filters.IsActive)
+	}
+	if filters.ParentID != nil {
+		query = query.Where("parent_id = ?", *filters.ParentID)
+	}
+
+	err := query.Order("code ASC").Find(&locations).Error
+	return locations, err
+}
+
+// FindByID busca um local de estoque pelo ID
+func (r *GormStockLocationRepository) FindByID(id uint) (*models.StockLocation, error) {
+	var location models.StockLocation
+	if err := r.GetDB().Preload("Parent").First(&location, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &location, nil
+}
+
+// FindDefault busca o local de estoque padrão
+func (r *GormStockLocationRepository) FindDefault() (*models.StockLocation, error) {
+	var location models.StockLocation
+	if err := r.GetDB().Where("is_default = ?", true).First(&location).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &location, nil
+}
+
+// ExistsByCodeExcept verifica se existe outro local com o código informado, incluindo os excluídos
+func (r *GormStockLocationRepository) ExistsByCodeExcept(code string, id uint) (bool, error) {
+	var count int64
+	err := r.GetDB().Unscoped().Model(&models.StockLocation{}).
+		Where("code = ? AND id <> ?", code, id).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// HasChildren verifica se o local possui endereços
+func (r *GormStockLocationRepository) HasChildren(id uint) (bool, error) {
+	var count int64
+	err := r.GetDB().Model(&models.StockLocation{}).Where("parent_id = ?", id).Count(&count).Error
+	return count > 0, err
+}
+
+// HasBalance verifica se algum produto possui saldo no local
+func (r *GormStockLocationRepository) HasBalance(id uint) (bool, error) {
+	var count int64
+	err := r.GetDB().Model(&models.StockBalance{}).Where("location_id = ? AND quantity <> 0", id).Count(&count).Error
+	return count > 0, err
+}
+
+// Create cria um local de estoque. Um novo local padrão substitui o anterior.
+func (r *GormStockLocationRepository) Create(location *models.StockLocation) error {
+	return r.GetDB().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Parent").Create(location).Error; err != nil {
+			return err
+		}
+		return unsetOtherDefaults(tx, location)
+	})
+}
+
+// Update atualiza um local de estoque. Um novo local padrão substitui o anterior.
+func (r *GormStockLocationRepository) Update(location *models.StockLocation) error {
+	return r.GetDB().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Parent").Save(location).Error; err != nil {
+			return err
+		}
+		return unsetOtherDefaults(tx, location)
+	})
+}
+
+// unsetOtherDefaults desmarca os demais locais padrão quando o local informado é o padrão
+func unsetOtherDefaults(tx *gorm.DB, location *models.StockLocation) error {
+	if !location.IsDefault {
+		return nil
+	}
+	return tx.Model(&models.StockLocation{}).
+		Where("id <> ? AND is_default = ?", location.ID, true).
+		Update("is_default", false).Error
+}
+
+// AddBalance soma a quantidade (negativa nas saídas) ao saldo do produto no local, criando o saldo quando
+// ainda não existe, e retorna o novo saldo. A atualização é atômica no banco.
+func (r *GormStockLocationRepository) AddBalance(productID, locationID uint, quantity float64) (float64, error) {
+	var balance float64
+	err := r.GetDB().Raw(`
+		INSERT INTO stock_balances (product_id, location_id, quantity, updated_at)
+		VALUES (?, ?, ?, NOW())
+		ON CONFLICT (product_id, location_id)
+		DO UPDATE SET quantity = stock_balances.quantity + EXCLUDED.quantity, updated_at = NOW()
+		RETURNING quantity
+	`, productID, locationID, quantity).Scan(&balance).Error
+	return balance, err
+}
+
+// FindBalance retorna o saldo do produto no local (zero quando não há saldo registrado)
+func (r *GormStockLocationRepository) FindBalance(productID, locationID uint) (float64, error) {
+	var balance float64
+	err := r.GetDB().Model(&models.StockBalance{}).
+		Select("COALESCE(SUM(quantity), 0)").
+		Where("product_id = ? AND location_id = ?", productID, locationID).
+		Scan(&balance).Error
+	return balance, err
+}
+
+// FindBalancesByProduct retorna os saldos do produto em cada local, com os locais
+func (r *GormStockLocationRepository) FindBalancesByProduct(productID uint) ([]models.StockBalance, error) {
+	var balances []models.StockBalance
+	err := r.GetDB().Preload("Location").
+		Joins("JOIN stock_locations l ON l.id = stock_balances.location_id").
+		Where("stock_balances.product_id = ? AND stock_balances.quantity <> 0", productID).
+		Order("l.code ASC").
+		Find(&balances).Error
+	return balances, err
+}
+
+// FindBalancesByLocation retorna os saldos dos produtos no local, com os produtos
+func (r *GormStockLocationRepository) FindBalancesByLocation(locationID uint) ([]models.StockBalance, error) {
+	var balances []models.StockBalance
+	err := r.GetDB().Preload("Product").
+		Joins("JOIN products p ON p.id = stock_balances.product_id").
+		Where("stock_balances.location_id = ? AND stock_balances.quantity <> 0", locationID).
+		Order("p.name ASC").
+		Find(&balances).Error
+	return balances, err
+}
+
+// FindTransfers retorna as transferências de estoque com paginação, aplicando os filtros informados
+func (r *GormStockLocationRepository) FindTransfers(pagination *models.Pagination, filters dto.InGetStockTransfersFilters) ([]models.StockTransfer, error) {
+	var transfers []models.StockTransfer
+
+	query := r.GetDB().Model(&models.StockTransfer{}).Scopes(DateRange("stock_transfers.created_at", filters.DateFrom, filters.DateTo))
+	if filters.ProductID != 0 {
+		query = query.Where("stock_transfers.product_id = ?", filters.ProductID)
+	}
+	if filters.LocationID != 0 {
+		query = query.Where("(stock_transfers.from_location_id = ? OR stock_transfers.to_location_id = ?)", filters.LocationID, filters.LocationID)
+	}
+
+	query, err := utils.Paginate(&models.StockTransfer{}, pagination, query)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := query.Preload("Product").Preload("FromLocation").Preload("ToLocation").Preload("Lot").Find(&transfers).Error; err != nil {
+		return nil, err
+	}
+
+	return transfers, nil
+}
+
+// FindTransferByID busca uma transferência de estoque pelo ID, com o produto e os locais
+func (r *GormStockLocationRepository) FindTransferByID(id uint) (*models.StockTransfer, error) {
+	var transfer models.StockTransfer
+	err := r.GetDB().Preload("Product").Preload("FromLocation").Preload("ToLocation").Preload("Lot").First(&transfer, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+// CreateTransfer registra uma transferência de estoque
+func (r *GormStockLocationRepository) CreateTransfer(transfer *models.StockTransfer) error {
+	return r.GetDB().Omit("Product", "FromLocation", "ToLocation", "CreatedBy", "Lot").Create(transfer).Error
+}
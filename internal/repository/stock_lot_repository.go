@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"errors"
+	"simple-erp-service/internal/data-structure/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// StockLotRepository define as operações de acesso a dados para lotes, saldos por lote e a rastreabilidade
+// dos lotes vendidos
+type StockLotRepository interface {
+	Repository
+	FindByID(id uint) (*models.StockLot, error)
+	FindByProduct(productID uint) ([]models.StockLot, error)
+	FindByProductAndCode(productID uint, code string) (*models.StockLot, error)
+	Create(lot *models.StockLot) error
+	Update(lot *models.StockLot) error
+	AddBalance(lotID, locationID uint, quantity float64) (float64, error)
+	FindBalance(lotID, locationID uint) (float64, error)
+	FindAvailableForUpdate(productID, locationID uint, today time.Time) ([]models.StockLotBalance, error)
+	SumAvailable(productID, locationID uint, today time.Time) (float64, error)
+	FindExpiring(until time.Time, locationID uint) ([]models.StockLotBalance, error)
+	CreateSaleAllocations(allocations []models.SaleItemLot) error
+	FindSaleAllocations(saleID uint) ([]models.SaleItemLot, error)
+	FindLotAllocations(lotID uint) ([]models.SaleItemLot, error)
+	FindLotCustomers(lotID uint) ([]models.LotCustomerSummary, error)
+	ReassignCustomer(fromID, toID uint) (int64, error)
+}
+
+// GormStockLotRepository implementa StockLotRepository usando GORM
+type GormStockLotRepository struct {
+	*BaseRepository
+}
+
+// NewStockLotRepository cria um novo repository de lotes
+func NewStockLotRepository(db *gorm.DB) StockLotRepository {
+	return &GormStockLotRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// FindByID busca um lote pelo ID, com o produto e os saldos por local
+func (r *GormStockLotRepository) FindByID(id uint) (*models.StockLot, error) {
+	var lot models.StockLot
+	err := r.GetDB().
+		Preload("Product").
+		Preload("Balances", "quantity <> 0").
+		Preload("Balances.Location").
+		First(&lot, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &lot, nil
+}
+
+// FindByProduct retorna os lotes do produto por ordem de validade, com os saldos por local
+func (r *GormStockLotRepository) FindByProduct(productID uint) ([]models.StockLot, error) {
+	var lots []models.StockLot
+	err := r.GetDB().
+		Preload("Balances", "quantity <> 0").
+		Preload("Balances.Location").
+		Where("product_id = ?", productID).
+		Order("expires_at ASC NULLS LAST, id ASC").
+		Find(&lots).Error
+	return lots, err
+}
+
+// FindByProductAndCode busca o lote do produto pelo número
+func (r *GormStockLotRepository) FindByProductAndCode(productID uint, code string) (*models.StockLot, error) {
+	var lot models.StockLot
+	if err := r.GetDB().Where("product_id = ? AND code = ?", productID, code).First(&lot).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &lot, nil
+}
+
+// Create cria um lote
+func (r *GormStockLotRepository) Create(lot *models.StockLot) error {
+	return r.GetDB().Omit(clause.Associations).Create(lot).Error
+}
+
+// Update atualiza um lote
+func (r *GormStockLotRepository) Update(lot *models.StockLot) error {
+	return r.GetDB().Omit(clause.Associations).Save(lot).Error
+}
+
+// AddBalance soma a quantidade (negativa nas saídas) ao saldo do lote no local, criando o saldo quando ainda
+// não existe, e retorna o novo saldo. A atualização é atômica no banco.
+func (r *GormStockLotRepository) AddBalance(lotID, locationID uint, quantity float64) (float64, error) {
+	var balance float64
+	err := r.GetDB().Raw(`
+		INSERT INTO stock_lot_balances (lot_id, location_id, quantity, updated_at)
+		VALUES (?, ?, ?, NOW())
+		ON CONFLICT (lot_id, location_id)
+		DO UPDATE SET quantity = stock_lot_balances.quantity + EXCLUDED.quantity, updated_at = NOW()
+		RETURNING quantity
+	`, lotID, locationID, quantity).Scan(&balance).Error
+	return balance, err
+}
+
+// FindBalance retorna o saldo do lote no local (zero quando não há saldo registrado)
+func (r *GormStockLotRepository) FindBalance(lotID, locationID uint) (float64, error) {
+	var balance float64
+	err := r.GetDB().Model(&models.StockLotBalance{}).
+		Select("COALESCE(SUM(quantity), 0)").
+		Where("lot_id = ? AND location_id = ?", lotID, locationID).
+		Scan(&balance).Error
+	return balance, err
+}
+
+// FindAvailableForUpdate retorna os saldos positivos dos lotes não vencidos do produto no local, na ordem
+// de saída FEFO (primeiro o que vence primeiro; lotes sem validade por último), bloqueando-os até o fim da
+// transação
+func (r *GormStockLotRepository) FindAvailableForUpdate(productID, locationID uint, today time.Time) ([]models.StockLotBalance, error) {
+	var balances []models.StockLotBalance
+	err := r.GetDB().
+		Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: "stock_lot_balances"}}).
+		Preload("Lot").
+		Joins("JOIN stock_lots l ON l.id = stock_lot_balances.lot_id AND l.deleted_at IS NULL").
+		Where("l.product_id = ? AND stock_lot_balances.location_id = ? AND stock_lot_balances.quantity > 0", productID, locationID).
+		Where("l.expires_at IS NULL OR l.expires_at >= ?", today).
+		Order("l.expires_at ASC NULLS LAST, l.id ASC").
+		Find(&balances).Error
+	return balances, err
+}
+
+// SumAvailable retorna a soma dos saldos positivos dos lotes não vencidos do produto no local, ou seja, o
+// saldo de que FindAvailableForUpdate pode dar saída
+func (r *GormStockLotRepository) SumAvailable(productID, locationID uint, today time.Time) (float64, error) {
+	var balance float64
+	err := r.GetDB().Model(&models.StockLotBalance{}).
+		Select("COALESCE(SUM(stock_lot_balances.quantity), 0)").
+		Joins("JOIN stock_lots l ON l.id = stock_lot_balances.lot_id AND l.deleted_at IS NULL").
+		Where("l.product_id = ? AND stock_lot_balances.location_id = ? AND stock_lot_balances.quantity > 0", productID, locationID).
+		Where("l.expires_at IS NULL OR l.expires_at >= ?", today).
+		Scan(&balance).Error
+	return balance, err
+}
+
+// FindExpiring retorna os saldos positivos dos lotes que vencem até a data informada, inclusive os já
+// vencidos, com o lote, o produto e o local, por ordem de validade
+func (r *GormStockLotRepository) FindExpiring(until time.Time, locationID uint) ([]models.StockLotBalance, error) {
+	var balances []models.StockLotBalance
+	query := r.GetDB().
+		Preload("Lot.Product").
+		Preload("Location").
+		Joins("JOIN stock_lots l ON l.id = stock_lot_balances.lot_id AND l.deleted_at IS NULL").
+		Where("stock_lot_balances.quantity > 0 AND l.expires_at <= ?", until)
+	if locationID != 0 {
+		query = query.Where("stock_lot_balances.location_id = ?", locationID)
+	}
+
+	err := query.Order("l.expires_at ASC, l.id ASC").Find(&balances).Error
+	return balances, err
+}
+
+// CreateSaleAllocations registra os lotes entregues nos itens de uma venda
+func (r *GormStockLotRepository) CreateSaleAllocations(allocations []models.SaleItemLot) error {
+	if len(allocations) == 0 {
+		return nil
+	}
+	return r.GetDB().Omit(clause.Associations).Create(&allocations).Error
+}
+
+// ReassignCustomer transfere para outro cliente os lotes entregues nas vendas do cliente, na mesclagem de
+// clientes duplicados
+func (r *GormStockLotRepository) ReassignCustomer(fromID, toID uint) (int64, error) {
+	result := r.GetDB().Model(&models.SaleItemLot{}).Where("customer_id = ?", fromID).Update("customer_id", toID)
+	return result.RowsAffected, result.Error
+}
+
+// FindSaleAllocations retorna os lotes entregues na venda, com o produto, o lote e o local
+func (r *GormStockLotRepository) FindSaleAllocations(saleID uint) ([]models.SaleItemLot, error) {
+	var allocations []models.SaleItemLot
+	err := r.GetDB().
+		Preload("Product").
+		Preload("Lot").
+		Preload("Location").
+		Where("sale_id = ?", saleID).
+		Order("sale_item_id ASC, id ASC").
+		Find(&allocations).Error
+	return allocations, err
+}
+
+// FindLotAllocations retorna as vendas em que o lote foi entregue, da mais recente para a mais antiga
+func (r *GormStockLotRepository) FindLotAllocations(lotID uint) ([]models.SaleItemLot, error) {
+	var allocations []models.SaleItemLot
+	err := r.GetDB().
+		Preload("Location").
+		Where("lot_id = ?", lotID).
+		Order("sale_date DESC, id DESC").
+		Find(&allocations).Error
+	return allocations, err
+}
+
+// FindLotCustomers retorna as quantidades do lote vendidas a cada cliente, da maior para a menor
+func (r *GormStockLotRepository) FindLotCustomers(lotID uint) ([]models.LotCustomerSummary, error) {
+	var summaries []models.LotCustomerSummary
+	err := r.GetDB().Model(&models.SaleItemLot{}).
+		Select(`customer_id, SUM(quantity) AS quantity, COUNT(DISTINCT sale_id) AS sales,
+			MIN(sale_date) AS first_sale, MAX(sale_date) AS last_sale`).
+		Where("lot_id = ?", lotID).
+		Group("customer_id").
+		Order("quantity DESC").
+		Scan(&summaries).Error
+	return summaries, err
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"simple-erp-service/internal/data-structure/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// StockReservationRepository define as operações de acesso a dados para as reservas de estoque das vendas
+// pendentes
+type StockReservationRepository interface {
+	Repository
+	CreateAll(reservations []models.StockReservation) error
+	FindBySale(saleID uint) ([]models.StockReservation, error)
+	FindReserved(productIDs []uint) ([]models.ReservedStock, error)
+	FindReservedAt(productIDs []uint, locationID, exceptSaleID uint) ([]models.ReservedStock, error)
+	LockProducts(productIDs []uint) error
+	CloseBySale(saleID uint, status string) error
+	ExpireOverdue(now time.Time) (int64, error)
+}
+
+// GormStockReservationRepository implementa StockReservationRepository usando GORM
+type GormStockReservationRepository struct {
+	*BaseRepository
+}
+
+// NewStockReservationRepository cria um novo repository de reservas de estoque
+func NewStockReservationRepository(db *gorm.DB) StockReservationRepository {
+	return &GormStockReservationRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// activeReservations filtra as reservas ativas e ainda não expiradas
+func activeReservations(db *gorm.DB) *gorm.DB {
+	return db.Where("stock_reservations.status = ? AND (stock_reservations.expires_at IS NULL OR stock_reservations.expires_at > NOW())", models.ReservationStatusActive)
+}
+
+// CreateAll registra as reservas
+func (r *GormStockReservationRepository) CreateAll(reservations []models.StockReservation) error {
+	if len(reservations) == 0 {
+		return nil
+	}
+	return r.GetDB().Omit(clause.Associations).Create(&reservations).Error
+}
+
+// FindBySale retorna as reservas da venda, com os produtos e os locais
+func (r *GormStockReservationRepository) FindBySale(saleID uint) ([]models.StockReservation, error) {
+	var reservations []models.StockReservation
+	err := r.GetDB().Preload("Product").Preload("Location").
+		Where("sale_id = ?", saleID).
+		Order("id ASC").
+		Find(&reservations).Error
+	return reservations, err
+}
+
+// FindReserved retorna a quantidade reservada de cada produto informado, somando todos os locais
+func (r *GormStockReservationRepository) FindReserved(productIDs []uint) ([]models.ReservedStock, error) {
+	var reserved []models.ReservedStock
+	if len(productIDs) == 0 {
+		return reserved, nil
+	}
+	err := r.GetDB().Model(&models.StockReservation{}).
+		Scopes(activeReservations).
+		Select("product_id, SUM(quantity) AS quantity").
+		Where("product_id IN ?", productIDs).
+		Group("product_id").
+		Scan(&reserved).Error
+	return reserved, err
+}
+
+// FindReservedAt retorna a quantidade reservada de cada produto informado no local, sem as reservas da venda
+// informada
+func (r *GormStockReservationRepository) FindReservedAt(productIDs []uint, locationID, exceptSaleID uint) ([]models.ReservedStock, error) {
+	var reserved []models.ReservedStock
+	if len(productIDs) == 0 {
+		return reserved, nil
+	}
+	err := r.GetDB().Model(&models.StockReservation{}).
+		Scopes(activeReservations).
+		Select("product_id, SUM(quantity) AS quantity").
+		Where("product_id IN ? AND location_id = ? AND sale_id <> ?", productIDs, locationID, exceptSaleID).
+		Group("product_id").
+		Scan(&reserved).Error
+	return reserved, err
+}
+
+// LockProducts bloqueia os produtos até o fim da transação, para que reservas e saídas concorrentes dos
+// mesmos produtos sejam validadas uma de cada vez
+func (r *GormStockReservationRepository) LockProducts(productIDs []uint) error {
+	if len(productIDs) == 0 {
+		return nil
+	}
+	var ids []uint
+	return r.GetDB().Model(&models.Product{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id IN ?", productIDs).
+		Order("id ASC").
+		Pluck("id", &ids).Error
+}
+
+// CloseBySale encerra as reservas ativas da venda com a situação informada (baixada ou liberada)
+func (r *GormStockReservationRepository) CloseBySale(saleID uint, status string) error {
+	return r.GetDB().Model(&models.StockReservation{}).
+		Where("sale_id = ? AND status = ?", saleID, models.ReservationStatusActive).
+		Updates(map[string]interface{}{"status": status, "closed_at": time.Now()}).Error
+}
+
+// ExpireOverdue marca como expiradas as reservas ativas vencidas e retorna quantas foram expiradas
+func (r *GormStockReservationRepository) ExpireOverdue(now time.Time) (int64, error) {
+	result := r.GetDB().Model(&models.StockReservation{}).
+		Where("status = ? AND expires_at <= ?", models.ReservationStatusActive, now).
+		Updates(map[string]interface{}{"status": models.ReservationStatusExpired, "closed_at": now})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"errors"
+	"simple-erp-service/internal/data-structure/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SupplierProductRepository define as operações de acesso a dados para o catálogo dos fornecedores
+type SupplierProductRepository interface {
+	Repository
+	FindBySupplier(supplierID uint) ([]models.SupplierProduct, error)
+	FindByProduct(productID uint) ([]models.SupplierProduct, error)
+	FindByIDAndSupplier(id, supplierID uint) (*models.SupplierProduct, error)
+	FindBySupplierAndProduct(supplierID, productID uint) (*models.SupplierProduct, error)
+	ExistsBySupplierAndProductExcept(supplierID, productID, id uint) (bool, error)
+	ExistsBySupplierCodeExcept(supplierID uint, code string, id uint) (bool, error)
+	Create(item *models.SupplierProduct) error
+	Update(item *models.SupplierProduct) error
+	Delete(id uint) error
+	ClearPreferred(productID, exceptID uint) error
+}
+
+// GormSupplierProductRepository implementa SupplierProductRepository usando GORM
+type GormSupplierProductRepository struct {
+	*BaseRepository
+}
+
+// NewSupplierProductRepository cria um novo repository do catálogo dos fornecedores
+func NewSupplierProductRepository(db *gorm.DB) SupplierProductRepository {
+	return &GormSupplierProductRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// FindBySupplier retorna o catálogo do fornecedor com os produtos
+func (r *GormSupplierProductRepository) FindBySupplier(supplierID uint) ([]models.SupplierProduct, error) {
+	var items []models.SupplierProduct
+	err := r.GetDB().Preload("Product").
+		Where("supplier_id = ?", supplierID).
+		Order("supplier_code ASC").
+		Find(&items).Error
+	return items, err
+}
+
+// FindByProduct retorna os fornecedores que vendem o produto, com os dados do fornecedor
+func (r *GormSupplierProductRepository) FindByProduct(productID uint) ([]models.SupplierProduct, error) {
+	var items []models.SupplierProduct
+	err := r.GetDB().Preload("Supplier").
+		Where("product_id = ?", productID).
+		Order("id ASC").
+		Find(&items).Error
+	return items, err
+}
+
+// FindByIDAndSupplier busca um item do catálogo pelo ID, garantindo que pertence ao fornecedor
+func (r *GormSupplierProductRepository) FindByIDAndSupplier(id, supplierID uint) (*models.SupplierProduct, error) {
+	var item models.SupplierProduct
+	if err := r.GetDB().Preload("Product").Where("supplier_id = ?", supplierID).First(&item, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &item, nil
+}
+
+// FindBySupplierAndProduct busca o item do catálogo do fornecedor para o produto
+func (r *GormSupplierProductRepository) FindBySupplierAndProduct(supplierID, productID uint) (*models.SupplierProduct, error) {
+	var item models.SupplierProduct
+	if err := r.GetDB().Where("supplier_id = ? AND product_id = ?", supplierID, productID).First(&item).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &item, nil
+}
+
+// ExistsBySupplierAndProductExcept verifica se o produto já está no catálogo do fornecedor, exceto o item com o ID especificado
+func (r *GormSupplierProductRepository) ExistsBySupplierAndProductExcept(supplierID, productID, id uint) (bool, error) {
+	var count int64
+	err := r.GetDB().Model(&models.SupplierProduct{}).
+		Where("supplier_id = ? AND product_id = ? AND id != ?", supplierID, productID, id).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// ExistsBySupplierCodeExcept verifica se o código já está em uso no catálogo do fornecedor, exceto o item com o ID especificado
+func (r *GormSupplierProductRepository) ExistsBySupplierCodeExcept(supplierID uint, code string, id uint) (bool, error) {
+	var count int64
+	err := r.GetDB().Model(&models.SupplierProduct{}).
+		Where("supplier_id = ? AND supplier_code = ? AND id != ?", supplierID, code, id).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// Create cria um novo item no catálogo do fornecedor
+func (r *GormSupplierProductRepository) Create(item *models.SupplierProduct) error {
+	return r.GetDB().Create(item).Error
+}
+
+// Update atualiza um item do catálogo do fornecedor
+func (r *GormSupplierProductRepository) Update(item *models.SupplierProduct) error {
+	return r.GetDB().Omit(clause.Associations).Save(item).Error
+}
+
+// Delete remove definitivamente o item do catálogo, liberando o código e o produto para um novo cadastro
+func (r *GormSupplierProductRepository) Delete(id uint) error {
+	return r.GetDB().Unscoped().Delete(&models.SupplierProduct{}, id).Error
+}
+
+// ClearPreferred desmarca o fornecedor preferencial do produto nos demais itens do catálogo
+func (r *GormSupplierProductRepository) ClearPreferred(productID, exceptID uint) error {
+	return r.GetDB().Model(&models.SupplierProduct{}).
+		Where("product_id = ? AND id != ? AND is_preferred", productID, exceptID).
+		Update("is_preferred", false).Error
+}
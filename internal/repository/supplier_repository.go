@@ -2,6 +2,7 @@ package repository
 
 import (
 	"errors"
+	"simple-erp-service/internal/data-structure/dto"
 	"simple-erp-service/internal/data-structure/models"
 	"simple-erp-service/internal/utils"
 
@@ -11,10 +12,13 @@ import (
 // SupplierRepository define as operações de acesso a dados para fornecedores
 type SupplierRepository interface {
 	Repository
-	FindAll(pagination *models.Pagination) ([]models.Supplier, error)
+	FindAll(pagination *models.Pagination, filters dto.InGetPartiesFilters) ([]models.Supplier, error)
 	FindByID(id uint) (*models.Supplier, error)
+	FindByIDWithRelations(id uint) (*models.Supplier, error)
+	FindByIDs(ids []uint) ([]models.Supplier, error)
 	FindByDocument(document string) (*models.Supplier, error)
 	Create(supplier *models.Supplier) error
+	CreateBatch(suppliers []models.Supplier) error
 	Update(supplier *models.Supplier) error
 	Delete(id uint) error
 	ExistsByDocument(document string) (bool, error)
@@ -35,11 +39,11 @@ func NewSupplierRepository(db *gorm.DB) SupplierRepository {
 	}
 }
 
-// FindAll retorna todos os fornecedores com paginação
-func (r *GormSupplierRepository) FindAll(pagination *models.Pagination) ([]models.Supplier, error) {
+// FindAll retorna os fornecedores com paginação, aplicando a busca textual e os filtros informados
+func (r *GormSupplierRepository) FindAll(pagination *models.Pagination, filters dto.InGetPartiesFilters) ([]models.Supplier, error) {
 	var suppliers []models.Supplier
 
-	query := r.GetDB().Model(&models.Supplier{})
+	query := r.GetDB().Model(&models.Supplier{}).Scopes(PartyFilters(SupplierParty, filters))
 	query, err := utils.Paginate(&models.Supplier{}, pagination, query)
 	if err != nil {
 		return nil, err
@@ -64,10 +68,40 @@ func (r *GormSupplierRepository) FindByID(id uint) (*models.Supplier, error) {
 	return &supplier, nil
 }
 
+// FindByIDs busca os fornecedores com os IDs informados
+func (r *GormSupplierRepository) FindByIDs(ids []uint) ([]models.Supplier, error) {
+	var suppliers []models.Supplier
+	if len(ids) == 0 {
+		return suppliers, nil
+	}
+	err := r.GetDB().Where("id IN ?", ids).Find(&suppliers).Error
+	return suppliers, err
+}
+
+// FindByIDWithRelations busca um fornecedor pelo ID carregando endereços, contatos, documentos e usuários de auditoria
+func (r *GormSupplierRepository) FindByIDWithRelations(id uint) (*models.Supplier, error) {
+	var supplier models.Supplier
+	err := r.GetDB().
+		Preload("Addresses", func(db *gorm.DB) *gorm.DB { return db.Order("is_primary DESC, id ASC") }).
+		Preload("Addresses.City.State").
+		Preload("Contacts", func(db *gorm.DB) *gorm.DB { return db.Order("is_primary DESC, id ASC") }).
+		Preload("Documents.State").
+		Preload("CreatedBy").
+		Preload("UpdatedBy").
+		First(&supplier, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &supplier, nil
+}
+
 // FindByDocument busca um fornecedor pelo documento
 func (r *GormSupplierRepository) FindByDocument(document string) (*models.Supplier, error) {
 	var supplier models.Supplier
-	if err := r.GetDB().Where("document = ?", document).First(&supplier).Error; err != nil {
+	if err := r.GetDB().Where("document_number = ?", document).First(&supplier).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
 		}
@@ -81,6 +115,37 @@ func (r *GormSupplierRepository) Create(supplier *models.Supplier) error {
 	return r.GetDB().Create(supplier).Error
 }
 
+// CreateBatch insere vários fornecedores em uma única transação
+func (r *GormSupplierRepository) CreateBatch(suppliers []models.Supplier) error {
+	if len(suppliers) == 0 {
+		return nil
+	}
+	// O default:true de IsActive faz o GORM ignorar o valor false na inserção,
+	// por isso os inativos são guardados antes e atualizados em seguida
+	inactive := make([]int, 0)
+	for i, supplier := range suppliers {
+		if !supplier.IsActive {
+			inactive = append(inactive, i)
+		}
+	}
+
+	return r.GetDB().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&suppliers).Error; err != nil {
+			return err
+		}
+		if len(inactive) == 0 {
+			return nil
+		}
+
+		ids := make([]uint, 0, len(inactive))
+		for _, i := range inactive {
+			suppliers[i].IsActive = false
+			ids = append(ids, suppliers[i].ID)
+		}
+		return tx.Model(&models.Supplier{}).Where("id IN ?", ids).Update("is_active", false).Error
+	})
+}
+
 // Update atualiza um fornecedor existente
 func (r *GormSupplierRepository) Update(supplier *models.Supplier) error {
 	return r.GetDB().Save(supplier).Error
@@ -94,14 +159,14 @@ func (r *GormSupplierRepository) Delete(id uint) error {
 // ExistsByDocument verifica se existe um fornecedor com o documento especificado
 func (r *GormSupplierRepository) ExistsByDocument(document string) (bool, error) {
 	var count int64
-	err := r.GetDB().Model(&models.Supplier{}).Where("document = ?", document).Count(&count).Error
+	err := r.GetDB().Model(&models.Supplier{}).Where("document_number = ?", document).Count(&count).Error
 	return count > 0, err
 }
 
 // ExistsByDocumentExcept verifica se existe um fornecedor com o documento especificado, exceto o fornecedor com o ID especificado
 func (r *GormSupplierRepository) ExistsByDocumentExcept(document string, id uint) (bool, error) {
 	var count int64
-	err := r.GetDB().Model(&models.Supplier{}).Where("document = ? AND id != ?", document, id).Count(&count).Error
+	err := r.GetDB().Model(&models.Supplier{}).Where("document_number = ? AND id != ?", document, id).Count(&count).Error
 	return count > 0, err
 }
 
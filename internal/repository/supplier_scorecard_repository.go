@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"simple-erp-service/internal/data-structure/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SupplierScorecardRepository define as consultas agregadas do desempenho dos fornecedores
+type SupplierScorecardRepository interface {
+	Repository
+	PurchaseStats(supplierID uint, from, to time.Time) ([]models.SupplierPurchaseStats, error)
+	ReturnStats(supplierID uint, from, to time.Time) ([]models.SupplierReturnStats, error)
+}
+
+// GormSupplierScorecardRepository implementa SupplierScorecardRepository usando GORM
+type GormSupplierScorecardRepository struct {
+	*BaseRepository
+}
+
+// NewSupplierScorecardRepository cria um novo repository do scorecard de fornecedores
+func NewSupplierScorecardRepository(db *gorm.DB) SupplierScorecardRepository {
+	return &GormSupplierScorecardRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// PurchaseStats soma, por fornecedor, as compras recebidas com data da compra no período.
+// Com supplierID diferente de zero, somente o fornecedor informado é considerado. A pontualidade
+// compara os dias do recebimento e da data prevista, sem considerar o horário.
+func (r *GormSupplierScorecardRepository) PurchaseStats(supplierID uint, from, to time.Time) ([]models.SupplierPurchaseStats, error) {
+	stats := []models.SupplierPurchaseStats{}
+
+	// Os itens são agregados por compra antes, para que a contagem e a média de prazo sejam por compra
+	items := r.GetDB().Table("purchase_items").
+		Select("purchase_id, SUM(quantity) AS ordered, SUM(received_quantity) AS received, " +
+			"SUM(unit_price * received_quantity) AS agreed, " +
+			"SUM(COALESCE(received_unit_price, unit_price) * received_quantity) AS paid").
+		Where("deleted_at IS NULL").
+		Group("purchase_id")
+
+	query := r.GetDB().Table("purchases p").
+		Joins("JOIN (?) i ON i.purchase_id = p.id", items).
+		Select("p.supplier_id, "+
+			"COUNT(*) AS purchases, "+
+			"COUNT(*) FILTER (WHERE p.expected_date IS NOT NULL) AS with_due_date, "+
+			"COUNT(*) FILTER (WHERE p.expected_date IS NOT NULL AND p.received_at::date <= p.expected_date::date) AS on_time, "+
+			"COALESCE(SUM(i.ordered), 0) AS ordered_quantity, "+
+			"COALESCE(SUM(i.received), 0) AS received_quantity, "+
+			"COALESCE(SUM(i.agreed), 0) AS agreed_value, "+
+			"COALESCE(SUM(i.paid), 0) AS paid_value, "+
+			"COALESCE(AVG(EXTRACT(EPOCH FROM p.received_at - p.purchase_date) / 86400), 0) AS avg_lead_time_days").
+		Where("p.deleted_at IS NULL AND p.supplier_id IS NOT NULL AND p.status = ?", models.PurchaseStatusReceived).
+		Scopes(DateRange("p.purchase_date", from, to)).
+		Group("p.supplier_id")
+	if supplierID != 0 {
+		query = query.Where("p.supplier_id = ?", supplierID)
+	}
+
+	if err := query.Scan(&stats).Error; err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// ReturnStats conta, por fornecedor, as devoluções com data no período e as unidades devolvidas
+func (r *GormSupplierScorecardRepository) ReturnStats(supplierID uint, from, to time.Time) ([]models.SupplierReturnStats, error) {
+	stats := []models.SupplierReturnStats{}
+
+	query := r.GetDB().Table("purchase_returns pr").
+		Joins("JOIN purchase_return_items pri ON pri.purchase_return_id = pr.id AND pri.deleted_at IS NULL").
+		Select("pr.supplier_id, COUNT(DISTINCT pr.id) AS returns, COALESCE(SUM(pri.quantity), 0) AS returned_quantity").
+		Where("pr.deleted_at IS NULL AND pr.supplier_id IS NOT NULL").
+		Scopes(DateRange("pr.return_date", from, to)).
+		Group("pr.supplier_id")
+	if supplierID != 0 {
+		query = query.Where("pr.supplier_id = ?", supplierID)
+	}
+
+	if err := query.Scan(&stats).Error; err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"net/url"
+	"simple-erp-service/internal/data-structure/models"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// SystemLogRepository define as operações de acesso a dados para o log de auditoria
+type SystemLogRepository interface {
+	Repository
+	FindByEntity(entityType, entityID string) ([]models.SystemLog, error)
+	Create(log *models.SystemLog) error
+	RedactDetails(entityType, entityID, referenceKey string, keys []string) (int64, error)
+	RedactQueries(terms []string) (int64, error)
+}
+
+// GormSystemLogRepository implementa SystemLogRepository usando GORM
+type GormSystemLogRepository struct {
+	*BaseRepository
+}
+
+// NewSystemLogRepository cria um novo repository do log de auditoria
+func NewSystemLogRepository(db *gorm.DB) SystemLogRepository {
+	return &GormSystemLogRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// FindByEntity retorna os registros de auditoria de uma entidade, do mais antigo para o mais recente.
+// Inclui os registros gravados pelo LoggerMiddleware, que não informam o tipo da entidade mas guardam
+// o caminho da requisição (ex: PUT /api/v1/customers/10).
+func (r *GormSystemLogRepository) FindByEntity(entityType, entityID string) ([]models.SystemLog, error) {
+	var logs []models.SystemLog
+	err := r.GetDB().
+		Where("entity_id = ?", entityID).
+		Where("entity_type = ? OR ((entity_type = '' OR entity_type IS NULL) AND action LIKE ?)",
+			entityType, "%/"+entityType+"/"+entityID+"%").
+		Order("created_at ASC, id ASC").
+		Find(&logs).Error
+	if err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+// Create grava um registro de auditoria
+func (r *GormSystemLogRepository) Create(log *models.SystemLog) error {
+	return r.GetDB().Create(log).Error
+}
+
+// RedactDetails remove as chaves informadas dos detalhes dos registros de auditoria da entidade e dos
+// registros de outras entidades que a citam no campo de referência dos detalhes (ex: merged_id). Retorna
+// quantos registros foram alterados.
+func (r *GormSystemLogRepository) RedactDetails(entityType, entityID, referenceKey string, keys []string) (int64, error) {
+	keyArray := "{" + strings.Join(keys, ",") + "}"
+	result := r.GetDB().Model(&models.SystemLog{}).
+		Where("(entity_type = ? AND entity_id = ?) OR details->>? = ?", entityType, entityID, referenceKey, entityID).
+		Where("jsonb_exists_any(details, ?::text[])", keyArray).
+		UpdateColumn("details", gorm.Expr("details - ?::text[]", keyArray))
+	return result.RowsAffected, result.Error
+}
+
+// RedactQueries remove a query string dos detalhes dos registros de auditoria em que ela contém algum dos
+// termos informados, também na forma codificada da URL (ex: as buscas registradas durante as
+// personificações). Retorna quantos registros foram alterados.
+func (r *GormSystemLogRepository) RedactQueries(terms []string) (int64, error) {
+	if len(terms) == 0 {
+		return 0, nil
+	}
+	variants := make([]string, 0, len(terms)*3)
+	for _, term := range terms {
+		variants = append(variants, term)
+		if encoded := url.QueryEscape(term); encoded != term {
+			variants = append(variants, encoded, strings.ReplaceAll(encoded, "+", "%20"))
+		}
+	}
+	condition, args := ContainsAny("details->>'query'", variants)
+
+	result := r.GetDB().Model(&models.SystemLog{}).
+		Where(condition, args...).
+		UpdateColumn("details", gorm.Expr("details - 'query'"))
+	return result.RowsAffected, result.Error
+}
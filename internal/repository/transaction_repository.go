@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"simple-erp-service/internal/data-structure/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TransactionRepository define as operações de acesso a dados para lançamentos financeiros
+type TransactionRepository interface {
+	Repository
+	FindOpenReceivablesByCustomer(customerID uint) ([]models.OpenReceivable, error)
+	Create(transaction *models.Transaction) error
+}
+
+// GormTransactionRepository implementa TransactionRepository usando GORM
+type GormTransactionRepository struct {
+	*BaseRepository
+}
+
+// NewTransactionRepository cria um novo repository de lançamentos financeiros
+func NewTransactionRepository(db *gorm.DB) TransactionRepository {
+	return &GormTransactionRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// FindOpenReceivablesByCustomer retorna os títulos a receber do cliente que ainda possuem saldo,
+// calculando o valor pago a partir dos pagamentos
+func (r *GormTransactionRepository) FindOpenReceivablesByCustomer(customerID uint) ([]models.OpenReceivable, error) {
+	receivables := []models.OpenReceivable{}
+
+	err := r.GetDB().Table("transactions t").
+		Joins(`LEFT JOIN (
+			SELECT transaction_id, SUM(amount) AS paid FROM payments
+			WHERE deleted_at IS NULL GROUP BY transaction_id
+		) p ON p.transaction_id = t.id`).
+		Select("t.id AS transaction_id, t.code, t.date, t.due_date, "+
+			"t.amount + COALESCE(t.interest, 0) + COALESCE(t.penalty, 0) AS amount, COALESCE(p.paid, 0) AS paid").
+		Where("t.deleted_at IS NULL").
+		Where("t.type = ? AND t.customer_id = ? AND t.status <> ?",
+			models.TransactionTypeReceivable, customerID, models.TransactionStatusSettled).
+		Where("t.amount + COALESCE(t.interest, 0) + COALESCE(t.penalty, 0) - COALESCE(p.paid, 0) > 0").
+		Order("t.due_date ASC").
+		Scan(&receivables).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return receivables, nil
+}
+
+// Create registra um lançamento financeiro
+func (r *GormTransactionRepository) Create(transaction *models.Transaction) error {
+	return r.GetDB().Omit(clause.Associations).Create(transaction).Error
+}
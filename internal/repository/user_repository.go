@@ -24,6 +24,8 @@ type UserRepository interface {
 	ExistsByUsernameExcept(username string, id uint) (bool, error)
 	ExistsByEmailExcept(email string, id uint) (bool, error)
 	CountByRoleID(roleID uint) (int64, error)
+	FindActiveIDsByPermission(permission string) ([]uint, error)
+	FilterActiveIDs(ids []uint) ([]uint, error)
 }
 
 // GormUserRepository implementa UserRepository usando GORM
@@ -152,3 +154,25 @@ func (r *GormUserRepository) CountByRoleID(roleID uint) (int64, error) {
 	err := r.GetDB().Model(&models.User{}).Where("role_id = ?", roleID).Count(&count).Error
 	return count, err
 }
+
+// FindActiveIDsByPermission retorna os IDs dos usuários ativos cujo perfil possui a permissão
+func (r *GormUserRepository) FindActiveIDsByPermission(permission string) ([]uint, error) {
+	var ids []uint
+	err := r.GetDB().Model(&models.User{}).
+		Joins("JOIN role_permissions ON role_permissions.role_id = users.role_id").
+		Joins("JOIN permissions ON permissions.id = role_permissions.permission_id AND permissions.deleted_at IS NULL").
+		Where("users.is_active AND permissions.permission = ?", permission).
+		Distinct().
+		Pluck("users.id", &ids).Error
+	return ids, err
+}
+
+// FilterActiveIDs retorna, dentre os IDs informados, os dos usuários ativos
+func (r *GormUserRepository) FilterActiveIDs(ids []uint) ([]uint, error) {
+	var active []uint
+	if len(ids) == 0 {
+		return active, nil
+	}
+	err := r.GetDB().Model(&models.User{}).Where("id IN ? AND is_active", ids).Pluck("id", &active).Error
+	return active, err
+}
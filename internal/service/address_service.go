@@ -0,0 +1,177 @@
+package service
+
+import (
+	dto "simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/utils/brdoc"
+	"simple-erp-service/internal/validator"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// AddressService gerencia os endereços de clientes e fornecedores
+type AddressService struct {
+	addressRepo repository.AddressRepository
+	owners      ownerLookup
+	validator   *validator.AddressValidator
+}
+
+// NewAddressService cria um novo serviço de endereços
+func NewAddressService(
+	addressRepo repository.AddressRepository,
+	locationRepo repository.LocationRepository,
+	customerRepo repository.CustomerRepository,
+	supplierRepo repository.SupplierRepository,
+) *AddressService {
+	return &AddressService{
+		addressRepo: addressRepo,
+		owners:      ownerLookup{customerRepo: customerRepo, supplierRepo: supplierRepo},
+		validator:   validator.NewAddressValidator(locationRepo),
+	}
+}
+
+// GetAddresses retorna os endereços de um cliente ou fornecedor
+func (s *AddressService) GetAddresses(owner models.Owner) ([]dto.ApiAddress, error) {
+	if _, err := s.owners.personType(owner); err != nil {
+		return nil, err
+	}
+
+	addresses, err := s.addressRepo.FindByOwner(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	result, _ := dto.ApiAddressesFromModel(addresses)
+	return result, nil
+}
+
+// CreateAddress cria um endereço. O primeiro endereço cadastrado é sempre o principal.
+func (s *AddressService) CreateAddress(owner models.Owner, req models.CreateAddressRequest) (*dto.ApiAddress, error) {
+	if _, err := s.owners.personType(owner); err != nil {
+		return nil, err
+	}
+
+	// Validar dados
+	if err := s.validator.ValidateForCreation(req); err != nil {
+		return nil, err
+	}
+
+	customerID, supplierID := owner.IDs()
+	address := models.Address{
+		Street:       strings.TrimSpace(req.Street),
+		Number:       strings.TrimSpace(req.Number),
+		Complement:   strings.TrimSpace(req.Complement),
+		Neighborhood: strings.TrimSpace(req.Neighborhood),
+		ZipCode:      brdoc.OnlyDigits(req.ZipCode),
+		CityID:       req.CityID,
+		IsPrimary:    req.IsPrimary,
+		CustomerID:   customerID,
+		SupplierID:   supplierID,
+	}
+
+	err := s.addressRepo.GetDB().Transaction(func(tx *gorm.DB) error {
+		txRepo := repository.NewAddressRepository(tx)
+
+		count, err := txRepo.CountByOwner(owner)
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			address.IsPrimary = true
+		}
+
+		if err := txRepo.Create(&address); err != nil {
+			return err
+		}
+		if address.IsPrimary {
+			return txRepo.ClearPrimary(owner, address.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getAddress(owner, address.ID)
+}
+
+// UpdateAddress atualiza um endereço existente
+func (s *AddressService) UpdateAddress(owner models.Owner, id uint, req models.UpdateAddressRequest) (*dto.ApiAddress, error) {
+	address, err := s.addressRepo.FindByIDAndOwner(id, owner)
+	if err != nil {
+		return nil, err
+	}
+	if address == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	// Validar dados
+	if err := s.validator.ValidateForUpdate(req); err != nil {
+		return nil, err
+	}
+
+	address.Street = strings.TrimSpace(req.Street)
+	address.Number = strings.TrimSpace(req.Number)
+	address.Complement = strings.TrimSpace(req.Complement)
+	address.Neighborhood = strings.TrimSpace(req.Neighborhood)
+	address.ZipCode = brdoc.OnlyDigits(req.ZipCode)
+	address.CityID = req.CityID
+
+	// Só é possível trocar o principal marcando outro endereço como principal
+	wasPrimary := address.IsPrimary
+	address.IsPrimary = req.IsPrimary || wasPrimary
+
+	err = s.addressRepo.GetDB().Transaction(func(tx *gorm.DB) error {
+		txRepo := repository.NewAddressRepository(tx)
+
+		if err := txRepo.Update(address); err != nil {
+			return err
+		}
+		if address.IsPrimary && !wasPrimary {
+			return txRepo.ClearPrimary(owner, address.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getAddress(owner, address.ID)
+}
+
+// DeleteAddress exclui um endereço. Se era o principal, o mais antigo restante assume.
+func (s *AddressService) DeleteAddress(owner models.Owner, id uint) error {
+	address, err := s.addressRepo.FindByIDAndOwner(id, owner)
+	if err != nil {
+		return err
+	}
+	if address == nil {
+		return utils.ErrNotFound
+	}
+
+	return s.addressRepo.GetDB().Transaction(func(tx *gorm.DB) error {
+		txRepo := repository.NewAddressRepository(tx)
+
+		if err := txRepo.Delete(id); err != nil {
+			return err
+		}
+		return txRepo.PromoteOldest(owner)
+	})
+}
+
+// getAddress recarrega o endereço com a cidade para montar o DTO
+func (s *AddressService) getAddress(owner models.Owner, id uint) (*dto.ApiAddress, error) {
+	address, err := s.addressRepo.FindByIDAndOwner(id, owner)
+	if err != nil {
+		return nil, err
+	}
+	if address == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	addressDTO := dto.ApiAddressFromModel(*address)
+	return &addressDTO, nil
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"errors"
+	"strconv"
 	"time"
 
 	"simple-erp-service/config"
@@ -152,3 +153,115 @@ func (s *AuthService) GetUserByID(userID uint) (*models.User, error) {
 	}
 	return &user, nil
 }
+
+// ImpersonationResponse representa a resposta do início de uma personificação
+type ImpersonationResponse struct {
+	User         dto.ApiUserDetail `json:"user"`
+	Impersonator dto.ApiUser       `json:"impersonator"`
+	AccessToken  string            `json:"access_token"`
+	ExpiresAt    time.Time         `json:"expires_at"`
+}
+
+// Impersonate gera um token de acesso de curta duração para que o impersonator veja o sistema como o usuário alvo
+func (s *AuthService) Impersonate(impersonatorID, targetID uint, ipAddress string) (*ImpersonationResponse, error) {
+	if impersonatorID == targetID {
+		return nil, errors.New("não é possível personificar o próprio usuário")
+	}
+
+	impersonator, err := s.GetUserByID(impersonatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	var target models.User
+	result := s.db.Preload("Role.Permissions").First(&target, targetID)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, utils.ErrNotFound
+		}
+		return nil, result.Error
+	}
+
+	// Verificar se o usuário está ativo
+	if !target.IsActive {
+		return nil, errors.New("usuário inativo")
+	}
+
+	// O alvo não pode ter acessos que o impersonator não tem
+	if !canImpersonate(*impersonator, target) {
+		return nil, utils.ErrForbidden
+	}
+
+	// Extrair perfil e permissões
+	var roleName string
+	var permissions []string
+	if target.Role != nil {
+		roleName = target.Role.Name
+		for _, perm := range target.Role.Permissions {
+			permissions = append(permissions, perm.Permission)
+		}
+	}
+
+	accessToken, err := utils.GenerateImpersonationToken(target.ID, target.Username, target.RoleID, roleName, permissions, impersonator.ID, s.cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logImpersonation("auth.impersonate.start", target.ID, impersonator.ID, ipAddress)
+
+	return &ImpersonationResponse{
+		User:         dto.ApiUserDetailFromModel(target),
+		Impersonator: dto.ApiUserFromModel(*impersonator),
+		AccessToken:  accessToken,
+		ExpiresAt:    time.Now().Add(s.cfg.JWT.ImpersonationTokenExp),
+	}, nil
+}
+
+// canImpersonate indica se as permissões do perfil do alvo estão contidas nas do impersonator. O ADMIN tem acesso
+// completo e pode personificar qualquer usuário; somente ele pode personificar outro ADMIN.
+func canImpersonate(impersonator, target models.User) bool {
+	if impersonator.Role == nil {
+		return false
+	}
+	if impersonator.Role.Name == "ADMIN" {
+		return true
+	}
+	if target.Role == nil {
+		return true
+	}
+	if target.Role.Name == "ADMIN" {
+		return false
+	}
+
+	granted := make(map[string]bool, len(impersonator.Role.Permissions))
+	for _, perm := range impersonator.Role.Permissions {
+		granted[perm.Permission] = true
+	}
+	for _, perm := range target.Role.Permissions {
+		if !granted[perm.Permission] {
+			return false
+		}
+	}
+	return true
+}
+
+// StopImpersonation registra o encerramento de uma personificação
+func (s *AuthService) StopImpersonation(impersonatorID, userID uint, ipAddress string) {
+	s.logImpersonation("auth.impersonate.stop", userID, impersonatorID, ipAddress)
+}
+
+// logImpersonation grava no log do sistema um evento de personificação com as duas identidades
+func (s *AuthService) logImpersonation(action string, userID, impersonatorID uint, ipAddress string) {
+	s.db.Create(&models.SystemLog{
+		UserID:         &userID,
+		ImpersonatorID: &impersonatorID,
+		Action:         action,
+		EntityType:     "users",
+		EntityID:       strconv.FormatUint(uint64(userID), 10),
+		IPAddress:      ipAddress,
+		Details: map[string]interface{}{
+			"impersonator_id": impersonatorID,
+			"user_id":         userID,
+		},
+	})
+}
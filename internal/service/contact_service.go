@@ -0,0 +1,157 @@
+package service
+
+import (
+	dto "simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/validator"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// ContactService gerencia os contatos de clientes e fornecedores
+type ContactService struct {
+	contactRepo repository.ContactRepository
+	owners      ownerLookup
+	validator   *validator.ContactValidator
+}
+
+// NewContactService cria um novo serviço de contatos
+func NewContactService(
+	contactRepo repository.ContactRepository,
+	customerRepo repository.CustomerRepository,
+	supplierRepo repository.SupplierRepository,
+) *ContactService {
+	return &ContactService{
+		contactRepo: contactRepo,
+		owners:      ownerLookup{customerRepo: customerRepo, supplierRepo: supplierRepo},
+		validator:   validator.NewContactValidator(contactRepo),
+	}
+}
+
+// GetContacts retorna os contatos de um cliente ou fornecedor
+func (s *ContactService) GetContacts(owner models.Owner) ([]dto.ApiContact, error) {
+	if _, err := s.owners.personType(owner); err != nil {
+		return nil, err
+	}
+
+	contacts, err := s.contactRepo.FindByOwner(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	result, _ := dto.ApiContactsFromModel(contacts)
+	return result, nil
+}
+
+// CreateContact cria um contato. O primeiro contato cadastrado é sempre o principal.
+func (s *ContactService) CreateContact(owner models.Owner, req models.CreateContactRequest) (*dto.ApiContact, error) {
+	if _, err := s.owners.personType(owner); err != nil {
+		return nil, err
+	}
+
+	// Validar dados
+	if err := s.validator.ValidateForCreation(req); err != nil {
+		return nil, err
+	}
+
+	customerID, supplierID := owner.IDs()
+	contact := models.Contact{
+		Type:       req.Type,
+		Contact:    validator.NormalizeContact(req.Type, req.Contact),
+		Name:       strings.TrimSpace(req.Name),
+		IsPrimary:  req.IsPrimary,
+		CustomerID: customerID,
+		SupplierID: supplierID,
+	}
+
+	err := s.contactRepo.GetDB().Transaction(func(tx *gorm.DB) error {
+		txRepo := repository.NewContactRepository(tx)
+
+		count, err := txRepo.CountByOwner(owner)
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			contact.IsPrimary = true
+		}
+
+		if err := txRepo.Create(&contact); err != nil {
+			return err
+		}
+		if contact.IsPrimary {
+			return txRepo.ClearPrimary(owner, contact.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	contactDTO := dto.ApiContactFromModel(contact)
+	return &contactDTO, nil
+}
+
+// UpdateContact atualiza um contato existente
+func (s *ContactService) UpdateContact(owner models.Owner, id uint, req models.UpdateContactRequest) (*dto.ApiContact, error) {
+	contact, err := s.contactRepo.FindByIDAndOwner(id, owner)
+	if err != nil {
+		return nil, err
+	}
+	if contact == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	// Validar dados
+	if err := s.validator.ValidateForUpdate(id, req); err != nil {
+		return nil, err
+	}
+
+	contact.Type = req.Type
+	contact.Contact = validator.NormalizeContact(req.Type, req.Contact)
+	contact.Name = strings.TrimSpace(req.Name)
+
+	// Só é possível trocar o principal marcando outro contato como principal
+	wasPrimary := contact.IsPrimary
+	contact.IsPrimary = req.IsPrimary || wasPrimary
+
+	err = s.contactRepo.GetDB().Transaction(func(tx *gorm.DB) error {
+		txRepo := repository.NewContactRepository(tx)
+
+		if err := txRepo.Update(contact); err != nil {
+			return err
+		}
+		if contact.IsPrimary && !wasPrimary {
+			return txRepo.ClearPrimary(owner, contact.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	contactDTO := dto.ApiContactFromModel(*contact)
+	return &contactDTO, nil
+}
+
+// DeleteContact exclui um contato. Se era o principal, o mais antigo restante assume.
+func (s *ContactService) DeleteContact(owner models.Owner, id uint) error {
+	contact, err := s.contactRepo.FindByIDAndOwner(id, owner)
+	if err != nil {
+		return err
+	}
+	if contact == nil {
+		return utils.ErrNotFound
+	}
+
+	return s.contactRepo.GetDB().Transaction(func(tx *gorm.DB) error {
+		txRepo := repository.NewContactRepository(tx)
+
+		if err := txRepo.Delete(id); err != nil {
+			return err
+		}
+		return txRepo.PromoteOldest(owner)
+	})
+}
@@ -0,0 +1,238 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"simple-erp-service/config"
+	dto "simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/utils"
+	"time"
+)
+
+// CreditService gerencia o perfil financeiro dos clientes e a análise de crédito de vendas a prazo
+type CreditService struct {
+	customerRepo    repository.CustomerRepository
+	transactionRepo repository.TransactionRepository
+	saleRepo        repository.SaleRepository
+	cfg             config.CreditConfig
+}
+
+// NewCreditService cria um novo serviço de crédito
+func NewCreditService(
+	customerRepo repository.CustomerRepository,
+	transactionRepo repository.TransactionRepository,
+	saleRepo repository.SaleRepository,
+	cfg config.CreditConfig,
+) *CreditService {
+	return &CreditService{
+		customerRepo:    customerRepo,
+		transactionRepo: transactionRepo,
+		saleRepo:        saleRepo,
+		cfg:             cfg,
+	}
+}
+
+// UpdateCredit atualiza o limite, o prazo de pagamento e o bloqueio manual do cliente
+func (s *CreditService) UpdateCredit(customerID uint, req models.UpdateCustomerCreditRequest, userID uint) (*dto.ApiCustomerCredit, error) {
+	customer, err := s.customerRepo.FindByID(customerID)
+	if err != nil {
+		return nil, err
+	}
+	if customer == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	customer.CreditLimit = roundMoney(*req.CreditLimit)
+	customer.PaymentTermDays = req.PaymentTermDays
+	customer.CreditBlocked = req.CreditBlocked
+	customer.CreditBlockReason = ""
+	if req.CreditBlocked {
+		customer.CreditBlockReason = req.CreditBlockReason
+	}
+	customer.UpdatedByID = &userID
+
+	if err := s.customerRepo.Update(customer); err != nil {
+		return nil, err
+	}
+
+	credit := customerCreditFromModel(*customer)
+	return &credit, nil
+}
+
+// GetStatement monta o extrato do cliente com os títulos em aberto, a exposição e o aging. A exposição
+// inclui as vendas a prazo, aprovadas ou aguardando aprovação, que ainda não foram faturadas.
+func (s *CreditService) GetStatement(customerID uint) (*dto.ApiCustomerStatement, error) {
+	customer, err := s.customerRepo.FindByID(customerID)
+	if err != nil {
+		return nil, err
+	}
+	if customer == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	return s.buildStatement(*customer, time.Now())
+}
+
+// AuthorizeCreditSale avalia uma venda a prazo para o cliente. É chamada na criação da venda a prazo
+// (SaleService.CreateSale), dentro da transação da venda e com o cliente bloqueado; a venda recebe o
+// Status retornado como CreditStatus.
+// A venda é recusada para clientes inativos ou bloqueados manualmente. Limite excedido ou títulos
+// vencidos além da tolerância configurada recusam a venda ou a enviam para aprovação do gerente,
+// conforme CREDIT_EXCEEDED_ACTION.
+func (s *CreditService) AuthorizeCreditSale(customerID uint, amount float64) (*dto.ApiCreditDecision, error) {
+	customer, err := s.customerRepo.FindByID(customerID)
+	if err != nil {
+		return nil, err
+	}
+	if customer == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	statement, err := s.buildStatement(*customer, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	decision := dto.ApiCreditDecision{
+		Status:          models.CreditStatusApproved,
+		Reasons:         []string{},
+		Amount:          roundMoney(amount),
+		Exposure:        statement.Exposure,
+		CreditLimit:     statement.CreditLimit,
+		AvailableCredit: statement.AvailableCredit,
+		MaxDaysOverdue:  statement.MaxDaysOverdue,
+	}
+
+	// Impedimentos que não podem ser liberados por aprovação
+	if !customer.IsActive {
+		decision.Reasons = append(decision.Reasons, "cliente inativo")
+	}
+	if customer.CreditBlocked {
+		reason := "cliente bloqueado para vendas a prazo"
+		if customer.CreditBlockReason != "" {
+			reason += ": " + customer.CreditBlockReason
+		}
+		decision.Reasons = append(decision.Reasons, reason)
+	}
+	if len(decision.Reasons) > 0 {
+		decision.Status = models.CreditStatusRejected
+		return &decision, nil
+	}
+
+	// Impedimentos sujeitos à ação configurada (recusar ou enviar para aprovação)
+	if statement.MaxDaysOverdue > s.cfg.OverdueToleranceDays {
+		decision.Reasons = append(decision.Reasons, fmt.Sprintf(
+			"cliente possui títulos vencidos há %d dias (tolerância de %d dias)",
+			statement.MaxDaysOverdue, s.cfg.OverdueToleranceDays,
+		))
+	}
+	if decision.Amount > statement.AvailableCredit {
+		decision.Reasons = append(decision.Reasons, fmt.Sprintf(
+			"valor da venda (%.2f) excede o crédito disponível (%.2f)",
+			decision.Amount, statement.AvailableCredit,
+		))
+	}
+	if len(decision.Reasons) > 0 {
+		decision.Status = models.CreditStatusApprovalRequired
+		if s.cfg.ExceededAction == config.CreditActionReject {
+			decision.Status = models.CreditStatusRejected
+		}
+	}
+
+	return &decision, nil
+}
+
+// buildStatement agrega os títulos em aberto e as vendas a prazo ainda não faturadas do cliente na data
+// informada
+func (s *CreditService) buildStatement(customer models.Customer, now time.Time) (*dto.ApiCustomerStatement, error) {
+	receivables, err := s.transactionRepo.FindOpenReceivablesByCustomer(customer.ID)
+	if err != nil {
+		return nil, err
+	}
+	pendingCredit, err := s.saleRepo.SumPendingCredit(customer.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	statement := dto.ApiCustomerStatement{
+		ApiCustomerCredit: customerCreditFromModel(customer),
+		Receivables:       make([]dto.ApiOpenReceivable, 0, len(receivables)),
+		GeneratedAt:       now,
+	}
+
+	today := truncateDay(now)
+	for _, receivable := range receivables {
+		balance := roundMoney(receivable.Balance())
+		daysOverdue := int(today.Sub(truncateDay(receivable.DueDate.In(now.Location()))).Hours() / 24)
+		if daysOverdue < 0 {
+			daysOverdue = 0
+		}
+
+		statement.Exposure += balance
+		switch {
+		case daysOverdue == 0:
+			statement.Aging.Current += balance
+		case daysOverdue <= 30:
+			statement.Aging.Days1To30 += balance
+		case daysOverdue <= 60:
+			statement.Aging.Days31To60 += balance
+		case daysOverdue <= 90:
+			statement.Aging.Days61To90 += balance
+		default:
+			statement.Aging.Over90 += balance
+		}
+		if daysOverdue > 0 {
+			statement.OverdueBalance += balance
+		}
+		if daysOverdue > statement.MaxDaysOverdue {
+			statement.MaxDaysOverdue = daysOverdue
+		}
+
+		statement.Receivables = append(statement.Receivables, dto.ApiOpenReceivable{
+			TransactionID: receivable.TransactionID,
+			Code:          receivable.Code,
+			Date:          receivable.Date,
+			DueDate:       receivable.DueDate,
+			Amount:        roundMoney(receivable.Amount),
+			Paid:          roundMoney(receivable.Paid),
+			Balance:       balance,
+			DaysOverdue:   daysOverdue,
+		})
+	}
+
+	statement.PendingCreditSales = roundMoney(pendingCredit)
+	statement.Exposure = roundMoney(statement.Exposure + statement.PendingCreditSales)
+	statement.OverdueBalance = roundMoney(statement.OverdueBalance)
+	statement.Aging = dto.ApiAging{
+		Current:    roundMoney(statement.Aging.Current),
+		Days1To30:  roundMoney(statement.Aging.Days1To30),
+		Days31To60: roundMoney(statement.Aging.Days31To60),
+		Days61To90: roundMoney(statement.Aging.Days61To90),
+		Over90:     roundMoney(statement.Aging.Over90),
+	}
+	statement.AvailableCredit = math.Max(0, roundMoney(customer.CreditLimit-statement.Exposure))
+
+	return &statement, nil
+}
+
+func customerCreditFromModel(c models.Customer) dto.ApiCustomerCredit {
+	return dto.ApiCustomerCredit{
+		CustomerID:        c.ID,
+		CreditLimit:       c.CreditLimit,
+		PaymentTermDays:   c.PaymentTermDays,
+		CreditBlocked:     c.CreditBlocked,
+		CreditBlockReason: c.CreditBlockReason,
+	}
+}
+
+// roundMoney arredonda o valor para centavos
+func roundMoney(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+// truncateDay descarta o horário, mantendo somente a data
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
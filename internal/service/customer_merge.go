@@ -0,0 +1,178 @@
+package service
+
+import (
+	"math"
+	dto "simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/utils"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+// Pesos e limites da busca de clientes duplicados
+const (
+	duplicateNameWeight     = 0.5
+	duplicateContactWeight  = 0.3
+	duplicateAddressWeight  = 0.2
+	DefaultDuplicateScore   = 0.5
+	DefaultDuplicateResults = 50
+)
+
+// FindDuplicateCustomers retorna os pares de clientes possivelmente duplicados com nota igual ou acima da mínima
+func (s *CustomerService) FindDuplicateCustomers(minScore float64, limit int) ([]dto.ApiCustomerDuplicate, error) {
+	candidates, err := s.customerRepo.FindDuplicateCandidates(models.DuplicateScoring{
+		NameWeight:    duplicateNameWeight,
+		ContactWeight: duplicateContactWeight,
+		AddressWeight: duplicateAddressWeight,
+		MinScore:      minScore,
+		Limit:         limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, 0, len(candidates)*2)
+	for _, candidate := range candidates {
+		ids = append(ids, candidate.CustomerID, candidate.DuplicateID)
+	}
+
+	customersByID := make(map[uint]models.Customer, len(ids))
+	if len(ids) > 0 {
+		customers, err := s.customerRepo.FindByIDs(uniqueIDs(ids))
+		if err != nil {
+			return nil, err
+		}
+		for _, customer := range customers {
+			customersByID[customer.ID] = customer
+		}
+	}
+
+	duplicates := make([]dto.ApiCustomerDuplicate, 0, len(candidates))
+	for _, candidate := range candidates {
+		customer, ok := customersByID[candidate.CustomerID]
+		duplicate, okDuplicate := customersByID[candidate.DuplicateID]
+		if !ok || !okDuplicate {
+			continue
+		}
+
+		score := duplicateNameWeight*candidate.NameScore +
+			duplicateContactWeight*candidate.ContactScore +
+			duplicateAddressWeight*candidate.AddressScore
+
+		duplicates = append(duplicates, dto.ApiCustomerDuplicate{
+			Customer:     dto.ApiCustomerFromModel(customer),
+			Duplicate:    dto.ApiCustomerFromModel(duplicate),
+			Score:        roundScore(score),
+			NameScore:    roundScore(candidate.NameScore),
+			ContactScore: candidate.ContactScore,
+			AddressScore: candidate.AddressScore,
+		})
+	}
+
+	return duplicates, nil
+}
+
+// MergeCustomers mescla o cliente duplicado no cliente mantido: transfere vendas, lançamentos, endereços,
+// contatos, documentos, a rastreabilidade dos lotes vendidos e os números de série vendidos (com a garantia),
+// completa os campos vazios, exclui (soft delete) o duplicado e registra a operação no log de auditoria. Tudo
+// em uma única transação.
+func (s *CustomerService) MergeCustomers(survivorID uint, req models.MergeCustomerRequest, userID uint, ipAddress string) (*dto.ApiCustomerMergeResult, error) {
+	if survivorID == req.DuplicateID {
+		return nil, utils.ErrInvalidInput
+	}
+
+	result := dto.ApiCustomerMergeResult{MergedCustomerID: req.DuplicateID}
+
+	err := s.customerRepo.GetDB().Transaction(func(tx *gorm.DB) error {
+		customerRepo := repository.NewCustomerRepository(tx)
+
+		survivor, err := customerRepo.FindByID(survivorID)
+		if err != nil {
+			return err
+		}
+		duplicate, err := customerRepo.FindByID(req.DuplicateID)
+		if err != nil {
+			return err
+		}
+		if survivor == nil || duplicate == nil {
+			return utils.ErrNotFound
+		}
+
+		from := models.Owner{Type: models.OwnerCustomer, ID: duplicate.ID}
+		to := models.Owner{Type: models.OwnerCustomer, ID: survivor.ID}
+
+		if result.MovedAddresses, err = repository.NewAddressRepository(tx).MoveToOwner(from, to); err != nil {
+			return err
+		}
+		if result.MovedContacts, err = repository.NewContactRepository(tx).MoveToOwner(from, to); err != nil {
+			return err
+		}
+		if result.MovedDocuments, err = repository.NewDocumentRepository(tx).MoveToOwner(from, to); err != nil {
+			return err
+		}
+		if result.MovedSales, result.MovedTransactions, err = customerRepo.ReassignSalesAndTransactions(duplicate.ID, survivor.ID); err != nil {
+			return err
+		}
+		if result.MovedLotSales, err = repository.NewStockLotRepository(tx).ReassignCustomer(duplicate.ID, survivor.ID); err != nil {
+			return err
+		}
+		if result.MovedSerials, err = repository.NewSerialNumberRepository(tx).ReassignCustomer(duplicate.ID, survivor.ID); err != nil {
+			return err
+		}
+
+		// Completar os dados que só o duplicado possui
+		if survivor.LastName == "" {
+			survivor.LastName = duplicate.LastName
+		}
+		if survivor.CompanyName == "" {
+			survivor.CompanyName = duplicate.CompanyName
+		}
+		if survivor.Notes == "" {
+			survivor.Notes = duplicate.Notes
+		}
+		survivor.UpdatedByID = &userID
+		if err := customerRepo.Update(survivor); err != nil {
+			return err
+		}
+
+		if err := customerRepo.Delete(duplicate.ID); err != nil {
+			return err
+		}
+
+		return tx.Create(&models.SystemLog{
+			UserID:     &userID,
+			Action:     "customers.merge",
+			EntityType: "customers",
+			EntityID:   strconv.FormatUint(uint64(survivor.ID), 10),
+			IPAddress:  ipAddress,
+			Details: map[string]interface{}{
+				"survivor_id":        survivor.ID,
+				"merged_id":          duplicate.ID,
+				"moved_addresses":    result.MovedAddresses,
+				"moved_contacts":     result.MovedContacts,
+				"moved_documents":    result.MovedDocuments,
+				"moved_sales":        result.MovedSales,
+				"moved_transactions": result.MovedTransactions,
+				"moved_lot_sales":    result.MovedLotSales,
+				"moved_serials":      result.MovedSerials,
+			},
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	customer, err := s.GetCustomerByID(survivorID)
+	if err != nil {
+		return nil, err
+	}
+	result.Customer = *customer
+	return &result, nil
+}
+
+// roundScore arredonda a nota para duas casas decimais
+func roundScore(score float64) float64 {
+	return math.Round(score*100) / 100
+}
@@ -5,8 +5,10 @@ import (
 	"simple-erp-service/internal/data-structure/models"
 	"simple-erp-service/internal/repository"
 	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/utils/brdoc"
 	"simple-erp-service/internal/validator"
-	"strings"
+
+	"gorm.io/gorm"
 )
 
 // CustomerService gerencia operações relacionadas a clientes
@@ -23,9 +25,9 @@ func NewCustomerService(customerRepo repository.CustomerRepository) *CustomerSer
 	}
 }
 
-// GetCustomers retorna uma lista paginada de clientes
-func (s *CustomerService) GetCustomers(pagination *models.Pagination) (*dto.ApiCustomerListPaginated, error) {
-	customers, err := s.customerRepo.FindAll(pagination)
+// GetCustomers retorna uma lista paginada e filtrada de clientes
+func (s *CustomerService) GetCustomers(pagination *models.Pagination, filters dto.InGetPartiesFilters) (*dto.ApiCustomerListPaginated, error) {
+	customers, err := s.customerRepo.FindAll(pagination, filters)
 	if err != nil {
 		return nil, err
 	}
@@ -44,7 +46,7 @@ func (s *CustomerService) GetCustomers(pagination *models.Pagination) (*dto.ApiC
 
 // GetCustomerByID busca um cliente pelo ID
 func (s *CustomerService) GetCustomerByID(id uint) (*dto.ApiCustomerDetail, error) {
-	customer, err := s.customerRepo.FindByID(id)
+	customer, err := s.customerRepo.FindByIDWithRelations(id)
 	if err != nil {
 		return nil, err
 	}
@@ -65,7 +67,7 @@ func (s *CustomerService) CreateCustomer(req models.CreateCustomerRequest, userI
 	}
 
 	// Limpar CPF/CNPJ
-	document := strings.NewReplacer(".", "", "-", "", "/", "").Replace(req.DocumentNumber)
+	document := brdoc.Clean(req.DocumentNumber)
 
 	// Criar cliente
 	customer := models.Customer{
@@ -108,42 +110,26 @@ func (s *CustomerService) UpdateCustomer(id uint, req models.UpdateCustomerReque
 	// Atualizar campos básicos
 	customer.FirstName = req.FirstName
 	customer.LastName = req.LastName
-	customer.CompanyName = req.CompanyName
+	if req.CompanyName != "" { // Sem o campo, a razão social gravada é mantida
+		customer.CompanyName = req.CompanyName
+	}
 	customer.IsActive = req.IsActive
 	customer.Notes = req.Notes
 
 	// Atualizar e formatar o DocumentNumber (removendo caracteres especiais)
-	document := strings.NewReplacer(".", "", "-", "", "/", "").Replace(req.DocumentNumber)
+	document := brdoc.Clean(req.DocumentNumber)
 	customer.DocumentNumber = document
 
-	// TODO Implementar os Métodos
-	//// Atualizar Relacionamentos (se IDs foram enviados)
-	// if len(req.DocumentsIDs) > 0 {
-	// 	documents, err := s.documentRepo.FindByIDs(req.DocumentsIDs)
-	// 	if err != nil {
-	// 		return nil, err
-	// 	}
-	// 	customer.Documents = documents
-	// }
-
-	// if len(req.AdressesIDs) > 0 {
-	// 	addresses, err := s.addressRepo.FindByIDs(req.AdressesIDs)
-	// 	if err != nil {
-	// 		return nil, err
-	// 	}
-	// 	customer.Addresses = addresses
-	// }
-
-	// if len(req.ContactsIDs) > 0 {
-	// 	contacts, err := s.contactRepo.FindByIDs(req.ContactsIDs)
-	// 	if err != nil {
-	// 		return nil, err
-	// 	}
-	// 	customer.Contacts = contacts
-	// }
-
-	// Salvar alterações
-	if err := s.customerRepo.Update(customer); err != nil {
+	// Vincular documentos, endereços e contatos enviados pelo front e salvar as alterações na mesma transação,
+	// para que um erro na gravação não deixe os vínculos reatribuídos
+	owner := models.Owner{Type: models.OwnerCustomer, ID: id}
+	err = s.customerRepo.GetDB().Transaction(func(tx *gorm.DB) error {
+		if err := newRelationLinker(tx).link(owner, req.DocumentsIDs, req.AdressesIDs, req.ContactsIDs); err != nil {
+			return err
+		}
+		return repository.NewCustomerRepository(tx).Update(customer)
+	})
+	if err != nil {
 		return nil, err
 	}
 
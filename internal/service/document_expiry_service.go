@@ -0,0 +1,215 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"simple-erp-service/config"
+	dto "simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+)
+
+// DocumentAlertsPermission é a permissão dos usuários que recebem os alertas de vencimento de documentos,
+// além do usuário que cadastrou o cliente ou fornecedor
+const DocumentAlertsPermission = "documents.alerts"
+
+// DocumentExpiryService acompanha a validade dos documentos de clientes e fornecedores
+type DocumentExpiryService struct {
+	documentRepo     repository.DocumentRepository
+	userRepo         repository.UserRepository
+	notificationRepo repository.NotificationRepository
+	cfg              config.DocumentsConfig
+}
+
+// NewDocumentExpiryService cria um novo serviço de validade de documentos
+func NewDocumentExpiryService(
+	documentRepo repository.DocumentRepository,
+	userRepo repository.UserRepository,
+	notificationRepo repository.NotificationRepository,
+	cfg config.DocumentsConfig,
+) *DocumentExpiryService {
+	return &DocumentExpiryService{
+		documentRepo:     documentRepo,
+		userRepo:         userRepo,
+		notificationRepo: notificationRepo,
+		cfg:              cfg,
+	}
+}
+
+// GetExpiringDocuments retorna os documentos vencidos e os que vencem nos próximos dias, do mais antigo
+// para o mais recente. Sem o filtro de dias, usa a antecedência configurada.
+func (s *DocumentExpiryService) GetExpiringDocuments(filters dto.InGetExpiringDocumentsFilters) ([]dto.ApiExpiringDocument, error) {
+	days := s.cfg.ExpiryWarningDays
+	if filters.Days != nil {
+		days = *filters.Days
+	}
+
+	today := startOfDay(time.Now())
+	documents, err := s.documentRepo.FindExpiring(today.AddDate(0, 0, days+1), filters.OwnerType, filters.MandatoryOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.ApiExpiringDocument, 0, len(documents))
+	for _, document := range documents {
+		result = append(result, expiringDocument(document, today))
+	}
+	return result, nil
+}
+
+// NotifyExpiringDocuments cria as notificações dos documentos vencidos e a vencer dentro da antecedência
+// configurada para os usuários com a permissão de alertas e para quem cadastrou o cliente ou fornecedor.
+// Cada usuário recebe um aviso quando o documento entra no período de alerta e outro quando ele vence;
+// execuções repetidas não duplicam as notificações. Retorna quantas notificações foram criadas.
+func (s *DocumentExpiryService) NotifyExpiringDocuments() (int64, error) {
+	today := startOfDay(time.Now())
+	documents, err := s.documentRepo.FindExpiring(today.AddDate(0, 0, s.cfg.ExpiryWarningDays+1), "", false)
+	if err != nil || len(documents) == 0 {
+		return 0, err
+	}
+
+	recipients, err := s.userRepo.FindActiveIDsByPermission(DocumentAlertsPermission)
+	if err != nil {
+		return 0, err
+	}
+
+	// Responsáveis pelos cadastros: somente os usuários ativos
+	creatorIDs := make([]uint, 0)
+	for _, document := range documents {
+		if creatorID := documentOwnerCreator(document); creatorID != nil {
+			creatorIDs = append(creatorIDs, *creatorID)
+		}
+	}
+	activeCreators, err := s.userRepo.FilterActiveIDs(creatorIDs)
+	if err != nil {
+		return 0, err
+	}
+	isActiveCreator := make(map[uint]bool, len(activeCreators))
+	for _, id := range activeCreators {
+		isActiveCreator[id] = true
+	}
+
+	notifications := make([]models.Notification, 0)
+	for _, document := range documents {
+		expiring := expiringDocument(document, today)
+		notification := documentNotification(expiring)
+
+		users := make(map[uint]bool, len(recipients)+1)
+		for _, id := range recipients {
+			users[id] = true
+		}
+		if creatorID := documentOwnerCreator(document); creatorID != nil && isActiveCreator[*creatorID] {
+			users[*creatorID] = true
+		}
+
+		for userID := range users {
+			n := notification
+			n.UserID = userID
+			notifications = append(notifications, n)
+		}
+	}
+
+	return s.notificationRepo.CreateMissing(notifications)
+}
+
+// RunDocumentExpiryAlerts executa a verificação de vencimentos imediatamente e depois a cada intervalo
+// configurado, até o canal stop ser fechado. Intervalo zero desativa a rotina.
+func (s *DocumentExpiryService) RunDocumentExpiryAlerts(stop <-chan struct{}) {
+	if s.cfg.ExpiryCheckInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.cfg.ExpiryCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		created, err := s.NotifyExpiringDocuments()
+		if err != nil {
+			log.Printf("Erro ao verificar o vencimento de documentos: %v", err)
+		} else if created > 0 {
+			log.Printf("Vencimento de documentos: %d notificações criadas", created)
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// expiringDocument monta o DTO do documento a vencer, calculando os dias restantes a partir de hoje
+func expiringDocument(document models.Document, today time.Time) dto.ApiExpiringDocument {
+	validate := startOfDay(*document.Validate)
+	result := dto.ApiExpiringDocument{
+		ID:            document.ID,
+		Type:          document.Type,
+		Number:        document.Number,
+		Validate:      validate,
+		DaysRemaining: int(validate.Sub(today).Hours() / 24),
+		IsMandatory:   document.IsMandatory,
+	}
+	result.Expired = result.DaysRemaining < 0
+
+	if document.SupplierID != nil {
+		result.OwnerType = models.OwnerSupplier
+		result.OwnerID = *document.SupplierID
+		if document.Supplier != nil {
+			result.OwnerName = dto.SupplierDisplayName(*document.Supplier)
+		}
+	} else if document.CustomerID != nil {
+		result.OwnerType = models.OwnerCustomer
+		result.OwnerID = *document.CustomerID
+		if document.Customer != nil {
+			result.OwnerName = dto.CustomerDisplayName(*document.Customer)
+		}
+	}
+	return result
+}
+
+// documentNotification monta a notificação do documento, sem o destinatário. A chave inclui a validade
+// para que um documento renovado volte a gerar alertas no próximo vencimento.
+func documentNotification(document dto.ApiExpiringDocument) models.Notification {
+	owner := "cliente"
+	if document.OwnerType == models.OwnerSupplier {
+		owner = "fornecedor"
+	}
+	validate := document.Validate.Format("02/01/2006")
+
+	notification := models.Notification{
+		Type:          models.NotificationTypeDocumentExpiring,
+		Title:         fmt.Sprintf("Documento %s a vencer", document.Type),
+		Message:       fmt.Sprintf("O documento %s do %s %s vence em %s (%d dias).", document.Type, owner, document.OwnerName, validate, document.DaysRemaining),
+		ReferenceType: "document",
+		ReferenceID:   &document.ID,
+	}
+	if document.DaysRemaining == 0 {
+		notification.Message = fmt.Sprintf("O documento %s do %s %s vence hoje (%s).", document.Type, owner, document.OwnerName, validate)
+	}
+	if document.Expired {
+		notification.Type = models.NotificationTypeDocumentExpired
+		notification.Title = fmt.Sprintf("Documento %s vencido", document.Type)
+		notification.Message = fmt.Sprintf("O documento %s do %s %s venceu em %s.", document.Type, owner, document.OwnerName, validate)
+	}
+	notification.Key = fmt.Sprintf("%s:%d:%s", notification.Type, document.ID, document.Validate.Format("2006-01-02"))
+	return notification
+}
+
+// documentOwnerCreator retorna o usuário que cadastrou o cliente ou fornecedor dono do documento
+func documentOwnerCreator(document models.Document) *uint {
+	if document.Supplier != nil {
+		return document.Supplier.CreatedByID
+	}
+	if document.Customer != nil {
+		return document.Customer.CreatedByID
+	}
+	return nil
+}
+
+// startOfDay retorna a meia-noite (UTC) do dia da data informada, a mesma referência das datas sem horário
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
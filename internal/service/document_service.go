@@ -0,0 +1,141 @@
+package service
+
+import (
+	dto "simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/utils/brdoc"
+	"simple-erp-service/internal/validator"
+	"strings"
+)
+
+// DocumentService gerencia os documentos de clientes e fornecedores
+type DocumentService struct {
+	documentRepo repository.DocumentRepository
+	owners       ownerLookup
+	validator    *validator.DocumentValidator
+}
+
+// NewDocumentService cria um novo serviço de documentos
+func NewDocumentService(
+	documentRepo repository.DocumentRepository,
+	locationRepo repository.LocationRepository,
+	customerRepo repository.CustomerRepository,
+	supplierRepo repository.SupplierRepository,
+) *DocumentService {
+	return &DocumentService{
+		documentRepo: documentRepo,
+		owners:       ownerLookup{customerRepo: customerRepo, supplierRepo: supplierRepo},
+		validator:    validator.NewDocumentValidator(documentRepo, locationRepo),
+	}
+}
+
+// GetDocuments retorna os documentos de um cliente ou fornecedor
+func (s *DocumentService) GetDocuments(owner models.Owner) ([]dto.ApiDocument, error) {
+	if _, err := s.owners.personType(owner); err != nil {
+		return nil, err
+	}
+
+	documents, err := s.documentRepo.FindByOwner(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.ApiDocumentsFromModel(documents), nil
+}
+
+// CreateDocument cria um documento
+func (s *DocumentService) CreateDocument(owner models.Owner, req models.CreateDocumentRequest) (*dto.ApiDocument, error) {
+	personType, err := s.owners.personType(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validar dados
+	if err := s.validator.ValidateForCreation(personType, req); err != nil {
+		return nil, err
+	}
+
+	customerID, supplierID := owner.IDs()
+	document := models.Document{
+		Type:         req.Type,
+		Number:       brdoc.Clean(req.Number),
+		Validate:     req.Validate,
+		EmissionDate: req.EmissionDate,
+		Department:   strings.TrimSpace(req.Department),
+		StateID:      req.StateID,
+		IsMandatory:  req.IsMandatory,
+		CustomerID:   customerID,
+		SupplierID:   supplierID,
+	}
+
+	if err := s.documentRepo.Create(&document); err != nil {
+		return nil, err
+	}
+
+	return s.getDocument(owner, document.ID)
+}
+
+// UpdateDocument atualiza um documento existente
+func (s *DocumentService) UpdateDocument(owner models.Owner, id uint, req models.UpdateDocumentRequest) (*dto.ApiDocument, error) {
+	personType, err := s.owners.personType(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := s.documentRepo.FindByIDAndOwner(id, owner)
+	if err != nil {
+		return nil, err
+	}
+	if document == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	// Validar dados
+	if err := s.validator.ValidateForUpdate(id, personType, req); err != nil {
+		return nil, err
+	}
+
+	document.Type = req.Type
+	document.Number = brdoc.Clean(req.Number)
+	document.Validate = req.Validate
+	document.EmissionDate = req.EmissionDate
+	document.Department = strings.TrimSpace(req.Department)
+	document.StateID = req.StateID
+	document.IsMandatory = req.IsMandatory
+	document.State = nil
+
+	if err := s.documentRepo.Update(document); err != nil {
+		return nil, err
+	}
+
+	return s.getDocument(owner, document.ID)
+}
+
+// DeleteDocument exclui um documento (soft delete)
+func (s *DocumentService) DeleteDocument(owner models.Owner, id uint) error {
+	document, err := s.documentRepo.FindByIDAndOwner(id, owner)
+	if err != nil {
+		return err
+	}
+	if document == nil {
+		return utils.ErrNotFound
+	}
+
+	return s.documentRepo.Delete(id)
+}
+
+// getDocument recarrega o documento com a UF para montar o DTO
+func (s *DocumentService) getDocument(owner models.Owner, id uint) (*dto.ApiDocument, error) {
+	document, err := s.documentRepo.FindByIDAndOwner(id, owner)
+	if err != nil {
+		return nil, err
+	}
+	if document == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	documentDTO := dto.ApiDocumentFromModel(*document)
+	return &documentDTO, nil
+}
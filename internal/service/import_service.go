@@ -0,0 +1,511 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/utils/brdoc"
+	"simple-erp-service/internal/utils/spreadsheet"
+	"simple-erp-service/internal/validator"
+	"strings"
+	"time"
+)
+
+const (
+	importBatchSize  = 100  // Linhas gravadas por transação
+	importMaxRows    = 5000 // Limite de linhas por arquivo
+	importSampleRows = 5    // Linhas devolvidas na pré-visualização
+
+	importInterruptedMessage = "importação interrompida pela reinicialização do servidor"
+)
+
+// importField descreve um campo de destino e os cabeçalhos reconhecidos na sugestão de mapeamento
+type importField struct {
+	Key      string
+	Label    string
+	Required bool
+	Aliases  []string
+}
+
+// partyImportFields são os campos importáveis de clientes e fornecedores.
+// Quando o tipo de pessoa não é mapeado ele é deduzido pelo tamanho do documento.
+var partyImportFields = []importField{
+	{"first_name", "Nome", true, []string{"nome", "primeironome", "firstname", "name", "nomefantasia"}},
+	{"last_name", "Sobrenome", false, []string{"sobrenome", "lastname"}},
+	{"person_type", "Tipo de pessoa (F/J)", false, []string{"tipo", "tipopessoa", "tipodepessoa", "persontype"}},
+	{"document_number", "CPF/CNPJ", true, []string{"cpfcnpj", "cpf", "cnpj", "documento", "document", "documentnumber"}},
+	{"company_name", "Razão social", false, []string{"razaosocial", "empresa", "companyname"}},
+	{"is_active", "Ativo", false, []string{"ativo", "situacao", "status", "isactive"}},
+	{"notes", "Observações", false, []string{"observacoes", "observacao", "obs", "notas", "notes"}},
+}
+
+// partyRow representa uma linha da planilha já convertida para os campos de cliente/fornecedor
+type partyRow struct {
+	Line           int
+	FirstName      string
+	LastName       string
+	PersonType     string
+	DocumentNumber string
+	CompanyName    string
+	IsActive       bool
+	Notes          string
+}
+
+// partyImporter abstrai a validação e a gravação de clientes ou fornecedores importados
+type partyImporter interface {
+	validate(row partyRow) error
+	createBatch(rows []partyRow, userID uint) error
+}
+
+// ImportService gerencia a importação em lote de clientes e fornecedores
+type ImportService struct {
+	jobRepo   repository.ImportJobRepository
+	importers map[string]partyImporter
+}
+
+// NewImportService cria um novo serviço de importação
+func NewImportService(
+	jobRepo repository.ImportJobRepository,
+	customerRepo repository.CustomerRepository,
+	supplierRepo repository.SupplierRepository,
+) *ImportService {
+	return &ImportService{
+		jobRepo: jobRepo,
+		importers: map[string]partyImporter{
+			models.OwnerCustomer: &customerImporter{repo: customerRepo, validator: validator.NewCustomerValidator(customerRepo)},
+			models.OwnerSupplier: &supplierImporter{repo: supplierRepo, validator: validator.NewSupplierValidator(supplierRepo)},
+		},
+	}
+}
+
+// Preview monta a etapa de mapeamento de colunas a partir da planilha enviada
+func (s *ImportService) Preview(fileName string, sheet *spreadsheet.Sheet) *dto.ApiImportPreview {
+	fields := make([]dto.ApiImportField, 0, len(partyImportFields))
+	for _, field := range partyImportFields {
+		fields = append(fields, dto.ApiImportField{Key: field.Key, Label: field.Label, Required: field.Required})
+	}
+
+	sample := sheet.Rows
+	if len(sample) > importSampleRows {
+		sample = sample[:importSampleRows]
+	}
+
+	return &dto.ApiImportPreview{
+		FileName:         fileName,
+		Headers:          sheet.Headers,
+		SampleRows:       sample,
+		TotalRows:        len(sheet.Rows),
+		Fields:           fields,
+		SuggestedMapping: suggestMapping(sheet.Headers),
+	}
+}
+
+// DryRun valida todas as linhas sem gravar nada e devolve o relatório de erros por linha
+func (s *ImportService) DryRun(entity string, sheet *spreadsheet.Sheet, mapping map[string]string) (*dto.ApiImportReport, error) {
+	importer, err := s.prepare(entity, sheet, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := mapRows(sheet, mapping)
+	valid, rowErrors, err := validateRows(importer, rows, make(map[string]int))
+	if err != nil {
+		return nil, err
+	}
+
+	if rowErrors == nil {
+		rowErrors = []models.ImportRowError{}
+	}
+	return &dto.ApiImportReport{
+		TotalRows:   len(rows),
+		ValidRows:   len(valid),
+		InvalidRows: len(rowErrors),
+		RowErrors:   rowErrors,
+	}, nil
+}
+
+// StartImport cria o job de importação e grava as linhas válidas em segundo plano.
+// O andamento pode ser acompanhado por GetJob.
+func (s *ImportService) StartImport(entity, fileName string, sheet *spreadsheet.Sheet, mapping map[string]string, userID uint) (*dto.ApiImportJob, error) {
+	importer, err := s.prepare(entity, sheet, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	job := models.ImportJob{
+		Entity:      entity,
+		FileName:    fileName,
+		Status:      models.ImportStatusPending,
+		TotalRows:   len(sheet.Rows),
+		CreatedByID: &userID,
+	}
+	if err := s.jobRepo.Create(&job); err != nil {
+		return nil, err
+	}
+
+	go s.run(job, importer, mapRows(sheet, mapping), userID)
+
+	jobDTO := dto.ApiImportJobFromModel(job)
+	return &jobDTO, nil
+}
+
+// GetJob retorna o andamento de um job de importação. Somente quem iniciou a importação pode consultá-la.
+func (s *ImportService) GetJob(id, userID uint) (*dto.ApiImportJob, error) {
+	job, err := s.jobRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil || job.CreatedByID == nil || *job.CreatedByID != userID {
+		return nil, utils.ErrNotFound
+	}
+
+	jobDTO := dto.ApiImportJobFromModel(*job)
+	return &jobDTO, nil
+}
+
+// prepare valida o tipo de importação, o tamanho da planilha e o mapeamento de colunas
+func (s *ImportService) prepare(entity string, sheet *spreadsheet.Sheet, mapping map[string]string) (partyImporter, error) {
+	importer, ok := s.importers[entity]
+	if !ok {
+		return nil, utils.ErrInvalidInput
+	}
+
+	var errors validator.ValidationErrors
+	if len(sheet.Rows) == 0 {
+		errors.AddError("file", "a planilha não possui linhas de dados")
+	}
+	if len(sheet.Rows) > importMaxRows {
+		errors.AddError("file", fmt.Sprintf("a planilha excede o limite de %d linhas", importMaxRows))
+	}
+
+	headers := make(map[string]bool, len(sheet.Headers))
+	for _, header := range sheet.Headers {
+		headers[header] = true
+	}
+
+	known := make(map[string]bool, len(partyImportFields))
+	for _, field := range partyImportFields {
+		known[field.Key] = true
+		if field.Required && mapping[field.Key] == "" {
+			errors.AddError("mapping."+field.Key, "o campo "+field.Label+" deve ser mapeado para uma coluna")
+		}
+	}
+	for key, column := range mapping {
+		if !known[key] {
+			errors.AddError("mapping."+key, "campo de destino desconhecido")
+		} else if column != "" && !headers[column] {
+			errors.AddError("mapping."+key, "a coluna \""+column+"\" não existe na planilha")
+		}
+	}
+
+	if errors.HasErrors() {
+		return nil, errors
+	}
+	return importer, nil
+}
+
+// run processa o job em segundo plano, gravando as linhas válidas em lotes e registrando o progresso
+func (s *ImportService) run(job models.ImportJob, importer partyImporter, rows []partyRow, userID uint) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Importação %d interrompida: %v", job.ID, r)
+			s.finish(&job, models.ImportStatusFailed, fmt.Sprint(r))
+		}
+	}()
+
+	now := time.Now()
+	job.Status = models.ImportStatusRunning
+	job.StartedAt = &now
+	s.saveProgress(&job)
+
+	// Os documentos já vistos valem para a planilha inteira, como no DryRun, e não só para o lote
+	seen := make(map[string]int)
+	for start := 0; start < len(rows); start += importBatchSize {
+		end := start + importBatchSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+		batch := rows[start:end]
+
+		valid, rowErrors, err := validateRows(importer, batch, seen)
+		if err != nil {
+			s.finish(&job, models.ImportStatusFailed, err.Error())
+			return
+		}
+
+		inserted, insertErrors := insertRows(importer, valid, userID)
+		rowErrors = append(rowErrors, insertErrors...)
+
+		job.ProcessedRows += len(batch)
+		job.InsertedRows += inserted
+		job.FailedRows += len(rowErrors)
+		job.RowErrors = append(job.RowErrors, rowErrors...)
+		s.saveProgress(&job)
+	}
+
+	s.finish(&job, models.ImportStatusCompleted, "")
+}
+
+// FailInterruptedJobs marca como falhos os jobs pendentes ou em andamento. Deve ser chamado na
+// inicialização do servidor: a goroutine que processava esses jobs foi perdida na parada anterior.
+func (s *ImportService) FailInterruptedJobs() (int64, error) {
+	return s.jobRepo.FailUnfinished(importInterruptedMessage, time.Now())
+}
+
+// finish encerra o job com a situação informada
+func (s *ImportService) finish(job *models.ImportJob, status, message string) {
+	now := time.Now()
+	job.Status = status
+	job.Message = message
+	job.FinishedAt = &now
+	s.saveProgress(job)
+}
+
+func (s *ImportService) saveProgress(job *models.ImportJob) {
+	if err := s.jobRepo.Update(job); err != nil {
+		log.Printf("Erro ao atualizar o progresso da importação %d: %v", job.ID, err)
+	}
+}
+
+// validateRows valida as linhas, incluindo documentos repetidos na planilha. seen guarda a linha em que cada
+// documento apareceu primeiro e é atualizado a cada chamada, para que a checagem atravesse os lotes.
+func validateRows(importer partyImporter, rows []partyRow, seen map[string]int) ([]partyRow, []models.ImportRowError, error) {
+	var (
+		valid     []partyRow
+		rowErrors []models.ImportRowError
+	)
+
+	for _, row := range rows {
+		var fieldErrors validator.ValidationErrors
+
+		if err := importer.validate(row); err != nil {
+			if !validator.IsValidationError(err) {
+				return nil, nil, err
+			}
+			fieldErrors = validator.GetValidationErrors(err)
+		}
+
+		// Na API estes campos são exigidos pelo binding, que não passa pela importação
+		if row.FirstName == "" {
+			fieldErrors.AddError("first_name", "o nome é obrigatório")
+		}
+		if row.DocumentNumber == "" {
+			fieldErrors.AddError("document_number", "o documento é obrigatório")
+		}
+
+		document := brdoc.Clean(row.DocumentNumber)
+		if line, ok := seen[document]; ok && document != "" {
+			fieldErrors.AddError("document_number", fmt.Sprintf("documento repetido na linha %d da planilha", line))
+		} else {
+			seen[document] = row.Line
+		}
+
+		if fieldErrors.HasErrors() {
+			rowErrors = append(rowErrors, newImportRowError(row.Line, fieldErrors))
+			continue
+		}
+		valid = append(valid, row)
+	}
+
+	return valid, rowErrors, nil
+}
+
+// insertRows grava o lote em uma transação. Se o lote falhar (ex: documento cadastrado por outro
+// usuário durante a importação), as linhas são gravadas uma a uma para isolar as problemáticas.
+func insertRows(importer partyImporter, rows []partyRow, userID uint) (int, []models.ImportRowError) {
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	if err := importer.createBatch(rows, userID); err == nil {
+		return len(rows), nil
+	}
+
+	inserted := 0
+	var rowErrors []models.ImportRowError
+	for _, row := range rows {
+		if err := importer.createBatch([]partyRow{row}, userID); err != nil {
+			rowErrors = append(rowErrors, models.ImportRowError{
+				Row:    row.Line,
+				Errors: []models.ImportFieldError{{Field: "row", Message: "erro ao gravar: " + err.Error()}},
+			})
+			continue
+		}
+		inserted++
+	}
+	return inserted, rowErrors
+}
+
+func newImportRowError(line int, errors validator.ValidationErrors) models.ImportRowError {
+	fieldErrors := make([]models.ImportFieldError, 0, len(errors))
+	for _, e := range errors {
+		fieldErrors = append(fieldErrors, models.ImportFieldError{Field: e.Field, Message: e.Message})
+	}
+	return models.ImportRowError{Row: line, Errors: fieldErrors}
+}
+
+// mapRows converte as linhas da planilha para partyRow de acordo com o mapeamento campo -> coluna, com o
+// número da linha na planilha original
+func mapRows(sheet *spreadsheet.Sheet, mapping map[string]string) []partyRow {
+	rows := make([]partyRow, 0, len(sheet.Rows))
+	for i, values := range sheet.Rows {
+		record := sheet.Record(values)
+		value := func(key string) string {
+			if column := mapping[key]; column != "" {
+				return record[column]
+			}
+			return ""
+		}
+
+		document := value("document_number")
+		personType := parsePersonType(value("person_type"), document)
+
+		rows = append(rows, partyRow{
+			Line:           sheet.RowNumbers[i],
+			FirstName:      value("first_name"),
+			LastName:       value("last_name"),
+			PersonType:     personType,
+			DocumentNumber: padDocument(personType, document),
+			CompanyName:    value("company_name"),
+			IsActive:       parseActive(value("is_active")),
+			Notes:          value("notes"),
+		})
+	}
+	return rows
+}
+
+// parsePersonType aceita F/J, PF/PJ e os nomes por extenso; se vazio, deduz pelo tamanho do documento
+func parsePersonType(value, document string) string {
+	switch spreadsheet.NormalizeHeader(value) {
+	case "f", "pf", "fisica", "pessoafisica":
+		return brdoc.PersonTypeIndividual
+	case "j", "pj", "juridica", "pessoajuridica":
+		return brdoc.PersonTypeCompany
+	case "":
+		if len(brdoc.Clean(document)) > 11 {
+			return brdoc.PersonTypeCompany
+		}
+		return brdoc.PersonTypeIndividual
+	}
+	return strings.ToUpper(value)
+}
+
+// padDocument recupera os zeros à esquerda perdidos quando o documento foi digitado como número na planilha
+func padDocument(personType, document string) string {
+	cleaned := brdoc.Clean(document)
+	if cleaned == "" || cleaned != brdoc.OnlyDigits(cleaned) {
+		return document
+	}
+
+	size := 11
+	if personType == brdoc.PersonTypeCompany {
+		size = 14
+	}
+	if len(cleaned) < size {
+		return strings.Repeat("0", size-len(cleaned)) + cleaned
+	}
+	return document
+}
+
+// parseActive interpreta a coluna de situação; valores vazios ou não reconhecidos são considerados ativos
+func parseActive(value string) bool {
+	switch spreadsheet.NormalizeHeader(value) {
+	case "n", "nao", "false", "0", "inativo", "inativa", "i":
+		return false
+	}
+	return true
+}
+
+// suggestMapping associa os campos de destino aos cabeçalhos com nomes conhecidos
+func suggestMapping(headers []string) map[string]string {
+	mapping := make(map[string]string)
+	for _, field := range partyImportFields {
+		for _, header := range headers {
+			normalized := spreadsheet.NormalizeHeader(header)
+			if normalized == "" {
+				continue
+			}
+			for _, alias := range field.Aliases {
+				if normalized == alias {
+					mapping[field.Key] = header
+				}
+			}
+			if mapping[field.Key] != "" {
+				break
+			}
+		}
+	}
+	return mapping
+}
+
+// customerImporter valida e grava clientes importados
+type customerImporter struct {
+	repo      repository.CustomerRepository
+	validator *validator.CustomerValidator
+}
+
+func (i *customerImporter) validate(row partyRow) error {
+	return i.validator.ValidateForCreation(models.CreateCustomerRequest{
+		FirstName:      row.FirstName,
+		LastName:       row.LastName,
+		PersonType:     row.PersonType,
+		DocumentNumber: row.DocumentNumber,
+		CompanyName:    row.CompanyName,
+		IsActive:       row.IsActive,
+		Notes:          row.Notes,
+	})
+}
+
+func (i *customerImporter) createBatch(rows []partyRow, userID uint) error {
+	customers := make([]models.Customer, 0, len(rows))
+	for _, row := range rows {
+		customers = append(customers, models.Customer{
+			FirstName:      row.FirstName,
+			LastName:       row.LastName,
+			PersonType:     row.PersonType,
+			DocumentNumber: brdoc.Clean(row.DocumentNumber),
+			CompanyName:    row.CompanyName,
+			IsActive:       row.IsActive,
+			Notes:          row.Notes,
+			CreatedByID:    &userID,
+		})
+	}
+	return i.repo.CreateBatch(customers)
+}
+
+// supplierImporter valida e grava fornecedores importados
+type supplierImporter struct {
+	repo      repository.SupplierRepository
+	validator *validator.SupplierValidator
+}
+
+func (i *supplierImporter) validate(row partyRow) error {
+	return i.validator.ValidateForCreation(models.CreateSupplierRequest{
+		FirstName:      row.FirstName,
+		LastName:       row.LastName,
+		PersonType:     row.PersonType,
+		DocumentNumber: row.DocumentNumber,
+		CompanyName:    row.CompanyName,
+		IsActive:       row.IsActive,
+		Notes:          row.Notes,
+	})
+}
+
+func (i *supplierImporter) createBatch(rows []partyRow, userID uint) error {
+	suppliers := make([]models.Supplier, 0, len(rows))
+	for _, row := range rows {
+		suppliers = append(suppliers, models.Supplier{
+			FirstName:      row.FirstName,
+			LastName:       row.LastName,
+			PersonType:     row.PersonType,
+			DocumentNumber: brdoc.Clean(row.DocumentNumber),
+			CompanyName:    row.CompanyName,
+			IsActive:       row.IsActive,
+			Notes:          row.Notes,
+			CreatedByID:    &userID,
+		})
+	}
+	return i.repo.CreateBatch(suppliers)
+}
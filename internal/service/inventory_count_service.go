@@ -0,0 +1,409 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	dto "simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/validator"
+
+	"gorm.io/gorm"
+)
+
+// ErrInventoryCountNotOpen é retornado ao contar, aprovar ou cancelar um inventário que não está aberto
+var ErrInventoryCountNotOpen = errors.New("o inventário não está aberto")
+
+// InventoryCountService gerencia os inventários: a abertura com os saldos esperados, as contagens, as
+// recontagens e a aprovação com os ajustes de estoque
+type InventoryCountService struct {
+	countRepo    repository.InventoryCountRepository
+	locationRepo repository.StockLocationRepository
+	categoryRepo repository.ProductCategoryRepository
+}
+
+// NewInventoryCountService cria um novo serviço de inventários
+func NewInventoryCountService(
+	countRepo repository.InventoryCountRepository,
+	locationRepo repository.StockLocationRepository,
+	categoryRepo repository.ProductCategoryRepository,
+) *InventoryCountService {
+	return &InventoryCountService{
+		countRepo:    countRepo,
+		locationRepo: locationRepo,
+		categoryRepo: categoryRepo,
+	}
+}
+
+// GetCounts retorna uma lista paginada e filtrada de inventários
+func (s *InventoryCountService) GetCounts(pagination *models.Pagination, filters dto.InGetInventoryCountsFilters) (*dto.ApiInventoryCountListPaginated, error) {
+	counts, err := s.countRepo.FindAll(pagination, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	countDTOs := make([]dto.ApiInventoryCount, 0, len(counts))
+	for _, count := range counts {
+		countDTOs = append(countDTOs, dto.ApiInventoryCountFromModel(count))
+	}
+
+	return &dto.ApiInventoryCountListPaginated{
+		Counts:     countDTOs,
+		Pagination: *dto.ApiPaginationFromModel(pagination),
+	}, nil
+}
+
+// GetCountByID busca um inventário pelo ID com os itens e as contagens. No inventário cego aberto, as
+// quantidades não são exibidas.
+func (s *InventoryCountService) GetCountByID(id uint) (*dto.ApiInventoryCount, error) {
+	count, err := s.countRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if count == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	countDTO := dto.ApiInventoryCountFromModel(*count)
+	return &countDTO, nil
+}
+
+// CreateCount abre um inventário do local, da categoria ou dos dois, copiando para os itens os saldos atuais
+// (por lote, nos produtos com controle de lotes) e o custo de cada produto
+func (s *InventoryCountService) CreateCount(req models.CreateInventoryCountRequest, userID uint) (*dto.ApiInventoryCount, error) {
+	var validationErrors validator.ValidationErrors
+
+	if req.LocationID == nil && req.CategoryID == nil {
+		validationErrors.AddError("location_id", "informe o local, a categoria ou os dois")
+		return nil, validationErrors
+	}
+	if req.LocationID != nil {
+		if _, err := resolveStockLocation(s.locationRepo, &validationErrors, "location_id", req.LocationID); err != nil {
+			return nil, err
+		}
+	}
+	if req.CategoryID != nil {
+		category, err := s.categoryRepo.FindByID(*req.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+		if category == nil {
+			validationErrors.AddError("category_id", "categoria não encontrada")
+		}
+	}
+	if validationErrors.HasErrors() {
+		return nil, validationErrors
+	}
+
+	items, err := s.countRepo.FindExpectedItems(req.LocationID, req.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		validationErrors.AddError("location_id", "nenhum saldo de estoque a inventariar no local e na categoria informados")
+		return nil, validationErrors
+	}
+	for i := range items {
+		items[i].Round = 1
+		items[i].Status = models.CountItemStatusPending
+	}
+
+	count := models.InventoryCount{
+		Description: req.Description,
+		LocationID:  req.LocationID,
+		CategoryID:  req.CategoryID,
+		Blind:       req.Blind,
+		Status:      models.InventoryCountStatusOpen,
+		Notes:       req.Notes,
+		CreatedByID: &userID,
+		Items:       items,
+	}
+	if err := s.countRepo.Create(&count); err != nil {
+		return nil, err
+	}
+
+	return s.GetCountByID(count.ID)
+}
+
+// SubmitCounts registra as quantidades contadas pelo usuário na rodada atual de cada item. Uma nova contagem
+// do mesmo usuário na mesma rodada substitui a anterior. Quando as contagens da rodada coincidem, o item fica
+// contado com essa quantidade; quando divergem, o item vai para recontagem em uma nova rodada.
+func (s *InventoryCountService) SubmitCounts(id uint, req models.SubmitInventoryCountRequest, userID uint) (*dto.ApiInventoryCount, error) {
+	err := s.countRepo.GetDB().Transaction(func(tx *gorm.DB) error {
+		countRepo := repository.NewInventoryCountRepository(tx)
+		itemsByID, err := lockOpenCountItems(countRepo, id, countEntryItemIDs(req.Counts), "counts[%d].item_id")
+		if err != nil {
+			return err
+		}
+
+		for _, countReq := range req.Counts {
+			item := itemsByID[countReq.ItemID]
+			if err := submitCountEntry(countRepo, item, roundQuantity(countReq.Quantity), userID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetCountByID(id)
+}
+
+// RecountItems envia os itens informados para uma nova rodada de contagem, descartando a quantidade apurada
+func (s *InventoryCountService) RecountItems(id uint, req models.RecountInventoryCountRequest) (*dto.ApiInventoryCount, error) {
+	err := s.countRepo.GetDB().Transaction(func(tx *gorm.DB) error {
+		countRepo := repository.NewInventoryCountRepository(tx)
+		itemsByID, err := lockOpenCountItems(countRepo, id, req.ItemIDs, "item_ids[%d]")
+		if err != nil {
+			return err
+		}
+
+		for _, item := range itemsByID {
+			item.Round++
+			item.Status = models.CountItemStatusRecount
+			item.CountedQuantity = nil
+			if err := countRepo.UpdateItem(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetCountByID(id)
+}
+
+// ApproveCount aprova um inventário com todos os itens contados: cada diferença entre o contado e o
+// esperado gera uma movimentação de ajuste no local (e no lote) do item, com o inventário como referência.
+// As movimentações feitas depois da abertura são preservadas, pois o ajuste é a diferença apurada.
+func (s *InventoryCountService) ApproveCount(id uint, userID uint) (*dto.ApiInventoryCount, error) {
+	err := s.countRepo.GetDB().Transaction(func(tx *gorm.DB) error {
+		countRepo := repository.NewInventoryCountRepository(tx)
+		count, err := countRepo.FindByIDForUpdate(id)
+		if err != nil {
+			return err
+		}
+		if count == nil {
+			return utils.ErrNotFound
+		}
+		if count.Status != models.InventoryCountStatusOpen {
+			return ErrInventoryCountNotOpen
+		}
+
+		items, err := countRepo.FindItemsForUpdate(id, nil)
+		if err != nil {
+			return err
+		}
+		pending := 0
+		for _, item := range items {
+			if item.Status != models.CountItemStatusCounted {
+				pending++
+			}
+		}
+		if pending > 0 {
+			var validationErrors validator.ValidationErrors
+			validationErrors.AddError("items", fmt.Sprintf("%d itens ainda não foram contados ou aguardam recontagem", pending))
+			return validationErrors
+		}
+
+		notes := fmt.Sprintf("Inventário #%d", count.ID)
+		for _, item := range items {
+			variance := roundQuantity(item.Variance())
+			if variance == 0 {
+				continue
+			}
+			_, err := recordStockMovement(tx, stockMovement{
+				ProductID:     item.ProductID,
+				LocationID:    &item.LocationID,
+				LotID:         item.LotID,
+				Quantity:      variance,
+				MovementType:  models.MovementTypeAdjustment,
+				ReferenceType: models.MovementReferenceCount,
+				ReferenceID:   &count.ID,
+				Notes:         notes,
+				UserID:        userID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		count.Status = models.InventoryCountStatusApproved
+		count.ApprovedAt = &now
+		count.ApprovedByID = &userID
+		return countRepo.Update(count)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetCountByID(id)
+}
+
+// CancelCount cancela um inventário aberto, sem ajustar o estoque
+func (s *InventoryCountService) CancelCount(id uint) (*dto.ApiInventoryCount, error) {
+	count, err := s.countRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if count == nil {
+		return nil, utils.ErrNotFound
+	}
+	if count.Status != models.InventoryCountStatusOpen {
+		return nil, ErrInventoryCountNotOpen
+	}
+
+	count.Status = models.InventoryCountStatusCancelled
+	if err := s.countRepo.Update(count); err != nil {
+		return nil, err
+	}
+
+	return s.GetCountByID(id)
+}
+
+// GetVarianceReport retorna as diferenças do inventário valorizadas pelo custo dos produtos na abertura: os
+// itens com diferença e os ainda não apurados, com os totais de sobras e faltas
+func (s *InventoryCountService) GetVarianceReport(id uint) (*dto.ApiInventoryCountVarianceReport, error) {
+	count, err := s.countRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if count == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	report := dto.ApiInventoryCountVarianceReport{
+		InventoryCountID: count.ID,
+		Status:           count.Status,
+		TotalItems:       len(count.Items),
+		Items:            make([]dto.ApiInventoryCountVariance, 0),
+	}
+	for _, item := range count.Items {
+		variance := dto.ApiInventoryCountVarianceFromModel(item)
+		if item.CountedQuantity == nil {
+			report.Items = append(report.Items, variance)
+			continue
+		}
+
+		report.CountedItems++
+		variance.Variance = roundQuantity(item.Variance())
+		if variance.Variance == 0 {
+			continue
+		}
+		variance.VarianceValue = roundMoney(variance.Variance * item.UnitCost)
+		report.DivergentItems++
+		if variance.VarianceValue > 0 {
+			report.SurplusValue += variance.VarianceValue
+		} else {
+			report.ShortageValue -= variance.VarianceValue
+		}
+		report.Items = append(report.Items, variance)
+	}
+	report.SurplusValue = roundMoney(report.SurplusValue)
+	report.ShortageValue = roundMoney(report.ShortageValue)
+	report.NetValue = roundMoney(report.SurplusValue - report.ShortageValue)
+
+	return &report, nil
+}
+
+// lockOpenCountItems bloqueia o inventário, que deve estar aberto, e os itens informados, que devem pertencer
+// a ele e não podem se repetir. Os erros de cada item são registrados no campo formatado com a posição.
+func lockOpenCountItems(countRepo repository.InventoryCountRepository, id uint, itemIDs []uint, field string) (map[uint]*models.InventoryCountItem, error) {
+	count, err := countRepo.FindByIDForUpdate(id)
+	if err != nil {
+		return nil, err
+	}
+	if count == nil {
+		return nil, utils.ErrNotFound
+	}
+	if count.Status != models.InventoryCountStatusOpen {
+		return nil, ErrInventoryCountNotOpen
+	}
+
+	items, err := countRepo.FindItemsForUpdate(id, itemIDs)
+	if err != nil {
+		return nil, err
+	}
+	itemsByID := make(map[uint]*models.InventoryCountItem, len(items))
+	for i := range items {
+		itemsByID[items[i].ID] = &items[i]
+	}
+
+	var validationErrors validator.ValidationErrors
+	seen := make(map[uint]bool, len(itemIDs))
+	for i, itemID := range itemIDs {
+		if _, ok := itemsByID[itemID]; !ok {
+			validationErrors.AddError(fmt.Sprintf(field, i), fmt.Sprintf("item %d não pertence ao inventário", itemID))
+			continue
+		}
+		if seen[itemID] {
+			validationErrors.AddError(fmt.Sprintf(field, i), fmt.Sprintf("item %d repetido", itemID))
+		}
+		seen[itemID] = true
+	}
+	if validationErrors.HasErrors() {
+		return nil, validationErrors
+	}
+	return itemsByID, nil
+}
+
+// submitCountEntry registra a contagem do usuário na rodada atual do item e apura o item: contado quando as
+// contagens da rodada coincidem, em recontagem (nova rodada) quando divergem
+func submitCountEntry(countRepo repository.InventoryCountRepository, item *models.InventoryCountItem, quantity float64, userID uint) error {
+	entries, err := countRepo.FindRoundEntries(item.ID, item.Round)
+	if err != nil {
+		return err
+	}
+
+	var entry *models.InventoryCountEntry
+	for i := range entries {
+		if entries[i].CountedByID != nil && *entries[i].CountedByID == userID {
+			entry = &entries[i]
+		}
+	}
+	if entry == nil {
+		entries = append(entries, models.InventoryCountEntry{
+			InventoryCountItemID: item.ID,
+			Round:                item.Round,
+			CountedByID:          &userID,
+		})
+		entry = &entries[len(entries)-1]
+	}
+	entry.Quantity = quantity
+	if err := countRepo.SaveEntry(entry); err != nil {
+		return err
+	}
+
+	agreed := true
+	for _, other := range entries {
+		if roundQuantity(other.Quantity) != quantity {
+			agreed = false
+		}
+	}
+	if agreed {
+		item.Status = models.CountItemStatusCounted
+		item.CountedQuantity = &quantity
+	} else {
+		item.Round++
+		item.Status = models.CountItemStatusRecount
+		item.CountedQuantity = nil
+	}
+	return countRepo.UpdateItem(item)
+}
+
+// countEntryItemIDs retorna os IDs dos itens das contagens informadas
+func countEntryItemIDs(counts []models.InventoryCountEntryRequest) []uint {
+	itemIDs := make([]uint, 0, len(counts))
+	for _, count := range counts {
+		itemIDs = append(itemIDs, count.ItemID)
+	}
+	return itemIDs
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"sort"
+	"time"
+
+	dto "simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/repository"
+)
+
+// InventoryValuationService calcula o valor do estoque pelo custo médio, em qualquer data, pelas
+// movimentações de estoque
+type InventoryValuationService struct {
+	movementRepo repository.InventoryMovementRepository
+}
+
+// NewInventoryValuationService cria um novo serviço de valorização do estoque
+func NewInventoryValuationService(movementRepo repository.InventoryMovementRepository) *InventoryValuationService {
+	return &InventoryValuationService{
+		movementRepo: movementRepo,
+	}
+}
+
+// GetValuation retorna o valor do estoque no fim do dia informado (sem data, hoje), por categoria e local,
+// com os totais de cada categoria e de cada local. O saldo é reconstruído pelas movimentações até a data e
+// valorizado pelo custo médio do produto naquele momento.
+func (s *InventoryValuationService) GetValuation(filters dto.InGetInventoryValuationFilters) (*dto.ApiInventoryValuation, error) {
+	date := filters.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+	date = startOfDay(date)
+
+	rows, err := s.movementRepo.FindValuation(date.AddDate(0, 0, 1), filters.CategoryID, filters.LocationID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.ApiInventoryValuation{
+		Date: date,
+		Rows: make([]dto.ApiInventoryValuationRow, 0, len(rows)),
+	}
+	byCategory := make(map[uint]*dto.ApiInventoryValuationTotal)
+	byLocation := make(map[uint]*dto.ApiInventoryValuationTotal)
+	addTo := func(totals map[uint]*dto.ApiInventoryValuationTotal, id *uint, name string, value float64) {
+		var key uint // Zero: sem categoria ou sem local
+		if id != nil {
+			key = *id
+		}
+		total, ok := totals[key]
+		if !ok {
+			total = &dto.ApiInventoryValuationTotal{ID: id, Name: name}
+			totals[key] = total
+		}
+		total.Value = roundMoney(total.Value + value)
+	}
+	for _, r := range rows {
+		row := dto.ApiInventoryValuationRowFromModel(r)
+		result.Rows = append(result.Rows, row)
+		result.TotalValue = roundMoney(result.TotalValue + row.Value)
+		addTo(byCategory, row.CategoryID, row.CategoryName, row.Value)
+		locationName := row.LocationCode
+		if row.LocationName != "" {
+			locationName = row.LocationCode + " - " + row.LocationName
+		}
+		addTo(byLocation, row.LocationID, locationName, row.Value)
+	}
+
+	result.ByCategory = sortedValuationTotals(byCategory)
+	result.ByLocation = sortedValuationTotals(byLocation)
+	return result, nil
+}
+
+// sortedValuationTotals ordena os totais pelo nome, com os sem categoria ou sem local no final
+func sortedValuationTotals(totals map[uint]*dto.ApiInventoryValuationTotal) []dto.ApiInventoryValuationTotal {
+	result := make([]dto.ApiInventoryValuationTotal, 0, len(totals))
+	for _, total := range totals {
+		result = append(result, *total)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if (a.ID == nil) != (b.ID == nil) {
+			return b.ID == nil
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.ID != nil && *a.ID < *b.ID
+	})
+	return result
+}
@@ -0,0 +1,357 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"simple-erp-service/config"
+	dto "simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/utils/brdoc"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// AnonymizedCustomerName substitui o nome dos clientes anonimizados
+	AnonymizedCustomerName = "Titular anonimizado"
+	// anonymizedDocumentPrefix identifica o CPF/CNPJ substituído na anonimização (ANON-<id>)
+	anonymizedDocumentPrefix = "ANON-"
+	// redactedImportMessage substitui as mensagens de erro de importação que citavam dados pessoais
+	redactedImportMessage = "mensagem removida na anonimização de dados pessoais"
+	// minPersonalDataTermLength é o tamanho mínimo de um dado pessoal procurado nos textos livres, para
+	// que termos curtos não apaguem registros de outras pessoas
+	minPersonalDataTermLength = 4
+)
+
+// ErrAlreadyAnonymized indica que os dados pessoais do cliente já foram totalmente anonimizados
+var ErrAlreadyAnonymized = errors.New("os dados do cliente já foram anonimizados")
+
+// fiscalDocumentTypes são os documentos mantidos enquanto durar a guarda obrigatória dos registros fiscais
+var fiscalDocumentTypes = []string{"CPF", "CNPJ"}
+
+// auditPersonalDataKeys são os campos com dados pessoais removidos dos detalhes da auditoria na anonimização
+var auditPersonalDataKeys = []string{
+	"merged_document_number", "merged_name",
+	"document_number", "first_name", "last_name", "company_name", "name", "email", "phone", "contact", "notes",
+}
+
+// LGPDService atende às solicitações dos titulares de dados pessoais (acesso/portabilidade e anonimização)
+type LGPDService struct {
+	customerRepo  repository.CustomerRepository
+	systemLogRepo repository.SystemLogRepository
+	cfg           config.LGPDConfig
+}
+
+// NewLGPDService cria um novo serviço de LGPD
+func NewLGPDService(
+	customerRepo repository.CustomerRepository,
+	systemLogRepo repository.SystemLogRepository,
+	cfg config.LGPDConfig,
+) *LGPDService {
+	return &LGPDService{
+		customerRepo:  customerRepo,
+		systemLogRepo: systemLogRepo,
+		cfg:           cfg,
+	}
+}
+
+// ExportCustomerData reúne tudo o que é mantido sobre o cliente: cadastro, endereços, contatos, documentos,
+// vendas, lançamentos financeiros e registros de auditoria. A própria solicitação é registrada na
+// auditoria antes da coleta, de modo que também aparece no pacote exportado.
+func (s *LGPDService) ExportCustomerData(customerID uint, format string, userID uint, ipAddress string) (*dto.ApiCustomerDataExport, error) {
+	customer, err := s.customerRepo.FindByIDWithRelations(customerID)
+	if err != nil {
+		return nil, err
+	}
+	if customer == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	err = s.systemLogRepo.Create(&models.SystemLog{
+		UserID:     &userID,
+		Action:     "customers.lgpd.export",
+		EntityType: "customers",
+		EntityID:   strconv.FormatUint(uint64(customer.ID), 10),
+		IPAddress:  ipAddress,
+		Details:    map[string]interface{}{"format": format},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sales, transactions, err := s.customerRepo.FindSalesAndTransactions(customer.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := s.systemLogRepo.FindByEntity("customers", strconv.FormatUint(uint64(customer.ID), 10))
+	if err != nil {
+		return nil, err
+	}
+	auditEntries := make([]dto.ApiAuditEntry, 0, len(logs))
+	for _, log := range logs {
+		auditEntries = append(auditEntries, dto.ApiAuditEntryFromModel(log))
+	}
+
+	return &dto.ApiCustomerDataExport{
+		GeneratedAt:  time.Now(),
+		Customer:     dto.ApiCustomerDetailFromModel(*customer),
+		Sales:        sales,
+		Transactions: transactions,
+		AuditEntries: auditEntries,
+	}, nil
+}
+
+// CustomerDataArchive monta o pacote ZIP da exportação, com um arquivo JSON por seção
+func CustomerDataArchive(export *dto.ApiCustomerDataExport) ([]byte, error) {
+	files := []struct {
+		name string
+		data interface{}
+	}{
+		{"cliente.json", export.Customer},
+		{"vendas.json", export.Sales},
+		{"lancamentos.json", export.Transactions},
+		{"auditoria.json", export.AuditEntries},
+	}
+
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+	for _, file := range files {
+		writer, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     file.name,
+			Method:   zip.Deflate,
+			Modified: export.GeneratedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(file.data); err != nil {
+			return nil, err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// AnonymizeCustomer remove de forma irreversível os dados pessoais do cliente: nome, observações,
+// endereços, contatos e documentos são apagados definitivamente (sem soft delete), e os dados pessoais são
+// retirados dos detalhes da auditoria do cliente e das mesclagens em que ele foi o duplicado. Os textos
+// livres gravados fora do cadastro também são limpos: as consultas registradas na auditoria e os erros
+// de importação que citam o nome, o documento ou os contatos do cliente, as observações das vendas e as
+// dos eventos dos números de série vendidos a ele. Os lotes entregues e as movimentações de estoque das
+// vendas não têm texto digitado (guardam só o código da venda) e ficam como estão. As vendas e os
+// lançamentos continuam vinculados ao cliente. Enquanto durar a guarda obrigatória dos registros fiscais
+// (LGPD_FISCAL_RETENTION_YEARS após a última movimentação) o CPF/CNPJ é mantido; depois desse prazo,
+// uma nova anonimização remove também o documento. Tudo em uma única transação e registrado na auditoria.
+func (s *LGPDService) AnonymizeCustomer(customerID uint, userID uint, ipAddress string) (*dto.ApiCustomerAnonymization, error) {
+	now := time.Now()
+	result := dto.ApiCustomerAnonymization{CustomerID: customerID}
+
+	err := s.customerRepo.GetDB().Transaction(func(tx *gorm.DB) error {
+		customerRepo := repository.NewCustomerRepository(tx)
+
+		customer, err := customerRepo.FindByID(customerID)
+		if err != nil {
+			return err
+		}
+		if customer == nil {
+			return utils.ErrNotFound
+		}
+		if customer.AnonymizedAt != nil && strings.HasPrefix(customer.DocumentNumber, anonymizedDocumentPrefix) {
+			return ErrAlreadyAnonymized
+		}
+
+		lastActivity, err := customerRepo.LastFiscalActivity(customer.ID)
+		if err != nil {
+			return err
+		}
+		if lastActivity != nil {
+			retainedUntil := lastActivity.AddDate(s.cfg.FiscalRetentionYears, 0, 0)
+			if now.Before(retainedUntil) {
+				result.DocumentRetained = true
+				result.RetainedUntil = &retainedUntil
+			}
+		}
+
+		owner := models.Owner{Type: models.OwnerCustomer, ID: customer.ID}
+		contactRepo := repository.NewContactRepository(tx)
+		documentRepo := repository.NewDocumentRepository(tx)
+
+		// Os dados pessoais são lidos antes de apagados, para procurá-los nos textos livres
+		contacts, err := contactRepo.FindByOwner(owner)
+		if err != nil {
+			return err
+		}
+		documents, err := documentRepo.FindByOwner(owner)
+		if err != nil {
+			return err
+		}
+		terms := personalDataTerms(*customer, contacts, documents)
+
+		if result.RemovedAddresses, err = repository.NewAddressRepository(tx).DeleteByOwner(owner); err != nil {
+			return err
+		}
+		if result.RemovedContacts, err = contactRepo.DeleteByOwner(owner); err != nil {
+			return err
+		}
+		var keepTypes []string
+		if result.DocumentRetained {
+			keepTypes = fiscalDocumentTypes
+		}
+		if result.RemovedDocuments, err = documentRepo.DeleteByOwner(owner, keepTypes...); err != nil {
+			return err
+		}
+
+		customer.FirstName = AnonymizedCustomerName
+		customer.LastName = ""
+		customer.Notes = ""
+		customer.CreditBlockReason = ""
+		customer.IsActive = false
+		// A razão social de pessoa jurídica não é dado pessoal e identifica os registros fiscais
+		if customer.PersonType != "J" {
+			customer.CompanyName = ""
+		}
+		if !result.DocumentRetained {
+			customer.DocumentNumber = anonymizedDocumentPrefix + strconv.FormatUint(uint64(customer.ID), 10)
+		}
+		if customer.AnonymizedAt == nil {
+			customer.AnonymizedAt = &now
+		}
+		customer.UpdatedByID = &userID
+		if err := customerRepo.Update(customer); err != nil {
+			return err
+		}
+		result.AnonymizedAt = *customer.AnonymizedAt
+
+		systemLogRepo := repository.NewSystemLogRepository(tx)
+		entityID := strconv.FormatUint(uint64(customer.ID), 10)
+		if result.RedactedAuditEntries, err = systemLogRepo.RedactDetails("customers", entityID, "merged_id", auditPersonalDataKeys); err != nil {
+			return err
+		}
+		redactedQueries, err := systemLogRepo.RedactQueries(terms)
+		if err != nil {
+			return err
+		}
+		result.RedactedAuditEntries += redactedQueries
+		if result.RedactedImportJobs, err = redactImportErrors(repository.NewImportJobRepository(tx), terms); err != nil {
+			return err
+		}
+		if result.ClearedSaleNotes, err = repository.NewSaleRepository(tx).ClearCustomerNotes(customer.ID); err != nil {
+			return err
+		}
+		if result.ClearedSerialEvents, err = repository.NewSerialNumberRepository(tx).ClearCustomerEventNotes(customer.ID); err != nil {
+			return err
+		}
+
+		// Os detalhes do log não podem guardar os dados pessoais removidos
+		return systemLogRepo.Create(&models.SystemLog{
+			UserID:     &userID,
+			Action:     "customers.lgpd.anonymize",
+			EntityType: "customers",
+			EntityID:   entityID,
+			IPAddress:  ipAddress,
+			Details: map[string]interface{}{
+				"document_retained":      result.DocumentRetained,
+				"retained_until":         result.RetainedUntil,
+				"removed_addresses":      result.RemovedAddresses,
+				"removed_contacts":       result.RemovedContacts,
+				"removed_documents":      result.RemovedDocuments,
+				"redacted_audit_entries": result.RedactedAuditEntries,
+				"redacted_import_jobs":   result.RedactedImportJobs,
+				"cleared_sale_notes":     result.ClearedSaleNotes,
+				"cleared_serial_events":  result.ClearedSerialEvents,
+			},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// personalDataTerms retorna o nome, os documentos e os contatos do cliente, nas formas em que costumam ser
+// digitados (com e sem máscara), para procurá-los nos textos livres durante a anonimização. Os valores já
+// anonimizados e os curtos demais são ignorados.
+func personalDataTerms(customer models.Customer, contacts []models.Contact, documents []models.Document) []string {
+	values := []string{customer.FirstName + " " + customer.LastName}
+	if customer.PersonType != "J" {
+		values = append(values, customer.CompanyName)
+	}
+
+	numbers := []string{customer.DocumentNumber}
+	for _, document := range documents {
+		numbers = append(numbers, document.Number)
+	}
+	for _, number := range numbers {
+		if strings.HasPrefix(number, anonymizedDocumentPrefix) {
+			continue
+		}
+		values = append(values, number, brdoc.Clean(number), brdoc.FormatDocument(number))
+	}
+
+	for _, contact := range contacts {
+		values = append(values, contact.Contact)
+		if contact.Type != models.ContactTypeEmail {
+			values = append(values, brdoc.NormalizePhone(contact.Contact))
+		}
+	}
+
+	terms := make([]string, 0, len(values))
+	seen := make(map[string]bool, len(values))
+	for _, value := range values {
+		term := strings.ToLower(strings.TrimSpace(value))
+		if len(term) < minPersonalDataTermLength || seen[term] || term == strings.ToLower(AnonymizedCustomerName) {
+			continue
+		}
+		seen[term] = true
+		terms = append(terms, term)
+	}
+	return terms
+}
+
+// redactImportErrors substitui, nos erros por linha dos jobs de importação, as mensagens que citam algum dos
+// termos informados. Retorna quantos jobs foram alterados.
+func redactImportErrors(jobRepo repository.ImportJobRepository, terms []string) (int64, error) {
+	jobs, err := jobRepo.FindByRowErrorsContaining(terms)
+	if err != nil {
+		return 0, err
+	}
+
+	var redacted int64
+	for _, job := range jobs {
+		changed := false
+		for i := range job.RowErrors {
+			for j := range job.RowErrors[i].Errors {
+				message := strings.ToLower(job.RowErrors[i].Errors[j].Message)
+				for _, term := range terms {
+					if strings.Contains(message, term) {
+						job.RowErrors[i].Errors[j].Message = redactedImportMessage
+						changed = true
+						break
+					}
+				}
+			}
+		}
+		if !changed {
+			continue
+		}
+		if err := jobRepo.Update(&job); err != nil {
+			return 0, err
+		}
+		redacted++
+	}
+	return redacted, nil
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"sort"
+
+	dto "simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/utils/ibge"
+)
+
+// Quantidade de cidades gravadas por comando na importação do IBGE
+const municipalityImportBatchSize = 500
+
+// LocationService gerencia os dados geográficos (países, estados e cidades)
+type LocationService struct {
+	locationRepo repository.LocationRepository
+}
+
+// NewLocationService cria um novo serviço de dados geográficos
+func NewLocationService(locationRepo repository.LocationRepository) *LocationService {
+	return &LocationService{
+		locationRepo: locationRepo,
+	}
+}
+
+// MunicipalityImportResult resume uma importação de municípios do IBGE
+type MunicipalityImportResult struct {
+	Imported   int      // Municípios inseridos ou atualizados
+	MissingUFs []string // Códigos IBGE das UFs sem estado cadastrado; seus municípios são ignorados
+	Skipped    int      // Municípios ignorados por falta do estado
+}
+
+// GetCountries retorna os países cadastrados
+func (s *LocationService) GetCountries() ([]dto.ApiCountry, error) {
+	countries, err := s.locationRepo.FindCountries()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.ApiCountry, len(countries))
+	for i, country := range countries {
+		result[i] = dto.ApiCountryFromModel(country)
+	}
+	return result, nil
+}
+
+// GetStates retorna os estados do país
+func (s *LocationService) GetStates(countryID uint) ([]dto.ApiState, error) {
+	country, err := s.locationRepo.FindCountryByID(countryID)
+	if err != nil {
+		return nil, err
+	}
+	if country == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	states, err := s.locationRepo.FindStatesByCountry(countryID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.ApiState, len(states))
+	for i, state := range states {
+		result[i] = dto.ApiStateFromModel(state)
+	}
+	return result, nil
+}
+
+// GetCities retorna as cidades do estado, filtradas pelo nome
+func (s *LocationService) GetCities(stateID uint, filters dto.InGetCitiesFilters) ([]dto.ApiCity, error) {
+	state, err := s.locationRepo.FindStateByID(stateID)
+	if err != nil {
+		return nil, err
+	}
+	if state == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	cities, err := s.locationRepo.FindCitiesByState(stateID, filters.Search, filters.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.ApiCity, len(cities))
+	for i, city := range cities {
+		result[i] = dto.ApiCityFromModel(city)
+	}
+	return result, nil
+}
+
+// ImportMunicipalities grava os municípios do IBGE, associando cada um ao estado pelo código da UF.
+// A importação é idempotente: municípios já cadastrados (pelo código IBGE) têm o nome e o estado atualizados.
+// Os estados devem estar cadastrados; municípios de UFs desconhecidas são ignorados e informados no resultado.
+func (s *LocationService) ImportMunicipalities(municipalities []ibge.Municipality) (*MunicipalityImportResult, error) {
+	states, err := s.locationRepo.FindAllStates()
+	if err != nil {
+		return nil, err
+	}
+	stateIDs := make(map[string]uint, len(states))
+	for _, state := range states {
+		stateIDs[state.IBGECode] = state.ID
+	}
+
+	result := &MunicipalityImportResult{}
+	missing := make(map[string]bool)
+	cities := make([]models.City, 0, len(municipalities))
+	for _, municipality := range municipalities {
+		stateID, ok := stateIDs[municipality.UFCode()]
+		if !ok {
+			missing[municipality.UFCode()] = true
+			result.Skipped++
+			continue
+		}
+		cities = append(cities, models.City{
+			Name:     municipality.Name,
+			IBGECode: municipality.Code,
+			StateID:  stateID,
+		})
+	}
+
+	for uf := range missing {
+		result.MissingUFs = append(result.MissingUFs, uf)
+	}
+	sort.Strings(result.MissingUFs)
+
+	if len(cities) == 0 {
+		return result, nil
+	}
+	if err := s.locationRepo.UpsertCities(cities, municipalityImportBatchSize); err != nil {
+		return nil, err
+	}
+	result.Imported = len(cities)
+	return result, nil
+}
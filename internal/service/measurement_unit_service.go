@@ -0,0 +1,173 @@
+package service
+
+import (
+	dto "simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/validator"
+)
+
+// MeasurementUnitService gerencia as unidades de medida e as conversões entre elas
+type MeasurementUnitService struct {
+	unitRepo    repository.MeasurementUnitRepository
+	productRepo repository.ProductRepository
+	validator   *validator.UnitConversionValidator
+}
+
+// NewMeasurementUnitService cria um novo serviço de unidades de medida
+func NewMeasurementUnitService(unitRepo repository.MeasurementUnitRepository, productRepo repository.ProductRepository) *MeasurementUnitService {
+	return &MeasurementUnitService{
+		unitRepo:    unitRepo,
+		productRepo: productRepo,
+		validator:   validator.NewUnitConversionValidator(unitRepo, productRepo),
+	}
+}
+
+// GetUnits retorna todas as unidades de medida
+func (s *MeasurementUnitService) GetUnits() ([]dto.ApiMeasurementUnit, error) {
+	units, err := s.unitRepo.FindUnits()
+	if err != nil {
+		return nil, err
+	}
+
+	unitDTOs := make([]dto.ApiMeasurementUnit, 0, len(units))
+	for _, unit := range units {
+		unitDTOs = append(unitDTOs, dto.ApiMeasurementUnitFromModel(unit))
+	}
+	return unitDTOs, nil
+}
+
+// GetConversions retorna as conversões exclusivas do produto informado ou, sem produto, as globais
+func (s *MeasurementUnitService) GetConversions(filters dto.InGetUnitConversionsFilters) ([]dto.ApiUnitConversion, error) {
+	conversions, err := s.unitRepo.FindConversions(filters.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	conversionDTOs := make([]dto.ApiUnitConversion, 0, len(conversions))
+	for _, conversion := range conversions {
+		conversionDTOs = append(conversionDTOs, dto.ApiUnitConversionFromModel(conversion))
+	}
+	return conversionDTOs, nil
+}
+
+// CreateConversion cadastra uma conversão de unidades, global ou exclusiva de um produto
+func (s *MeasurementUnitService) CreateConversion(req models.CreateUnitConversionRequest) (*dto.ApiUnitConversion, error) {
+	if err := s.validator.ValidateForCreation(req); err != nil {
+		return nil, err
+	}
+
+	conversion := models.UnitConversion{
+		FromUnitID: req.FromUnitID,
+		ToUnitID:   req.ToUnitID,
+		Factor:     req.Factor,
+		ProductID:  req.ProductID,
+	}
+	if err := s.unitRepo.CreateConversion(&conversion); err != nil {
+		return nil, err
+	}
+
+	return s.getConversion(conversion.ID)
+}
+
+// UpdateConversion atualiza o fator de uma conversão de unidades. Os itens de compra e venda já
+// registrados mantêm o fator usado no momento do registro.
+func (s *MeasurementUnitService) UpdateConversion(id uint, req models.UpdateUnitConversionRequest) (*dto.ApiUnitConversion, error) {
+	conversion, err := s.unitRepo.FindConversionByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if conversion == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	conversion.Factor = req.Factor
+	if err := s.unitRepo.UpdateConversion(conversion); err != nil {
+		return nil, err
+	}
+
+	result := dto.ApiUnitConversionFromModel(*conversion)
+	return &result, nil
+}
+
+// DeleteConversion exclui uma conversão de unidades
+func (s *MeasurementUnitService) DeleteConversion(id uint) error {
+	conversion, err := s.unitRepo.FindConversionByID(id)
+	if err != nil {
+		return err
+	}
+	if conversion == nil {
+		return utils.ErrNotFound
+	}
+
+	return s.unitRepo.DeleteConversion(id)
+}
+
+// GetProductUnits retorna as unidades em que o produto pode ser comprado ou vendido: a unidade do
+// produto e as unidades com conversão para ela, exclusiva do produto ou global
+func (s *MeasurementUnitService) GetProductUnits(productID uint) ([]dto.ApiProductUnit, error) {
+	product, err := s.productRepo.FindByID(productID)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	units := make([]dto.ApiProductUnit, 0)
+	if product.UnitID == nil || product.Unit == nil {
+		return units, nil
+	}
+	units = append(units, dto.ApiProductUnit{
+		UnitID:       product.Unit.ID,
+		Name:         product.Unit.Name,
+		Abbreviation: product.Unit.Abbreviation,
+		Factor:       1,
+		IsBase:       true,
+	})
+
+	conversions, err := s.unitRepo.FindConversionsForProduct(product.ID, *product.UnitID)
+	if err != nil {
+		return nil, err
+	}
+
+	// As conversões do produto vêm antes das globais e prevalecem sobre elas
+	seen := map[uint]bool{*product.UnitID: true}
+	for _, conversion := range conversions {
+		unit := conversion.FromUnit
+		if conversion.FromUnitID == *product.UnitID {
+			unit = conversion.ToUnit
+		}
+		if unit == nil || seen[unit.ID] {
+			continue
+		}
+		seen[unit.ID] = true
+
+		factor, _ := conversionFactor(conversion, unit.ID, *product.UnitID)
+		units = append(units, dto.ApiProductUnit{
+			UnitID:       unit.ID,
+			Name:         unit.Name,
+			Abbreviation: unit.Abbreviation,
+			Factor:       factor,
+			IsExclusive:  conversion.ProductID != nil,
+			ConversionID: &conversion.ID,
+		})
+	}
+
+	return units, nil
+}
+
+// getConversion busca a conversão pelo ID, com as unidades
+func (s *MeasurementUnitService) getConversion(id uint) (*dto.ApiUnitConversion, error) {
+	conversion, err := s.unitRepo.FindConversionByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if conversion == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	result := dto.ApiUnitConversionFromModel(*conversion)
+	return &result, nil
+}
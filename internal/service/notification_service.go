@@ -0,0 +1,63 @@
+package service
+
+import (
+	"time"
+
+	dto "simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/utils"
+)
+
+// NotificationService gerencia as notificações exibidas aos usuários no sistema
+type NotificationService struct {
+	notificationRepo repository.NotificationRepository
+}
+
+// NewNotificationService cria um novo serviço de notificações
+func NewNotificationService(notificationRepo repository.NotificationRepository) *NotificationService {
+	return &NotificationService{
+		notificationRepo: notificationRepo,
+	}
+}
+
+// GetNotifications retorna as notificações do usuário com paginação e o total de não lidas
+func (s *NotificationService) GetNotifications(userID uint, pagination *models.Pagination, filters dto.InGetNotificationsFilters) (*dto.ApiNotificationListPaginated, error) {
+	notifications, err := s.notificationRepo.FindByUser(userID, pagination, filters.UnreadOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	unread, err := s.notificationRepo.CountUnread(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.ApiNotification, len(notifications))
+	for i, notification := range notifications {
+		result[i] = dto.ApiNotificationFromModel(notification)
+	}
+
+	return &dto.ApiNotificationListPaginated{
+		Notifications: result,
+		Unread:        unread,
+		Pagination:    *dto.ApiPaginationFromModel(pagination),
+	}, nil
+}
+
+// MarkAsRead marca uma notificação do usuário como lida
+func (s *NotificationService) MarkAsRead(id, userID uint) error {
+	found, err := s.notificationRepo.MarkAsRead(id, userID, time.Now())
+	if err != nil {
+		return err
+	}
+	if found == 0 {
+		return utils.ErrNotFound
+	}
+	return nil
+}
+
+// MarkAllAsRead marca todas as notificações do usuário como lidas, retornando quantas foram marcadas
+func (s *NotificationService) MarkAllAsRead(userID uint) (int64, error) {
+	return s.notificationRepo.MarkAllAsRead(userID, time.Now())
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/validator"
+
+	"gorm.io/gorm"
+)
+
+// ownerLookup localiza o cliente ou fornecedor dono de endereços, contatos e documentos
+type ownerLookup struct {
+	customerRepo repository.CustomerRepository
+	supplierRepo repository.SupplierRepository
+}
+
+// personType retorna o tipo de pessoa (F/J) do dono, ou utils.ErrNotFound se ele não existir
+func (l ownerLookup) personType(owner models.Owner) (string, error) {
+	switch owner.Type {
+	case models.OwnerCustomer:
+		customer, err := l.customerRepo.FindByID(owner.ID)
+		if err != nil {
+			return "", err
+		}
+		if customer == nil {
+			return "", utils.ErrNotFound
+		}
+		return customer.PersonType, nil
+	case models.OwnerSupplier:
+		supplier, err := l.supplierRepo.FindByID(owner.ID)
+		if err != nil {
+			return "", err
+		}
+		if supplier == nil {
+			return "", utils.ErrNotFound
+		}
+		return supplier.PersonType, nil
+	default:
+		return "", utils.ErrInvalidInput
+	}
+}
+
+// relationLinker vincula endereços, contatos e documentos já existentes a um cliente ou fornecedor
+type relationLinker struct {
+	addressRepo  repository.AddressRepository
+	contactRepo  repository.ContactRepository
+	documentRepo repository.DocumentRepository
+}
+
+// newRelationLinker cria um relationLinker cujos repositories usam a conexão informada, em geral a transação em curso
+func newRelationLinker(db *gorm.DB) relationLinker {
+	return relationLinker{
+		addressRepo:  repository.NewAddressRepository(db),
+		contactRepo:  repository.NewContactRepository(db),
+		documentRepo: repository.NewDocumentRepository(db),
+	}
+}
+
+// link valida e vincula os IDs enviados pelo front. Registros que pertencem a outro cadastro são rejeitados.
+func (l relationLinker) link(owner models.Owner, documentIDs, addressIDs, contactIDs []uint) error {
+	var errors validator.ValidationErrors
+
+	if len(documentIDs) > 0 {
+		documents, err := l.documentRepo.FindByIDs(documentIDs)
+		if err != nil {
+			return err
+		}
+		if len(documents) != len(uniqueIDs(documentIDs)) {
+			errors.AddError("documents_ids", "um ou mais documentos não existem")
+		}
+		for _, document := range documents {
+			if !canLink(owner, document.CustomerID, document.SupplierID) {
+				errors.AddError("documents_ids", "um ou mais documentos pertencem a outro cadastro")
+				break
+			}
+		}
+	}
+
+	if len(addressIDs) > 0 {
+		addresses, err := l.addressRepo.FindByIDs(addressIDs)
+		if err != nil {
+			return err
+		}
+		if len(addresses) != len(uniqueIDs(addressIDs)) {
+			errors.AddError("adresses_ids", "um ou mais endereços não existem")
+		}
+		for _, address := range addresses {
+			if !canLink(owner, address.CustomerID, address.SupplierID) {
+				errors.AddError("adresses_ids", "um ou mais endereços pertencem a outro cadastro")
+				break
+			}
+		}
+	}
+
+	if len(contactIDs) > 0 {
+		contacts, err := l.contactRepo.FindByIDs(contactIDs)
+		if err != nil {
+			return err
+		}
+		if len(contacts) != len(uniqueIDs(contactIDs)) {
+			errors.AddError("contacts_ids", "um ou mais contatos não existem")
+		}
+		for _, contact := range contacts {
+			if !canLink(owner, contact.CustomerID, contact.SupplierID) {
+				errors.AddError("contacts_ids", "um ou mais contatos pertencem a outro cadastro")
+				break
+			}
+		}
+	}
+
+	if errors.HasErrors() {
+		return errors
+	}
+
+	if len(documentIDs) > 0 {
+		if err := l.documentRepo.AssignToOwner(documentIDs, owner); err != nil {
+			return err
+		}
+	}
+	if len(addressIDs) > 0 {
+		if err := l.addressRepo.AssignToOwner(addressIDs, owner); err != nil {
+			return err
+		}
+		if err := l.addressRepo.PromoteOldest(owner); err != nil {
+			return err
+		}
+	}
+	if len(contactIDs) > 0 {
+		if err := l.contactRepo.AssignToOwner(contactIDs, owner); err != nil {
+			return err
+		}
+		if err := l.contactRepo.PromoteOldest(owner); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// canLink verifica se o registro está livre ou já pertence ao dono informado
+func canLink(owner models.Owner, customerID, supplierID *uint) bool {
+	if customerID == nil && supplierID == nil {
+		return true
+	}
+	return owner.Owns(customerID, supplierID)
+}
+
+// uniqueIDs remove IDs repetidos
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	result := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+
+	"simple-erp-service/internal/repository"
+)
+
+// PostalCodeAddress é o endereço de um CEP retornado por um PostalCodeProvider
+type PostalCodeAddress struct {
+	ZipCode      string
+	Street       string
+	Neighborhood string
+	Complement   string
+	CityIBGECode string // Usado para associar o CEP à cidade cadastrada
+}
+
+// PostalCodeProvider consulta o endereço de um CEP com 8 dígitos, sem máscara. Retorna nil quando o
+// CEP não é encontrado. Provedores remotos devem implementar esta interface e podem gravar as respostas
+// na base local, usando o LocalPostalCodeProvider como cache.
+type PostalCodeProvider interface {
+	Lookup(ctx context.Context, zipCode string) (*PostalCodeAddress, error)
+}
+
+// LocalPostalCodeProvider consulta a base de CEPs importada no banco, sem chamadas externas
+type LocalPostalCodeProvider struct {
+	postalCodeRepo repository.PostalCodeRepository
+}
+
+// NewLocalPostalCodeProvider cria um provedor de CEPs sobre a base local
+func NewLocalPostalCodeProvider(postalCodeRepo repository.PostalCodeRepository) *LocalPostalCodeProvider {
+	return &LocalPostalCodeProvider{
+		postalCodeRepo: postalCodeRepo,
+	}
+}
+
+// Lookup busca o CEP na base local
+func (p *LocalPostalCodeProvider) Lookup(ctx context.Context, zipCode string) (*PostalCodeAddress, error) {
+	postalCode, err := p.postalCodeRepo.FindByZipCode(zipCode)
+	if err != nil || postalCode == nil {
+		return nil, err
+	}
+
+	return &PostalCodeAddress{
+		ZipCode:      postalCode.ZipCode,
+		Street:       postalCode.Street,
+		Neighborhood: postalCode.Neighborhood,
+		Complement:   postalCode.Complement,
+		CityIBGECode: postalCode.City.IBGECode,
+	}, nil
+}
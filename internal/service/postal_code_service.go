@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	dto "simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/utils/brdoc"
+	"simple-erp-service/internal/utils/cep"
+	"simple-erp-service/internal/validator"
+)
+
+// Quantidade de CEPs gravados por comando na importação da base
+const postalCodeImportBatchSize = 1000
+
+// PostalCodeService consulta CEPs para o preenchimento de endereços e importa a base local
+type PostalCodeService struct {
+	provider       PostalCodeProvider
+	locationRepo   repository.LocationRepository
+	postalCodeRepo repository.PostalCodeRepository
+}
+
+// NewPostalCodeService cria um novo serviço de CEPs com o provedor informado
+func NewPostalCodeService(
+	provider PostalCodeProvider,
+	locationRepo repository.LocationRepository,
+	postalCodeRepo repository.PostalCodeRepository,
+) *PostalCodeService {
+	return &PostalCodeService{
+		provider:       provider,
+		locationRepo:   locationRepo,
+		postalCodeRepo: postalCodeRepo,
+	}
+}
+
+// PostalCodeImportResult resume uma importação da base de CEPs
+type PostalCodeImportResult struct {
+	Imported      int      // CEPs inseridos ou atualizados
+	Skipped       int      // CEPs ignorados porque o município não está cadastrado
+	MissingCities []string // Códigos IBGE dos municípios não cadastrados
+}
+
+// Lookup retorna o endereço do CEP com a cidade cadastrada correspondente
+func (s *PostalCodeService) Lookup(ctx context.Context, zipCode string) (*dto.ApiPostalCode, error) {
+	if !brdoc.IsCEP(zipCode) {
+		var errors validator.ValidationErrors
+		errors.AddError("zip_code", "CEP inválido, informe 8 dígitos no formato 00000-000")
+		return nil, errors
+	}
+
+	address, err := s.provider.Lookup(ctx, brdoc.OnlyDigits(zipCode))
+	if err != nil {
+		return nil, err
+	}
+	if address == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	result := &dto.ApiPostalCode{
+		ZipCode:      address.ZipCode,
+		Street:       address.Street,
+		Neighborhood: address.Neighborhood,
+		Complement:   address.Complement,
+		CityIBGECode: address.CityIBGECode,
+	}
+
+	city, err := s.locationRepo.FindCityByIBGECode(address.CityIBGECode)
+	if err != nil {
+		return nil, err
+	}
+	if city != nil {
+		result.CityID = &city.ID
+		result.CityName = city.Name
+		result.StateID = &city.StateID
+		result.UF = city.State.UF
+	}
+	return result, nil
+}
+
+// Import grava os CEPs na base local. A importação é idempotente: CEPs já cadastrados têm o endereço
+// atualizado. Os municípios devem estar cadastrados (ver cmd/import-ibge); CEPs de municípios
+// desconhecidos são ignorados e informados no resultado.
+func (s *PostalCodeService) Import(records []cep.Record) (*PostalCodeImportResult, error) {
+	codes := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, record := range records {
+		if !seen[record.CityIBGECode] {
+			seen[record.CityIBGECode] = true
+			codes = append(codes, record.CityIBGECode)
+		}
+	}
+
+	cities, err := s.locationRepo.FindCitiesByIBGECodes(codes)
+	if err != nil {
+		return nil, err
+	}
+	cityIDs := make(map[string]uint, len(cities))
+	for _, city := range cities {
+		cityIDs[city.IBGECode] = city.ID
+	}
+
+	result := &PostalCodeImportResult{}
+	missing := make(map[string]bool)
+	postalCodes := make([]models.PostalCode, 0, len(records))
+	for _, record := range records {
+		cityID, ok := cityIDs[record.CityIBGECode]
+		if !ok {
+			missing[record.CityIBGECode] = true
+			result.Skipped++
+			continue
+		}
+		postalCodes = append(postalCodes, models.PostalCode{
+			ZipCode:      record.ZipCode,
+			Street:       record.Street,
+			Neighborhood: record.Neighborhood,
+			Complement:   record.Complement,
+			CityID:       cityID,
+		})
+	}
+
+	for code := range missing {
+		result.MissingCities = append(result.MissingCities, code)
+	}
+	sort.Strings(result.MissingCities)
+
+	if len(postalCodes) == 0 {
+		return result, nil
+	}
+	if err := s.postalCodeRepo.Upsert(postalCodes, postalCodeImportBatchSize); err != nil {
+		return nil, err
+	}
+	result.Imported = len(postalCodes)
+	return result, nil
+}
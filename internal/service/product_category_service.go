@@ -0,0 +1,162 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	dto "simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/validator"
+
+	"gorm.io/gorm"
+)
+
+// ErrCategoryInUse é retornado ao excluir, sem reatribuição, uma categoria com produtos ou subcategorias
+var ErrCategoryInUse = errors.New("a categoria possui produtos ou subcategorias")
+
+// Modos de exclusão de categorias
+const (
+	CategoryDeleteBlock    = "block"    // Recusa a exclusão se houver produtos ou subcategorias
+	CategoryDeleteReassign = "reassign" // Move os produtos e as subcategorias para a categoria pai
+)
+
+// ProductCategoryService gerencia a árvore de categorias de produtos
+type ProductCategoryService struct {
+	categoryRepo repository.ProductCategoryRepository
+	validator    *validator.ProductCategoryValidator
+}
+
+// NewProductCategoryService cria um novo serviço de categorias de produtos
+func NewProductCategoryService(categoryRepo repository.ProductCategoryRepository) *ProductCategoryService {
+	return &ProductCategoryService{
+		categoryRepo: categoryRepo,
+		validator:    validator.NewProductCategoryValidator(categoryRepo),
+	}
+}
+
+// GetTree retorna a árvore completa de categorias com as quantidades de produtos de cada nó
+func (s *ProductCategoryService) GetTree() ([]dto.ApiProductCategoryNode, error) {
+	nodes, err := s.categoryRepo.FindTree()
+	if err != nil {
+		return nil, err
+	}
+	return dto.ApiProductCategoryTreeFromNodes(nodes), nil
+}
+
+// CreateCategory cria uma categoria, na raiz ou sob a categoria pai informada
+func (s *ProductCategoryService) CreateCategory(req models.CreateProductCategoryRequest) (*dto.ApiProductCategory, error) {
+	if err := s.validator.ValidateForCreation(req); err != nil {
+		return nil, err
+	}
+
+	category := models.ProductCategory{
+		Name:        strings.TrimSpace(req.Name),
+		Description: strings.TrimSpace(req.Description),
+		ParentID:    req.ParentID,
+	}
+	if err := s.categoryRepo.Create(&category); err != nil {
+		return nil, err
+	}
+
+	result := dto.ApiProductCategoryFromModel(category)
+	return &result, nil
+}
+
+// UpdateCategory atualiza o nome e a descrição da categoria
+func (s *ProductCategoryService) UpdateCategory(id uint, req models.UpdateProductCategoryRequest) (*dto.ApiProductCategory, error) {
+	category, err := s.categoryRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if category == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	if err := s.validator.ValidateForUpdate(category, req); err != nil {
+		return nil, err
+	}
+
+	category.Name = strings.TrimSpace(req.Name)
+	category.Description = strings.TrimSpace(req.Description)
+	if err := s.categoryRepo.Update(category); err != nil {
+		return nil, err
+	}
+
+	result := dto.ApiProductCategoryFromModel(*category)
+	return &result, nil
+}
+
+// MoveCategory move a categoria, com todas as subcategorias, para o novo pai
+func (s *ProductCategoryService) MoveCategory(id uint, req models.MoveProductCategoryRequest) (*dto.ApiProductCategory, error) {
+	category, err := s.categoryRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if category == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	if err := s.validator.ValidateMove(category, req); err != nil {
+		return nil, err
+	}
+
+	category.ParentID = req.ParentID
+	if err := s.categoryRepo.Update(category); err != nil {
+		return nil, err
+	}
+
+	result := dto.ApiProductCategoryFromModel(*category)
+	return &result, nil
+}
+
+// DeleteCategory exclui a categoria. No modo reassign, os produtos e as subcategorias passam para a
+// categoria pai (ou ficam sem categoria / na raiz, quando ela é raiz); no modo block, a exclusão é
+// recusada com ErrCategoryInUse se a categoria tiver produtos ou subcategorias.
+func (s *ProductCategoryService) DeleteCategory(id uint, mode string) error {
+	category, err := s.categoryRepo.FindByID(id)
+	if err != nil {
+		return err
+	}
+	if category == nil {
+		return utils.ErrNotFound
+	}
+
+	if mode != CategoryDeleteReassign {
+		children, err := s.categoryRepo.CountChildren(id)
+		if err != nil {
+			return err
+		}
+		products, err := s.categoryRepo.CountProducts(id)
+		if err != nil {
+			return err
+		}
+		if children > 0 || products > 0 {
+			return ErrCategoryInUse
+		}
+		return s.categoryRepo.Delete(id)
+	}
+
+	// As subcategorias não podem repetir o nome de uma categoria que já está no novo nível
+	conflicts, err := s.categoryRepo.FindChildNameConflicts(id, category.ParentID)
+	if err != nil {
+		return err
+	}
+	if len(conflicts) > 0 {
+		var validationErrors validator.ValidationErrors
+		validationErrors.AddError("mode", "já existem categorias com os nomes das subcategorias no nível superior: "+strings.Join(conflicts, ", "))
+		return validationErrors
+	}
+
+	return s.categoryRepo.GetDB().Transaction(func(tx *gorm.DB) error {
+		categoryRepo := repository.NewProductCategoryRepository(tx)
+		if err := categoryRepo.ReassignChildren(id, category.ParentID); err != nil {
+			return err
+		}
+		if err := categoryRepo.ReassignProducts(id, category.ParentID); err != nil {
+			return err
+		}
+		return categoryRepo.Delete(id)
+	})
+}
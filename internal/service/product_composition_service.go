@@ -0,0 +1,323 @@
+package service
+
+import (
+	"fmt"
+	"math"
+
+	dto "simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/validator"
+
+	"gorm.io/gorm"
+)
+
+// ProductCompositionService gerencia a composição dos kits e a ficha técnica dos produtos fabricados
+type ProductCompositionService struct {
+	productRepo   repository.ProductRepository
+	componentRepo repository.ProductComponentRepository
+}
+
+// NewProductCompositionService cria um novo serviço de composição de produtos
+func NewProductCompositionService(productRepo repository.ProductRepository, componentRepo repository.ProductComponentRepository) *ProductCompositionService {
+	return &ProductCompositionService{
+		productRepo:   productRepo,
+		componentRepo: componentRepo,
+	}
+}
+
+// GetComposition retorna a composição do produto, com o estoque de cada componente e quantas unidades do
+// produto podem ser montadas ou produzidas com ele
+func (s *ProductCompositionService) GetComposition(productID uint) (*dto.ApiProductComposition, error) {
+	product, err := s.productRepo.FindByID(productID)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	components, err := s.componentRepo.FindByProduct(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	composition := dto.ApiProductComposition{
+		ProductID:  product.ID,
+		Kind:       product.Kind,
+		Components: make([]dto.ApiProductComponent, 0, len(components)),
+	}
+	for i, component := range components {
+		apiComponent := dto.ApiProductComponent{
+			ComponentID: component.ComponentID,
+			Quantity:    component.Quantity,
+		}
+		if component.Component != nil {
+			apiComponent.SKU = component.Component.SKU
+			apiComponent.Name = component.Component.Name
+			apiComponent.CurrentStock = component.Component.CurrentStock
+			if component.Component.Unit != nil {
+				apiComponent.UnitAbbreviation = component.Component.Unit.Abbreviation
+			}
+		}
+		apiComponent.Available = math.Max(math.Floor(apiComponent.CurrentStock/component.Quantity), 0)
+
+		if i == 0 || apiComponent.Available < composition.Available {
+			composition.Available = apiComponent.Available
+		}
+		composition.Components = append(composition.Components, apiComponent)
+	}
+
+	return &composition, nil
+}
+
+// SetComposition substitui a composição do produto. Somente kits e produtos fabricados têm composição; os
+// componentes devem ser produtos ativos, sem variantes (informe a variante), sem número de série e que não
+// sejam kits, e o produto não pode ser componente de si mesmo, direta ou indiretamente.
+func (s *ProductCompositionService) SetComposition(productID uint, req models.SetProductComponentsRequest) (*dto.ApiProductComposition, error) {
+	product, err := s.productRepo.FindByID(productID)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	var validationErrors validator.ValidationErrors
+	if !product.HasComposition() {
+		validationErrors.AddError("product_id", "somente kits e produtos fabricados têm composição: altere o tipo do produto antes")
+		return nil, validationErrors
+	}
+
+	componentIDs := make([]uint, 0, len(req.Components))
+	for _, componentReq := range req.Components {
+		componentIDs = append(componentIDs, componentReq.ComponentID)
+	}
+	products, err := s.productRepo.FindByIDs(componentIDs)
+	if err != nil {
+		return nil, err
+	}
+	productsByID := make(map[uint]models.Product, len(products))
+	for _, p := range products {
+		productsByID[p.ID] = p
+	}
+	summaries, err := s.productRepo.FindVariantSummaries(componentIDs)
+	if err != nil {
+		return nil, err
+	}
+	withVariants := make(map[uint]bool, len(summaries))
+	for _, summary := range summaries {
+		withVariants[summary.ParentID] = true
+	}
+
+	components := make([]models.ProductComponent, 0, len(req.Components))
+	seen := make(map[uint]bool, len(req.Components))
+	for i, componentReq := range req.Components {
+		field := fmt.Sprintf("components[%d].component_id", i)
+		component, ok := productsByID[componentReq.ComponentID]
+		if !ok {
+			validationErrors.AddError(field, fmt.Sprintf("produto %d não encontrado", componentReq.ComponentID))
+			continue
+		}
+		if seen[component.ID] {
+			validationErrors.AddError(field, fmt.Sprintf("produto %d repetido na composição", component.ID))
+			continue
+		}
+		seen[component.ID] = true
+		if !component.IsActive {
+			validationErrors.AddError(field, fmt.Sprintf("produto %d está inativo", component.ID))
+			continue
+		}
+		if component.Kind == models.ProductKindKit {
+			validationErrors.AddError(field, fmt.Sprintf("produto %d é um kit: informe os componentes dele", component.ID))
+			continue
+		}
+		if component.TracksSerials {
+			validationErrors.AddError(field, fmt.Sprintf("produto %d tem número de série e não pode ser componente", component.ID))
+			continue
+		}
+		if withVariants[component.ID] {
+			validationErrors.AddError(field, fmt.Sprintf("produto %d possui variantes: informe a variante", component.ID))
+			continue
+		}
+		cycle, err := s.componentRepo.IsUsedBy(component.ID, product.ID)
+		if err != nil {
+			return nil, err
+		}
+		if cycle {
+			validationErrors.AddError(field, fmt.Sprintf("produto %d é o próprio produto ou depende dele na sua composição", component.ID))
+			continue
+		}
+
+		components = append(components, models.ProductComponent{
+			ProductID:   product.ID,
+			ComponentID: component.ID,
+			Quantity:    roundQuantity(componentReq.Quantity),
+		})
+	}
+	if validationErrors.HasErrors() {
+		return nil, validationErrors
+	}
+
+	if err := s.componentRepo.Replace(product.ID, components); err != nil {
+		return nil, err
+	}
+
+	return s.GetComposition(product.ID)
+}
+
+// RecordSaleStock registra as saídas de estoque dos itens da venda, do local da venda ou do local padrão.
+// Os kits não têm estoque próprio e baixam os componentes, na quantidade da composição. Os produtos com
+// controle de lotes saem por ordem de validade (FEFO), e os lotes entregues ficam registrados em cada item.
+// Nos produtos com número de série, o item informa um número por unidade, vendido ao cliente da venda.
+// O estoque disponível deve cobrir os itens, e as reservas da própria venda são baixadas.
+// O custo médio na saída fica registrado em cada item como custo das mercadorias vendidas.
+// É chamada pelo faturamento da venda (SaleService.InvoiceSale), na mesma transação.
+func RecordSaleStock(tx *gorm.DB, sale models.Sale, userID uint) error {
+	if err := validateSaleStock(tx, sale); err != nil {
+		return err
+	}
+	if err := repository.NewStockReservationRepository(tx).CloseBySale(sale.ID, models.ReservationStatusConsumed); err != nil {
+		return err
+	}
+
+	productIDs := make([]uint, 0, len(sale.Items))
+	for _, item := range sale.Items {
+		productIDs = append(productIDs, item.ProductID)
+	}
+	products, err := repository.NewProductRepository(tx).FindByIDs(productIDs)
+	if err != nil {
+		return err
+	}
+	kits := make(map[uint]models.Product)
+	kitIDs := make([]uint, 0)
+	tracksLots := make(map[uint]bool)
+	productsByID := make(map[uint]*models.Product, len(products))
+	for i, product := range products {
+		if product.Kind == models.ProductKindKit {
+			kits[product.ID] = product
+			kitIDs = append(kitIDs, product.ID)
+		}
+		tracksLots[product.ID] = product.TracksLots
+		productsByID[product.ID] = &products[i]
+	}
+
+	var validationErrors validator.ValidationErrors
+	for i, item := range sale.Items {
+		validateSerialRequests(&validationErrors, fmt.Sprintf("items[%d].serial_numbers", i), productsByID[item.ProductID], item.Quantity, item.SerialNumbers)
+	}
+	if validationErrors.HasErrors() {
+		return validationErrors
+	}
+	components, err := repository.NewProductComponentRepository(tx).FindByProducts(kitIDs)
+	if err != nil {
+		return err
+	}
+	componentsByKit := make(map[uint][]models.ProductComponent, len(kitIDs))
+	for _, component := range components {
+		componentsByKit[component.ProductID] = append(componentsByKit[component.ProductID], component)
+		if component.Component != nil {
+			tracksLots[component.ComponentID] = component.Component.TracksLots
+		}
+	}
+
+	allocations := make([]models.SaleItemLot, 0)
+	costByItem := make(map[uint]float64, len(sale.Items))
+	recordOutput := func(item models.SaleItem, productID uint, quantity float64, notes string) error {
+		movements, err := recordStockOutput(tx, stockMovement{
+			ProductID:     productID,
+			LocationID:    sale.LocationID,
+			Quantity:      -quantity,
+			MovementType:  models.MovementTypeOut,
+			ReferenceType: models.MovementReferenceSale,
+			ReferenceID:   &sale.ID,
+			Notes:         notes,
+			UserID:        userID,
+		}, tracksLots[productID])
+		if err != nil {
+			return err
+		}
+		for _, movement := range movements {
+			costByItem[item.ID] += -movement.Quantity * movement.UnitCost
+			if movement.LotID == nil {
+				continue
+			}
+			allocations = append(allocations, models.SaleItemLot{
+				SaleID:     sale.ID,
+				SaleItemID: item.ID,
+				SaleDate:   sale.SaleDate,
+				CustomerID: sale.CustomerID,
+				ProductID:  productID,
+				LotID:      *movement.LotID,
+				LocationID: *movement.LocationID,
+				Quantity:   -movement.Quantity,
+			})
+		}
+		return nil
+	}
+
+	for _, item := range sale.Items {
+		kit, isKit := kits[item.ProductID]
+		if !isKit {
+			if err := recordOutput(item, item.ProductID, item.Quantity, "Venda "+sale.Code); err != nil {
+				return err
+			}
+			if err := recordSaleSerials(tx, sale, item, userID); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if len(componentsByKit[kit.ID]) == 0 {
+			return fmt.Errorf("o kit %s não possui composição", kit.SKU)
+		}
+		for _, component := range componentsByKit[kit.ID] {
+			quantity := roundQuantity(component.Quantity * item.Quantity)
+			if err := recordOutput(item, component.ComponentID, quantity, fmt.Sprintf("Venda %s: kit %s", sale.Code, kit.SKU)); err != nil {
+				return err
+			}
+		}
+	}
+
+	saleRepo := repository.NewSaleRepository(tx)
+	for _, item := range sale.Items {
+		cost := costByItem[item.ID]
+		if err := saleRepo.UpdateItemCost(item.ID, roundUnitPrice(cost/item.Quantity), roundMoney(cost)); err != nil {
+			return err
+		}
+	}
+
+	return repository.NewStockLotRepository(tx).CreateSaleAllocations(allocations)
+}
+
+// recordSaleSerials registra a venda dos números de série escolhidos no item, que devem estar em estoque no
+// local da venda
+func recordSaleSerials(tx *gorm.DB, sale models.Sale, item models.SaleItem, userID uint) error {
+	if len(item.SerialNumbers) == 0 {
+		return nil
+	}
+	locationID, err := movementLocationID(tx, sale.LocationID)
+	if err != nil {
+		return err
+	}
+
+	saleDate := sale.SaleDate
+	itemID := item.ID
+	return moveSerialNumbers(tx, item.ProductID, locationID, item.SerialNumbers, models.SerialNumberEvent{
+		EventType:     models.SerialEventSold,
+		ReferenceType: models.MovementReferenceSale,
+		ReferenceID:   &sale.ID,
+		LocationID:    &locationID,
+		CustomerID:    sale.CustomerID,
+		Notes:         "Venda " + sale.Code,
+		CreatedByID:   &userID,
+	}, func(serial *models.SerialNumber) {
+		serial.Status = models.SerialStatusSold
+		serial.LocationID = nil
+		serial.SaleID = &sale.ID
+		serial.SaleItemID = &itemID
+		serial.CustomerID = sale.CustomerID
+		serial.SoldAt = &saleDate
+	})
+}
@@ -0,0 +1,313 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	dto "simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/validator"
+
+	"gorm.io/gorm"
+)
+
+// ErrProductHasVariants é retornado ao excluir um produto que ainda possui variantes
+var ErrProductHasVariants = errors.New("o produto possui variantes")
+
+// ErrProductIsComponent é retornado ao excluir um produto usado na composição de kits ou produtos fabricados
+var ErrProductIsComponent = errors.New("o produto é componente de outros produtos")
+
+// ProductService gerencia operações relacionadas a produtos
+type ProductService struct {
+	productRepo     repository.ProductRepository
+	componentRepo   repository.ProductComponentRepository
+	reservationRepo repository.StockReservationRepository
+	validator       *validator.ProductValidator
+}
+
+// NewProductService cria um novo serviço de produtos
+func NewProductService(
+	productRepo repository.ProductRepository,
+	componentRepo repository.ProductComponentRepository,
+	reservationRepo repository.StockReservationRepository,
+) *ProductService {
+	return &ProductService{
+		productRepo:     productRepo,
+		componentRepo:   componentRepo,
+		reservationRepo: reservationRepo,
+		validator:       validator.NewProductValidator(productRepo, componentRepo),
+	}
+}
+
+// GetProducts retorna uma lista paginada e filtrada de produtos
+func (s *ProductService) GetProducts(pagination *models.Pagination, filters dto.InGetProductsFilters) (*dto.ApiProductListPaginated, error) {
+	products, err := s.productRepo.FindAll(pagination, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	// Converter para DTOs
+	productDTOs := make([]dto.ApiProduct, 0, len(products))
+	for _, product := range products {
+		productDTOs = append(productDTOs, dto.ApiProductFromModel(product))
+	}
+	if err := s.applyVariantSummaries(productDTOs); err != nil {
+		return nil, err
+	}
+	if err := s.applyKitAvailability(productDTOs); err != nil {
+		return nil, err
+	}
+	if err := s.applyStockReservations(productDTOs); err != nil {
+		return nil, err
+	}
+
+	return &dto.ApiProductListPaginated{
+		Products:   productDTOs,
+		Pagination: *dto.ApiPaginationFromModel(pagination),
+	}, nil
+}
+
+// GetProductByID busca um produto pelo ID
+func (s *ProductService) GetProductByID(id uint) (*dto.ApiProduct, error) {
+	product, err := s.productRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	productDTOs := []dto.ApiProduct{dto.ApiProductFromModel(*product)}
+	if err := s.applyVariantSummaries(productDTOs); err != nil {
+		return nil, err
+	}
+	if err := s.applyKitAvailability(productDTOs); err != nil {
+		return nil, err
+	}
+	if err := s.applyStockReservations(productDTOs); err != nil {
+		return nil, err
+	}
+	return &productDTOs[0], nil
+}
+
+// CreateProduct cria um novo produto. O estoque inicial é zero e só muda por movimentações.
+func (s *ProductService) CreateProduct(req models.CreateProductRequest, userID uint) (*dto.ApiProduct, error) {
+	// Validar dados
+	if err := s.validator.ValidateForCreation(req); err != nil {
+		return nil, err
+	}
+
+	product := models.Product{
+		SKU:          strings.TrimSpace(req.SKU),
+		Barcode:      optionalBarcode(req.Barcode),
+		Name:         strings.TrimSpace(req.Name),
+		Description:  req.Description,
+		CategoryID:   req.CategoryID,
+		UnitID:       req.UnitID,
+		CostPrice:    roundMoney(req.CostPrice),
+		SellingPrice: roundMoney(req.SellingPrice),
+		MinStock:     req.MinStock,
+		MaxStock:     req.MaxStock,
+		IsActive:     true, // Por padrão, produtos são criados ativos
+		Kind:         req.Kind,
+		TracksLots:   req.TracksLots,
+		CreatedByID:  &userID,
+
+		TracksSerials:  req.TracksSerials,
+		WarrantyMonths: req.WarrantyMonths,
+	}
+	if product.Kind == "" {
+		product.Kind = models.ProductKindSimple
+	}
+
+	if err := s.productRepo.Create(&product); err != nil {
+		return nil, err
+	}
+
+	return s.GetProductByID(product.ID)
+}
+
+// UpdateProduct atualiza um produto existente. O custo só é alterado sem estoque: com estoque, vale o custo
+// médio das entradas. Nas variantes, preços diferentes dos do produto pai passam a ser preços próprios; no
+// produto pai, a categoria e os preços são replicados para as variantes que não têm preço próprio.
+func (s *ProductService) UpdateProduct(id uint, req models.UpdateProductRequest) (*dto.ApiProduct, error) {
+	product, err := s.productRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	// Validar dados
+	if err := s.validator.ValidateForUpdate(product, req); err != nil {
+		return nil, err
+	}
+
+	product.SKU = strings.TrimSpace(req.SKU)
+	product.Barcode = optionalBarcode(req.Barcode)
+	product.Name = strings.TrimSpace(req.Name)
+	product.Description = req.Description
+	product.CategoryID = req.CategoryID
+	product.UnitID = req.UnitID
+	if product.CurrentStock == 0 {
+		product.CostPrice = roundMoney(req.CostPrice)
+	}
+	product.SellingPrice = roundMoney(req.SellingPrice)
+	product.MinStock = req.MinStock
+	product.MaxStock = req.MaxStock
+	product.IsActive = req.IsActive
+	if req.Kind != "" {
+		product.Kind = req.Kind
+	}
+	product.TracksLots = req.TracksLots
+	product.TracksSerials = req.TracksSerials
+	product.WarrantyMonths = req.WarrantyMonths
+
+	if product.ParentID != nil {
+		parent, err := s.productRepo.FindByID(*product.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		product.PriceOverride = parent != nil && (product.SellingPrice != parent.SellingPrice ||
+			(product.CurrentStock == 0 && product.CostPrice != parent.CostPrice))
+	}
+
+	err = s.productRepo.GetDB().Transaction(func(tx *gorm.DB) error {
+		productRepo := repository.NewProductRepository(tx)
+		if err := productRepo.Update(product); err != nil {
+			return err
+		}
+		if product.ParentID != nil {
+			return nil
+		}
+		return productRepo.SyncVariants(product)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetProductByID(id)
+}
+
+// DeleteProduct exclui um produto
+func (s *ProductService) DeleteProduct(id uint) error {
+	product, err := s.productRepo.FindByID(id)
+	if err != nil {
+		return err
+	}
+	if product == nil {
+		return utils.ErrNotFound
+	}
+
+	summaries, err := s.productRepo.FindVariantSummaries([]uint{id})
+	if err != nil {
+		return err
+	}
+	if len(summaries) > 0 {
+		return ErrProductHasVariants
+	}
+
+	isComponent, err := s.componentRepo.IsComponent(id)
+	if err != nil {
+		return err
+	}
+	if isComponent {
+		return ErrProductIsComponent
+	}
+
+	return s.productRepo.Delete(id)
+}
+
+// applyVariantSummaries preenche, nos produtos com variantes, a quantidade de variantes e o estoque total
+func (s *ProductService) applyVariantSummaries(products []dto.ApiProduct) error {
+	ids := make([]uint, 0, len(products))
+	for _, product := range products {
+		if product.ParentID == nil {
+			ids = append(ids, product.ID)
+		}
+	}
+	summaries, err := s.productRepo.FindVariantSummaries(ids)
+	if err != nil {
+		return err
+	}
+
+	byParent := make(map[uint]models.ProductVariantSummary, len(summaries))
+	for _, summary := range summaries {
+		byParent[summary.ParentID] = summary
+	}
+	for i := range products {
+		if summary, ok := byParent[products[i].ID]; ok {
+			products[i].VariantCount = summary.Variants
+			products[i].TotalStock = roundQuantity(products[i].CurrentStock + summary.Stock)
+		}
+	}
+	return nil
+}
+
+// applyKitAvailability preenche, nos kits, o estoque total com a quantidade que pode ser montada com o
+// estoque dos componentes, já que o kit não tem estoque próprio
+func (s *ProductService) applyKitAvailability(products []dto.ApiProduct) error {
+	ids := make([]uint, 0, len(products))
+	for _, product := range products {
+		if product.Kind == models.ProductKindKit {
+			ids = append(ids, product.ID)
+		}
+	}
+	availability, err := s.componentRepo.FindKitAvailability(ids)
+	if err != nil {
+		return err
+	}
+
+	byProduct := make(map[uint]float64, len(availability))
+	for _, kit := range availability {
+		byProduct[kit.ProductID] = kit.Available
+	}
+	for i := range products {
+		if products[i].Kind == models.ProductKindKit {
+			products[i].TotalStock = byProduct[products[i].ID] // Sem composição: zero
+		}
+	}
+	return nil
+}
+
+// applyStockReservations preenche a quantidade reservada para vendas pendentes e o estoque disponível. Os
+// kits não têm reservas próprias: o disponível é a quantidade que pode ser montada, já descontadas as
+// reservas dos componentes.
+func (s *ProductService) applyStockReservations(products []dto.ApiProduct) error {
+	ids := make([]uint, 0, len(products))
+	for _, product := range products {
+		if product.Kind != models.ProductKindKit {
+			ids = append(ids, product.ID)
+		}
+	}
+	reserved, err := s.reservationRepo.FindReserved(ids)
+	if err != nil {
+		return err
+	}
+
+	byProduct := make(map[uint]float64, len(reserved))
+	for _, r := range reserved {
+		byProduct[r.ProductID] = r.Quantity
+	}
+	for i := range products {
+		if products[i].Kind == models.ProductKindKit {
+			products[i].AvailableStock = products[i].TotalStock
+			continue
+		}
+		products[i].ReservedStock = roundQuantity(byProduct[products[i].ID])
+		products[i].AvailableStock = roundQuantity(products[i].CurrentStock - products[i].ReservedStock)
+	}
+	return nil
+}
+
+// optionalBarcode retorna nil para códigos de barras vazios, que são gravados como NULL
+func optionalBarcode(barcode string) *string {
+	barcode = strings.TrimSpace(barcode)
+	if barcode == "" {
+		return nil
+	}
+	return &barcode
+}
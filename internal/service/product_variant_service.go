@@ -0,0 +1,380 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	dto "simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/utils/spreadsheet"
+	"simple-erp-service/internal/validator"
+
+	"gorm.io/gorm"
+)
+
+// maxVariantCombinations limita a quantidade de combinações de uma grade gerada de uma só vez
+const maxVariantCombinations = 500
+
+// ProductVariantService gerencia os atributos das variantes e a grade de variantes dos produtos
+type ProductVariantService struct {
+	productRepo   repository.ProductRepository
+	attributeRepo repository.ProductAttributeRepository
+	validator     *validator.ProductAttributeValidator
+}
+
+// NewProductVariantService cria um novo serviço de variantes de produtos
+func NewProductVariantService(productRepo repository.ProductRepository, attributeRepo repository.ProductAttributeRepository) *ProductVariantService {
+	return &ProductVariantService{
+		productRepo:   productRepo,
+		attributeRepo: attributeRepo,
+		validator:     validator.NewProductAttributeValidator(attributeRepo),
+	}
+}
+
+// GetAttributes retorna os atributos das variantes com os seus valores
+func (s *ProductVariantService) GetAttributes() ([]dto.ApiProductAttribute, error) {
+	attributes, err := s.attributeRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	attributeDTOs := make([]dto.ApiProductAttribute, 0, len(attributes))
+	for _, attribute := range attributes {
+		attributeDTOs = append(attributeDTOs, dto.ApiProductAttributeFromModel(attribute))
+	}
+	return attributeDTOs, nil
+}
+
+// CreateAttribute cadastra um atributo com os seus valores
+func (s *ProductVariantService) CreateAttribute(req models.CreateProductAttributeRequest) (*dto.ApiProductAttribute, error) {
+	normalizeAttributeValues(req.Values)
+	if err := s.validator.ValidateForCreation(req); err != nil {
+		return nil, err
+	}
+
+	attribute := models.ProductAttribute{
+		Name:   strings.TrimSpace(req.Name),
+		Values: attributeValuesFromRequest(0, nil, req.Values),
+	}
+	if err := s.attributeRepo.Create(&attribute); err != nil {
+		return nil, err
+	}
+
+	return s.getAttribute(attribute.ID)
+}
+
+// AddAttributeValues inclui valores em um atributo existente
+func (s *ProductVariantService) AddAttributeValues(id uint, req models.AddProductAttributeValuesRequest) (*dto.ApiProductAttribute, error) {
+	attribute, err := s.attributeRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if attribute == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	normalizeAttributeValues(req.Values)
+	if err := s.validator.ValidateNewValues(attribute, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.attributeRepo.CreateValues(attributeValuesFromRequest(attribute.ID, attribute.Values, req.Values)); err != nil {
+		return nil, err
+	}
+
+	return s.getAttribute(id)
+}
+
+// GetVariants retorna as variantes do produto com os valores dos atributos
+func (s *ProductVariantService) GetVariants(productID uint) ([]dto.ApiProduct, error) {
+	product, err := s.productRepo.FindByID(productID)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	variants, err := s.productRepo.FindVariants(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	variantDTOs := make([]dto.ApiProduct, 0, len(variants))
+	for _, variant := range variants {
+		variantDTOs = append(variantDTOs, dto.ApiProductFromModel(variant))
+	}
+	return variantDTOs, nil
+}
+
+// GenerateVariants gera as variantes do produto para todas as combinações dos valores informados, com
+// SKU formado pelo SKU do pai e pelos códigos dos valores (ex: CAMISETA-M-AZ). As combinações que já
+// existem são mantidas, e as variantes novas herdam a categoria, a unidade e os preços do pai. Uma vez
+// criada a grade, novas gerações devem usar os mesmos atributos.
+func (s *ProductVariantService) GenerateVariants(productID uint, req models.GenerateProductVariantsRequest, userID uint) ([]dto.ApiProduct, error) {
+	parent, err := s.productRepo.FindByID(productID)
+	if err != nil {
+		return nil, err
+	}
+	if parent == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	var validationErrors validator.ValidationErrors
+	if parent.ParentID != nil {
+		validationErrors.AddError("product_id", "o produto é uma variante e não pode ter variantes")
+		return nil, validationErrors
+	}
+	if parent.Kind != models.ProductKindSimple {
+		validationErrors.AddError("product_id", "kits e produtos fabricados não têm variantes")
+		return nil, validationErrors
+	}
+	if parent.CurrentStock != 0 {
+		validationErrors.AddError("product_id", "o produto possui estoque próprio: zere o estoque antes de criar a grade, que passa a ser controlada nas variantes")
+		return nil, validationErrors
+	}
+
+	// Atributos e valores da grade, na ordem informada
+	attributeIDs := make([]uint, 0, len(req.Attributes))
+	for _, attributeReq := range req.Attributes {
+		attributeIDs = append(attributeIDs, attributeReq.AttributeID)
+	}
+	attributes, err := s.attributeRepo.FindByIDs(attributeIDs)
+	if err != nil {
+		return nil, err
+	}
+	attributesByID := make(map[uint]models.ProductAttribute, len(attributes))
+	for _, attribute := range attributes {
+		attributesByID[attribute.ID] = attribute
+	}
+
+	axes := make([][]models.ProductAttributeValue, 0, len(req.Attributes))
+	seenAttributes := make(map[uint]bool, len(req.Attributes))
+	combinations := 1
+	for i, attributeReq := range req.Attributes {
+		field := fmt.Sprintf("attributes[%d]", i)
+		attribute, ok := attributesByID[attributeReq.AttributeID]
+		if !ok {
+			validationErrors.AddError(field+".attribute_id", "atributo não encontrado")
+			continue
+		}
+		if seenAttributes[attribute.ID] {
+			validationErrors.AddError(field+".attribute_id", "atributo repetido na grade")
+			continue
+		}
+		seenAttributes[attribute.ID] = true
+
+		values := make([]models.ProductAttributeValue, 0, len(attributeReq.ValueIDs))
+		seenValues := make(map[uint]bool, len(attributeReq.ValueIDs))
+		for _, valueID := range attributeReq.ValueIDs {
+			value, ok := findAttributeValue(attribute, valueID)
+			if !ok {
+				validationErrors.AddError(field+".value_ids", fmt.Sprintf("valor %d não pertence ao atributo %s", valueID, attribute.Name))
+				continue
+			}
+			if !seenValues[valueID] {
+				seenValues[valueID] = true
+				values = append(values, value)
+			}
+		}
+		axes = append(axes, values)
+		combinations *= len(values)
+	}
+	if validationErrors.HasErrors() {
+		return nil, validationErrors
+	}
+	if combinations > maxVariantCombinations {
+		validationErrors.AddError("attributes", fmt.Sprintf("a grade teria %d combinações; o limite é %d por geração", combinations, maxVariantCombinations))
+		return nil, validationErrors
+	}
+
+	// As variantes existentes definem os atributos da grade
+	existing, err := s.productRepo.FindVariants(parent.ID)
+	if err != nil {
+		return nil, err
+	}
+	existingKeys := make(map[string]bool, len(existing))
+	for _, variant := range existing {
+		attributeIDs := make([]uint, 0, len(variant.VariantValues))
+		valueIDs := make([]uint, 0, len(variant.VariantValues))
+		for _, value := range variant.VariantValues {
+			attributeIDs = append(attributeIDs, value.AttributeID)
+			valueIDs = append(valueIDs, value.AttributeValueID)
+		}
+		if variantKey(attributeIDs) != variantKey(keysOf(seenAttributes)) {
+			validationErrors.AddError("attributes", "a grade do produto já usa outros atributos: informe os mesmos atributos das variantes existentes")
+			return nil, validationErrors
+		}
+		existingKeys[variantKey(valueIDs)] = true
+	}
+
+	// Variantes novas para as combinações que ainda não existem
+	var variants []models.Product
+	skus := make(map[string]bool)
+	for _, combination := range cartesian(axes) {
+		valueIDs := make([]uint, len(combination))
+		codes := make([]string, len(combination))
+		names := make([]string, len(combination))
+		variantValues := make([]models.ProductVariantValue, len(combination))
+		for i, value := range combination {
+			valueIDs[i] = value.ID
+			codes[i] = value.Code
+			names[i] = value.Value
+			variantValues[i] = models.ProductVariantValue{AttributeID: value.AttributeID, AttributeValueID: value.ID}
+		}
+		if existingKeys[variantKey(valueIDs)] {
+			continue
+		}
+
+		sku := parent.SKU + "-" + strings.Join(codes, "-")
+		if len(sku) > 50 {
+			validationErrors.AddError("attributes", fmt.Sprintf("o SKU %s passa de 50 caracteres", sku))
+			continue
+		}
+		taken, err := s.productRepo.ExistsBySKUExcept(sku, 0)
+		if err != nil {
+			return nil, err
+		}
+		if taken || skus[sku] {
+			validationErrors.AddError("attributes", fmt.Sprintf("o SKU %s já está em uso", sku))
+			continue
+		}
+		skus[sku] = true
+
+		name := parent.Name + " " + strings.Join(names, " / ")
+		if len([]rune(name)) > 255 {
+			name = string([]rune(name)[:255])
+		}
+		variants = append(variants, models.Product{
+			SKU:           sku,
+			Name:          name,
+			Description:   parent.Description,
+			CategoryID:    parent.CategoryID,
+			UnitID:        parent.UnitID,
+			CostPrice:     parent.CostPrice,
+			SellingPrice:  parent.SellingPrice,
+			IsActive:      parent.IsActive,
+			CreatedByID:   &userID,
+			ParentID:      &parent.ID,
+			VariantValues: variantValues,
+		})
+	}
+	if validationErrors.HasErrors() {
+		return nil, validationErrors
+	}
+
+	err = s.productRepo.GetDB().Transaction(func(tx *gorm.DB) error {
+		productRepo := repository.NewProductRepository(tx)
+		for i := range variants {
+			if err := productRepo.Create(&variants[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetVariants(parent.ID)
+}
+
+// getAttribute busca o atributo pelo ID, com os valores
+func (s *ProductVariantService) getAttribute(id uint) (*dto.ApiProductAttribute, error) {
+	attribute, err := s.attributeRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if attribute == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	result := dto.ApiProductAttributeFromModel(*attribute)
+	return &result, nil
+}
+
+// normalizeAttributeValues preenche os códigos dos valores: o código informado ou o próprio valor, em
+// maiúsculas, sem acentos e só com letras e números
+func normalizeAttributeValues(values []models.CreateProductAttributeValueRequest) {
+	for i := range values {
+		values[i].Value = strings.TrimSpace(values[i].Value)
+		code := values[i].Code
+		if strings.TrimSpace(code) == "" {
+			code = values[i].Value
+		}
+		code = strings.ToUpper(spreadsheet.NormalizeHeader(code))
+		if len(code) > 10 {
+			code = code[:10]
+		}
+		values[i].Code = code
+	}
+}
+
+// attributeValuesFromRequest monta os valores do atributo. Sem posição informada, os valores entram
+// depois dos existentes, na ordem da requisição.
+func attributeValuesFromRequest(attributeID uint, existing []models.ProductAttributeValue, values []models.CreateProductAttributeValueRequest) []models.ProductAttributeValue {
+	position := 0
+	for _, value := range existing {
+		if value.Position >= position {
+			position = value.Position + 1
+		}
+	}
+
+	result := make([]models.ProductAttributeValue, 0, len(values))
+	for _, value := range values {
+		attributeValue := models.ProductAttributeValue{AttributeID: attributeID, Value: value.Value, Code: value.Code, Position: position}
+		if value.Position != nil {
+			attributeValue.Position = *value.Position
+		} else {
+			position++
+		}
+		result = append(result, attributeValue)
+	}
+	return result
+}
+
+// findAttributeValue busca o valor entre os valores carregados do atributo
+func findAttributeValue(attribute models.ProductAttribute, valueID uint) (models.ProductAttributeValue, bool) {
+	for _, value := range attribute.Values {
+		if value.ID == valueID {
+			return value, true
+		}
+	}
+	return models.ProductAttributeValue{}, false
+}
+
+// cartesian retorna todas as combinações com um valor de cada eixo, na ordem dos eixos
+func cartesian(axes [][]models.ProductAttributeValue) [][]models.ProductAttributeValue {
+	combinations := [][]models.ProductAttributeValue{{}}
+	for _, values := range axes {
+		next := make([][]models.ProductAttributeValue, 0, len(combinations)*len(values))
+		for _, combination := range combinations {
+			for _, value := range values {
+				extended := make([]models.ProductAttributeValue, len(combination), len(combination)+1)
+				copy(extended, combination)
+				next = append(next, append(extended, value))
+			}
+		}
+		combinations = next
+	}
+	return combinations
+}
+
+// variantKey identifica um conjunto de IDs independentemente da ordem
+func variantKey(ids []uint) string {
+	sorted := append([]uint(nil), ids...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return fmt.Sprint(sorted)
+}
+
+// keysOf retorna as chaves do mapa
+func keysOf(set map[uint]bool) []uint {
+	keys := make([]uint, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	return keys
+}
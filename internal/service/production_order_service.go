@@ -0,0 +1,304 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	dto "simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/validator"
+
+	"gorm.io/gorm"
+)
+
+// ErrProductionOrderNotPending é retornado ao concluir ou cancelar uma ordem de produção que não está pendente
+var ErrProductionOrderNotPending = errors.New("a ordem de produção não está pendente")
+
+// ProductionOrderService gerencia as ordens de produção dos produtos fabricados
+type ProductionOrderService struct {
+	orderRepo     repository.ProductionOrderRepository
+	productRepo   repository.ProductRepository
+	componentRepo repository.ProductComponentRepository
+	locationRepo  repository.StockLocationRepository
+}
+
+// NewProductionOrderService cria um novo serviço de ordens de produção
+func NewProductionOrderService(
+	orderRepo repository.ProductionOrderRepository,
+	productRepo repository.ProductRepository,
+	componentRepo repository.ProductComponentRepository,
+	locationRepo repository.StockLocationRepository,
+) *ProductionOrderService {
+	return &ProductionOrderService{
+		orderRepo:     orderRepo,
+		productRepo:   productRepo,
+		componentRepo: componentRepo,
+		locationRepo:  locationRepo,
+	}
+}
+
+// GetOrders retorna uma lista paginada e filtrada de ordens de produção
+func (s *ProductionOrderService) GetOrders(pagination *models.Pagination, filters dto.InGetProductionOrdersFilters) (*dto.ApiProductionOrderListPaginated, error) {
+	orders, err := s.orderRepo.FindAll(pagination, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	orderDTOs := make([]dto.ApiProductionOrder, 0, len(orders))
+	for _, order := range orders {
+		orderDTOs = append(orderDTOs, dto.ApiProductionOrderFromModel(order))
+	}
+
+	return &dto.ApiProductionOrderListPaginated{
+		Orders:     orderDTOs,
+		Pagination: *dto.ApiPaginationFromModel(pagination),
+	}, nil
+}
+
+// GetOrderByID busca uma ordem de produção pelo ID com os componentes
+func (s *ProductionOrderService) GetOrderByID(id uint) (*dto.ApiProductionOrder, error) {
+	order, err := s.orderRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	orderDTO := dto.ApiProductionOrderFromModel(*order)
+	return &orderDTO, nil
+}
+
+// CreateOrder abre uma ordem de produção pendente para um produto fabricado. O consumo de cada componente
+// é calculado pela ficha técnica atual e fica registrado na ordem. Sem local informado, a ordem usa o local
+// padrão na conclusão.
+func (s *ProductionOrderService) CreateOrder(req models.CreateProductionOrderRequest, userID uint) (*dto.ApiProductionOrder, error) {
+	var validationErrors validator.ValidationErrors
+
+	product, err := s.productRepo.FindByID(req.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		validationErrors.AddError("product_id", "produto não encontrado")
+		return nil, validationErrors
+	}
+	if product.Kind != models.ProductKindManufactured {
+		validationErrors.AddError("product_id", "somente produtos fabricados têm ordens de produção")
+		return nil, validationErrors
+	}
+	if !product.IsActive {
+		validationErrors.AddError("product_id", "o produto está inativo")
+		return nil, validationErrors
+	}
+
+	components, err := s.componentRepo.FindByProduct(product.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(components) == 0 {
+		validationErrors.AddError("product_id", "o produto não possui ficha técnica: cadastre os componentes antes")
+		return nil, validationErrors
+	}
+	if req.LocationID != nil {
+		if _, err := resolveStockLocation(s.locationRepo, &validationErrors, "location_id", req.LocationID); err != nil {
+			return nil, err
+		}
+		if validationErrors.HasErrors() {
+			return nil, validationErrors
+		}
+	}
+
+	quantity := roundQuantity(req.Quantity)
+	order := models.ProductionOrder{
+		ProductID:   product.ID,
+		Quantity:    quantity,
+		Status:      models.ProductionStatusPending,
+		Notes:       req.Notes,
+		LocationID:  req.LocationID,
+		CreatedByID: &userID,
+		Components:  make([]models.ProductionOrderComponent, 0, len(components)),
+	}
+	for _, component := range components {
+		order.Components = append(order.Components, models.ProductionOrderComponent{
+			ComponentID: component.ComponentID,
+			Quantity:    roundQuantity(component.Quantity * quantity),
+		})
+	}
+
+	if err := s.orderRepo.Create(&order); err != nil {
+		return nil, err
+	}
+
+	return s.GetOrderByID(order.ID)
+}
+
+// CompleteOrder conclui uma ordem de produção pendente: baixa o estoque dos componentes e dá entrada no
+// produto fabricado, na mesma transação e no local da ordem. A ordem só é concluída se houver saldo de todos
+// os componentes nesse local. Os componentes com controle de lotes saem por ordem de validade, e o produto
+// com controle de lotes entra no lote informado; com número de série, entram os números informados. O produto
+// fabricado entra pelo custo médio dos componentes consumidos, que recalcula o seu custo médio.
+func (s *ProductionOrderService) CompleteOrder(id uint, req models.CompleteProductionOrderRequest, userID uint) (*dto.ApiProductionOrder, error) {
+	order, err := s.orderRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil {
+		return nil, utils.ErrNotFound
+	}
+	if order.Status != models.ProductionStatusPending {
+		return nil, ErrProductionOrderNotPending
+	}
+
+	var validationErrors validator.ValidationErrors
+	location, err := resolveStockLocation(s.locationRepo, &validationErrors, "location_id", order.LocationID)
+	if err != nil {
+		return nil, err
+	}
+	if validationErrors.HasErrors() {
+		return nil, validationErrors
+	}
+
+	for i, component := range order.Components {
+		if component.Component == nil {
+			validationErrors.AddError(fmt.Sprintf("components[%d]", i), fmt.Sprintf("componente %d não encontrado", component.ComponentID))
+			continue
+		}
+		if component.Component.TracksSerials {
+			validationErrors.AddError(fmt.Sprintf("components[%d]", i), fmt.Sprintf("o componente %s tem número de série e não pode ser consumido na produção", component.Component.SKU))
+			continue
+		}
+		balance, err := s.locationRepo.FindBalance(component.ComponentID, location.ID)
+		if err != nil {
+			return nil, err
+		}
+		if balance < component.Quantity {
+			validationErrors.AddError(fmt.Sprintf("components[%d]", i), fmt.Sprintf(
+				"estoque insuficiente de %s em %s: necessário %s, disponível %s",
+				component.Component.SKU, location.Code, formatQuantity(component.Quantity), formatQuantity(balance),
+			))
+		}
+	}
+	if order.Product != nil && order.Product.TracksLots && req.Lot == nil {
+		validationErrors.AddError("lot", fmt.Sprintf("o produto %s controla lotes: informe o lote produzido", order.Product.SKU))
+	}
+	if order.Product != nil && !order.Product.TracksLots && req.Lot != nil {
+		validationErrors.AddError("lot", fmt.Sprintf("o produto %s não controla lotes", order.Product.SKU))
+	}
+	validateSerialRequests(&validationErrors, "serials", order.Product, order.Quantity, req.Serials)
+	if validationErrors.HasErrors() {
+		return nil, validationErrors
+	}
+
+	completedAt := time.Now()
+	if req.CompletedAt != nil {
+		completedAt = *req.CompletedAt
+	}
+	notes := fmt.Sprintf("Ordem de produção #%d", order.ID)
+
+	err = s.orderRepo.GetDB().Transaction(func(tx *gorm.DB) error {
+		// O custo de entrada do produto fabricado é o custo médio dos componentes consumidos
+		var componentsCost float64
+		for _, component := range order.Components {
+			movements, err := recordStockOutput(tx, stockMovement{
+				ProductID:     component.ComponentID,
+				LocationID:    &location.ID,
+				Quantity:      -component.Quantity,
+				MovementType:  models.MovementTypeOut,
+				ReferenceType: models.MovementReferenceProduction,
+				ReferenceID:   &order.ID,
+				Notes:         notes,
+				UserID:        userID,
+			}, component.Component.TracksLots)
+			if err != nil {
+				return err
+			}
+			for _, movement := range movements {
+				componentsCost += -movement.Quantity * movement.UnitCost
+			}
+		}
+
+		unitCost := roundUnitPrice(componentsCost / order.Quantity)
+		output := stockMovement{
+			ProductID:     order.ProductID,
+			LocationID:    &location.ID,
+			Quantity:      order.Quantity,
+			MovementType:  models.MovementTypeIn,
+			ReferenceType: models.MovementReferenceProduction,
+			ReferenceID:   &order.ID,
+			Notes:         notes,
+			UserID:        userID,
+			UnitCost:      &unitCost,
+		}
+		if req.Lot != nil {
+			lotReq := *req.Lot
+			if lotReq.ManufacturedAt == nil {
+				lotReq.ManufacturedAt = &completedAt
+			}
+			lot, err := receiveStockLot(tx, order.ProductID, lotReq, models.StockLot{ProductionOrderID: &order.ID})
+			if err != nil {
+				return err
+			}
+			output.LotID = &lot.ID
+			order.LotID = &lot.ID
+		}
+		if _, err := recordStockMovement(tx, output); err != nil {
+			return err
+		}
+		if len(req.Serials) > 0 {
+			err := receiveSerialNumbers(tx, order.ProductID, req.Serials, models.SerialNumber{
+				LocationID:        &location.ID,
+				ProductionOrderID: &order.ID,
+				ReceivedAt:        &completedAt,
+			}, models.SerialNumberEvent{
+				EventType:     models.SerialEventProduced,
+				ReferenceType: models.MovementReferenceProduction,
+				ReferenceID:   &order.ID,
+				LocationID:    &location.ID,
+				Notes:         notes,
+				CreatedByID:   &userID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		order.Status = models.ProductionStatusCompleted
+		order.CompletedAt = &completedAt
+		order.LocationID = &location.ID
+		if req.Notes != "" {
+			order.Notes = strings.TrimSpace(order.Notes + "\n" + req.Notes)
+		}
+		return repository.NewProductionOrderRepository(tx).Update(order)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetOrderByID(id)
+}
+
+// CancelOrder cancela uma ordem de produção pendente
+func (s *ProductionOrderService) CancelOrder(id uint) (*dto.ApiProductionOrder, error) {
+	order, err := s.orderRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil {
+		return nil, utils.ErrNotFound
+	}
+	if order.Status != models.ProductionStatusPending {
+		return nil, ErrProductionOrderNotPending
+	}
+
+	order.Status = models.ProductionStatusCancelled
+	if err := s.orderRepo.Update(order); err != nil {
+		return nil, err
+	}
+
+	return s.GetOrderByID(id)
+}
@@ -0,0 +1,799 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"simple-erp-service/config"
+	dto "simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/validator"
+
+	"gorm.io/gorm"
+)
+
+// Erros das operações de compra
+var (
+	ErrPurchaseNotPending  = errors.New("a compra não está pendente")
+	ErrPurchaseNotReceived = errors.New("a compra ainda não foi recebida")
+	ErrPurchaseNotDraft    = errors.New("a compra não é um rascunho")
+)
+
+// PurchaseService gerencia os pedidos de compra e o recebimento das mercadorias
+type PurchaseService struct {
+	purchaseRepo        repository.PurchaseRepository
+	supplierRepo        repository.SupplierRepository
+	productRepo         repository.ProductRepository
+	supplierProductRepo repository.SupplierProductRepository
+	documentRepo        repository.DocumentRepository
+	unitRepo            repository.MeasurementUnitRepository
+	locationRepo        repository.StockLocationRepository
+	documentsCfg        config.DocumentsConfig
+}
+
+// NewPurchaseService cria um novo serviço de compras
+func NewPurchaseService(
+	purchaseRepo repository.PurchaseRepository,
+	supplierRepo repository.SupplierRepository,
+	productRepo repository.ProductRepository,
+	supplierProductRepo repository.SupplierProductRepository,
+	documentRepo repository.DocumentRepository,
+	unitRepo repository.MeasurementUnitRepository,
+	locationRepo repository.StockLocationRepository,
+	documentsCfg config.DocumentsConfig,
+) *PurchaseService {
+	return &PurchaseService{
+		purchaseRepo:        purchaseRepo,
+		supplierRepo:        supplierRepo,
+		productRepo:         productRepo,
+		supplierProductRepo: supplierProductRepo,
+		documentRepo:        documentRepo,
+		unitRepo:            unitRepo,
+		locationRepo:        locationRepo,
+		documentsCfg:        documentsCfg,
+	}
+}
+
+// GetPurchases retorna uma lista paginada e filtrada de compras
+func (s *PurchaseService) GetPurchases(pagination *models.Pagination, filters dto.InGetPurchasesFilters) (*dto.ApiPurchaseListPaginated, error) {
+	purchases, err := s.purchaseRepo.FindAll(pagination, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	purchaseDTOs := make([]dto.ApiPurchase, 0, len(purchases))
+	for _, purchase := range purchases {
+		purchaseDTOs = append(purchaseDTOs, dto.ApiPurchaseFromModel(purchase))
+	}
+
+	return &dto.ApiPurchaseListPaginated{
+		Purchases:  purchaseDTOs,
+		Pagination: *dto.ApiPaginationFromModel(pagination),
+	}, nil
+}
+
+// GetPurchaseByID busca uma compra pelo ID com os itens
+func (s *PurchaseService) GetPurchaseByID(id uint) (*dto.ApiPurchase, error) {
+	purchase, err := s.purchaseRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if purchase == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	purchaseDTO := dto.ApiPurchaseFromModel(*purchase)
+	return &purchaseDTO, nil
+}
+
+// CreatePurchase cria um pedido de compra pendente. Os itens informados pelo código do fornecedor são
+// convertidos para a unidade do produto pela embalagem do catálogo, e os informados em outra unidade pelas
+// conversões de unidades. Os itens sem preço usam o último preço pago ao fornecedor. Sem data prevista, a
+// entrega é estimada pelo maior prazo do catálogo.
+func (s *PurchaseService) CreatePurchase(req models.CreatePurchaseRequest, userID uint) (*dto.ApiPurchase, error) {
+	return s.createPurchase(req, models.PurchaseStatusPending, userID)
+}
+
+// createPurchase valida e cria o pedido de compra com a situação informada (pendente ou rascunho)
+func (s *PurchaseService) createPurchase(req models.CreatePurchaseRequest, status string, userID uint) (*dto.ApiPurchase, error) {
+	var validationErrors validator.ValidationErrors
+
+	supplier, err := s.supplierRepo.FindByID(req.SupplierID)
+	if err != nil {
+		return nil, err
+	}
+	if supplier == nil {
+		validationErrors.AddError("supplier_id", "fornecedor não encontrado")
+		return nil, validationErrors
+	}
+	if !supplier.IsActive {
+		validationErrors.AddError("supplier_id", "o fornecedor está inativo")
+		return nil, validationErrors
+	}
+
+	if err := s.validateSupplierDocuments(&validationErrors, supplier.ID); err != nil {
+		return nil, err
+	}
+	if validationErrors.HasErrors() {
+		return nil, validationErrors
+	}
+
+	// Sem local informado, a compra é recebida no local padrão
+	if req.LocationID != nil {
+		if _, err := resolveStockLocation(s.locationRepo, &validationErrors, "location_id", req.LocationID); err != nil {
+			return nil, err
+		}
+	}
+
+	catalog, err := s.supplierProductRepo.FindBySupplier(supplier.ID)
+	if err != nil {
+		return nil, err
+	}
+	byCode := make(map[string]models.SupplierProduct, len(catalog))
+	byProduct := make(map[uint]models.SupplierProduct, len(catalog))
+	for _, entry := range catalog {
+		byCode[entry.SupplierCode] = entry
+		byProduct[entry.ProductID] = entry
+	}
+
+	// Produtos dos itens, identificados pelo ID ou pelo código do fornecedor
+	productIDs := make([]uint, 0, len(req.Items))
+	for _, itemReq := range req.Items {
+		if found, ok := byCode[strings.TrimSpace(itemReq.SupplierCode)]; ok {
+			productIDs = append(productIDs, found.ProductID)
+		} else {
+			productIDs = append(productIDs, itemReq.ProductID)
+		}
+	}
+	products, err := s.productRepo.FindByIDs(productIDs)
+	if err != nil {
+		return nil, err
+	}
+	productsByID := make(map[uint]models.Product, len(products))
+	for _, product := range products {
+		productsByID[product.ID] = product
+	}
+
+	// Produtos com grade têm o estoque controlado nas variantes
+	summaries, err := s.productRepo.FindVariantSummaries(productIDs)
+	if err != nil {
+		return nil, err
+	}
+	withVariants := make(map[uint]bool, len(summaries))
+	for _, summary := range summaries {
+		withVariants[summary.ParentID] = true
+	}
+
+	purchaseDate := time.Now()
+	if req.PurchaseDate != nil {
+		purchaseDate = *req.PurchaseDate
+	}
+
+	purchase := models.Purchase{
+		SupplierID:   &supplier.ID,
+		PurchaseDate: purchaseDate,
+		ExpectedDate: req.ExpectedDate,
+		Status:       status,
+		Notes:        req.Notes,
+		CreatedByID:  &userID,
+		LocationID:   req.LocationID,
+	}
+
+	leadTimeDays := 0
+	for i, itemReq := range req.Items {
+		field := fmt.Sprintf("items[%d]", i)
+		item := models.PurchaseItem{ProductID: itemReq.ProductID, PackSize: 1, UnitQuantity: itemReq.Quantity}
+
+		var entry *models.SupplierProduct
+		if code := strings.TrimSpace(itemReq.SupplierCode); code != "" {
+			found, ok := byCode[code]
+			if !ok {
+				validationErrors.AddError(field+".supplier_code", "código não encontrado no catálogo do fornecedor")
+				continue
+			}
+			if itemReq.ProductID != 0 && itemReq.ProductID != found.ProductID {
+				validationErrors.AddError(field+".supplier_code", "o código do fornecedor pertence a outro produto")
+				continue
+			}
+			entry = &found
+			item.ProductID = found.ProductID
+			item.SupplierCode = found.SupplierCode
+			item.PackSize = found.PackSize
+		} else if found, ok := byProduct[itemReq.ProductID]; ok {
+			entry = &found
+			item.SupplierCode = found.SupplierCode
+		}
+
+		product, ok := productsByID[item.ProductID]
+		if !ok {
+			validationErrors.AddError(field+".product_id", fmt.Sprintf("produto %d não encontrado", item.ProductID))
+			continue
+		}
+		if !product.IsActive {
+			validationErrors.AddError(field+".product_id", fmt.Sprintf("produto %d está inativo", item.ProductID))
+			continue
+		}
+		if withVariants[product.ID] {
+			validationErrors.AddError(field+".product_id", fmt.Sprintf("produto %d possui variantes: informe a variante", item.ProductID))
+			continue
+		}
+		if product.Kind == models.ProductKindKit {
+			validationErrors.AddError(field+".product_id", fmt.Sprintf("produto %d é um kit: compre os componentes", item.ProductID))
+			continue
+		}
+
+		// Quantidade informada na embalagem do fornecedor, em outra unidade ou na unidade do produto
+		item.ConversionFactor = float64(item.PackSize)
+		if itemReq.UnitID != nil {
+			factor, err := unitFactor(s.unitRepo, product, itemReq.UnitID)
+			if errors.Is(err, ErrIncompatibleUnit) {
+				validationErrors.AddError(field+".unit_id", err.Error())
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			if *itemReq.UnitID != *product.UnitID {
+				item.UnitID = itemReq.UnitID
+			}
+			item.ConversionFactor = factor
+		}
+		item.Quantity = roundQuantity(itemReq.Quantity * item.ConversionFactor)
+
+		// O preço informado está na mesma unidade da quantidade
+		switch {
+		case itemReq.UnitPrice != nil:
+			item.UnitPrice = roundUnitPrice(*itemReq.UnitPrice / item.ConversionFactor)
+		case entry != nil && entry.UnitPrice() != nil:
+			item.UnitPrice = roundUnitPrice(*entry.UnitPrice())
+		default:
+			validationErrors.AddError(field+".unit_price", "informe o preço: não há preço anterior do fornecedor para o produto")
+			continue
+		}
+		item.TotalAmount = roundMoney(item.Quantity * item.UnitPrice)
+
+		if entry != nil && entry.LeadTimeDays > leadTimeDays {
+			leadTimeDays = entry.LeadTimeDays
+		}
+		purchase.Items = append(purchase.Items, item)
+	}
+
+	if validationErrors.HasErrors() {
+		return nil, validationErrors
+	}
+
+	for _, item := range purchase.Items {
+		purchase.TotalAmount += item.TotalAmount
+	}
+	purchase.TotalAmount = roundMoney(purchase.TotalAmount)
+
+	if purchase.ExpectedDate == nil && leadTimeDays > 0 {
+		expected := purchaseDate.AddDate(0, 0, leadTimeDays)
+		purchase.ExpectedDate = &expected
+	}
+
+	if err := s.purchaseRepo.Create(&purchase); err != nil {
+		return nil, err
+	}
+
+	return s.GetPurchaseByID(purchase.ID)
+}
+
+// ReceivePurchase recebe a compra: registra a entrada no estoque das quantidades recebidas e atualiza o
+// último preço pago no catálogo do fornecedor. Itens sem quantidade ou preço informados são recebidos
+// conforme o pedido. Nos produtos com controle de lotes, a entrada é registrada por lote. O frete e os outros
+// custos de aquisição são rateados entre os itens pelo valor recebido de cada um e entram, com o preço, no
+// custo médio dos produtos.
+func (s *PurchaseService) ReceivePurchase(id uint, req models.ReceivePurchaseRequest, userID uint) (*dto.ApiPurchase, error) {
+	purchase, err := s.purchaseRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if purchase == nil {
+		return nil, utils.ErrNotFound
+	}
+	if purchase.Status != models.PurchaseStatusPending {
+		return nil, ErrPurchaseNotPending
+	}
+
+	received := make(map[uint]models.ReceivePurchaseItemRequest, len(req.Items))
+	positions := make(map[uint]int, len(req.Items))
+	var validationErrors validator.ValidationErrors
+	for i, itemReq := range req.Items {
+		if !purchaseHasItem(*purchase, itemReq.ItemID) {
+			validationErrors.AddError(fmt.Sprintf("items[%d].item_id", i), "item não pertence à compra")
+			continue
+		}
+		received[itemReq.ItemID] = itemReq
+		positions[itemReq.ItemID] = i
+	}
+	for _, item := range purchase.Items {
+		itemReq, ok := received[item.ID]
+		lotsField, serialsField := "items", "items"
+		if ok {
+			lotsField = fmt.Sprintf("items[%d].lots", positions[item.ID])
+			serialsField = fmt.Sprintf("items[%d].serials", positions[item.ID])
+		}
+		quantity := receivedQuantity(item, itemReq, ok)
+		validateStockLotRequests(&validationErrors, lotsField, item.Product, quantity, itemReq.Lots)
+		validateSerialRequests(&validationErrors, serialsField, item.Product, quantity, itemReq.Serials)
+	}
+	locationID := purchase.LocationID
+	if req.LocationID != nil {
+		locationID = req.LocationID
+	}
+	location, err := resolveStockLocation(s.locationRepo, &validationErrors, "location_id", locationID)
+	if err != nil {
+		return nil, err
+	}
+	if validationErrors.HasErrors() {
+		return nil, validationErrors
+	}
+
+	receivedAt := time.Now()
+	if req.ReceivedAt != nil {
+		receivedAt = *req.ReceivedAt
+	}
+
+	for i := range purchase.Items {
+		item := &purchase.Items[i]
+		itemReq, ok := received[item.ID]
+		unitPrice := item.UnitPrice
+		if ok && itemReq.UnitPrice != nil {
+			unitPrice = roundUnitPrice(*itemReq.UnitPrice)
+		}
+		item.ReceivedQuantity = receivedQuantity(*item, itemReq, ok)
+		item.ReceivedUnitPrice = &unitPrice
+	}
+	allocateLandedCosts(purchase.Items, roundMoney(req.FreightAmount+req.OtherCosts))
+
+	err = s.purchaseRepo.GetDB().Transaction(func(tx *gorm.DB) error {
+		purchaseRepo := repository.NewPurchaseRepository(tx)
+
+		for i := range purchase.Items {
+			item := &purchase.Items[i]
+
+			itemReq := received[item.ID]
+			quantity, unitPrice := item.ReceivedQuantity, *item.ReceivedUnitPrice
+			if err := purchaseRepo.UpdateItem(item); err != nil {
+				return err
+			}
+
+			if quantity == 0 {
+				continue
+			}
+			if err := recordPurchaseReceipt(tx, *purchase, *item, quantity, itemReq, location.ID, receivedAt, userID); err != nil {
+				return err
+			}
+
+			if err := recordSupplierPrice(tx, *purchase, *item, unitPrice, receivedAt); err != nil {
+				return err
+			}
+		}
+
+		purchase.Status = models.PurchaseStatusReceived
+		purchase.ReceivedAt = &receivedAt
+		purchase.LocationID = &location.ID
+		purchase.FreightAmount = roundMoney(req.FreightAmount)
+		purchase.OtherCosts = roundMoney(req.OtherCosts)
+		if req.Notes != "" {
+			purchase.Notes = strings.TrimSpace(purchase.Notes + "\n" + req.Notes)
+		}
+		return purchaseRepo.Update(purchase)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetPurchaseByID(id)
+}
+
+// ConfirmPurchase confirma um pedido de compra em rascunho, que passa a pendente com a data de hoje. A data
+// prevista de entrega é adiada pelo tempo em que o pedido ficou em rascunho, mantendo o prazo.
+func (s *PurchaseService) ConfirmPurchase(id uint) (*dto.ApiPurchase, error) {
+	purchase, err := s.purchaseRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if purchase == nil {
+		return nil, utils.ErrNotFound
+	}
+	if purchase.Status != models.PurchaseStatusDraft {
+		return nil, ErrPurchaseNotDraft
+	}
+
+	// Os documentos do fornecedor podem ter vencido enquanto o pedido estava em rascunho
+	if purchase.SupplierID != nil {
+		var validationErrors validator.ValidationErrors
+		if err := s.validateSupplierDocuments(&validationErrors, *purchase.SupplierID); err != nil {
+			return nil, err
+		}
+		if validationErrors.HasErrors() {
+			return nil, validationErrors
+		}
+	}
+
+	now := time.Now()
+	if purchase.ExpectedDate != nil && now.After(purchase.PurchaseDate) {
+		expected := purchase.ExpectedDate.Add(now.Sub(purchase.PurchaseDate))
+		purchase.ExpectedDate = &expected
+	}
+	purchase.PurchaseDate = now
+	purchase.Status = models.PurchaseStatusPending
+	if err := s.purchaseRepo.Update(purchase); err != nil {
+		return nil, err
+	}
+
+	return s.GetPurchaseByID(id)
+}
+
+// validateSupplierDocuments bloqueia os pedidos de fornecedores com documentos obrigatórios vencidos
+// (alvarás, licenças etc.), quando o bloqueio está configurado
+func (s *PurchaseService) validateSupplierDocuments(validationErrors *validator.ValidationErrors, supplierID uint) error {
+	if !s.documentsCfg.BlockExpiredSupplierPurchases {
+		return nil
+	}
+
+	expired, err := s.documentRepo.FindExpiredMandatory(supplierID, startOfDay(time.Now()))
+	if err != nil {
+		return err
+	}
+	if len(expired) > 0 {
+		descriptions := make([]string, len(expired))
+		for i, document := range expired {
+			descriptions[i] = fmt.Sprintf("%s (venceu em %s)", document.Type, document.Validate.Format("02/01/2006"))
+		}
+		validationErrors.AddError("supplier_id", "o fornecedor possui documentos obrigatórios vencidos: "+strings.Join(descriptions, ", "))
+	}
+	return nil
+}
+
+// CancelPurchase cancela uma compra pendente ou em rascunho
+func (s *PurchaseService) CancelPurchase(id uint) (*dto.ApiPurchase, error) {
+	purchase, err := s.purchaseRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if purchase == nil {
+		return nil, utils.ErrNotFound
+	}
+	if purchase.Status != models.PurchaseStatusPending && purchase.Status != models.PurchaseStatusDraft {
+		return nil, ErrPurchaseNotPending
+	}
+
+	purchase.Status = models.PurchaseStatusCancelled
+	if err := s.purchaseRepo.Update(purchase); err != nil {
+		return nil, err
+	}
+
+	return s.GetPurchaseByID(id)
+}
+
+// GetReturns retorna as devoluções ao fornecedor registradas para a compra
+func (s *PurchaseService) GetReturns(id uint) ([]dto.ApiPurchaseReturn, error) {
+	purchase, err := s.purchaseRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if purchase == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	returns, err := s.purchaseRepo.FindReturns(id)
+	if err != nil {
+		return nil, err
+	}
+
+	returnDTOs := make([]dto.ApiPurchaseReturn, 0, len(returns))
+	for _, purchaseReturn := range returns {
+		returnDTOs = append(returnDTOs, dto.ApiPurchaseReturnFromModel(purchaseReturn))
+	}
+	return returnDTOs, nil
+}
+
+// ReturnPurchaseItems registra a devolução ao fornecedor de itens recebidos, com a saída do estoque do local
+// informado ou, sem ele, do local em que a compra foi recebida. Cada item pode ser devolvido até a
+// quantidade recebida menos as devoluções anteriores. Nos produtos com controle de lotes, a saída é do lote
+// informado (inclusive vencido) ou, sem ele, dos lotes por ordem de validade; nos produtos com número de
+// série, dos números informados.
+func (s *PurchaseService) ReturnPurchaseItems(id uint, req models.CreatePurchaseReturnRequest, userID uint) (*dto.ApiPurchaseReturn, error) {
+	purchase, err := s.purchaseRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if purchase == nil {
+		return nil, utils.ErrNotFound
+	}
+	if purchase.Status != models.PurchaseStatusReceived {
+		return nil, ErrPurchaseNotReceived
+	}
+
+	items := make(map[uint]*models.PurchaseItem, len(purchase.Items))
+	for i := range purchase.Items {
+		items[purchase.Items[i].ID] = &purchase.Items[i]
+	}
+
+	// Quantidades somadas por item e por lote, pois o mesmo item pode aparecer mais de uma vez na requisição
+	type returnKey struct {
+		itemID uint
+		lotID  uint
+	}
+	var validationErrors validator.ValidationErrors
+	lotRepo := repository.NewStockLotRepository(s.purchaseRepo.GetDB())
+	itemQuantities := make(map[uint]float64, len(req.Items))
+	quantities := make(map[returnKey]float64, len(req.Items))
+	serials := make(map[uint][]string)
+	lots := make(map[uint]*models.StockLot)
+	order := make([]returnKey, 0, len(req.Items))
+	for i, itemReq := range req.Items {
+		item, ok := items[itemReq.ItemID]
+		if !ok {
+			validationErrors.AddError(fmt.Sprintf("items[%d].item_id", i), "item não pertence à compra")
+			continue
+		}
+		lot, err := findProductLot(lotRepo, &validationErrors, fmt.Sprintf("items[%d].lot_id", i), item.Product, itemReq.LotID)
+		if err != nil {
+			return nil, err
+		}
+		key := returnKey{itemID: item.ID}
+		if lot != nil {
+			key.lotID = lot.ID
+			lots[lot.ID] = lot
+		}
+		if _, seen := quantities[key]; !seen {
+			order = append(order, key)
+		}
+		validateSerialRequests(&validationErrors, fmt.Sprintf("items[%d].serials", i), item.Product, itemReq.Quantity, itemReq.Serials)
+		serials[item.ID] = append(serials[item.ID], itemReq.Serials...)
+		quantities[key] = roundQuantity(quantities[key] + itemReq.Quantity)
+		itemQuantities[item.ID] = roundQuantity(itemQuantities[item.ID] + itemReq.Quantity)
+		if available := roundQuantity(item.ReceivedQuantity - item.ReturnedQuantity); itemQuantities[item.ID] > available {
+			validationErrors.AddError(fmt.Sprintf("items[%d].quantity", i),
+				fmt.Sprintf("quantidade maior que a disponível para devolução (%s)", formatQuantity(available)))
+		}
+	}
+	locationID := purchase.LocationID
+	if req.LocationID != nil {
+		locationID = req.LocationID
+	}
+	location, err := resolveStockLocation(s.locationRepo, &validationErrors, "location_id", locationID)
+	if err != nil {
+		return nil, err
+	}
+	if validationErrors.HasErrors() {
+		return nil, validationErrors
+	}
+
+	returnDate := time.Now()
+	if req.ReturnDate != nil {
+		returnDate = *req.ReturnDate
+	}
+
+	purchaseReturn := models.PurchaseReturn{
+		PurchaseID:  purchase.ID,
+		SupplierID:  purchase.SupplierID,
+		ReturnDate:  returnDate,
+		Reason:      strings.TrimSpace(req.Reason),
+		LocationID:  &location.ID,
+		CreatedByID: &userID,
+	}
+	for _, key := range order {
+		returnItem := models.PurchaseReturnItem{
+			PurchaseItemID: key.itemID,
+			ProductID:      items[key.itemID].ProductID,
+			Quantity:       quantities[key],
+		}
+		if key.lotID != 0 {
+			lotID := key.lotID
+			returnItem.LotID = &lotID
+		}
+		purchaseReturn.Items = append(purchaseReturn.Items, returnItem)
+	}
+
+	err = s.purchaseRepo.GetDB().Transaction(func(tx *gorm.DB) error {
+		purchaseRepo := repository.NewPurchaseRepository(tx)
+		if err := purchaseRepo.CreateReturn(&purchaseReturn); err != nil {
+			return err
+		}
+
+		for _, returnItem := range purchaseReturn.Items {
+			item := items[returnItem.PurchaseItemID]
+			item.ReturnedQuantity = roundQuantity(item.ReturnedQuantity + returnItem.Quantity)
+			if err := purchaseRepo.UpdateItem(item); err != nil {
+				return err
+			}
+
+			_, err := recordStockOutput(tx, stockMovement{
+				ProductID:     returnItem.ProductID,
+				LocationID:    &location.ID,
+				LotID:         returnItem.LotID,
+				Quantity:      -returnItem.Quantity,
+				MovementType:  models.MovementTypeOut,
+				ReferenceType: models.MovementReferenceReturn,
+				ReferenceID:   &purchaseReturn.ID,
+				Notes:         fmt.Sprintf("Devolução da compra #%d: %s", purchase.ID, purchaseReturn.Reason),
+				UserID:        userID,
+			}, item.Product != nil && item.Product.TracksLots)
+			if err != nil {
+				return err
+			}
+
+			if len(serials[item.ID]) > 0 {
+				err := moveSerialNumbers(tx, item.ProductID, location.ID, serials[item.ID], models.SerialNumberEvent{
+					EventType:     models.SerialEventReturned,
+					ReferenceType: models.MovementReferenceReturn,
+					ReferenceID:   &purchaseReturn.ID,
+					LocationID:    &location.ID,
+					SupplierID:    purchase.SupplierID,
+					Notes:         purchaseReturn.Reason,
+					CreatedByID:   &userID,
+				}, func(serial *models.SerialNumber) {
+					serial.Status = models.SerialStatusReturned
+					serial.LocationID = nil
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range purchaseReturn.Items {
+		purchaseReturn.Items[i].Product = items[purchaseReturn.Items[i].PurchaseItemID].Product
+		if lotID := purchaseReturn.Items[i].LotID; lotID != nil {
+			purchaseReturn.Items[i].Lot = lots[*lotID]
+		}
+	}
+	returnDTO := dto.ApiPurchaseReturnFromModel(purchaseReturn)
+	return &returnDTO, nil
+}
+
+// receivedQuantity retorna a quantidade recebida do item: a informada no recebimento ou, sem ela, a do pedido
+func receivedQuantity(item models.PurchaseItem, itemReq models.ReceivePurchaseItemRequest, informed bool) float64 {
+	if informed && itemReq.Quantity != nil {
+		return roundQuantity(*itemReq.Quantity)
+	}
+	return item.Quantity
+}
+
+// allocateLandedCosts rateia os custos de aquisição entre os itens recebidos, pelo valor recebido de cada um
+// (sem valor, pela quantidade), e preenche o custo unitário de entrada dos itens: o preço recebido mais o
+// rateio por unidade
+func allocateLandedCosts(items []models.PurchaseItem, additionalCosts float64) {
+	var totalValue, totalQuantity float64
+	for _, item := range items {
+		totalValue += item.ReceivedQuantity * *item.ReceivedUnitPrice
+		totalQuantity += item.ReceivedQuantity
+	}
+
+	for i := range items {
+		item := &items[i]
+		landedUnitCost := *item.ReceivedUnitPrice
+		if item.ReceivedQuantity > 0 && additionalCosts > 0 {
+			share := item.ReceivedQuantity / totalQuantity
+			if totalValue > 0 {
+				share = item.ReceivedQuantity * *item.ReceivedUnitPrice / totalValue
+			}
+			landedUnitCost = roundUnitPrice(landedUnitCost + additionalCosts*share/item.ReceivedQuantity)
+		}
+		item.LandedUnitCost = &landedUnitCost
+	}
+}
+
+// recordPurchaseReceipt registra a entrada no estoque da quantidade recebida do item, pelo custo de entrada
+// do item. Nos produtos com controle de lotes, registra uma entrada por lote, cadastrando os lotes novos com
+// o fornecedor e a compra de origem; nos produtos com número de série, registra a entrada de cada número.
+func recordPurchaseReceipt(tx *gorm.DB, purchase models.Purchase, item models.PurchaseItem, quantity float64, itemReq models.ReceivePurchaseItemRequest, locationID uint, receivedAt time.Time, userID uint) error {
+	movement := stockMovement{
+		ProductID:     item.ProductID,
+		LocationID:    &locationID,
+		Quantity:      quantity,
+		MovementType:  models.MovementTypeIn,
+		ReferenceType: models.MovementReferencePurchase,
+		ReferenceID:   &purchase.ID,
+		Notes:         fmt.Sprintf("Recebimento da compra #%d", purchase.ID),
+		UserID:        userID,
+		UnitCost:      item.LandedUnitCost,
+	}
+	if item.Product != nil && item.Product.TracksSerials {
+		err := receiveSerialNumbers(tx, item.ProductID, itemReq.Serials, models.SerialNumber{
+			LocationID: &locationID,
+			SupplierID: purchase.SupplierID,
+			PurchaseID: &purchase.ID,
+			ReceivedAt: &receivedAt,
+		}, models.SerialNumberEvent{
+			EventType:     models.SerialEventReceived,
+			ReferenceType: models.MovementReferencePurchase,
+			ReferenceID:   &purchase.ID,
+			LocationID:    &locationID,
+			SupplierID:    purchase.SupplierID,
+			Notes:         movement.Notes,
+			CreatedByID:   &userID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if item.Product == nil || !item.Product.TracksLots {
+		_, err := recordStockMovement(tx, movement)
+		return err
+	}
+
+	for _, lotReq := range itemReq.Lots {
+		lot, err := receiveStockLot(tx, item.ProductID, lotReq.StockLotRequest, models.StockLot{
+			SupplierID: purchase.SupplierID,
+			PurchaseID: &purchase.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		lotMovement := movement
+		lotMovement.LotID = &lot.ID
+		lotMovement.Quantity = roundQuantity(lotReq.Quantity)
+		lotMovement.Notes = fmt.Sprintf("Recebimento da compra #%d (lote %s)", purchase.ID, lot.Code)
+		if _, err := recordStockMovement(tx, lotMovement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordSupplierPrice atualiza no catálogo o último preço pago ao fornecedor, convertido para a embalagem
+// do fornecedor. Produtos comprados fora do catálogo são incluídos nele com o SKU como código do
+// fornecedor, quando esse código estiver livre.
+func recordSupplierPrice(tx *gorm.DB, purchase models.Purchase, item models.PurchaseItem, unitPrice float64, receivedAt time.Time) error {
+	if purchase.SupplierID == nil {
+		return nil
+	}
+	supplierProductRepo := repository.NewSupplierProductRepository(tx)
+
+	entry, err := supplierProductRepo.FindBySupplierAndProduct(*purchase.SupplierID, item.ProductID)
+	if err != nil {
+		return err
+	}
+	if entry == nil {
+		if item.Product == nil {
+			return nil
+		}
+		taken, err := supplierProductRepo.ExistsBySupplierCodeExcept(*purchase.SupplierID, item.Product.SKU, 0)
+		if err != nil || taken {
+			return err
+		}
+		entry = &models.SupplierProduct{SupplierID: *purchase.SupplierID, ProductID: item.ProductID, SupplierCode: item.Product.SKU, PackSize: 1}
+	}
+
+	price := roundUnitPrice(unitPrice * float64(entry.PackSize))
+	entry.LastPrice = &price
+	entry.LastPurchaseAt = &receivedAt
+	entry.LastPurchaseID = &purchase.ID
+
+	if entry.ID == 0 {
+		return supplierProductRepo.Create(entry)
+	}
+	return supplierProductRepo.Update(entry)
+}
+
+// purchaseHasItem informa se o item pertence à compra
+func purchaseHasItem(purchase models.Purchase, itemID uint) bool {
+	for _, item := range purchase.Items {
+		if item.ID == itemID {
+			return true
+		}
+	}
+	return false
+}
+
+// roundUnitPrice arredonda preços unitários para quatro casas decimais
+func roundUnitPrice(value float64) float64 {
+	return math.Round(value*10000) / 10000
+}
@@ -0,0 +1,286 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	dto "simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/validator"
+)
+
+// reorderSalesWindowDays é a janela padrão da média diária de vendas nas sugestões de reposição
+const reorderSalesWindowDays = 30
+
+// ReplenishmentService sugere a reposição do estoque pelos estoques mínimo e máximo e pelas vendas, e gera
+// os pedidos de compra em rascunho
+type ReplenishmentService struct {
+	replenishmentRepo repository.ReplenishmentRepository
+	reservationRepo   repository.StockReservationRepository
+	locationRepo      repository.StockLocationRepository
+	purchaseService   *PurchaseService
+}
+
+// NewReplenishmentService cria um novo serviço de reposição de estoque
+func NewReplenishmentService(
+	replenishmentRepo repository.ReplenishmentRepository,
+	reservationRepo repository.StockReservationRepository,
+	locationRepo repository.StockLocationRepository,
+	purchaseService *PurchaseService,
+) *ReplenishmentService {
+	return &ReplenishmentService{
+		replenishmentRepo: replenishmentRepo,
+		reservationRepo:   reservationRepo,
+		locationRepo:      locationRepo,
+		purchaseService:   purchaseService,
+	}
+}
+
+// GetReorderSuggestions retorna os produtos no ponto de reposição ou abaixo dele, com a quantidade sugerida,
+// agrupados pelo fornecedor preferencial
+func (s *ReplenishmentService) GetReorderSuggestions(filters dto.InReorderSuggestionsFilters) (*dto.ApiReorderSuggestions, error) {
+	return s.reorderSuggestions(filters.Days, filters.CategoryID, filters.SupplierID, nil)
+}
+
+// CreateReorderDrafts gera um pedido de compra em rascunho para cada fornecedor das sugestões de reposição,
+// com a quantidade sugerida na embalagem do fornecedor e o último preço pago. Os pedidos são conferidos e
+// confirmados depois. Produtos sem fornecedor e fornecedores que não podem receber pedidos (por exemplo,
+// com documentos obrigatórios vencidos) ficam de fora, com o motivo.
+func (s *ReplenishmentService) CreateReorderDrafts(req models.CreateReorderDraftsRequest, userID uint) (*dto.ApiReorderDrafts, error) {
+	if req.LocationID != nil {
+		var validationErrors validator.ValidationErrors
+		if _, err := resolveStockLocation(s.locationRepo, &validationErrors, "location_id", req.LocationID); err != nil {
+			return nil, err
+		}
+		if validationErrors.HasErrors() {
+			return nil, validationErrors
+		}
+	}
+
+	var categoryID, supplierID uint
+	if req.CategoryID != nil {
+		categoryID = *req.CategoryID
+	}
+	if req.SupplierID != nil {
+		supplierID = *req.SupplierID
+	}
+	suggestions, err := s.reorderSuggestions(req.Days, categoryID, supplierID, req.ProductIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.ApiReorderDrafts{
+		Purchases: make([]dto.ApiPurchase, 0, len(suggestions.Groups)),
+		Skipped:   make([]dto.ApiReorderSkippedGroup, 0),
+	}
+	for _, group := range suggestions.Groups {
+		if group.SupplierID == nil {
+			result.Skipped = append(result.Skipped, dto.ApiReorderSkippedGroup{
+				Reason: "produtos sem fornecedor no catálogo",
+			})
+			continue
+		}
+
+		purchaseReq := models.CreatePurchaseRequest{
+			SupplierID: *group.SupplierID,
+			LocationID: req.LocationID,
+			Notes:      fmt.Sprintf("Reposição sugerida pelas vendas dos últimos %d dias", suggestions.Days),
+		}
+		for _, item := range group.Items {
+			packPrice := roundUnitPrice(item.UnitPrice * float64(item.PackSize))
+			purchaseReq.Items = append(purchaseReq.Items, models.CreatePurchaseItemRequest{
+				ProductID:    item.ProductID,
+				SupplierCode: item.SupplierCode,
+				Quantity:     item.Packs,
+				UnitPrice:    &packPrice,
+			})
+		}
+
+		purchase, err := s.purchaseService.createPurchase(purchaseReq, models.PurchaseStatusDraft, userID)
+		if validator.IsValidationError(err) {
+			messages := make([]string, 0)
+			for _, validationError := range validator.GetValidationErrors(err) {
+				messages = append(messages, validationError.Message)
+			}
+			result.Skipped = append(result.Skipped, dto.ApiReorderSkippedGroup{
+				SupplierID:   group.SupplierID,
+				SupplierName: group.SupplierName,
+				Reason:       strings.Join(messages, "; "),
+			})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		result.Purchases = append(result.Purchases, *purchase)
+	}
+
+	return result, nil
+}
+
+// reorderSuggestions calcula as sugestões de reposição. O ponto de reposição é o estoque mínimo mais as
+// vendas esperadas no prazo de entrega do fornecedor, pela média diária da janela. A posição do estoque é o
+// estoque atual menos as reservas das vendas pendentes mais o que falta receber dos pedidos pendentes e em
+// rascunho. Na posição igual ou abaixo do ponto, sugere a compra até o estoque máximo (sem ele, o ponto de
+// reposição mais as vendas de uma janela), em embalagens inteiras do fornecedor.
+func (s *ReplenishmentService) reorderSuggestions(days int, categoryID, supplierID uint, productIDs []uint) (*dto.ApiReorderSuggestions, error) {
+	if days <= 0 {
+		days = reorderSalesWindowDays
+	}
+	now := time.Now()
+
+	products, err := s.replenishmentRepo.FindCandidates(categoryID, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uint, 0, len(products))
+	for _, product := range products {
+		ids = append(ids, product.ID)
+	}
+
+	reserved, err := s.reservationRepo.FindReserved(ids)
+	if err != nil {
+		return nil, err
+	}
+	reservedByProduct := make(map[uint]float64, len(reserved))
+	for _, r := range reserved {
+		reservedByProduct[r.ProductID] = r.Quantity
+	}
+	open, err := s.replenishmentRepo.FindOpenPurchaseQuantities(ids)
+	if err != nil {
+		return nil, err
+	}
+	openByProduct := make(map[uint]float64, len(open))
+	for _, o := range open {
+		openByProduct[o.ProductID] = o.Quantity
+	}
+	sold, err := s.replenishmentRepo.FindSoldQuantities(ids, now.AddDate(0, 0, -days))
+	if err != nil {
+		return nil, err
+	}
+	soldByProduct := make(map[uint]float64, len(sold))
+	for _, q := range sold {
+		soldByProduct[q.ProductID] = q.Quantity
+	}
+	catalog, err := s.replenishmentRepo.FindCatalogEntries(ids)
+	if err != nil {
+		return nil, err
+	}
+	entries := preferredCatalogEntries(catalog)
+
+	groups := make(map[uint]*dto.ApiReorderSupplierGroup)
+	for _, product := range products {
+		entry, hasEntry := entries[product.ID]
+		if supplierID != 0 && (!hasEntry || entry.SupplierID != supplierID) {
+			continue
+		}
+
+		suggestion := dto.ApiReorderSuggestion{
+			ProductID:     product.ID,
+			ProductSKU:    product.SKU,
+			ProductName:   product.Name,
+			CurrentStock:  product.CurrentStock,
+			ReservedStock: roundQuantity(reservedByProduct[product.ID]),
+			OpenQuantity:  roundQuantity(openByProduct[product.ID]),
+			MinStock:      product.MinStock,
+			MaxStock:      product.MaxStock,
+			DailySales:    roundQuantity(math.Max(soldByProduct[product.ID], 0) / float64(days)),
+			PackSize:      1,
+			UnitPrice:     product.CostPrice,
+		}
+		if product.Unit != nil {
+			suggestion.UnitAbbreviation = product.Unit.Abbreviation
+		}
+		if hasEntry {
+			suggestion.SupplierCode = entry.SupplierCode
+			suggestion.LeadTimeDays = entry.LeadTimeDays
+			if entry.PackSize > 1 {
+				suggestion.PackSize = entry.PackSize
+			}
+			if price := entry.UnitPrice(); price != nil {
+				suggestion.UnitPrice = roundUnitPrice(*price)
+			}
+		}
+
+		suggestion.StockPosition = roundQuantity(suggestion.CurrentStock - suggestion.ReservedStock + suggestion.OpenQuantity)
+		suggestion.ReorderPoint = roundQuantity(suggestion.MinStock + suggestion.DailySales*float64(suggestion.LeadTimeDays))
+		if suggestion.StockPosition > suggestion.ReorderPoint {
+			continue
+		}
+		suggestion.TargetStock = roundQuantity(suggestion.ReorderPoint + suggestion.DailySales*float64(days))
+		if product.MaxStock != nil {
+			suggestion.TargetStock = math.Max(*product.MaxStock, suggestion.ReorderPoint)
+		}
+		suggestion.Packs = math.Ceil(roundQuantity((suggestion.TargetStock - suggestion.StockPosition) / float64(suggestion.PackSize)))
+		if suggestion.Packs <= 0 {
+			continue
+		}
+		suggestion.SuggestedQuantity = suggestion.Packs * float64(suggestion.PackSize)
+		suggestion.TotalAmount = roundMoney(suggestion.SuggestedQuantity * suggestion.UnitPrice)
+
+		var key uint // Zero: produtos sem fornecedor
+		if hasEntry {
+			key = entry.SupplierID
+		}
+		group, ok := groups[key]
+		if !ok {
+			group = &dto.ApiReorderSupplierGroup{Items: make([]dto.ApiReorderSuggestion, 0)}
+			if hasEntry {
+				group.SupplierID = &entry.SupplierID
+				if entry.Supplier != nil {
+					group.SupplierName = dto.SupplierDisplayName(*entry.Supplier)
+				}
+			}
+			groups[key] = group
+		}
+		group.Items = append(group.Items, suggestion)
+		group.TotalAmount = roundMoney(group.TotalAmount + suggestion.TotalAmount)
+		if suggestion.LeadTimeDays > group.LeadTimeDays {
+			group.LeadTimeDays = suggestion.LeadTimeDays
+		}
+	}
+
+	// Fornecedores por nome; os produtos sem fornecedor no final
+	result := &dto.ApiReorderSuggestions{
+		Days:        days,
+		GeneratedAt: now,
+		Groups:      make([]dto.ApiReorderSupplierGroup, 0, len(groups)),
+	}
+	for _, group := range groups {
+		result.Groups = append(result.Groups, *group)
+	}
+	sort.Slice(result.Groups, func(i, j int) bool {
+		a, b := result.Groups[i], result.Groups[j]
+		if (a.SupplierID == nil) != (b.SupplierID == nil) {
+			return b.SupplierID == nil
+		}
+		if a.SupplierName != b.SupplierName {
+			return a.SupplierName < b.SupplierName
+		}
+		return a.SupplierID != nil && *a.SupplierID < *b.SupplierID
+	})
+	return result, nil
+}
+
+// preferredCatalogEntries escolhe, para cada produto, o item do catálogo do fornecedor preferencial ou, sem
+// ele, o do fornecedor da compra recebida mais recente
+func preferredCatalogEntries(catalog []models.SupplierProduct) map[uint]models.SupplierProduct {
+	entries := make(map[uint]models.SupplierProduct, len(catalog))
+	for _, entry := range catalog {
+		current, ok := entries[entry.ProductID]
+		switch {
+		case !ok:
+			entries[entry.ProductID] = entry
+		case current.IsPreferred:
+		case entry.IsPreferred:
+			entries[entry.ProductID] = entry
+		case entry.LastPurchaseAt != nil && (current.LastPurchaseAt == nil || entry.LastPurchaseAt.After(*current.LastPurchaseAt)):
+			entries[entry.ProductID] = entry
+		}
+	}
+	return entries
+}
@@ -0,0 +1,410 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"simple-erp-service/config"
+	dto "simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/validator"
+
+	"gorm.io/gorm"
+)
+
+// Erros das operações de venda
+var (
+	ErrSaleNotPending         = errors.New("a venda não está pendente")
+	ErrSaleCreditNotApproved  = errors.New("a venda a prazo aguarda a aprovação do crédito")
+	ErrSaleNoApprovalRequired = errors.New("a venda não aguarda aprovação do crédito")
+)
+
+// SaleService gerencia as vendas e a reserva do estoque dos itens vendidos
+type SaleService struct {
+	saleRepo     repository.SaleRepository
+	customerRepo repository.CustomerRepository
+	productRepo  repository.ProductRepository
+	unitRepo     repository.MeasurementUnitRepository
+	locationRepo repository.StockLocationRepository
+	lotRepo      repository.StockLotRepository
+	creditCfg    config.CreditConfig
+	inventoryCfg config.InventoryConfig
+}
+
+// NewSaleService cria um novo serviço de vendas
+func NewSaleService(
+	saleRepo repository.SaleRepository,
+	customerRepo repository.CustomerRepository,
+	productRepo repository.ProductRepository,
+	unitRepo repository.MeasurementUnitRepository,
+	locationRepo repository.StockLocationRepository,
+	lotRepo repository.StockLotRepository,
+	creditCfg config.CreditConfig,
+	inventoryCfg config.InventoryConfig,
+) *SaleService {
+	return &SaleService{
+		saleRepo:     saleRepo,
+		customerRepo: customerRepo,
+		productRepo:  productRepo,
+		unitRepo:     unitRepo,
+		locationRepo: locationRepo,
+		lotRepo:      lotRepo,
+		creditCfg:    creditCfg,
+		inventoryCfg: inventoryCfg,
+	}
+}
+
+// GetSales retorna uma lista paginada e filtrada de vendas
+func (s *SaleService) GetSales(pagination *models.Pagination, filters dto.InGetSalesFilters) (*dto.ApiSaleListPaginated, error) {
+	sales, err := s.saleRepo.FindAll(pagination, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	saleDTOs := make([]dto.ApiSale, 0, len(sales))
+	for _, sale := range sales {
+		saleDTOs = append(saleDTOs, dto.ApiSaleFromModel(sale))
+	}
+
+	return &dto.ApiSaleListPaginated{
+		Sales:      saleDTOs,
+		Pagination: *dto.ApiPaginationFromModel(pagination),
+	}, nil
+}
+
+// GetSaleByID busca uma venda pelo ID com os itens e os lotes entregues em cada item
+func (s *SaleService) GetSaleByID(id uint) (*dto.ApiSale, error) {
+	sale, err := s.saleRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if sale == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	allocations, err := s.lotRepo.FindSaleAllocations(id)
+	if err != nil {
+		return nil, err
+	}
+	lotsByItem := make(map[uint][]dto.ApiSaleLot)
+	for _, allocation := range allocations {
+		lotsByItem[allocation.SaleItemID] = append(lotsByItem[allocation.SaleItemID], dto.ApiSaleLotFromModel(allocation))
+	}
+
+	saleDTO := dto.ApiSaleFromModel(*sale)
+	for i := range saleDTO.Items {
+		saleDTO.Items[i].Lots = lotsByItem[saleDTO.Items[i].ID]
+	}
+	return &saleDTO, nil
+}
+
+// CreateSale cria uma venda pendente e reserva o estoque dos itens no local da venda ou, sem ele, no local
+// padrão. Os itens informados em outra unidade são convertidos para a unidade do produto pelas conversões
+// de unidades, e os itens sem preço usam o preço de venda do produto. O estoque disponível deve cobrir os
+// itens. As vendas a prazo passam pela análise de crédito do cliente: recusadas, não são gravadas; acima
+// do limite, conforme a configuração, aguardam a aprovação do gerente antes do faturamento.
+func (s *SaleService) CreateSale(req models.CreateSaleRequest, userID uint) (*dto.ApiSale, error) {
+	var validationErrors validator.ValidationErrors
+
+	if req.IsCredit && req.CustomerID == nil {
+		validationErrors.AddError("customer_id", "informe o cliente da venda a prazo")
+	}
+	if req.CustomerID != nil {
+		customer, err := s.customerRepo.FindByID(*req.CustomerID)
+		if err != nil {
+			return nil, err
+		}
+		if customer == nil {
+			validationErrors.AddError("customer_id", "cliente não encontrado")
+		} else if !customer.IsActive {
+			validationErrors.AddError("customer_id", "o cliente está inativo")
+		}
+	}
+	if req.LocationID != nil {
+		if _, err := resolveStockLocation(s.locationRepo, &validationErrors, "location_id", req.LocationID); err != nil {
+			return nil, err
+		}
+	}
+
+	productIDs := make([]uint, 0, len(req.Items))
+	for _, itemReq := range req.Items {
+		productIDs = append(productIDs, itemReq.ProductID)
+	}
+	products, err := s.productRepo.FindByIDs(productIDs)
+	if err != nil {
+		return nil, err
+	}
+	productsByID := make(map[uint]models.Product, len(products))
+	for _, product := range products {
+		productsByID[product.ID] = product
+	}
+
+	// Produtos com grade têm o estoque controlado nas variantes
+	summaries, err := s.productRepo.FindVariantSummaries(productIDs)
+	if err != nil {
+		return nil, err
+	}
+	withVariants := make(map[uint]bool, len(summaries))
+	for _, summary := range summaries {
+		withVariants[summary.ParentID] = true
+	}
+
+	saleDate := time.Now()
+	if req.SaleDate != nil {
+		saleDate = *req.SaleDate
+	}
+
+	sale := models.Sale{
+		CustomerID:  req.CustomerID,
+		SaleDate:    saleDate,
+		Status:      models.SaleStatusPending,
+		Notes:       req.Notes,
+		CreatedByID: &userID,
+		LocationID:  req.LocationID,
+	}
+
+	for i, itemReq := range req.Items {
+		field := fmt.Sprintf("items[%d]", i)
+
+		product, ok := productsByID[itemReq.ProductID]
+		if !ok {
+			validationErrors.AddError(field+".product_id", fmt.Sprintf("produto %d não encontrado", itemReq.ProductID))
+			continue
+		}
+		if !product.IsActive {
+			validationErrors.AddError(field+".product_id", fmt.Sprintf("produto %d está inativo", itemReq.ProductID))
+			continue
+		}
+		if withVariants[product.ID] {
+			validationErrors.AddError(field+".product_id", fmt.Sprintf("produto %d possui variantes: informe a variante", itemReq.ProductID))
+			continue
+		}
+
+		// Quantidade informada em outra unidade ou na unidade do produto
+		item := models.SaleItem{ProductID: product.ID, UnitQuantity: itemReq.Quantity, ConversionFactor: 1}
+		if itemReq.UnitID != nil {
+			factor, err := unitFactor(s.unitRepo, product, itemReq.UnitID)
+			if errors.Is(err, ErrIncompatibleUnit) {
+				validationErrors.AddError(field+".unit_id", err.Error())
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			if *itemReq.UnitID != *product.UnitID {
+				item.UnitID = itemReq.UnitID
+			}
+			item.ConversionFactor = factor
+		}
+		item.Quantity = roundQuantity(itemReq.Quantity * item.ConversionFactor)
+
+		// O preço informado está na mesma unidade da quantidade
+		price := product.SellingPrice * item.ConversionFactor
+		if itemReq.UnitPrice != nil {
+			price = *itemReq.UnitPrice
+		}
+		grossAmount := roundMoney(itemReq.Quantity * price)
+		item.UnitPrice = roundMoney(price / item.ConversionFactor)
+		item.DiscountPercent = itemReq.DiscountPercent
+		item.DiscountAmount = roundMoney(grossAmount * itemReq.DiscountPercent / 100)
+		item.TotalAmount = roundMoney(grossAmount - item.DiscountAmount)
+
+		sale.Items = append(sale.Items, item)
+	}
+
+	if validationErrors.HasErrors() {
+		return nil, validationErrors
+	}
+
+	for _, item := range sale.Items {
+		sale.Subtotal += item.TotalAmount
+	}
+	sale.Subtotal = roundMoney(sale.Subtotal)
+	sale.TotalAmount = sale.Subtotal
+	sale.DiscountAmount = roundMoney(req.DiscountAmount)
+	if sale.DiscountAmount > sale.TotalAmount {
+		validationErrors.AddError("discount_amount", "o desconto não pode ser maior que o total dos itens")
+		return nil, validationErrors
+	}
+	sale.FinalAmount = roundMoney(sale.TotalAmount - sale.DiscountAmount + sale.TaxAmount)
+
+	err = s.saleRepo.GetDB().Transaction(func(tx *gorm.DB) error {
+		saleRepo := repository.NewSaleRepository(tx)
+
+		// O cliente fica bloqueado até o fim da transação, para que as vendas a prazo concorrentes sejam
+		// analisadas uma de cada vez, cada uma já considerando a exposição das anteriores
+		if req.IsCredit {
+			customerRepo := repository.NewCustomerRepository(tx)
+			if err := customerRepo.LockByID(*req.CustomerID); err != nil {
+				return err
+			}
+			creditService := NewCreditService(customerRepo, repository.NewTransactionRepository(tx), saleRepo, s.creditCfg)
+			decision, err := creditService.AuthorizeCreditSale(*req.CustomerID, sale.FinalAmount)
+			if err != nil {
+				return err
+			}
+			if decision.Status == models.CreditStatusRejected {
+				validationErrors.AddError("is_credit", "venda a prazo recusada: "+strings.Join(decision.Reasons, "; "))
+				return validationErrors
+			}
+			sale.IsCredit = true
+			sale.CreditStatus = decision.Status
+		}
+
+		// O código da venda é o ID reservado, para que seja único sem depender de uma segunda gravação
+		id, err := saleRepo.NextID()
+		if err != nil {
+			return err
+		}
+		sale.ID = id
+		sale.Code = fmt.Sprintf("VD%08d", id)
+		if err := saleRepo.Create(&sale); err != nil {
+			return err
+		}
+
+		return ReserveSaleStock(tx, sale, s.inventoryCfg, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetSaleByID(sale.ID)
+}
+
+// InvoiceSale fatura uma venda pendente: registra a saída do estoque dos itens, baixa as reservas da venda e
+// registra o custo das mercadorias vendidas em cada item (ver RecordSaleStock). Os números de série
+// informados nos itens passam a constar como vendidos ao cliente da venda. As vendas a prazo precisam do
+// crédito aprovado e geram o título a receber, com o vencimento pelo prazo de pagamento do cliente.
+func (s *SaleService) InvoiceSale(id uint, req models.InvoiceSaleRequest, userID uint) (*dto.ApiSale, error) {
+	sale, err := s.saleRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if sale == nil {
+		return nil, utils.ErrNotFound
+	}
+	if sale.Status != models.SaleStatusPending {
+		return nil, ErrSaleNotPending
+	}
+	if sale.IsCredit && sale.CreditStatus != models.CreditStatusApproved {
+		return nil, ErrSaleCreditNotApproved
+	}
+
+	positions := make(map[uint]int, len(sale.Items))
+	for i, item := range sale.Items {
+		positions[item.ID] = i
+	}
+	var validationErrors validator.ValidationErrors
+	for i, itemReq := range req.Items {
+		position, ok := positions[itemReq.ItemID]
+		if !ok {
+			validationErrors.AddError(fmt.Sprintf("items[%d].item_id", i), "item não pertence à venda")
+			continue
+		}
+		sale.Items[position].SerialNumbers = itemReq.SerialNumbers
+	}
+	if validationErrors.HasErrors() {
+		return nil, validationErrors
+	}
+
+	err = s.saleRepo.GetDB().Transaction(func(tx *gorm.DB) error {
+		if err := RecordSaleStock(tx, *sale, userID); err != nil {
+			return err
+		}
+
+		invoicedAt := time.Now()
+		if sale.IsCredit {
+			if err := createSaleReceivable(tx, *sale, invoicedAt); err != nil {
+				return err
+			}
+		}
+
+		sale.Status = models.SaleStatusInvoiced
+		sale.InvoicedAt = &invoicedAt
+		return repository.NewSaleRepository(tx).Update(sale)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetSaleByID(id)
+}
+
+// CancelSale cancela uma venda pendente e libera as reservas de estoque dos itens
+func (s *SaleService) CancelSale(id uint) (*dto.ApiSale, error) {
+	sale, err := s.saleRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if sale == nil {
+		return nil, utils.ErrNotFound
+	}
+	if sale.Status != models.SaleStatusPending {
+		return nil, ErrSaleNotPending
+	}
+
+	err = s.saleRepo.GetDB().Transaction(func(tx *gorm.DB) error {
+		if err := ReleaseSaleStock(tx, sale.ID); err != nil {
+			return err
+		}
+
+		sale.Status = models.SaleStatusCancelled
+		return repository.NewSaleRepository(tx).Update(sale)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetSaleByID(id)
+}
+
+// ApproveSaleCredit aprova o crédito de uma venda a prazo pendente que excedeu o limite ou tem títulos
+// vencidos do cliente, liberando o faturamento
+func (s *SaleService) ApproveSaleCredit(id uint, userID uint) (*dto.ApiSale, error) {
+	sale, err := s.saleRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if sale == nil {
+		return nil, utils.ErrNotFound
+	}
+	if sale.Status != models.SaleStatusPending {
+		return nil, ErrSaleNotPending
+	}
+	if !sale.IsCredit || sale.CreditStatus != models.CreditStatusApprovalRequired {
+		return nil, ErrSaleNoApprovalRequired
+	}
+
+	sale.CreditStatus = models.CreditStatusApproved
+	sale.CreditApprovedByID = &userID
+	if err := s.saleRepo.Update(sale); err != nil {
+		return nil, err
+	}
+
+	return s.GetSaleByID(id)
+}
+
+// createSaleReceivable registra o título a receber da venda a prazo faturada, que passa a compor a exposição
+// do cliente
+func createSaleReceivable(tx *gorm.DB, sale models.Sale, invoicedAt time.Time) error {
+	paymentTermDays := 0
+	if sale.Customer != nil {
+		paymentTermDays = sale.Customer.PaymentTermDays
+	}
+
+	return repository.NewTransactionRepository(tx).Create(&models.Transaction{
+		Type:       models.TransactionTypeReceivable,
+		Code:       sale.Code,
+		Date:       invoicedAt,
+		Currency:   "BRL",
+		Amount:     sale.FinalAmount,
+		DueDate:    invoicedAt.AddDate(0, 0, paymentTermDays),
+		Status:     models.TransactionStatusPending,
+		CustomerID: sale.CustomerID,
+		SaleID:     &sale.ID,
+	})
+}
@@ -0,0 +1,258 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	dto "simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/validator"
+
+	"gorm.io/gorm"
+)
+
+// SerialNumberService consulta os números de série, o histórico de cada unidade e a situação da garantia
+type SerialNumberService struct {
+	serialRepo   repository.SerialNumberRepository
+	supplierRepo repository.SupplierRepository
+	customerRepo repository.CustomerRepository
+}
+
+// NewSerialNumberService cria um novo serviço de números de série
+func NewSerialNumberService(serialRepo repository.SerialNumberRepository, supplierRepo repository.SupplierRepository, customerRepo repository.CustomerRepository) *SerialNumberService {
+	return &SerialNumberService{
+		serialRepo:   serialRepo,
+		supplierRepo: supplierRepo,
+		customerRepo: customerRepo,
+	}
+}
+
+// GetSerialNumbers retorna uma lista paginada e filtrada de números de série, com a situação da garantia
+func (s *SerialNumberService) GetSerialNumbers(pagination *models.Pagination, filters dto.InGetSerialNumbersFilters) (*dto.ApiSerialNumberListPaginated, error) {
+	filters.Serial = strings.TrimSpace(filters.Serial)
+	serials, err := s.serialRepo.FindAll(pagination, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	today := startOfDay(time.Now())
+	serialDTOs := make([]dto.ApiSerialNumber, 0, len(serials))
+	for _, serial := range serials {
+		serialDTO := dto.ApiSerialNumberFromModel(serial)
+		applySerialWarranty(&serialDTO, today)
+		serialDTOs = append(serialDTOs, serialDTO)
+	}
+
+	return &dto.ApiSerialNumberListPaginated{
+		SerialNumbers: serialDTOs,
+		Pagination:    *dto.ApiPaginationFromModel(pagination),
+	}, nil
+}
+
+// GetSerialNumberByID busca um número de série pelo ID, com a garantia e o histórico: de qual fornecedor
+// foi recebido, a qual cliente foi vendido e as devoluções
+func (s *SerialNumberService) GetSerialNumberByID(id uint) (*dto.ApiSerialNumber, error) {
+	serial, err := s.serialRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if serial == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	supplierIDs := make([]uint, 0)
+	customerIDs := make([]uint, 0)
+	for _, event := range serial.Events {
+		if event.SupplierID != nil {
+			supplierIDs = append(supplierIDs, *event.SupplierID)
+		}
+		if event.CustomerID != nil {
+			customerIDs = append(customerIDs, *event.CustomerID)
+		}
+	}
+	suppliers, err := s.supplierRepo.FindByIDs(supplierIDs)
+	if err != nil {
+		return nil, err
+	}
+	supplierNames := make(map[uint]string, len(suppliers))
+	for _, supplier := range suppliers {
+		supplierNames[supplier.ID] = dto.SupplierDisplayName(supplier)
+	}
+	customers, err := s.customerRepo.FindByIDs(customerIDs)
+	if err != nil {
+		return nil, err
+	}
+	customerNames := make(map[uint]string, len(customers))
+	for _, customer := range customers {
+		customerNames[customer.ID] = dto.CustomerDisplayName(customer)
+	}
+
+	serialDTO := dto.ApiSerialNumberFromModel(*serial)
+	applySerialWarranty(&serialDTO, startOfDay(time.Now()))
+	serialDTO.Events = make([]dto.ApiSerialNumberEvent, 0, len(serial.Events))
+	for _, event := range serial.Events {
+		eventDTO := dto.ApiSerialNumberEventFromModel(event)
+		if event.SupplierID != nil {
+			eventDTO.SupplierName = supplierNames[*event.SupplierID]
+		}
+		if event.CustomerID != nil {
+			eventDTO.CustomerName = customerNames[*event.CustomerID]
+		}
+		serialDTO.Events = append(serialDTO.Events, eventDTO)
+	}
+	return &serialDTO, nil
+}
+
+// applySerialWarranty calcula o fim e a situação da garantia do número de série, contada a partir da venda
+func applySerialWarranty(serial *dto.ApiSerialNumber, today time.Time) {
+	if serial.Status != models.SerialStatusSold || serial.SoldAt == nil {
+		serial.WarrantyStatus = models.WarrantyStatusNotSold
+		return
+	}
+	if serial.WarrantyMonths <= 0 {
+		serial.WarrantyStatus = models.WarrantyStatusNone
+		return
+	}
+
+	until := startOfDay(*serial.SoldAt).AddDate(0, serial.WarrantyMonths, -1)
+	serial.WarrantyUntil = &until
+	if today.After(until) {
+		serial.WarrantyStatus = models.WarrantyStatusExpired
+	} else {
+		serial.WarrantyStatus = models.WarrantyStatusActive
+	}
+}
+
+// normalizeSerials remove os espaços nas pontas dos números de série
+func normalizeSerials(serials []string) []string {
+	normalized := make([]string, 0, len(serials))
+	for _, serial := range serials {
+		normalized = append(normalized, strings.TrimSpace(serial))
+	}
+	return normalized
+}
+
+// validateSerialRequests confere os números de série informados em uma movimentação: obrigatórios nos
+// produtos com número de série, um por unidade e sem repetições; proibidos nos demais produtos
+func validateSerialRequests(validationErrors *validator.ValidationErrors, field string, product *models.Product, quantity float64, serials []string) {
+	if product == nil {
+		return
+	}
+	if !product.TracksSerials {
+		if len(serials) > 0 {
+			validationErrors.AddError(field, fmt.Sprintf("o produto %s não tem número de série", product.SKU))
+		}
+		return
+	}
+	if quantity == 0 {
+		return
+	}
+	if quantity != math.Trunc(quantity) {
+		validationErrors.AddError(field, fmt.Sprintf("o produto %s tem número de série e só é movimentado em unidades inteiras", product.SKU))
+		return
+	}
+	if len(serials) != int(quantity) {
+		validationErrors.AddError(field, fmt.Sprintf("informe um número de série por unidade do produto %s: %d informados para %s unidades", product.SKU, len(serials), formatQuantity(quantity)))
+		return
+	}
+
+	seen := make(map[string]bool, len(serials))
+	for _, serial := range normalizeSerials(serials) {
+		if seen[serial] {
+			validationErrors.AddError(field, fmt.Sprintf("número de série %s repetido", serial))
+		}
+		seen[serial] = true
+	}
+}
+
+// receiveSerialNumbers registra a entrada dos números de série no local da entrada. Números ainda não
+// cadastrados são criados; números que já saíram (vendidos ou devolvidos) voltam ao estoque. O evento
+// informado é registrado no histórico de cada número.
+func receiveSerialNumbers(tx *gorm.DB, productID uint, serials []string, entry models.SerialNumber, event models.SerialNumberEvent) error {
+	serialRepo := repository.NewSerialNumberRepository(tx)
+	serials = normalizeSerials(serials)
+
+	existing, err := serialRepo.FindByProductAndSerialsForUpdate(productID, serials)
+	if err != nil {
+		return err
+	}
+	bySerial := make(map[string]models.SerialNumber, len(existing))
+	for _, serial := range existing {
+		bySerial[serial.Serial] = serial
+	}
+
+	events := make([]models.SerialNumberEvent, 0, len(serials))
+	for _, code := range serials {
+		serial, found := bySerial[code]
+		if found && serial.Status == models.SerialStatusInStock {
+			return fmt.Errorf("o número de série %s já está em estoque", code)
+		}
+
+		serial.ProductID = productID
+		serial.Serial = code
+		serial.Status = models.SerialStatusInStock
+		serial.LocationID = entry.LocationID
+		serial.SupplierID = entry.SupplierID
+		serial.PurchaseID = entry.PurchaseID
+		serial.ProductionOrderID = entry.ProductionOrderID
+		serial.ReceivedAt = entry.ReceivedAt
+		serial.SaleID, serial.SaleItemID, serial.CustomerID, serial.SoldAt = nil, nil, nil, nil
+		if found {
+			err = serialRepo.Update(&serial)
+		} else {
+			err = serialRepo.Create(&serial)
+		}
+		if err != nil {
+			return err
+		}
+
+		serialEvent := event
+		serialEvent.SerialNumberID = serial.ID
+		events = append(events, serialEvent)
+	}
+
+	return serialRepo.CreateEvents(events)
+}
+
+// moveSerialNumbers registra a saída ou a transferência dos números de série, que devem estar em estoque no
+// local de origem. A função apply altera a situação de cada número, e o evento informado é registrado no
+// histórico de cada um.
+func moveSerialNumbers(tx *gorm.DB, productID, locationID uint, serials []string, event models.SerialNumberEvent, apply func(*models.SerialNumber)) error {
+	serialRepo := repository.NewSerialNumberRepository(tx)
+	serials = normalizeSerials(serials)
+
+	found, err := serialRepo.FindByProductAndSerialsForUpdate(productID, serials)
+	if err != nil {
+		return err
+	}
+	bySerial := make(map[string]models.SerialNumber, len(found))
+	for _, serial := range found {
+		bySerial[serial.Serial] = serial
+	}
+
+	events := make([]models.SerialNumberEvent, 0, len(serials))
+	for _, code := range serials {
+		serial, ok := bySerial[code]
+		if !ok {
+			return fmt.Errorf("número de série %s não encontrado para o produto", code)
+		}
+		if serial.Status != models.SerialStatusInStock || serial.LocationID == nil || *serial.LocationID != locationID {
+			return fmt.Errorf("o número de série %s não está em estoque no local de origem", code)
+		}
+
+		apply(&serial)
+		if err := serialRepo.Update(&serial); err != nil {
+			return err
+		}
+
+		serialEvent := event
+		serialEvent.SerialNumberID = serial.ID
+		events = append(events, serialEvent)
+	}
+
+	return serialRepo.CreateEvents(events)
+}
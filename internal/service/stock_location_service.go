@@ -0,0 +1,323 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	dto "simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/validator"
+
+	"gorm.io/gorm"
+)
+
+// StockLocationService gerencia os locais de estoque, os saldos por local e as transferências entre locais
+type StockLocationService struct {
+	locationRepo repository.StockLocationRepository
+	productRepo  repository.ProductRepository
+	validator    *validator.StockLocationValidator
+}
+
+// NewStockLocationService cria um novo serviço de locais de estoque
+func NewStockLocationService(locationRepo repository.StockLocationRepository, productRepo repository.ProductRepository) *StockLocationService {
+	return &StockLocationService{
+		locationRepo: locationRepo,
+		productRepo:  productRepo,
+		validator:    validator.NewStockLocationValidator(locationRepo),
+	}
+}
+
+// GetLocations retorna os locais de estoque ordenados pelo código
+func (s *StockLocationService) GetLocations(filters dto.InGetStockLocationsFilters) ([]dto.ApiStockLocation, error) {
+	locations, err := s.locationRepo.FindAll(filters)
+	if err != nil {
+		return nil, err
+	}
+
+	locationDTOs := make([]dto.ApiStockLocation, 0, len(locations))
+	for _, location := range locations {
+		locationDTOs = append(locationDTOs, dto.ApiStockLocationFromModel(location))
+	}
+	return locationDTOs, nil
+}
+
+// GetLocationByID busca um local de estoque pelo ID
+func (s *StockLocationService) GetLocationByID(id uint) (*dto.ApiStockLocation, error) {
+	location, err := s.locationRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if location == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	locationDTO := dto.ApiStockLocationFromModel(*location)
+	return &locationDTO, nil
+}
+
+// CreateLocation cadastra um depósito ou, com o depósito informado, um endereço dentro dele
+func (s *StockLocationService) CreateLocation(req models.CreateStockLocationRequest) (*dto.ApiStockLocation, error) {
+	if err := s.validator.ValidateForCreation(req); err != nil {
+		return nil, err
+	}
+
+	location := models.StockLocation{
+		Code:      strings.TrimSpace(req.Code),
+		Name:      strings.TrimSpace(req.Name),
+		ParentID:  req.ParentID,
+		IsDefault: req.IsDefault,
+		IsActive:  true,
+		Notes:     req.Notes,
+	}
+	if err := s.locationRepo.Create(&location); err != nil {
+		return nil, err
+	}
+
+	return s.GetLocationByID(location.ID)
+}
+
+// UpdateLocation atualiza um local de estoque
+func (s *StockLocationService) UpdateLocation(id uint, req models.UpdateStockLocationRequest) (*dto.ApiStockLocation, error) {
+	location, err := s.locationRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if location == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	if err := s.validator.ValidateForUpdate(location, req); err != nil {
+		return nil, err
+	}
+
+	location.Code = strings.TrimSpace(req.Code)
+	location.Name = strings.TrimSpace(req.Name)
+	location.ParentID = req.ParentID
+	location.IsDefault = req.IsDefault
+	location.IsActive = req.IsActive
+	location.Notes = req.Notes
+	if err := s.locationRepo.Update(location); err != nil {
+		return nil, err
+	}
+
+	return s.GetLocationByID(id)
+}
+
+// GetLocationBalances retorna os saldos dos produtos no local
+func (s *StockLocationService) GetLocationBalances(id uint) ([]dto.ApiStockBalance, error) {
+	location, err := s.locationRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if location == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	balances, err := s.locationRepo.FindBalancesByLocation(id)
+	if err != nil {
+		return nil, err
+	}
+	return stockBalanceDTOs(balances), nil
+}
+
+// GetProductBalances retorna os saldos do produto em cada local
+func (s *StockLocationService) GetProductBalances(productID uint) ([]dto.ApiStockBalance, error) {
+	product, err := s.productRepo.FindByID(productID)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	balances, err := s.locationRepo.FindBalancesByProduct(productID)
+	if err != nil {
+		return nil, err
+	}
+	return stockBalanceDTOs(balances), nil
+}
+
+// GetTransfers retorna uma lista paginada e filtrada de transferências de estoque
+func (s *StockLocationService) GetTransfers(pagination *models.Pagination, filters dto.InGetStockTransfersFilters) (*dto.ApiStockTransferListPaginated, error) {
+	transfers, err := s.locationRepo.FindTransfers(pagination, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	transferDTOs := make([]dto.ApiStockTransfer, 0, len(transfers))
+	for _, transfer := range transfers {
+		transferDTOs = append(transferDTOs, dto.ApiStockTransferFromModel(transfer))
+	}
+
+	return &dto.ApiStockTransferListPaginated{
+		Transfers:  transferDTOs,
+		Pagination: *dto.ApiPaginationFromModel(pagination),
+	}, nil
+}
+
+// CreateTransfer transfere estoque de um produto entre dois locais ativos, com uma saída na origem e uma
+// entrada no destino. A quantidade não pode passar do saldo do produto (ou do lote informado) na origem.
+// Nos produtos com controle de lotes sem lote informado, a quantidade sai dos lotes por ordem de validade e
+// cada lote entra no destino com a quantidade que saiu. Nos produtos com número de série, os números
+// informados passam para o destino.
+func (s *StockLocationService) CreateTransfer(req models.CreateStockTransferRequest, userID uint) (*dto.ApiStockTransfer, error) {
+	var validationErrors validator.ValidationErrors
+
+	product, err := s.productRepo.FindByID(req.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		validationErrors.AddError("product_id", "produto não encontrado")
+	}
+	from, err := resolveStockLocation(s.locationRepo, &validationErrors, "from_location_id", &req.FromLocationID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := resolveStockLocation(s.locationRepo, &validationErrors, "to_location_id", &req.ToLocationID)
+	if err != nil {
+		return nil, err
+	}
+	lotRepo := repository.NewStockLotRepository(s.locationRepo.GetDB())
+	lot, err := findProductLot(lotRepo, &validationErrors, "lot_id", product, req.LotID)
+	if err != nil {
+		return nil, err
+	}
+	validateSerialRequests(&validationErrors, "serials", product, roundQuantity(req.Quantity), req.Serials)
+	if validationErrors.HasErrors() {
+		return nil, validationErrors
+	}
+
+	quantity := roundQuantity(req.Quantity)
+	balance, err := s.locationRepo.FindBalance(product.ID, from.ID)
+	if err != nil {
+		return nil, err
+	}
+	if quantity > balance {
+		validationErrors.AddError("quantity", fmt.Sprintf("quantidade maior que o saldo do produto em %s (%s)", from.Code, formatQuantity(balance)))
+		return nil, validationErrors
+	}
+	if lot != nil {
+		lotBalance, err := lotRepo.FindBalance(lot.ID, from.ID)
+		if err != nil {
+			return nil, err
+		}
+		if quantity > lotBalance {
+			validationErrors.AddError("quantity", fmt.Sprintf("quantidade maior que o saldo do lote %s em %s (%s)", lot.Code, from.Code, formatQuantity(lotBalance)))
+			return nil, validationErrors
+		}
+	}
+
+	transfer := models.StockTransfer{
+		ProductID:      product.ID,
+		FromLocationID: from.ID,
+		ToLocationID:   to.ID,
+		Quantity:       quantity,
+		Notes:          req.Notes,
+		LotID:          req.LotID,
+		CreatedByID:    &userID,
+	}
+	err = s.locationRepo.GetDB().Transaction(func(tx *gorm.DB) error {
+		if err := repository.NewStockLocationRepository(tx).CreateTransfer(&transfer); err != nil {
+			return err
+		}
+
+		notes := fmt.Sprintf("Transferência #%d de %s para %s", transfer.ID, from.Code, to.Code)
+		outputs, err := recordStockOutput(tx, stockMovement{
+			ProductID:     product.ID,
+			LocationID:    &from.ID,
+			LotID:         transfer.LotID,
+			Quantity:      -quantity,
+			MovementType:  models.MovementTypeOut,
+			ReferenceType: models.MovementReferenceTransfer,
+			ReferenceID:   &transfer.ID,
+			Notes:         notes,
+			UserID:        userID,
+		}, product.TracksLots)
+		if err != nil {
+			return err
+		}
+
+		// Cada saída da origem entra no destino com o mesmo lote
+		for _, output := range outputs {
+			_, err := recordStockMovement(tx, stockMovement{
+				ProductID:     product.ID,
+				LocationID:    &to.ID,
+				LotID:         output.LotID,
+				Quantity:      -output.Quantity,
+				MovementType:  models.MovementTypeIn,
+				ReferenceType: models.MovementReferenceTransfer,
+				ReferenceID:   &transfer.ID,
+				Notes:         output.Notes,
+				UserID:        userID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if !product.TracksSerials {
+			return nil
+		}
+		return moveSerialNumbers(tx, product.ID, from.ID, req.Serials, models.SerialNumberEvent{
+			EventType:     models.SerialEventTransferred,
+			ReferenceType: models.MovementReferenceTransfer,
+			ReferenceID:   &transfer.ID,
+			LocationID:    &to.ID,
+			Notes:         notes,
+			CreatedByID:   &userID,
+		}, func(serial *models.SerialNumber) {
+			serial.LocationID = &to.ID
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	saved, err := s.locationRepo.FindTransferByID(transfer.ID)
+	if err != nil {
+		return nil, err
+	}
+	transferDTO := dto.ApiStockTransferFromModel(*saved)
+	return &transferDTO, nil
+}
+
+// resolveStockLocation retorna o local de estoque informado ou, sem ele, o local padrão. Locais
+// inexistentes ou inativos são registrados como erro de validação no campo informado.
+func resolveStockLocation(locationRepo repository.StockLocationRepository, validationErrors *validator.ValidationErrors, field string, locationID *uint) (*models.StockLocation, error) {
+	if locationID == nil {
+		location, err := locationRepo.FindDefault()
+		if err != nil {
+			return nil, err
+		}
+		if location == nil {
+			validationErrors.AddError(field, ErrNoDefaultStockLocation.Error())
+		}
+		return location, nil
+	}
+
+	location, err := locationRepo.FindByID(*locationID)
+	if err != nil {
+		return nil, err
+	}
+	if location == nil {
+		validationErrors.AddError(field, "local de estoque não encontrado")
+		return nil, nil
+	}
+	if !location.IsActive {
+		validationErrors.AddError(field, fmt.Sprintf("o local de estoque %s está inativo", location.Code))
+		return nil, nil
+	}
+	return location, nil
+}
+
+// stockBalanceDTOs converte os saldos por local para DTOs
+func stockBalanceDTOs(balances []models.StockBalance) []dto.ApiStockBalance {
+	balanceDTOs := make([]dto.ApiStockBalance, 0, len(balances))
+	for _, balance := range balances {
+		balanceDTOs = append(balanceDTOs, dto.ApiStockBalanceFromModel(balance))
+	}
+	return balanceDTOs
+}
@@ -0,0 +1,281 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	dto "simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/validator"
+
+	"gorm.io/gorm"
+)
+
+// lotExpiryWarningDays é a antecedência padrão do relatório de lotes a vencer
+const lotExpiryWarningDays = 30
+
+// StockLotService consulta os lotes, o vencimento dos saldos e a rastreabilidade dos lotes vendidos
+type StockLotService struct {
+	lotRepo      repository.StockLotRepository
+	productRepo  repository.ProductRepository
+	customerRepo repository.CustomerRepository
+}
+
+// NewStockLotService cria um novo serviço de lotes
+func NewStockLotService(lotRepo repository.StockLotRepository, productRepo repository.ProductRepository, customerRepo repository.CustomerRepository) *StockLotService {
+	return &StockLotService{
+		lotRepo:      lotRepo,
+		productRepo:  productRepo,
+		customerRepo: customerRepo,
+	}
+}
+
+// GetProductLots retorna os lotes do produto por ordem de validade, com os saldos por local
+func (s *StockLotService) GetProductLots(productID uint) ([]dto.ApiStockLot, error) {
+	product, err := s.productRepo.FindByID(productID)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	lots, err := s.lotRepo.FindByProduct(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	lotDTOs := make([]dto.ApiStockLot, 0, len(lots))
+	for _, lot := range lots {
+		lot.Product = product
+		lotDTOs = append(lotDTOs, dto.ApiStockLotFromModel(lot))
+	}
+	return lotDTOs, nil
+}
+
+// GetLotByID busca um lote pelo ID com os saldos por local
+func (s *StockLotService) GetLotByID(id uint) (*dto.ApiStockLot, error) {
+	lot, err := s.lotRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if lot == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	lotDTO := dto.ApiStockLotFromModel(*lot)
+	return &lotDTO, nil
+}
+
+// GetLotTraceability retorna os clientes que receberam o lote, com as quantidades, e as vendas em que ele
+// foi entregue
+func (s *StockLotService) GetLotTraceability(id uint) (*dto.ApiLotTraceability, error) {
+	lot, err := s.lotRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if lot == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	summaries, err := s.lotRepo.FindLotCustomers(id)
+	if err != nil {
+		return nil, err
+	}
+	customerIDs := make([]uint, 0, len(summaries))
+	for _, summary := range summaries {
+		if summary.CustomerID != nil {
+			customerIDs = append(customerIDs, *summary.CustomerID)
+		}
+	}
+	customers, err := s.customerRepo.FindByIDs(customerIDs)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[uint]string, len(customers))
+	for _, customer := range customers {
+		names[customer.ID] = dto.CustomerDisplayName(customer)
+	}
+
+	allocations, err := s.lotRepo.FindLotAllocations(id)
+	if err != nil {
+		return nil, err
+	}
+
+	result := dto.ApiLotTraceability{
+		Lot:       dto.ApiStockLotFromModel(*lot),
+		Customers: make([]dto.ApiLotCustomer, 0, len(summaries)),
+		Sales:     make([]dto.ApiLotSale, 0, len(allocations)),
+	}
+	for _, summary := range summaries {
+		customer := dto.ApiLotCustomer{
+			CustomerID: summary.CustomerID,
+			Quantity:   summary.Quantity,
+			Sales:      summary.Sales,
+			FirstSale:  summary.FirstSale,
+			LastSale:   summary.LastSale,
+		}
+		if summary.CustomerID != nil {
+			customer.CustomerName = names[*summary.CustomerID]
+		}
+		result.Customers = append(result.Customers, customer)
+	}
+	for _, allocation := range allocations {
+		result.Sales = append(result.Sales, dto.ApiLotSaleFromModel(allocation))
+	}
+	return &result, nil
+}
+
+// GetSaleLots retorna os lotes entregues em cada item da venda
+func (s *StockLotService) GetSaleLots(saleID uint) ([]dto.ApiSaleLot, error) {
+	allocations, err := s.lotRepo.FindSaleAllocations(saleID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.ApiSaleLot, 0, len(allocations))
+	for _, allocation := range allocations {
+		result = append(result, dto.ApiSaleLotFromModel(allocation))
+	}
+	return result, nil
+}
+
+// GetExpiringLots retorna os saldos dos lotes vencidos e dos que vencem nos próximos dias, do que vence
+// primeiro para o último. Sem o filtro de dias, usa a antecedência padrão.
+func (s *StockLotService) GetExpiringLots(filters dto.InGetExpiringLotsFilters) ([]dto.ApiExpiringLot, error) {
+	days := lotExpiryWarningDays
+	if filters.Days != nil {
+		days = *filters.Days
+	}
+
+	today := startOfDay(time.Now())
+	balances, err := s.lotRepo.FindExpiring(today.AddDate(0, 0, days+1), filters.LocationID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.ApiExpiringLot, 0, len(balances))
+	for _, balance := range balances {
+		if balance.Lot == nil || balance.Lot.ExpiresAt == nil {
+			continue
+		}
+		expiresAt := startOfDay(*balance.Lot.ExpiresAt)
+		expiring := dto.ApiExpiringLot{
+			LotID:         balance.LotID,
+			Code:          balance.Lot.Code,
+			ProductID:     balance.Lot.ProductID,
+			LocationID:    balance.LocationID,
+			Quantity:      balance.Quantity,
+			ExpiresAt:     expiresAt,
+			DaysRemaining: int(expiresAt.Sub(today).Hours() / 24),
+		}
+		expiring.Expired = expiring.DaysRemaining < 0
+		if balance.Lot.Product != nil {
+			expiring.ProductSKU = balance.Lot.Product.SKU
+			expiring.ProductName = balance.Lot.Product.Name
+		}
+		if balance.Location != nil {
+			expiring.LocationCode = balance.Location.Code
+		}
+		result = append(result, expiring)
+	}
+	return result, nil
+}
+
+// validateStockLotRequests confere os lotes informados na entrada de uma quantidade: obrigatórios nos
+// produtos com controle de lotes, sem números repetidos e com a soma das quantidades igual à quantidade da
+// entrada; proibidos nos demais produtos
+func validateStockLotRequests(validationErrors *validator.ValidationErrors, field string, product *models.Product, quantity float64, lots []models.ReceivePurchaseLotRequest) {
+	if product == nil {
+		return
+	}
+	if !product.TracksLots {
+		if len(lots) > 0 {
+			validationErrors.AddError(field, fmt.Sprintf("o produto %s não controla lotes", product.SKU))
+		}
+		return
+	}
+	if quantity == 0 {
+		return
+	}
+	if len(lots) == 0 {
+		validationErrors.AddError(field, fmt.Sprintf("o produto %s controla lotes: informe os lotes recebidos", product.SKU))
+		return
+	}
+
+	seen := make(map[string]bool, len(lots))
+	total := 0.0
+	for _, lot := range lots {
+		code := strings.TrimSpace(lot.Code)
+		if seen[code] {
+			validationErrors.AddError(field, fmt.Sprintf("lote %s repetido", code))
+		}
+		seen[code] = true
+		if lot.ManufacturedAt != nil && lot.ExpiresAt != nil && lot.ExpiresAt.Before(*lot.ManufacturedAt) {
+			validationErrors.AddError(field, fmt.Sprintf("a validade do lote %s é anterior à fabricação", code))
+		}
+		total = roundQuantity(total + roundQuantity(lot.Quantity))
+	}
+	if total != quantity {
+		validationErrors.AddError(field, fmt.Sprintf("a soma dos lotes (%s) é diferente da quantidade recebida (%s)", formatQuantity(total), formatQuantity(quantity)))
+	}
+}
+
+// receiveStockLot retorna o lote do produto com o número informado, criando-o com as datas e a origem
+// informadas quando ainda não existe. Um lote existente mantém as datas cadastradas; datas diferentes são
+// recusadas.
+func receiveStockLot(tx *gorm.DB, productID uint, req models.StockLotRequest, origin models.StockLot) (*models.StockLot, error) {
+	lotRepo := repository.NewStockLotRepository(tx)
+	code := strings.TrimSpace(req.Code)
+
+	lot, err := lotRepo.FindByProductAndCode(productID, code)
+	if err != nil {
+		return nil, err
+	}
+	if lot != nil {
+		if req.ExpiresAt != nil && (lot.ExpiresAt == nil || !startOfDay(*lot.ExpiresAt).Equal(startOfDay(*req.ExpiresAt))) {
+			return nil, fmt.Errorf("o lote %s já está cadastrado com outra validade", code)
+		}
+		return lot, nil
+	}
+
+	lot = &origin
+	lot.ProductID = productID
+	lot.Code = code
+	if req.ManufacturedAt != nil {
+		manufacturedAt := startOfDay(*req.ManufacturedAt)
+		lot.ManufacturedAt = &manufacturedAt
+	}
+	if req.ExpiresAt != nil {
+		expiresAt := startOfDay(*req.ExpiresAt)
+		lot.ExpiresAt = &expiresAt
+	}
+	if err := lotRepo.Create(lot); err != nil {
+		return nil, err
+	}
+	return lot, nil
+}
+
+// findProductLot busca o lote informado em uma saída e confere se é do produto
+func findProductLot(lotRepo repository.StockLotRepository, validationErrors *validator.ValidationErrors, field string, product *models.Product, lotID *uint) (*models.StockLot, error) {
+	if lotID == nil || product == nil {
+		return nil, nil
+	}
+	if !product.TracksLots {
+		validationErrors.AddError(field, fmt.Sprintf("o produto %s não controla lotes", product.SKU))
+		return nil, nil
+	}
+
+	lot, err := lotRepo.FindByID(*lotID)
+	if err != nil {
+		return nil, err
+	}
+	if lot == nil || lot.ProductID != product.ID {
+		validationErrors.AddError(field, "lote não encontrado para o produto")
+		return nil, nil
+	}
+	return lot, nil
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// ErrNoDefaultStockLocation é retornado ao movimentar o estoque sem local quando não há local padrão
+var ErrNoDefaultStockLocation = errors.New("nenhum local de estoque padrão cadastrado")
+
+// ErrInsufficientLotStock é retornado quando os lotes do local não cobrem a saída de um produto com controle
+// de lotes
+var ErrInsufficientLotStock = errors.New("saldo insuficiente nos lotes do local")
+
+// stockMovement descreve uma movimentação de estoque a ser registrada
+type stockMovement struct {
+	ProductID     uint
+	LocationID    *uint   // Local de estoque; nulo: local padrão
+	LotID         *uint   // Lote; obrigatório nos produtos com controle de lotes
+	Quantity      float64 // Na unidade do produto: positiva nas entradas e negativa nas saídas
+	MovementType  string  // models.MovementType*
+	ReferenceType string  // models.MovementReference*
+	ReferenceID   *uint
+	Notes         string
+	UserID        uint
+	UnitCost      *float64 // Custo unitário das entradas com custo (compras, produção); nulo: custo médio atual
+}
+
+// recordStockMovement atualiza o estoque atual do produto, o saldo no local e, com o lote informado, o saldo
+// do lote no local, e registra a movimentação com o estoque anterior e o novo. As entradas com custo
+// recalculam o custo médio ponderado do produto; a movimentação guarda o custo unitário e o custo médio
+// resultante. Deve ser chamada dentro de uma transação, junto com a operação que originou a movimentação.
+func recordStockMovement(tx *gorm.DB, m stockMovement) (*models.InventoryMovement, error) {
+	locationID, err := movementLocationID(tx, m.LocationID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := repository.NewStockLocationRepository(tx).AddBalance(m.ProductID, locationID, m.Quantity); err != nil {
+		return nil, err
+	}
+	if m.LotID != nil {
+		lotBalance, err := repository.NewStockLotRepository(tx).AddBalance(*m.LotID, locationID, m.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		if lotBalance < 0 {
+			return nil, fmt.Errorf("%w: a saída deixaria o lote %d com saldo negativo", ErrInsufficientLotStock, *m.LotID)
+		}
+	}
+
+	var entryCost *float64
+	if m.UnitCost != nil && m.Quantity > 0 {
+		entryCost = m.UnitCost
+	}
+	newStock, averageCost, err := repository.NewProductRepository(tx).AddStock(m.ProductID, m.Quantity, entryCost)
+	if err != nil {
+		return nil, err
+	}
+	unitCost := averageCost
+	if entryCost != nil {
+		unitCost = roundUnitPrice(*entryCost)
+	}
+
+	movement := models.InventoryMovement{
+		ProductID:     m.ProductID,
+		LocationID:    &locationID,
+		LotID:         m.LotID,
+		Quantity:      m.Quantity,
+		PreviousStock: roundQuantity(newStock - m.Quantity),
+		NewStock:      newStock,
+		MovementType:  m.MovementType,
+		ReferenceID:   m.ReferenceID,
+		ReferenceType: m.ReferenceType,
+		Notes:         m.Notes,
+		CreatedByID:   &m.UserID,
+		UnitCost:      unitCost,
+		AverageCost:   &averageCost,
+	}
+	if err := repository.NewInventoryMovementRepository(tx).Create(&movement); err != nil {
+		return nil, err
+	}
+
+	return &movement, nil
+}
+
+// movementLocationID retorna o local informado ou, sem ele, o local padrão
+func movementLocationID(tx *gorm.DB, locationID *uint) (uint, error) {
+	if locationID != nil {
+		return *locationID, nil
+	}
+	location, err := repository.NewStockLocationRepository(tx).FindDefault()
+	if err != nil {
+		return 0, err
+	}
+	if location == nil {
+		return 0, ErrNoDefaultStockLocation
+	}
+	return location.ID, nil
+}
+
+// recordStockOutput registra a saída de estoque de um produto. Nos produtos com controle de lotes sem lote
+// informado, a quantidade sai dos lotes não vencidos do local por ordem de validade (FEFO), com uma
+// movimentação por lote. Retorna as movimentações registradas.
+func recordStockOutput(tx *gorm.DB, m stockMovement, tracksLots bool) ([]models.InventoryMovement, error) {
+	if !tracksLots || m.LotID != nil {
+		movement, err := recordStockMovement(tx, m)
+		if err != nil {
+			return nil, err
+		}
+		return []models.InventoryMovement{*movement}, nil
+	}
+
+	locationID, err := movementLocationID(tx, m.LocationID)
+	if err != nil {
+		return nil, err
+	}
+	balances, err := repository.NewStockLotRepository(tx).FindAvailableForUpdate(m.ProductID, locationID, startOfDay(time.Now()))
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := roundQuantity(-m.Quantity)
+	movements := make([]models.InventoryMovement, 0, 1)
+	for _, balance := range balances {
+		if remaining <= 0 {
+			break
+		}
+		quantity := roundQuantity(math.Min(balance.Quantity, remaining))
+
+		lotMovement := m
+		lotMovement.LocationID = &locationID
+		lotMovement.LotID = &balance.LotID
+		lotMovement.Quantity = -quantity
+		if balance.Lot != nil {
+			lotMovement.Notes = fmt.Sprintf("%s (lote %s)", m.Notes, balance.Lot.Code)
+		}
+		movement, err := recordStockMovement(tx, lotMovement)
+		if err != nil {
+			return nil, err
+		}
+		movements = append(movements, *movement)
+		remaining = roundQuantity(remaining - quantity)
+	}
+	if remaining > 0 {
+		return nil, fmt.Errorf("%w: faltam %s do produto %d em lotes dentro da validade", ErrInsufficientLotStock, formatQuantity(remaining), m.ProductID)
+	}
+
+	return movements, nil
+}
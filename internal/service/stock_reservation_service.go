@@ -0,0 +1,190 @@
+package service
+
+import (
+	"log"
+	"time"
+
+	"simple-erp-service/config"
+	dto "simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/validator"
+
+	"gorm.io/gorm"
+)
+
+// StockReservationService consulta as reservas de estoque das vendas pendentes e expira as reservas vencidas
+type StockReservationService struct {
+	reservationRepo repository.StockReservationRepository
+	cfg             config.InventoryConfig
+}
+
+// NewStockReservationService cria um novo serviço de reservas de estoque
+func NewStockReservationService(reservationRepo repository.StockReservationRepository, cfg config.InventoryConfig) *StockReservationService {
+	return &StockReservationService{
+		reservationRepo: reservationRepo,
+		cfg:             cfg,
+	}
+}
+
+// GetSaleReservations retorna as reservas de estoque da venda, incluindo as já baixadas, liberadas ou
+// expiradas
+func (s *StockReservationService) GetSaleReservations(saleID uint) ([]dto.ApiStockReservation, error) {
+	reservations, err := s.reservationRepo.FindBySale(saleID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	result := make([]dto.ApiStockReservation, 0, len(reservations))
+	for _, reservation := range reservations {
+		result = append(result, dto.ApiStockReservationFromModel(reservation, now))
+	}
+	return result, nil
+}
+
+// ExpireReservations marca como expiradas as reservas ativas vencidas e retorna quantas foram expiradas. As
+// reservas vencidas já deixam de contar no estoque disponível; a rotina apenas registra a expiração.
+func (s *StockReservationService) ExpireReservations() (int64, error) {
+	return s.reservationRepo.ExpireOverdue(time.Now())
+}
+
+// RunReservationExpiry executa a expiração das reservas imediatamente e depois a cada intervalo configurado,
+// até o canal stop ser fechado. Intervalo zero desativa a rotina.
+func (s *StockReservationService) RunReservationExpiry(stop <-chan struct{}) {
+	if s.cfg.ReservationCheckInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.cfg.ReservationCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		expired, err := s.ExpireReservations()
+		if err != nil {
+			log.Printf("Erro ao expirar reservas de estoque: %v", err)
+		} else if expired > 0 {
+			log.Printf("Reservas de estoque: %d reservas expiradas", expired)
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ReserveSaleStock reserva o estoque dos itens da venda pendente no local da venda ou, sem ele, no local
+// padrão. Os kits reservam os componentes, na quantidade da composição. O estoque disponível (saldo menos as
+// reservas das outras vendas) deve cobrir os itens. As reservas anteriores da venda são liberadas, de modo
+// que a função também serve para a alteração da venda. Com validade configurada, as reservas expiram se a
+// venda não for faturada a tempo. Deve ser chamada pelo fluxo da venda, dentro da mesma transação, com os
+// itens já na unidade do produto.
+func ReserveSaleStock(tx *gorm.DB, sale models.Sale, cfg config.InventoryConfig, userID uint) error {
+	if err := validateSaleStock(tx, sale); err != nil {
+		return err
+	}
+
+	reservationRepo := repository.NewStockReservationRepository(tx)
+	if err := reservationRepo.CloseBySale(sale.ID, models.ReservationStatusReleased); err != nil {
+		return err
+	}
+	locationID, err := movementLocationID(tx, sale.LocationID)
+	if err != nil {
+		return err
+	}
+
+	productIDs := make([]uint, 0, len(sale.Items))
+	for _, item := range sale.Items {
+		productIDs = append(productIDs, item.ProductID)
+	}
+	products, err := repository.NewProductRepository(tx).FindByIDs(productIDs)
+	if err != nil {
+		return err
+	}
+	kitIDs := make([]uint, 0)
+	for _, product := range products {
+		if product.Kind == models.ProductKindKit {
+			kitIDs = append(kitIDs, product.ID)
+		}
+	}
+	components, err := repository.NewProductComponentRepository(tx).FindByProducts(kitIDs)
+	if err != nil {
+		return err
+	}
+	componentsByKit := make(map[uint][]models.ProductComponent, len(kitIDs))
+	for _, component := range components {
+		componentsByKit[component.ProductID] = append(componentsByKit[component.ProductID], component)
+	}
+
+	now := time.Now()
+	var expiresAt *time.Time
+	if cfg.ReservationExpiry > 0 {
+		expiry := now.Add(cfg.ReservationExpiry)
+		expiresAt = &expiry
+	}
+
+	reservations := make([]models.StockReservation, 0, len(sale.Items))
+	reserve := func(item models.SaleItem, productID uint, quantity float64) {
+		reservations = append(reservations, models.StockReservation{
+			SaleID:      sale.ID,
+			SaleItemID:  item.ID,
+			ProductID:   productID,
+			LocationID:  locationID,
+			Quantity:    quantity,
+			Status:      models.ReservationStatusActive,
+			ExpiresAt:   expiresAt,
+			CreatedByID: &userID,
+		})
+	}
+	for _, item := range sale.Items {
+		kitComponents, isKit := componentsByKit[item.ProductID]
+		if !isKit {
+			reserve(item, item.ProductID, roundQuantity(item.Quantity))
+			continue
+		}
+		for _, component := range kitComponents {
+			reserve(item, component.ComponentID, roundQuantity(component.Quantity*item.Quantity))
+		}
+	}
+
+	return reservationRepo.CreateAll(reservations)
+}
+
+// ReleaseSaleStock libera as reservas ativas da venda. Deve ser chamada pelo fluxo de cancelamento da venda,
+// dentro da mesma transação.
+func ReleaseSaleStock(tx *gorm.DB, saleID uint) error {
+	return repository.NewStockReservationRepository(tx).CloseBySale(saleID, models.ReservationStatusReleased)
+}
+
+// validateSaleStock bloqueia os produtos da venda e os componentes até o fim da transação e verifica se o
+// estoque disponível no local da venda cobre os itens, desconsiderando as reservas da própria venda
+func validateSaleStock(tx *gorm.DB, sale models.Sale) error {
+	productIDs := make([]uint, 0, len(sale.Items))
+	for _, item := range sale.Items {
+		productIDs = append(productIDs, item.ProductID)
+	}
+	componentRepo := repository.NewProductComponentRepository(tx)
+	components, err := componentRepo.FindByProducts(productIDs)
+	if err != nil {
+		return err
+	}
+	lockIDs := append([]uint{}, productIDs...)
+	for _, component := range components {
+		lockIDs = append(lockIDs, component.ComponentID)
+	}
+
+	reservationRepo := repository.NewStockReservationRepository(tx)
+	if err := reservationRepo.LockProducts(lockIDs); err != nil {
+		return err
+	}
+
+	return validator.NewSaleValidator(
+		repository.NewProductRepository(tx),
+		componentRepo,
+		repository.NewStockLocationRepository(tx),
+		reservationRepo,
+		repository.NewStockLotRepository(tx),
+	).ValidateStock(sale, startOfDay(time.Now()))
+}
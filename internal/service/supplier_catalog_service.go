@@ -0,0 +1,216 @@
+package service
+
+import (
+	"sort"
+	"strings"
+
+	dto "simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/validator"
+
+	"gorm.io/gorm"
+)
+
+// SupplierCatalogService gerencia o catálogo dos fornecedores: códigos, embalagens, prazos e últimos preços
+type SupplierCatalogService struct {
+	supplierRepo        repository.SupplierRepository
+	productRepo         repository.ProductRepository
+	supplierProductRepo repository.SupplierProductRepository
+	validator           *validator.SupplierProductValidator
+}
+
+// NewSupplierCatalogService cria um novo serviço do catálogo dos fornecedores
+func NewSupplierCatalogService(
+	supplierRepo repository.SupplierRepository,
+	productRepo repository.ProductRepository,
+	supplierProductRepo repository.SupplierProductRepository,
+) *SupplierCatalogService {
+	return &SupplierCatalogService{
+		supplierRepo:        supplierRepo,
+		productRepo:         productRepo,
+		supplierProductRepo: supplierProductRepo,
+		validator:           validator.NewSupplierProductValidator(supplierProductRepo, productRepo),
+	}
+}
+
+// GetCatalog retorna o catálogo do fornecedor
+func (s *SupplierCatalogService) GetCatalog(supplierID uint) ([]dto.ApiSupplierProduct, error) {
+	if err := s.ensureSupplier(supplierID); err != nil {
+		return nil, err
+	}
+
+	items, err := s.supplierProductRepo.FindBySupplier(supplierID)
+	if err != nil {
+		return nil, err
+	}
+
+	itemDTOs := make([]dto.ApiSupplierProduct, 0, len(items))
+	for _, item := range items {
+		itemDTOs = append(itemDTOs, dto.ApiSupplierProductFromModel(item))
+	}
+	return itemDTOs, nil
+}
+
+// CreateCatalogItem adiciona um produto ao catálogo do fornecedor
+func (s *SupplierCatalogService) CreateCatalogItem(supplierID uint, req models.SupplierProductRequest) (*dto.ApiSupplierProduct, error) {
+	if err := s.ensureSupplier(supplierID); err != nil {
+		return nil, err
+	}
+	if err := s.validator.Validate(supplierID, 0, req); err != nil {
+		return nil, err
+	}
+
+	item := models.SupplierProduct{SupplierID: supplierID}
+	applySupplierProductRequest(&item, req)
+
+	if err := s.save(&item, true); err != nil {
+		return nil, err
+	}
+	return s.getCatalogItem(supplierID, item.ID)
+}
+
+// UpdateCatalogItem atualiza um item do catálogo do fornecedor
+func (s *SupplierCatalogService) UpdateCatalogItem(supplierID, id uint, req models.SupplierProductRequest) (*dto.ApiSupplierProduct, error) {
+	item, err := s.supplierProductRepo.FindByIDAndSupplier(id, supplierID)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, utils.ErrNotFound
+	}
+	if err := s.validator.Validate(supplierID, id, req); err != nil {
+		return nil, err
+	}
+
+	applySupplierProductRequest(item, req)
+
+	if err := s.save(item, false); err != nil {
+		return nil, err
+	}
+	return s.getCatalogItem(supplierID, id)
+}
+
+// DeleteCatalogItem remove um item do catálogo do fornecedor
+func (s *SupplierCatalogService) DeleteCatalogItem(supplierID, id uint) error {
+	item, err := s.supplierProductRepo.FindByIDAndSupplier(id, supplierID)
+	if err != nil {
+		return err
+	}
+	if item == nil {
+		return utils.ErrNotFound
+	}
+
+	return s.supplierProductRepo.Delete(id)
+}
+
+// CompareSuppliers compara os fornecedores do produto pelo último preço convertido para a unidade do produto
+func (s *SupplierCatalogService) CompareSuppliers(productID uint) (*dto.ApiSupplierPriceComparison, error) {
+	product, err := s.productRepo.FindByID(productID)
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	items, err := s.supplierProductRepo.FindByProduct(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	comparison := dto.ApiSupplierPriceComparison{
+		ProductID:   product.ID,
+		ProductSKU:  product.SKU,
+		ProductName: product.Name,
+		Suppliers:   make([]dto.ApiSupplierProduct, 0, len(items)),
+	}
+	for _, item := range items {
+		comparison.Suppliers = append(comparison.Suppliers, dto.ApiSupplierProductFromModel(item))
+	}
+
+	// Menor preço primeiro; sem preço no final; empates pelo menor prazo de entrega
+	sort.SliceStable(comparison.Suppliers, func(i, j int) bool {
+		a, b := comparison.Suppliers[i], comparison.Suppliers[j]
+		if (a.UnitPrice == nil) != (b.UnitPrice == nil) {
+			return a.UnitPrice != nil
+		}
+		if a.UnitPrice != nil && *a.UnitPrice != *b.UnitPrice {
+			return *a.UnitPrice < *b.UnitPrice
+		}
+		return a.LeadTimeDays < b.LeadTimeDays
+	})
+	if len(comparison.Suppliers) > 0 && comparison.Suppliers[0].UnitPrice != nil {
+		comparison.BestSupplierID = &comparison.Suppliers[0].SupplierID
+	}
+
+	return &comparison, nil
+}
+
+// ensureSupplier retorna ErrNotFound quando o fornecedor não existe
+func (s *SupplierCatalogService) ensureSupplier(supplierID uint) error {
+	supplier, err := s.supplierRepo.FindByID(supplierID)
+	if err != nil {
+		return err
+	}
+	if supplier == nil {
+		return utils.ErrNotFound
+	}
+	return nil
+}
+
+// getCatalogItem busca um item do catálogo e o converte para DTO
+func (s *SupplierCatalogService) getCatalogItem(supplierID, id uint) (*dto.ApiSupplierProduct, error) {
+	item, err := s.supplierProductRepo.FindByIDAndSupplier(id, supplierID)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	itemDTO := dto.ApiSupplierProductFromModel(*item)
+	return &itemDTO, nil
+}
+
+// save grava o item do catálogo. Ao marcar o fornecedor como preferencial, os demais fornecedores do
+// produto deixam de ser preferenciais na mesma transação.
+func (s *SupplierCatalogService) save(item *models.SupplierProduct, create bool) error {
+	return s.supplierProductRepo.GetDB().Transaction(func(tx *gorm.DB) error {
+		supplierProductRepo := repository.NewSupplierProductRepository(tx)
+
+		var err error
+		if create {
+			err = supplierProductRepo.Create(item)
+		} else {
+			err = supplierProductRepo.Update(item)
+		}
+		if err != nil {
+			return err
+		}
+
+		if item.IsPreferred {
+			return supplierProductRepo.ClearPreferred(item.ProductID, item.ID)
+		}
+		return nil
+	})
+}
+
+// applySupplierProductRequest copia os dados da requisição para o item do catálogo
+func applySupplierProductRequest(item *models.SupplierProduct, req models.SupplierProductRequest) {
+	item.ProductID = req.ProductID
+	item.SupplierCode = strings.TrimSpace(req.SupplierCode)
+	item.PackSize = req.PackSize
+	if item.PackSize <= 0 {
+		item.PackSize = 1
+	}
+	item.PackUnit = strings.TrimSpace(req.PackUnit)
+	item.LeadTimeDays = req.LeadTimeDays
+	// O último preço é mantido pelos recebimentos; a requisição só o substitui quando informado
+	if req.LastPrice != nil {
+		item.LastPrice = req.LastPrice
+	}
+	item.IsPreferred = req.IsPreferred
+	item.Notes = req.Notes
+}
@@ -0,0 +1,258 @@
+package service
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	dto "simple-erp-service/internal/data-structure/dto"
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/utils"
+)
+
+// Pesos dos indicadores na nota do scorecard. Indicadores sem dados no período são desconsiderados
+// e os pesos dos demais são redistribuídos proporcionalmente.
+const (
+	scoreWeightOnTime  = 35.0
+	scoreWeightFill    = 30.0
+	scoreWeightPrice   = 20.0
+	scoreWeightReturns = 15.0
+
+	// Variação de preço e taxa de devolução a partir das quais o indicador zera
+	scorePriceVarianceLimit = 0.10
+	scoreReturnRateLimit    = 0.10
+)
+
+// SupplierScorecardService calcula os indicadores de desempenho e o ranking dos fornecedores a partir
+// das compras recebidas e das devoluções
+type SupplierScorecardService struct {
+	supplierRepo  repository.SupplierRepository
+	scorecardRepo repository.SupplierScorecardRepository
+}
+
+// NewSupplierScorecardService cria um novo serviço de scorecard de fornecedores
+func NewSupplierScorecardService(
+	supplierRepo repository.SupplierRepository,
+	scorecardRepo repository.SupplierScorecardRepository,
+) *SupplierScorecardService {
+	return &SupplierScorecardService{
+		supplierRepo:  supplierRepo,
+		scorecardRepo: scorecardRepo,
+	}
+}
+
+// GetScorecard retorna o scorecard do fornecedor no período
+func (s *SupplierScorecardService) GetScorecard(supplierID uint, filters dto.InSupplierScorecardFilters) (*dto.ApiSupplierScorecard, error) {
+	supplier, err := s.supplierRepo.FindByID(supplierID)
+	if err != nil {
+		return nil, err
+	}
+	if supplier == nil {
+		return nil, utils.ErrNotFound
+	}
+
+	scorecards, err := s.buildScorecards(supplierID, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	scorecard := buildScorecard(supplierID, models.SupplierPurchaseStats{}, models.SupplierReturnStats{})
+	if len(scorecards) > 0 {
+		scorecard = scorecards[0]
+	}
+	scorecard.SupplierName = dto.SupplierDisplayName(*supplier)
+	return &scorecard, nil
+}
+
+// GetLeaderboard retorna o ranking dos fornecedores com compras recebidas ou devoluções no período
+func (s *SupplierScorecardService) GetLeaderboard(filters dto.InSupplierScorecardFilters) (*dto.ApiSupplierLeaderboard, error) {
+	scorecards, err := s.buildScorecards(0, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fornecedores com poucas compras distorcem as taxas e podem ser excluídos do ranking
+	filtered := scorecards[:0]
+	for _, scorecard := range scorecards {
+		if scorecard.Purchases >= int64(filters.MinPurchases) {
+			filtered = append(filtered, scorecard)
+		}
+	}
+	scorecards = filtered
+
+	sortBy := filters.SortBy
+	if sortBy == "" {
+		sortBy = "score"
+	}
+	sortScorecards(scorecards, sortBy)
+	if filters.Limit > 0 && len(scorecards) > filters.Limit {
+		scorecards = scorecards[:filters.Limit]
+	}
+
+	// Nomes dos fornecedores do ranking
+	ids := make([]uint, 0, len(scorecards))
+	for _, scorecard := range scorecards {
+		ids = append(ids, scorecard.SupplierID)
+	}
+	suppliers, err := s.supplierRepo.FindByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[uint]string, len(suppliers))
+	for _, supplier := range suppliers {
+		names[supplier.ID] = dto.SupplierDisplayName(supplier)
+	}
+	for i := range scorecards {
+		scorecards[i].Rank = i + 1
+		scorecards[i].SupplierName = names[scorecards[i].SupplierID]
+	}
+
+	leaderboard := dto.ApiSupplierLeaderboard{
+		SortBy:      sortBy,
+		Suppliers:   scorecards,
+		GeneratedAt: time.Now(),
+	}
+	if !filters.DateFrom.IsZero() {
+		leaderboard.DateFrom = &filters.DateFrom
+	}
+	if !filters.DateTo.IsZero() {
+		leaderboard.DateTo = &filters.DateTo
+	}
+	return &leaderboard, nil
+}
+
+// buildScorecards calcula os scorecards dos fornecedores no período, ordenados pelo ID do fornecedor
+func (s *SupplierScorecardService) buildScorecards(supplierID uint, filters dto.InSupplierScorecardFilters) ([]dto.ApiSupplierScorecard, error) {
+	purchaseStats, err := s.scorecardRepo.PurchaseStats(supplierID, filters.DateFrom, filters.DateTo)
+	if err != nil {
+		return nil, err
+	}
+	returnStats, err := s.scorecardRepo.ReturnStats(supplierID, filters.DateFrom, filters.DateTo)
+	if err != nil {
+		return nil, err
+	}
+
+	purchasesBySupplier := make(map[uint]models.SupplierPurchaseStats, len(purchaseStats))
+	returnsBySupplier := make(map[uint]models.SupplierReturnStats, len(returnStats))
+	ids := make([]uint, 0, len(purchaseStats)+len(returnStats))
+	for _, stats := range purchaseStats {
+		purchasesBySupplier[stats.SupplierID] = stats
+		ids = append(ids, stats.SupplierID)
+	}
+	for _, stats := range returnStats {
+		returnsBySupplier[stats.SupplierID] = stats
+		if _, ok := purchasesBySupplier[stats.SupplierID]; !ok {
+			ids = append(ids, stats.SupplierID)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	scorecards := make([]dto.ApiSupplierScorecard, 0, len(ids))
+	for _, id := range ids {
+		scorecards = append(scorecards, buildScorecard(id, purchasesBySupplier[id], returnsBySupplier[id]))
+	}
+	return scorecards, nil
+}
+
+// buildScorecard calcula os indicadores e a nota ponderada do fornecedor
+func buildScorecard(supplierID uint, purchases models.SupplierPurchaseStats, returns models.SupplierReturnStats) dto.ApiSupplierScorecard {
+	scorecard := dto.ApiSupplierScorecard{
+		SupplierID:       supplierID,
+		Purchases:        purchases.Purchases,
+		Returns:          returns.Returns,
+		OrderedQuantity:  purchases.OrderedQuantity,
+		ReceivedQuantity: purchases.ReceivedQuantity,
+		ReturnedQuantity: returns.ReturnedQuantity,
+	}
+
+	if purchases.WithDueDate > 0 {
+		scorecard.OnTimeRate = ratio(float64(purchases.OnTime), float64(purchases.WithDueDate))
+	}
+	if purchases.OrderedQuantity > 0 {
+		scorecard.FillRate = ratio(purchases.ReceivedQuantity, purchases.OrderedQuantity)
+	}
+	if purchases.AgreedValue > 0 {
+		scorecard.PriceVariance = ratio(purchases.PaidValue-purchases.AgreedValue, purchases.AgreedValue)
+	}
+	if purchases.ReceivedQuantity > 0 {
+		scorecard.ReturnRate = ratio(returns.ReturnedQuantity, purchases.ReceivedQuantity)
+	}
+	if purchases.Purchases > 0 {
+		days := math.Round(purchases.AvgLeadTimeDays*10) / 10
+		scorecard.AvgLeadTimeDays = &days
+	}
+
+	var weighted, weights float64
+	addScore := func(value *float64, weight float64, score func(float64) float64) {
+		if value == nil {
+			return
+		}
+		weighted += score(*value) * weight
+		weights += weight
+	}
+	addScore(scorecard.OnTimeRate, scoreWeightOnTime, func(rate float64) float64 { return rate })
+	addScore(scorecard.FillRate, scoreWeightFill, func(rate float64) float64 { return math.Min(rate, 1) })
+	addScore(scorecard.PriceVariance, scoreWeightPrice, func(variance float64) float64 {
+		return 1 - clamp(variance/scorePriceVarianceLimit, 0, 1)
+	})
+	addScore(scorecard.ReturnRate, scoreWeightReturns, func(rate float64) float64 {
+		return 1 - clamp(rate/scoreReturnRateLimit, 0, 1)
+	})
+	if weights > 0 {
+		scorecard.Score = math.Round(weighted/weights*100*100) / 100
+	}
+
+	return scorecard
+}
+
+// sortScorecards ordena os scorecards pelo critério do ranking. Indicadores nulos ficam no final e
+// os empates são desfeitos pela nota e, por fim, pelo ID do fornecedor.
+func sortScorecards(scorecards []dto.ApiSupplierScorecard, sortBy string) {
+	// Retorna o valor do critério já orientado para que valores maiores venham primeiro
+	key := func(s dto.ApiSupplierScorecard) *float64 {
+		var value *float64
+		switch sortBy {
+		case "on_time_rate":
+			value = s.OnTimeRate
+		case "fill_rate":
+			value = s.FillRate
+		case "price_variance":
+			if s.PriceVariance != nil {
+				v := -*s.PriceVariance
+				value = &v
+			}
+		case "returns":
+			v := -float64(s.Returns)
+			value = &v
+		default:
+			value = &s.Score
+		}
+		return value
+	}
+
+	sort.SliceStable(scorecards, func(i, j int) bool {
+		a, b := key(scorecards[i]), key(scorecards[j])
+		if (a == nil) != (b == nil) {
+			return a != nil
+		}
+		if a != nil && *a != *b {
+			return *a > *b
+		}
+		if scorecards[i].Score != scorecards[j].Score {
+			return scorecards[i].Score > scorecards[j].Score
+		}
+		return scorecards[i].SupplierID < scorecards[j].SupplierID
+	})
+}
+
+// ratio retorna a razão arredondada para quatro casas decimais
+func ratio(numerator, denominator float64) *float64 {
+	value := math.Round(numerator/denominator*10000) / 10000
+	return &value
+}
+
+// clamp limita o valor ao intervalo informado
+func clamp(value, lower, upper float64) float64 {
+	return math.Max(lower, math.Min(upper, value))
+}
@@ -5,8 +5,10 @@ import (
 	"simple-erp-service/internal/data-structure/models"
 	"simple-erp-service/internal/repository"
 	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/utils/brdoc"
 	"simple-erp-service/internal/validator"
-	"strings"
+
+	"gorm.io/gorm"
 )
 
 // SupplierService gerencia operações relacionadas a fornecedores
@@ -23,9 +25,9 @@ func NewSupplierService(supplierRepo repository.SupplierRepository) *SupplierSer
 	}
 }
 
-// GetSuppliers retorna uma lista paginada de fornecedores
-func (s *SupplierService) GetSuppliers(pagination *models.Pagination) (*dto.ApiSupplierListPaginated, error) {
-	suppliers, err := s.supplierRepo.FindAll(pagination)
+// GetSuppliers retorna uma lista paginada e filtrada de fornecedores
+func (s *SupplierService) GetSuppliers(pagination *models.Pagination, filters dto.InGetPartiesFilters) (*dto.ApiSupplierListPaginated, error) {
+	suppliers, err := s.supplierRepo.FindAll(pagination, filters)
 	if err != nil {
 		return nil, err
 	}
@@ -43,8 +45,8 @@ func (s *SupplierService) GetSuppliers(pagination *models.Pagination) (*dto.ApiS
 }
 
 // GetSupplierByID busca um fornecedor pelo ID
-func (s *SupplierService) GetSupplierByID(id uint) (*dto.ApiSupplier, error) {
-	supplier, err := s.supplierRepo.FindByID(id)
+func (s *SupplierService) GetSupplierByID(id uint) (*dto.ApiSupplierDetail, error) {
+	supplier, err := s.supplierRepo.FindByIDWithRelations(id)
 	if err != nil {
 		return nil, err
 	}
@@ -53,7 +55,7 @@ func (s *SupplierService) GetSupplierByID(id uint) (*dto.ApiSupplier, error) {
 	}
 
 	// Converter para DTO
-	supplierDetailDTO := dto.ApiSupplierFromModel(*supplier)
+	supplierDetailDTO := dto.ApiSupplierDetailFromModel(*supplier)
 	return &supplierDetailDTO, nil
 }
 
@@ -64,10 +66,8 @@ func (s *SupplierService) CreateSupplier(req models.CreateSupplierRequest) (*dto
 		return nil, err
 	}
 
-	// Formatar documento (remover caracteres não numéricos)
-	document := strings.ReplaceAll(req.DocumentNumber, ".", "")
-	document = strings.ReplaceAll(document, "-", "")
-	document = strings.ReplaceAll(document, "/", "")
+	// Formatar documento (remover a máscara)
+	document := brdoc.Clean(req.DocumentNumber)
 
 	// Criar fornecedor
 	supplier := models.Supplier{
@@ -109,42 +109,26 @@ func (s *SupplierService) UpdateSupplier(id uint, req models.UpdateSupplierReque
 	// Atualizar campos básicos
 	supplier.FirstName = req.FirstName
 	supplier.LastName = req.LastName
-	supplier.CompanyName = req.CompanyName
+	if req.CompanyName != "" { // Sem o campo, a razão social gravada é mantida
+		supplier.CompanyName = req.CompanyName
+	}
 	supplier.IsActive = req.IsActive
 	supplier.Notes = req.Notes
 
 	// Atualizar e formatar o DocumentNumber (removendo caracteres especiais)
-	document := strings.NewReplacer(".", "", "-", "", "/", "").Replace(req.DocumentNumber)
+	document := brdoc.Clean(req.DocumentNumber)
 	supplier.DocumentNumber = document
 
-	// TODO Implementar os Métodos
-	//// Atualizar Relacionamentos (se IDs foram enviados)
-	// if len(req.DocumentsIDs) > 0 {
-	// 	documents, err := s.documentRepo.FindByIDs(req.DocumentsIDs)
-	// 	if err != nil {
-	// 		return nil, err
-	// 	}
-	// 	supplier.Documents = documents
-	// }
-
-	// if len(req.AdressesIDs) > 0 {
-	// 	addresses, err := s.addressRepo.FindByIDs(req.AdressesIDs)
-	// 	if err != nil {
-	// 		return nil, err
-	// 	}
-	// 	supplier.Addresses = addresses
-	// }
-
-	// if len(req.ContactsIDs) > 0 {
-	// 	contacts, err := s.contactRepo.FindByIDs(req.ContactsIDs)
-	// 	if err != nil {
-	// 		return nil, err
-	// 	}
-	// 	supplier.Contacts = contacts
-	// }
-
-	// Salvar alterações
-	if err := s.supplierRepo.Update(supplier); err != nil {
+	// Vincular documentos, endereços e contatos enviados pelo front e salvar as alterações na mesma transação,
+	// para que um erro na gravação não deixe os vínculos reatribuídos
+	owner := models.Owner{Type: models.OwnerSupplier, ID: id}
+	err = s.supplierRepo.GetDB().Transaction(func(tx *gorm.DB) error {
+		if err := newRelationLinker(tx).link(owner, req.DocumentsIDs, req.AdressesIDs, req.ContactsIDs); err != nil {
+			return err
+		}
+		return repository.NewSupplierRepository(tx).Update(supplier)
+	})
+	if err != nil {
 		return nil, err
 	}
 
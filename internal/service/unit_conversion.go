@@ -0,0 +1,58 @@
+package service
+
+import (
+	"errors"
+	"math"
+	"strconv"
+
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+)
+
+// ErrIncompatibleUnit é retornado quando a unidade informada não tem conversão para a unidade do produto
+var ErrIncompatibleUnit = errors.New("a unidade não possui conversão para a unidade do produto")
+
+// unitFactor retorna quantas unidades do produto há em cada unidade informada. Sem unidade, ou com a
+// própria unidade do produto, o fator é 1. As conversões exclusivas do produto têm precedência sobre as
+// globais, e cada conversão vale nos dois sentidos.
+func unitFactor(unitRepo repository.MeasurementUnitRepository, product models.Product, unitID *uint) (float64, error) {
+	if unitID == nil || (product.UnitID != nil && *unitID == *product.UnitID) {
+		return 1, nil
+	}
+	if product.UnitID == nil {
+		return 0, ErrIncompatibleUnit
+	}
+
+	conversions, err := unitRepo.FindConversionsForProduct(product.ID, *unitID)
+	if err != nil {
+		return 0, err
+	}
+	for _, conversion := range conversions {
+		if factor, ok := conversionFactor(conversion, *unitID, *product.UnitID); ok {
+			return factor, nil
+		}
+	}
+	return 0, ErrIncompatibleUnit
+}
+
+// conversionFactor retorna quantas unidades de destino há em cada unidade de origem segundo a conversão,
+// usando o inverso do fator quando a conversão está cadastrada no sentido contrário
+func conversionFactor(conversion models.UnitConversion, fromUnitID, toUnitID uint) (float64, bool) {
+	switch {
+	case conversion.FromUnitID == fromUnitID && conversion.ToUnitID == toUnitID:
+		return conversion.Factor, true
+	case conversion.FromUnitID == toUnitID && conversion.ToUnitID == fromUnitID:
+		return 1 / conversion.Factor, true
+	}
+	return 0, false
+}
+
+// roundQuantity arredonda quantidades para as quatro casas decimais gravadas no banco
+func roundQuantity(value float64) float64 {
+	return math.Round(value*10000) / 10000
+}
+
+// formatQuantity formata a quantidade para mensagens, sem zeros à direita
+func formatQuantity(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
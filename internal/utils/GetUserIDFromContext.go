@@ -18,3 +18,18 @@ func GetUserIDFromContext(c *gin.Context) (uint, bool) {
 
 	return userID, true
 }
+
+// GetImpersonatorIDFromContext retorna o ID do usuário que está personificando o usuário autenticado
+func GetImpersonatorIDFromContext(c *gin.Context) (uint, bool) {
+	impersonatorIDRaw, exists := c.Get("impersonatorID")
+	if !exists {
+		return 0, false
+	}
+
+	impersonatorID, ok := impersonatorIDRaw.(uint)
+	if !ok {
+		return 0, false
+	}
+
+	return impersonatorID, true
+}
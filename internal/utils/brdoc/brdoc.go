@@ -0,0 +1,129 @@
+// Package brdoc valida e formata documentos brasileiros (CPF, CNPJ, Inscrição Estadual e CEP)
+package brdoc
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Tipos de pessoa aceitos pelo sistema
+const (
+	PersonTypeIndividual = "F" // Pessoa Física
+	PersonTypeCompany    = "J" // Pessoa Jurídica
+)
+
+// Clean remove a máscara de um documento, mantendo apenas letras e números em caixa alta.
+// Letras são preservadas por causa do CNPJ alfanumérico.
+func Clean(value string) string {
+	var b strings.Builder
+	b.Grow(len(value))
+	for _, r := range value {
+		if r < unicode.MaxASCII && (unicode.IsDigit(r) || unicode.IsLetter(r)) {
+			b.WriteRune(unicode.ToUpper(r))
+		}
+	}
+	return b.String()
+}
+
+// OnlyDigits remove tudo o que não for dígito
+func OnlyDigits(value string) string {
+	var b strings.Builder
+	b.Grow(len(value))
+	for _, r := range value {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// NormalizePhone mantém apenas os dígitos do telefone (DDD + número), removendo o código do país (+55) quando informado
+func NormalizePhone(value string) string {
+	digits := OnlyDigits(value)
+	if (len(digits) == 12 || len(digits) == 13) && strings.HasPrefix(digits, "55") {
+		digits = digits[2:]
+	}
+	return digits
+}
+
+// IsValidPersonType verifica se o tipo de pessoa é F (Física) ou J (Jurídica)
+func IsValidPersonType(personType string) bool {
+	return personType == PersonTypeIndividual || personType == PersonTypeCompany
+}
+
+// ValidatePersonDocument valida o documento principal de acordo com o tipo de pessoa:
+// CPF para pessoa física e CNPJ para pessoa jurídica. Retorna uma mensagem de erro vazia quando válido.
+func ValidatePersonDocument(personType, document string) string {
+	document = Clean(document)
+
+	switch personType {
+	case PersonTypeIndividual:
+		if len(document) == cnpjLength {
+			return "CNPJ informado para pessoa física, informe um CPF"
+		}
+		if !IsCPF(document) {
+			return "CPF inválido"
+		}
+	case PersonTypeCompany:
+		if len(document) == cpfLength {
+			return "CPF informado para pessoa jurídica, informe um CNPJ"
+		}
+		if !IsCNPJ(document) {
+			return "CNPJ inválido"
+		}
+	default:
+		return "tipo de pessoa inválido, use F (Física) ou J (Jurídica)"
+	}
+
+	return ""
+}
+
+// allSameChars verifica se todos os caracteres são iguais (ex: 111.111.111-11)
+func allSameChars(value string) bool {
+	for i := 1; i < len(value); i++ {
+		if value[i] != value[0] {
+			return false
+		}
+	}
+	return true
+}
+
+// isDigits verifica se a string contém apenas dígitos
+func isDigits(value string) bool {
+	if value == "" {
+		return false
+	}
+	for i := 0; i < len(value); i++ {
+		if value[i] < '0' || value[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// weightedSum calcula a soma ponderada dos dígitos, usando o valor ASCII - 48 de cada caractere
+func weightedSum(value string, weights []int) int {
+	sum := 0
+	for i, w := range weights {
+		sum += int(value[i]-'0') * w
+	}
+	return sum
+}
+
+// mod11 calcula o dígito verificador no padrão mais comum: resto < 2 gera 0, senão 11 - resto
+func mod11(value string, weights []int) int {
+	rest := weightedSum(value, weights) % 11
+	if rest < 2 {
+		return 0
+	}
+	return 11 - rest
+}
+
+// descendingWeights gera pesos decrescentes de from até 2 (ex: 9,8,...,2)
+func descendingWeights(from int) []int {
+	weights := make([]int, 0, from-1)
+	for w := from; w >= 2; w-- {
+		weights = append(weights, w)
+	}
+	return weights
+}
@@ -0,0 +1,144 @@
+package brdoc
+
+import "testing"
+
+func TestIsCPF(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"52998224725", true},
+		{"529.982.247-25", true},
+		{"111.444.777-35", true},
+		{"52998224724", false},  // Segundo dígito verificador errado
+		{"52998224715", false},  // Primeiro dígito verificador errado
+		{"11111111111", false},  // Todos os dígitos iguais
+		{"5299822472", false},   // Tamanho inválido
+		{"529982247250", false}, // Tamanho inválido
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := IsCPF(tt.value); got != tt.want {
+			t.Errorf("IsCPF(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestIsCNPJ(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"11222333000181", true},
+		{"11.222.333/0001-81", true},
+		{"12.ABC.345/01DE-35", true}, // Alfanumérico
+		{"12abc34501de35", true},     // Alfanumérico em caixa baixa
+		{"11222333000180", false},    // Segundo dígito verificador errado
+		{"11222333000171", false},    // Primeiro dígito verificador errado
+		{"12ABC34501DE34", false},    // Alfanumérico com dígito verificador errado
+		{"12ABC34501DE3A", false},    // Dígito verificador com letra
+		{"00000000000000", false},    // Todos os dígitos iguais
+		{"1122233300018", false},     // Tamanho inválido
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := IsCNPJ(tt.value); got != tt.want {
+			t.Errorf("IsCNPJ(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestIsIE(t *testing.T) {
+	tests := []struct {
+		uf    string
+		value string
+		want  bool
+	}{
+		{"AC", "01.004.823/001-12", true},
+		{"AC", "0100482300113", false},
+		{"AL", "240000048", true},
+		{"AL", "240000049", false},
+		{"AP", "030123459", true},
+		{"AP", "030123458", false},
+		{"AM", "999999990", true},
+		{"AM", "999999991", false},
+		{"BA", "123456-63", true}, // 8 dígitos, módulo 10
+		{"BA", "612345-57", true}, // 8 dígitos, módulo 11
+		{"BA", "1000003-06", true},
+		{"BA", "12345664", false},
+		{"CE", "06000001-5", true},
+		{"CE", "060000016", false},
+		{"DF", "07.300001.001-09", true},
+		{"DF", "0730000100108", false},
+		{"ES", "999999990", true},
+		{"GO", "10.987.654-7", true},
+		{"GO", "109876548", false},
+		{"MA", "120000385", true},
+		{"MA", "130000385", false}, // Prefixo inválido
+		{"MT", "0013000001-9", true},
+		{"MT", "00130000018", false},
+		{"MS", "283123451", true},
+		{"MS", "283123450", false},
+		{"MG", "062.307.904/0081", true},
+		{"MG", "0623079040082", false},
+		{"PA", "15-999999-5", true},
+		{"PA", "16-999999-5", false}, // Prefixo inválido
+		{"PB", "06000001-5", true},
+		{"PR", "123.45678-50", true},
+		{"PR", "1234567851", false},
+		{"PE", "0321418-40", true},
+		{"PE", "032141841", false},
+		{"PI", "012345679", true},
+		{"RJ", "99.999.99-3", true},
+		{"RJ", "99999994", false},
+		{"RN", "20.040.040-1", true},
+		{"RN", "20.0.040.040-0", true}, // 10 dígitos
+		{"RN", "200400402", false},
+		{"RS", "224/3658792", true},
+		{"RS", "2243658793", false},
+		{"RO", "0000000062521-3", true},
+		{"RO", "00000000625214", false},
+		{"RR", "24006628-1", true},
+		{"RR", "240066282", false},
+		{"SC", "251.040.852", true},
+		{"SC", "251040853", false},
+		{"SP", "110.042.490.114", true},
+		{"SP", "110042490115", false},
+		{"SP", "P-01100424.3/002", true}, // Produtor rural
+		{"SP", "P011004244002", false},
+		{"SE", "27123456-3", true},
+		{"TO", "29.01.022783-6", true}, // 11 dígitos
+		{"TO", "29.022783-6", true},    // 9 dígitos
+		{"TO", "29010227837", false},
+		{"SP", "ISENTO", true},
+		{"XX", "123456789", false}, // UF sem regra
+		{"SP", "", false},
+	}
+	for _, tt := range tests {
+		if got := IsIE(tt.uf, tt.value); got != tt.want {
+			t.Errorf("IsIE(%q, %q) = %v, want %v", tt.uf, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestValidatePersonDocument(t *testing.T) {
+	tests := []struct {
+		personType string
+		document   string
+		valid      bool
+	}{
+		{PersonTypeIndividual, "52998224725", true},
+		{PersonTypeIndividual, "52998224724", false},
+		{PersonTypeIndividual, "11222333000181", false}, // CNPJ para pessoa física
+		{PersonTypeCompany, "11222333000181", true},
+		{PersonTypeCompany, "12ABC34501DE35", true},
+		{PersonTypeCompany, "52998224725", false}, // CPF para pessoa jurídica
+		{PersonTypeCompany, "", false},
+	}
+	for _, tt := range tests {
+		msg := ValidatePersonDocument(tt.personType, tt.document)
+		if (msg == "") != tt.valid {
+			t.Errorf("ValidatePersonDocument(%q, %q) = %q, want valid %v", tt.personType, tt.document, msg, tt.valid)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package brdoc
+
+const cnpjLength = 14
+
+var (
+	cnpjWeights1 = []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+	cnpjWeights2 = []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+)
+
+// IsCNPJ valida os dígitos verificadores de um CNPJ, com ou sem máscara.
+//
+// Aceita também o CNPJ alfanumérico (IN RFB nº 2.229/2024, a partir de julho de 2026):
+// as 12 primeiras posições podem conter letras [A-Z] e números, e os dois dígitos
+// verificadores continuam numéricos. Cada caractere vale seu código ASCII - 48,
+// o que mantém o cálculo idêntico ao do CNPJ numérico.
+func IsCNPJ(value string) bool {
+	cnpj := Clean(value)
+	if len(cnpj) != cnpjLength || allSameChars(cnpj) {
+		return false
+	}
+
+	for i := 0; i < 12; i++ {
+		c := cnpj[i]
+		if !(c >= '0' && c <= '9') && !(c >= 'A' && c <= 'Z') {
+			return false
+		}
+	}
+	if !isDigits(cnpj[12:]) {
+		return false
+	}
+
+	dv1 := mod11(cnpj, cnpjWeights1)
+	dv2 := mod11(cnpj, cnpjWeights2)
+
+	return int(cnpj[12]-'0') == dv1 && int(cnpj[13]-'0') == dv2
+}
+
+// IsAlphanumericCNPJ verifica se o CNPJ usa o novo formato com letras
+func IsAlphanumericCNPJ(value string) bool {
+	cnpj := Clean(value)
+	return IsCNPJ(cnpj) && !isDigits(cnpj)
+}
@@ -0,0 +1,21 @@
+package brdoc
+
+const cpfLength = 11
+
+var (
+	cpfWeights1 = descendingWeights(10)
+	cpfWeights2 = descendingWeights(11)
+)
+
+// IsCPF valida os dígitos verificadores de um CPF, com ou sem máscara
+func IsCPF(value string) bool {
+	cpf := Clean(value)
+	if len(cpf) != cpfLength || !isDigits(cpf) || allSameChars(cpf) {
+		return false
+	}
+
+	dv1 := mod11(cpf, cpfWeights1)
+	dv2 := mod11(cpf, cpfWeights2)
+
+	return int(cpf[9]-'0') == dv1 && int(cpf[10]-'0') == dv2
+}
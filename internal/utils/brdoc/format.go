@@ -0,0 +1,47 @@
+package brdoc
+
+// FormatCPF aplica a máscara 000.000.000-00. Valores com tamanho inválido são retornados limpos.
+func FormatCPF(value string) string {
+	cpf := Clean(value)
+	if len(cpf) != cpfLength {
+		return cpf
+	}
+	return cpf[0:3] + "." + cpf[3:6] + "." + cpf[6:9] + "-" + cpf[9:11]
+}
+
+// FormatCNPJ aplica a máscara 00.000.000/0000-00 (também para o CNPJ alfanumérico).
+// Valores com tamanho inválido são retornados limpos.
+func FormatCNPJ(value string) string {
+	cnpj := Clean(value)
+	if len(cnpj) != cnpjLength {
+		return cnpj
+	}
+	return cnpj[0:2] + "." + cnpj[2:5] + "." + cnpj[5:8] + "/" + cnpj[8:12] + "-" + cnpj[12:14]
+}
+
+// FormatDocument formata um CPF ou CNPJ de acordo com o tamanho
+func FormatDocument(value string) string {
+	switch len(Clean(value)) {
+	case cpfLength:
+		return FormatCPF(value)
+	case cnpjLength:
+		return FormatCNPJ(value)
+	default:
+		return Clean(value)
+	}
+}
+
+// FormatCEP aplica a máscara 00000-000. Valores com tamanho inválido são retornados limpos.
+func FormatCEP(value string) string {
+	cep := OnlyDigits(value)
+	if len(cep) != 8 {
+		return cep
+	}
+	return cep[0:5] + "-" + cep[5:8]
+}
+
+// IsCEP verifica se o CEP possui 8 dígitos
+func IsCEP(value string) bool {
+	cep := OnlyDigits(value)
+	return len(cep) == 8 && !allSameChars(cep)
+}
@@ -0,0 +1,355 @@
+package brdoc
+
+import (
+	"strconv"
+	"strings"
+)
+
+// IEExempt é o valor aceito no lugar da Inscrição Estadual para contribuintes isentos
+const IEExempt = "ISENTO"
+
+// ieValidators mapeia cada UF para a rotina de validação da sua Inscrição Estadual.
+// As regras seguem as especificações publicadas pelo SINTEGRA para cada estado.
+var ieValidators = map[string]func(string) bool{
+	"AC": ieAC,
+	"AL": ieAL,
+	"AP": ieAP,
+	"AM": ieDefault9,
+	"BA": ieBA,
+	"CE": ieDefault9,
+	"DF": ieDF,
+	"ES": ieDefault9,
+	"GO": ieGO,
+	"MA": iePrefixed9("12"),
+	"MT": ieMT,
+	"MS": ieMS,
+	"MG": ieMG,
+	"PA": iePrefixed9("15"),
+	"PB": ieDefault9,
+	"PR": iePR,
+	"PE": iePE,
+	"PI": ieDefault9,
+	"RJ": ieRJ,
+	"RN": ieRN,
+	"RS": ieRS,
+	"RO": ieRO,
+	"RR": ieRR,
+	"SC": ieDefault9,
+	"SP": ieSP,
+	"SE": ieDefault9,
+	"TO": ieTO,
+}
+
+// IsIE valida a Inscrição Estadual de acordo com as regras da UF informada.
+// O valor "ISENTO" é aceito para qualquer UF.
+func IsIE(uf, value string) bool {
+	if strings.EqualFold(strings.TrimSpace(value), IEExempt) {
+		return true
+	}
+
+	validate, ok := ieValidators[strings.ToUpper(uf)]
+	if !ok {
+		return false
+	}
+
+	ie := Clean(value)
+	// Somente SP (produtor rural) admite letra na inscrição
+	if strings.ToUpper(uf) != "SP" && !isDigits(ie) {
+		return false
+	}
+	if ie == "" || allSameChars(ie) {
+		return false
+	}
+
+	return validate(ie)
+}
+
+// IsSupportedIEState informa se existe regra de validação de IE para a UF
+func IsSupportedIEState(uf string) bool {
+	_, ok := ieValidators[strings.ToUpper(uf)]
+	return ok
+}
+
+// digitAt retorna o valor numérico do dígito na posição informada
+func digitAt(value string, i int) int {
+	return int(value[i] - '0')
+}
+
+// dv11 calcula 11 - resto, usando 0 quando o resultado for 10 ou 11
+func dv11(sum int) int {
+	dv := 11 - sum%11
+	if dv >= 10 {
+		return 0
+	}
+	return dv
+}
+
+// ieDefault9 é a regra usada por vários estados: 9 dígitos, pesos 9..2 e módulo 11
+func ieDefault9(ie string) bool {
+	if len(ie) != 9 {
+		return false
+	}
+	return digitAt(ie, 8) == mod11(ie, descendingWeights(9))
+}
+
+// iePrefixed9 aplica a regra padrão exigindo um prefixo fixo
+func iePrefixed9(prefix string) func(string) bool {
+	return func(ie string) bool {
+		return strings.HasPrefix(ie, prefix) && ieDefault9(ie)
+	}
+}
+
+func ieAC(ie string) bool {
+	if len(ie) != 13 || !strings.HasPrefix(ie, "01") {
+		return false
+	}
+	dv1 := dv11(weightedSum(ie, []int{4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}))
+	dv2 := dv11(weightedSum(ie, []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}))
+	return digitAt(ie, 11) == dv1 && digitAt(ie, 12) == dv2
+}
+
+func ieAL(ie string) bool {
+	if len(ie) != 9 || !strings.HasPrefix(ie, "24") {
+		return false
+	}
+	dv := (weightedSum(ie, descendingWeights(9)) * 10) % 11
+	if dv == 10 {
+		dv = 0
+	}
+	return digitAt(ie, 8) == dv
+}
+
+func ieAP(ie string) bool {
+	if len(ie) != 9 || !strings.HasPrefix(ie, "03") {
+		return false
+	}
+
+	number, _ := strconv.Atoi(ie[:8])
+	p, d := 0, 0
+	switch {
+	case number >= 3000001 && number <= 3017000:
+		p, d = 5, 0
+	case number >= 3017001 && number <= 3019022:
+		p, d = 9, 1
+	}
+
+	dv := 11 - (p+weightedSum(ie, descendingWeights(9)))%11
+	switch dv {
+	case 10:
+		dv = 0
+	case 11:
+		dv = d
+	}
+	return digitAt(ie, 8) == dv
+}
+
+func ieBA(ie string) bool {
+	if len(ie) != 8 && len(ie) != 9 {
+		return false
+	}
+
+	// O primeiro dígito (8 posições) ou o segundo (9 posições) define o módulo do cálculo
+	base := len(ie) - 2
+	control := digitAt(ie, 0)
+	if len(ie) == 9 {
+		control = digitAt(ie, 1)
+	}
+	modulo := 10
+	if control == 6 || control == 7 || control == 9 {
+		modulo = 11
+	}
+
+	calc := func(sum int) int {
+		rest := sum % modulo
+		if modulo == 10 {
+			if rest == 0 {
+				return 0
+			}
+			return 10 - rest
+		}
+		if rest <= 1 {
+			return 0
+		}
+		return 11 - rest
+	}
+
+	// O segundo dígito verificador é calculado primeiro
+	dv2 := calc(weightedSum(ie, descendingWeights(base+1)))
+	withDV2 := ie[:base] + strconv.Itoa(dv2)
+	dv1 := calc(weightedSum(withDV2, descendingWeights(base+2)))
+
+	return digitAt(ie, base) == dv1 && digitAt(ie, base+1) == dv2
+}
+
+func ieDF(ie string) bool {
+	if len(ie) != 13 || !strings.HasPrefix(ie, "07") {
+		return false
+	}
+	dv1 := dv11(weightedSum(ie, []int{4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}))
+	dv2 := dv11(weightedSum(ie, []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}))
+	return digitAt(ie, 11) == dv1 && digitAt(ie, 12) == dv2
+}
+
+func ieGO(ie string) bool {
+	if len(ie) != 9 {
+		return false
+	}
+	prefix := ie[:2]
+	if prefix != "10" && prefix != "11" && prefix != "15" && !(prefix >= "20" && prefix <= "29") {
+		return false
+	}
+
+	rest := weightedSum(ie, descendingWeights(9)) % 11
+	dv := 11 - rest
+	switch rest {
+	case 0:
+		dv = 0
+	case 1:
+		number, _ := strconv.Atoi(ie[:8])
+		dv = 0
+		if number >= 10103105 && number <= 10119997 {
+			dv = 1
+		}
+	}
+	return digitAt(ie, 8) == dv
+}
+
+func ieMT(ie string) bool {
+	if len(ie) > 11 {
+		return false
+	}
+	ie = strings.Repeat("0", 11-len(ie)) + ie
+	return digitAt(ie, 10) == mod11(ie, []int{3, 2, 9, 8, 7, 6, 5, 4, 3, 2})
+}
+
+func ieMS(ie string) bool {
+	if len(ie) != 9 || !(strings.HasPrefix(ie, "28") || strings.HasPrefix(ie, "50")) {
+		return false
+	}
+	rest := weightedSum(ie, descendingWeights(9)) % 11
+	dv := 0
+	if rest != 0 && 11-rest <= 9 {
+		dv = 11 - rest
+	}
+	return digitAt(ie, 8) == dv
+}
+
+func ieMG(ie string) bool {
+	if len(ie) != 13 {
+		return false
+	}
+
+	// Primeiro dígito: insere "0" após o código do município e soma os algarismos
+	// dos produtos com pesos alternados 1 e 2
+	expanded := ie[:3] + "0" + ie[3:11]
+	digitsSum := 0
+	for i := 0; i < len(expanded); i++ {
+		product := digitAt(expanded, i) * (1 + i%2)
+		digitsSum += product/10 + product%10
+	}
+	dv1 := (10 - digitsSum%10) % 10
+
+	withDV1 := ie[:11] + strconv.Itoa(dv1)
+	dv2 := mod11(withDV1, []int{3, 2, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2})
+
+	return digitAt(ie, 11) == dv1 && digitAt(ie, 12) == dv2
+}
+
+func iePR(ie string) bool {
+	if len(ie) != 10 {
+		return false
+	}
+	dv1 := mod11(ie, []int{3, 2, 7, 6, 5, 4, 3, 2})
+	dv2 := mod11(ie, []int{4, 3, 2, 7, 6, 5, 4, 3, 2})
+	return digitAt(ie, 8) == dv1 && digitAt(ie, 9) == dv2
+}
+
+func iePE(ie string) bool {
+	if len(ie) != 9 {
+		return false
+	}
+	dv1 := mod11(ie, descendingWeights(8))
+	dv2 := mod11(ie, descendingWeights(9))
+	return digitAt(ie, 7) == dv1 && digitAt(ie, 8) == dv2
+}
+
+func ieRJ(ie string) bool {
+	if len(ie) != 8 {
+		return false
+	}
+	return digitAt(ie, 7) == mod11(ie, []int{2, 7, 6, 5, 4, 3, 2})
+}
+
+func ieRN(ie string) bool {
+	if (len(ie) != 9 && len(ie) != 10) || !strings.HasPrefix(ie, "20") {
+		return false
+	}
+	last := len(ie) - 1
+	dv := (weightedSum(ie, descendingWeights(len(ie))) * 10) % 11
+	if dv == 10 {
+		dv = 0
+	}
+	return digitAt(ie, last) == dv
+}
+
+func ieRS(ie string) bool {
+	if len(ie) != 10 {
+		return false
+	}
+	return digitAt(ie, 9) == dv11(weightedSum(ie, []int{2, 9, 8, 7, 6, 5, 4, 3, 2}))
+}
+
+func ieRO(ie string) bool {
+	if len(ie) != 14 {
+		return false
+	}
+	dv := 11 - weightedSum(ie, []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2})%11
+	if dv >= 10 {
+		dv -= 10
+	}
+	return digitAt(ie, 13) == dv
+}
+
+func ieRR(ie string) bool {
+	if len(ie) != 9 || !strings.HasPrefix(ie, "24") {
+		return false
+	}
+	return digitAt(ie, 8) == weightedSum(ie, []int{1, 2, 3, 4, 5, 6, 7, 8})%9
+}
+
+func ieSP(ie string) bool {
+	spWeights := []int{1, 3, 4, 5, 6, 7, 8, 10}
+
+	// Produtor rural: P + 12 dígitos, com o dígito verificador na 9ª posição numérica
+	if strings.HasPrefix(ie, "P") {
+		rural := ie[1:]
+		if len(rural) != 12 || !isDigits(rural) {
+			return false
+		}
+		return digitAt(rural, 8) == weightedSum(rural, spWeights)%11%10
+	}
+
+	if len(ie) != 12 || !isDigits(ie) {
+		return false
+	}
+	dv1 := weightedSum(ie, spWeights) % 11 % 10
+	dv2 := weightedSum(ie, []int{3, 2, 10, 9, 8, 7, 6, 5, 4, 3, 2}) % 11 % 10
+	return digitAt(ie, 8) == dv1 && digitAt(ie, 11) == dv2
+}
+
+func ieTO(ie string) bool {
+	switch len(ie) {
+	case 9:
+		return ieDefault9(ie)
+	case 11:
+		// Formato antigo: as posições 3 e 4 indicam o tipo de empresa e não entram no cálculo
+		kind := ie[2:4]
+		if kind != "01" && kind != "02" && kind != "03" && kind != "99" {
+			return false
+		}
+		base := ie[:2] + ie[4:]
+		return digitAt(base, 8) == mod11(base, descendingWeights(9))
+	default:
+		return false
+	}
+}
@@ -0,0 +1,87 @@
+// Package cep lê bases de CEPs (logradouros) exportadas em CSV ou XLSX, como as distribuídas pelos
+// Correios (e-DNE) ou por projetos de dados abertos, para importação na base local de consulta.
+package cep
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"simple-erp-service/internal/utils/brdoc"
+	"simple-erp-service/internal/utils/spreadsheet"
+)
+
+// Record representa um CEP da base: o logradouro e o bairro e o código IBGE do município
+type Record struct {
+	ZipCode      string // CEP com 8 dígitos, sem máscara
+	Street       string
+	Neighborhood string
+	Complement   string
+	CityIBGECode string // Código IBGE do município com 7 dígitos
+}
+
+// Cabeçalhos aceitos para cada coluna, já normalizados (ver spreadsheet.NormalizeHeader)
+var (
+	zipCodeHeaders      = []string{"cep", "zipcode", "codigopostal"}
+	streetHeaders       = []string{"logradouro", "endereco", "rua", "street"}
+	neighborhoodHeaders = []string{"bairro", "neighborhood", "district"}
+	complementHeaders   = []string{"complemento", "complement"}
+	cityHeaders         = []string{"codigoibge", "ibge", "codigomunicipio", "ibgecode", "citycode"}
+)
+
+// ReadFile lê os CEPs de um arquivo CSV ou XLSX. As colunas do CEP e do código IBGE do município são
+// obrigatórias; CEPs gerais de município (sem logradouro) são aceitos.
+func ReadFile(path string) ([]Record, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	sheet, err := spreadsheet.Read(path, bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, err
+	}
+
+	zipCodeColumn, cityColumn := sheet.Column(zipCodeHeaders...), sheet.Column(cityHeaders...)
+	if zipCodeColumn < 0 || cityColumn < 0 {
+		return nil, errors.New("a planilha deve ter as colunas do CEP e do código IBGE do município")
+	}
+	streetColumn := sheet.Column(streetHeaders...)
+	neighborhoodColumn := sheet.Column(neighborhoodHeaders...)
+	complementColumn := sheet.Column(complementHeaders...)
+
+	records := make([]Record, 0, len(sheet.Rows))
+	seen := make(map[string]bool, len(sheet.Rows))
+	for i, row := range sheet.Rows {
+		record := Record{
+			ZipCode:      padZipCode(brdoc.OnlyDigits(spreadsheet.Cell(row, zipCodeColumn))),
+			Street:       spreadsheet.Cell(row, streetColumn),
+			Neighborhood: spreadsheet.Cell(row, neighborhoodColumn),
+			Complement:   spreadsheet.Cell(row, complementColumn),
+			CityIBGECode: brdoc.OnlyDigits(spreadsheet.Cell(row, cityColumn)),
+		}
+		if !brdoc.IsCEP(record.ZipCode) {
+			return nil, fmt.Errorf("linha %d: CEP inválido: %q", i+2, spreadsheet.Cell(row, zipCodeColumn))
+		}
+		if len(record.CityIBGECode) != 7 {
+			return nil, fmt.Errorf("linha %d: código IBGE do município inválido: %q", i+2, spreadsheet.Cell(row, cityColumn))
+		}
+		if seen[record.ZipCode] {
+			return nil, fmt.Errorf("linha %d: CEP repetido: %s", i+2, record.ZipCode)
+		}
+		seen[record.ZipCode] = true
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// padZipCode restaura os zeros à esquerda que as planilhas removem quando o CEP é gravado como número
+// (ex: 1001000 -> 01001000)
+func padZipCode(zipCode string) string {
+	if zipCode != "" && len(zipCode) < 8 {
+		return strings.Repeat("0", 8-len(zipCode)) + zipCode
+	}
+	return zipCode
+}
@@ -0,0 +1,157 @@
+// Package fieldcrypt criptografa campos com dados pessoais (AES-256-GCM) e gera os índices cegos
+// (HMAC-SHA256) que permitem buscas exatas e restrições de unicidade sobre os valores criptografados.
+//
+// Os valores são gravados no formato "enc:<id da chave>:<base64(nonce + texto cifrado)>". Valores sem o
+// prefixo são tratados como texto puro legado e devolvidos como estão, até serem criptografados pelo
+// comando de recriptografia.
+package fieldcrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+const prefix = "enc:"
+
+// Erros da criptografia de campos
+var (
+	ErrNotConfigured = errors.New("criptografia de campos não configurada")
+	ErrUnknownKey    = errors.New("chave de criptografia desconhecida")
+	ErrMalformed     = errors.New("valor criptografado inválido")
+)
+
+// Cipher criptografa e descriptografa valores e calcula os índices cegos
+type Cipher struct {
+	currentKeyID string
+	aeads        map[string]cipher.AEAD
+	indexKey     []byte
+}
+
+// New cria um Cipher com as chaves informadas. currentKeyID é a chave usada para criptografar;
+// as demais são mantidas somente para descriptografar valores antigos durante a rotação.
+func New(keys map[string][]byte, currentKeyID string, indexKey []byte) (*Cipher, error) {
+	if _, ok := keys[currentKeyID]; !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKey, currentKeyID)
+	}
+	if len(indexKey) == 0 {
+		return nil, errors.New("a chave dos índices cegos é obrigatória")
+	}
+
+	c := &Cipher{currentKeyID: currentKeyID, aeads: make(map[string]cipher.AEAD, len(keys)), indexKey: indexKey}
+	for id, key := range keys {
+		if strings.Contains(id, ":") {
+			return nil, fmt.Errorf("identificador de chave inválido: %q", id)
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("chave %q: %w", id, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("chave %q: %w", id, err)
+		}
+		c.aeads[id] = aead
+	}
+
+	return c, nil
+}
+
+// Encrypt criptografa o valor com a chave atual. Valores vazios continuam vazios.
+func (c *Cipher) Encrypt(plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+
+	aead := c.aeads[c.currentKeyID]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), nil)
+
+	return c.CurrentPrefix() + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt descriptografa o valor com a chave indicada nele. Valores sem o prefixo são texto puro legado.
+func (c *Cipher) Decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+
+	keyID, encoded, found := strings.Cut(strings.TrimPrefix(value, prefix), ":")
+	if !found {
+		return "", ErrMalformed
+	}
+	aead, ok := c.aeads[keyID]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownKey, keyID)
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", ErrMalformed
+	}
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return "", ErrMalformed
+	}
+
+	return string(plaintext), nil
+}
+
+// BlindIndex calcula o índice cego do valor (HMAC-SHA256 em hexadecimal). O valor deve estar normalizado
+// da mesma forma em que é gravado, pois somente valores idênticos geram o mesmo índice.
+func (c *Cipher) BlindIndex(value string) string {
+	mac := hmac.New(sha256.New, c.indexKey)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// CurrentPrefix retorna o prefixo dos valores criptografados com a chave atual
+func (c *Cipher) CurrentPrefix() string {
+	return prefix + c.currentKeyID + ":"
+}
+
+// IsEncrypted informa se o valor está no formato criptografado
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, prefix)
+}
+
+var defaultCipher atomic.Pointer[Cipher]
+
+// Configure define o Cipher usado pelo serializer "encrypted" do GORM e pelos índices cegos dos modelos
+func Configure(c *Cipher) {
+	defaultCipher.Store(c)
+}
+
+// Default retorna o Cipher configurado na inicialização da aplicação
+func Default() (*Cipher, error) {
+	c := defaultCipher.Load()
+	if c == nil {
+		return nil, ErrNotConfigured
+	}
+	return c, nil
+}
+
+// BlindIndex calcula o índice cego do valor com o Cipher configurado. Retorna nil para valores vazios,
+// de modo que a coluna fique nula e não participe da restrição de unicidade.
+func BlindIndex(value string) (*string, error) {
+	if value == "" {
+		return nil, nil
+	}
+	c, err := Default()
+	if err != nil {
+		return nil, err
+	}
+	index := c.BlindIndex(value)
+	return &index, nil
+}
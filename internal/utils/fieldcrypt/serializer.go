@@ -0,0 +1,61 @@
+package fieldcrypt
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm/schema"
+)
+
+func init() {
+	schema.RegisterSerializer("encrypted", Serializer{})
+}
+
+// Serializer é o serializer "encrypted" do GORM: criptografa o campo string ao gravar e o descriptografa
+// ao ler. Uso: `gorm:"serializer:encrypted"`. Consultas não podem filtrar pela coluna criptografada;
+// use a coluna do índice cego.
+type Serializer struct{}
+
+// Scan descriptografa o valor lido do banco
+func (Serializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	var value string
+	switch v := dbValue.(type) {
+	case nil:
+	case string:
+		value = v
+	case []byte:
+		value = string(v)
+	default:
+		return fmt.Errorf("fieldcrypt: tipo não suportado %T na coluna %s", dbValue, field.DBName)
+	}
+
+	if value != "" {
+		c, err := Default()
+		if err != nil {
+			return err
+		}
+		if value, err = c.Decrypt(value); err != nil {
+			return fmt.Errorf("fieldcrypt: coluna %s: %w", field.DBName, err)
+		}
+	}
+
+	return field.Set(ctx, dst, value)
+}
+
+// Value criptografa o valor que será gravado no banco
+func (Serializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
+	value, ok := fieldValue.(string)
+	if !ok {
+		return nil, fmt.Errorf("fieldcrypt: a coluna %s deve ser do tipo string", field.DBName)
+	}
+	if value == "" {
+		return "", nil
+	}
+
+	c, err := Default()
+	if err != nil {
+		return nil, err
+	}
+	return c.Encrypt(value)
+}
@@ -0,0 +1,177 @@
+// Package ibge lê a tabela de municípios do IBGE (Divisão Territorial Brasileira) de um arquivo CSV/XLSX,
+// do JSON da API de localidades do IBGE ou do conjunto embutido no binário.
+//
+// O seed importa somente o conjunto embutido (municipios.csv, colunas "codigo;nome"), sem acessar a rede.
+// O arquivo é gerado a partir da API com "go run ./cmd/import-ibge -api -export internal/utils/ibge/municipios.csv";
+// o comando cmd/import-ibge também importa a tabela direto da API ou de uma planilha da DTB.
+package ibge
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"simple-erp-service/internal/utils/spreadsheet"
+)
+
+// APIURL é o endereço da API de localidades do IBGE com todos os municípios
+const APIURL = "https://servicodados.ibge.gov.br/api/v1/localidades/municipios"
+
+//go:embed municipios.csv
+var embeddedCSV []byte
+
+// Municipality representa um município da tabela do IBGE
+type Municipality struct {
+	Code string // Código IBGE com 7 dígitos
+	Name string
+}
+
+// UFCode retorna o código IBGE da UF, formado pelos dois primeiros dígitos do código do município
+func (m Municipality) UFCode() string {
+	return m.Code[:2]
+}
+
+// Cabeçalhos aceitos para o código e o nome do município, já normalizados (ver spreadsheet.NormalizeHeader).
+// Incluem os nomes das colunas da planilha da DTB publicada pelo IBGE.
+var (
+	codeHeaders = []string{"codigomunicipiocompleto", "codigomunicipio", "codigoibge", "ibgecode", "codigo", "id"}
+	nameHeaders = []string{"nomemunicipio", "municipio", "nome", "name"}
+)
+
+// Embedded retorna os municípios do conjunto embutido no binário
+func Embedded() ([]Municipality, error) {
+	return readSheet("municipios.csv", bytes.NewReader(embeddedCSV), int64(len(embeddedCSV)))
+}
+
+// ReadFile lê os municípios de um arquivo CSV, XLSX ou JSON (no formato da API de localidades)
+func ReadFile(path string) ([]Municipality, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return ParseJSON(bytes.NewReader(content))
+	}
+	return readSheet(path, bytes.NewReader(content), int64(len(content)))
+}
+
+// Download baixa os municípios da API de localidades do IBGE
+func Download(ctx context.Context, url string) ([]Municipality, error) {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API do IBGE respondeu com status %d", resp.StatusCode)
+	}
+	return ParseJSON(resp.Body)
+}
+
+// WriteCSV grava os municípios no formato do conjunto embutido ("codigo;nome"), ordenados pelo código
+func WriteCSV(w io.Writer, municipalities []Municipality) error {
+	sorted := append([]Municipality(nil), municipalities...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Code < sorted[j].Code })
+
+	writer := csv.NewWriter(w)
+	writer.Comma = ';'
+	if err := writer.Write([]string{"codigo", "nome"}); err != nil {
+		return err
+	}
+	for _, municipality := range sorted {
+		if err := writer.Write([]string{municipality.Code, municipality.Name}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// ParseJSON lê a lista de municípios no formato da API de localidades ([{"id": 3550308, "nome": "São Paulo"}, ...])
+func ParseJSON(r io.Reader) ([]Municipality, error) {
+	var items []struct {
+		ID   json.Number `json:"id"`
+		Nome string      `json:"nome"`
+	}
+	if err := json.NewDecoder(r).Decode(&items); err != nil {
+		return nil, fmt.Errorf("JSON de municípios inválido: %w", err)
+	}
+
+	municipalities := make([]Municipality, 0, len(items))
+	for i, item := range items {
+		municipality, err := newMunicipality(item.ID.String(), item.Nome)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i+1, err)
+		}
+		municipalities = append(municipalities, municipality)
+	}
+	return deduplicate(municipalities)
+}
+
+// readSheet lê os municípios de uma planilha, localizando as colunas do código e do nome pelo cabeçalho
+func readSheet(fileName string, r io.ReaderAt, size int64) ([]Municipality, error) {
+	sheet, err := spreadsheet.Read(fileName, r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	codeColumn, nameColumn := sheet.Column(codeHeaders...), sheet.Column(nameHeaders...)
+	if codeColumn < 0 || nameColumn < 0 {
+		return nil, errors.New("a planilha deve ter as colunas do código IBGE e do nome do município")
+	}
+
+	municipalities := make([]Municipality, 0, len(sheet.Rows))
+	for i, row := range sheet.Rows {
+		municipality, err := newMunicipality(spreadsheet.Cell(row, codeColumn), spreadsheet.Cell(row, nameColumn))
+		if err != nil {
+			return nil, fmt.Errorf("linha %d: %w", i+2, err)
+		}
+		municipalities = append(municipalities, municipality)
+	}
+	return deduplicate(municipalities)
+}
+
+// newMunicipality valida o código (7 dígitos) e o nome do município
+func newMunicipality(code, name string) (Municipality, error) {
+	code, name = strings.TrimSpace(code), strings.TrimSpace(name)
+	if _, err := strconv.ParseUint(code, 10, 32); err != nil || len(code) != 7 {
+		return Municipality{}, fmt.Errorf("código IBGE inválido: %q", code)
+	}
+	if name == "" {
+		return Municipality{}, fmt.Errorf("município %s sem nome", code)
+	}
+	return Municipality{Code: code, Name: name}, nil
+}
+
+// deduplicate rejeita códigos repetidos, que indicam um arquivo corrompido ou concatenado
+func deduplicate(municipalities []Municipality) ([]Municipality, error) {
+	seen := make(map[string]bool, len(municipalities))
+	for _, municipality := range municipalities {
+		if seen[municipality.Code] {
+			return nil, fmt.Errorf("código IBGE repetido: %s", municipality.Code)
+		}
+		seen[municipality.Code] = true
+	}
+	return municipalities, nil
+}
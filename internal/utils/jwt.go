@@ -16,6 +16,9 @@ type JWTClaims struct {
 	RoleID      uint     `json:"role_id"`
 	Role        string   `json:"role"`
 	Permissions []string `json:"permissions"`
+
+	// ImpersonatorID identifica o usuário que está personificando o titular do token (nil fora da personificação)
+	ImpersonatorID *uint `json:"impersonator_id,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -40,6 +43,28 @@ func GenerateAccessToken(userID uint, username string, roleID uint, role string,
 	return token.SignedString([]byte(cfg.JWT.Secret))
 }
 
+// GenerateImpersonationToken gera um token de acesso de curta duração em nome de outro usuário
+func GenerateImpersonationToken(userID uint, username string, roleID uint, role string, permissions []string, impersonatorID uint, cfg *config.Config) (string, error) {
+	claims := JWTClaims{
+		UserID:         userID,
+		Username:       username,
+		RoleID:         roleID,
+		Role:           role,
+		Permissions:    permissions,
+		ImpersonatorID: &impersonatorID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(cfg.JWT.ImpersonationTokenExp)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			NotBefore: jwt.NewNumericDate(time.Now()),
+			Issuer:    "simple-erp-service",
+			Subject:   username,
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(cfg.JWT.Secret))
+}
+
 // GenerateRefreshToken gera um novo token JWT de refresh
 func GenerateRefreshToken(userID uint, username string, cfg *config.Config) (string, error) {
 	claims := jwt.RegisteredClaims{
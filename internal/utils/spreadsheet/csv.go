@@ -0,0 +1,41 @@
+package spreadsheet
+
+import (
+	"bufio"
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+// readCSV lê um CSV detectando o separador (";" é o padrão do Excel em português, "," e tab também são aceitos)
+func readCSV(r io.Reader) ([][]string, error) {
+	buffered := bufio.NewReader(r)
+
+	// Remover o BOM UTF-8 gravado por alguns editores
+	if bom, err := buffered.Peek(3); err == nil && string(bom) == "\xEF\xBB\xBF" {
+		buffered.Discard(3)
+	}
+
+	firstLine, _ := buffered.Peek(4096)
+	if i := strings.IndexAny(string(firstLine), "\r\n"); i >= 0 {
+		firstLine = firstLine[:i]
+	}
+
+	reader := csv.NewReader(buffered)
+	reader.Comma = detectDelimiter(string(firstLine))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	return reader.ReadAll()
+}
+
+// detectDelimiter escolhe o separador mais frequente na linha de cabeçalho
+func detectDelimiter(header string) rune {
+	delimiter, best := ';', strings.Count(header, ";")
+	for _, candidate := range []rune{',', '\t'} {
+		if count := strings.Count(header, string(candidate)); count > best {
+			delimiter, best = candidate, count
+		}
+	}
+	return delimiter
+}
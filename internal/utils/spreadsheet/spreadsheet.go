@@ -0,0 +1,135 @@
+package spreadsheet
+
+import (
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// ErrUnsupportedFormat é retornado quando a extensão do arquivo não é CSV nem XLSX
+var ErrUnsupportedFormat = errors.New("formato de arquivo não suportado, envie um CSV ou XLSX")
+
+// ErrEmptySheet é retornado quando a planilha não possui nem a linha de cabeçalho
+var ErrEmptySheet = errors.New("a planilha está vazia")
+
+// Sheet representa uma planilha lida: a primeira linha é o cabeçalho e as demais os dados
+type Sheet struct {
+	Headers    []string
+	Rows       [][]string
+	RowNumbers []int // Número de cada linha de dados na planilha original, contando o cabeçalho como linha 1
+}
+
+// Read lê um arquivo CSV ou XLSX, escolhendo o formato pela extensão do nome do arquivo.
+// Para XLSX somente a primeira aba é considerada.
+func Read(fileName string, r io.ReaderAt, size int64) (*Sheet, error) {
+	var (
+		rows [][]string
+		err  error
+	)
+
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv", ".txt":
+		rows, err = readCSV(io.NewSectionReader(r, 0, size))
+	case ".xlsx":
+		rows, err = readXLSX(r, size)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return newSheet(rows)
+}
+
+// Record retorna a linha como um mapa cabeçalho -> valor
+func (s *Sheet) Record(row []string) map[string]string {
+	record := make(map[string]string, len(s.Headers))
+	for i, header := range s.Headers {
+		if i < len(row) {
+			record[header] = strings.TrimSpace(row[i])
+		} else {
+			record[header] = ""
+		}
+	}
+	return record
+}
+
+// Column retorna o índice da primeira coluna cujo cabeçalho normalizado corresponde a um dos nomes
+// aceitos, respeitando a ordem de preferência dos nomes, ou -1
+func (s *Sheet) Column(aliases ...string) int {
+	normalized := make([]string, len(s.Headers))
+	for i, header := range s.Headers {
+		normalized[i] = NormalizeHeader(header)
+	}
+	for _, alias := range aliases {
+		for i, header := range normalized {
+			if header != "" && header == alias {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// Cell retorna o valor da coluna na linha, sem espaços nas pontas; colunas ausentes retornam vazio
+func Cell(row []string, column int) string {
+	if column < 0 || column >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[column])
+}
+
+// NormalizeHeader remove acentos, espaços e pontuação e deixa o texto em minúsculas
+func NormalizeHeader(value string) string {
+	value = strings.NewReplacer(
+		"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+		"é", "e", "ê", "e", "è", "e",
+		"í", "i", "î", "i",
+		"ó", "o", "ô", "o", "õ", "o", "ö", "o",
+		"ú", "u", "ü", "u",
+		"ç", "c",
+	).Replace(strings.ToLower(strings.TrimSpace(value)))
+
+	var b strings.Builder
+	for _, r := range value {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// newSheet separa o cabeçalho e descarta as linhas totalmente vazias, guardando o número original de cada
+// linha mantida
+func newSheet(rows [][]string) (*Sheet, error) {
+	if len(rows) == 0 {
+		return nil, ErrEmptySheet
+	}
+
+	headers := make([]string, len(rows[0]))
+	for i, header := range rows[0] {
+		headers[i] = strings.TrimSpace(header)
+	}
+
+	data := make([][]string, 0, len(rows)-1)
+	numbers := make([]int, 0, len(rows)-1)
+	for i, row := range rows[1:] {
+		if !isBlank(row) {
+			data = append(data, row)
+			numbers = append(numbers, i+2)
+		}
+	}
+
+	return &Sheet{Headers: headers, Rows: data, RowNumbers: numbers}, nil
+}
+
+func isBlank(row []string) bool {
+	for _, value := range row {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
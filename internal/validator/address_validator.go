@@ -0,0 +1,53 @@
+package validator
+
+import (
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/utils/brdoc"
+)
+
+// AddressValidator valida regras de negócio relacionadas a endereços de clientes e fornecedores
+type AddressValidator struct {
+	locationRepo repository.LocationRepository
+}
+
+// NewAddressValidator cria um novo validador de endereços
+func NewAddressValidator(locationRepo repository.LocationRepository) *AddressValidator {
+	return &AddressValidator{
+		locationRepo: locationRepo,
+	}
+}
+
+// ValidateForCreation valida os dados para criação de um endereço
+func (v *AddressValidator) ValidateForCreation(req models.CreateAddressRequest) error {
+	return v.validate(req.ZipCode, req.CityID)
+}
+
+// ValidateForUpdate valida os dados para atualização de um endereço
+func (v *AddressValidator) ValidateForUpdate(req models.UpdateAddressRequest) error {
+	return v.validate(req.ZipCode, req.CityID)
+}
+
+// validate aplica as regras comuns à criação e atualização
+func (v *AddressValidator) validate(zipCode string, cityID uint) error {
+	var errors ValidationErrors
+
+	// Validar formato do CEP
+	if !brdoc.IsCEP(zipCode) {
+		errors.AddError("zip_code", "CEP inválido, informe 8 dígitos no formato 00000-000")
+	}
+
+	// Verificar se a cidade existe
+	city, err := v.locationRepo.FindCityByID(cityID)
+	if err != nil {
+		return err
+	}
+	if city == nil {
+		errors.AddError("city_id", "cidade não encontrada")
+	}
+
+	if errors.HasErrors() {
+		return errors
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package validator
+
+import (
+	"net/mail"
+	"strings"
+
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/utils/brdoc"
+)
+
+// ContactValidator valida regras de negócio relacionadas a contatos de clientes e fornecedores
+type ContactValidator struct {
+	contactRepo repository.ContactRepository
+}
+
+// NewContactValidator cria um novo validador de contatos
+func NewContactValidator(contactRepo repository.ContactRepository) *ContactValidator {
+	return &ContactValidator{
+		contactRepo: contactRepo,
+	}
+}
+
+// NormalizeContact padroniza o valor do contato: email em minúsculas e telefones apenas com dígitos (DDD + número)
+func NormalizeContact(contactType, value string) string {
+	value = strings.TrimSpace(value)
+
+	switch contactType {
+	case models.ContactTypeEmail:
+		return strings.ToLower(value)
+	case models.ContactTypePhone, models.ContactTypeMobile:
+		return brdoc.NormalizePhone(value)
+	default:
+		return value
+	}
+}
+
+// ValidateForCreation valida os dados para criação de um contato
+func (v *ContactValidator) ValidateForCreation(req models.CreateContactRequest) error {
+	return v.validate(0, req.Type, req.Contact)
+}
+
+// ValidateForUpdate valida os dados para atualização de um contato
+func (v *ContactValidator) ValidateForUpdate(id uint, req models.UpdateContactRequest) error {
+	return v.validate(id, req.Type, req.Contact)
+}
+
+// validate aplica as regras de formato de acordo com o tipo do contato e verifica duplicidade
+func (v *ContactValidator) validate(id uint, contactType, value string) error {
+	var errors ValidationErrors
+
+	contact := NormalizeContact(contactType, value)
+
+	switch contactType {
+	case models.ContactTypeEmail:
+		parsed, err := mail.ParseAddress(contact)
+		if err != nil || parsed.Address != contact {
+			errors.AddError("contact", "email inválido")
+		}
+	case models.ContactTypePhone:
+		// Telefone fixo: DDD + 8 dígitos, começando entre 2 e 5
+		if len(contact) != 10 || contact[0] == '0' || contact[2] < '2' || contact[2] > '5' {
+			errors.AddError("contact", "telefone inválido, informe DDD + 8 dígitos")
+		}
+	case models.ContactTypeMobile:
+		// Celular: DDD + 9 dígitos, começando com 9
+		if len(contact) != 11 || contact[0] == '0' || contact[2] != '9' {
+			errors.AddError("contact", "celular inválido, informe DDD + 9 dígitos iniciando com 9")
+		}
+	default:
+		errors.AddError("type", "tipo de contato inválido, use email, telefone ou celular")
+	}
+
+	// Verificar se o contato já está cadastrado
+	if !errors.HasErrors() {
+		exists, err := v.contactRepo.ExistsByContactExcept(contact, id)
+		if err != nil {
+			return err
+		}
+		if exists {
+			errors.AddError("contact", "contato já está em uso")
+		}
+	}
+
+	if errors.HasErrors() {
+		return errors
+	}
+	return nil
+}
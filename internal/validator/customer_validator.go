@@ -3,7 +3,7 @@ package validator
 import (
 	"simple-erp-service/internal/data-structure/models"
 	"simple-erp-service/internal/repository"
-	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/utils/brdoc"
 )
 
 // CustomerValidator valida regras de negócio relacionadas a clientes
@@ -22,20 +22,35 @@ func NewCustomerValidator(customerRepo repository.CustomerRepository) *CustomerV
 func (v *CustomerValidator) ValidateForCreation(req models.CreateCustomerRequest) error {
 	var errors ValidationErrors
 
-	// Verificar se o documento já existe
+	// Validar tipo de pessoa
+	if !brdoc.IsValidPersonType(req.PersonType) {
+		errors.AddError("person_type", "tipo de pessoa inválido, use F (Física) ou J (Jurídica)")
+	}
+
+	// Validar CPF/CNPJ e verificar se o documento já existe
 	if req.DocumentNumber != "" {
-		// Remover caracteres não numéricos para comparação
-		document := utils.RemoveMask(req.DocumentNumber)
+		document := brdoc.Clean(req.DocumentNumber)
+
+		if brdoc.IsValidPersonType(req.PersonType) {
+			if msg := brdoc.ValidatePersonDocument(req.PersonType, document); msg != "" {
+				errors.AddError("document_number", msg)
+			}
+		}
 
 		exists, err := v.customerRepo.ExistsByDocument(document)
 		if err != nil {
 			return err
 		}
 		if exists {
-			errors.AddError("document", "documento já está em uso")
+			errors.AddError("document_number", "documento já está em uso")
 		}
 	}
 
+	// Pessoa jurídica deve informar a razão social
+	if req.PersonType == brdoc.PersonTypeCompany && req.CompanyName == "" {
+		errors.AddError("company_name", "a razão social é obrigatória para pessoa jurídica")
+	}
+
 	// Validar nome (se fornecido)
 	if req.FirstName != "" && len(req.FirstName) < 3 {
 		errors.AddError("first_name", "o primeiro nome deve ter pelo menos 3 caracteres")
@@ -64,17 +79,23 @@ func (v *CustomerValidator) ValidateForUpdate(id uint, req models.UpdateCustomer
 		return errors
 	}
 
-	// Verificar se o documento já está em uso por outro cliente (se fornecido)
-	if req.DocumentNumber != "" && req.DocumentNumber != customer.DocumentNumber {
-		// Remover caracteres não numéricos para comparação
-		document := utils.RemoveMask(req.DocumentNumber)
+	// O tipo de pessoa não pode ser alterado, então o documento é validado contra o tipo gravado
+	if req.DocumentNumber != "" {
+		document := brdoc.Clean(req.DocumentNumber)
 
-		exists, err := v.customerRepo.ExistsByDocumentExcept(document, id)
-		if err != nil {
-			return err
+		if msg := brdoc.ValidatePersonDocument(customer.PersonType, document); msg != "" {
+			errors.AddError("document_number", msg)
 		}
-		if exists {
-			errors.AddError("document_number", "documento já está em uso")
+
+		// Verificar se o documento já está em uso por outro cliente
+		if document != customer.DocumentNumber {
+			exists, err := v.customerRepo.ExistsByDocumentExcept(document, id)
+			if err != nil {
+				return err
+			}
+			if exists {
+				errors.AddError("document_number", "documento já está em uso")
+			}
 		}
 	}
 
@@ -0,0 +1,149 @@
+package validator
+
+import (
+	"strings"
+	"time"
+
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+	"simple-erp-service/internal/utils/brdoc"
+)
+
+// DocumentValidator valida regras de negócio relacionadas a documentos de clientes e fornecedores
+type DocumentValidator struct {
+	documentRepo repository.DocumentRepository
+	locationRepo repository.LocationRepository
+}
+
+// NewDocumentValidator cria um novo validador de documentos
+func NewDocumentValidator(documentRepo repository.DocumentRepository, locationRepo repository.LocationRepository) *DocumentValidator {
+	return &DocumentValidator{
+		documentRepo: documentRepo,
+		locationRepo: locationRepo,
+	}
+}
+
+// documentInput reúne os campos comuns às requisições de criação e atualização
+type documentInput struct {
+	Type         string
+	Number       string
+	Validate     *time.Time
+	EmissionDate *time.Time
+	Department   string
+	StateID      *uint
+	IsMandatory  bool
+}
+
+// ValidateForCreation valida os dados para criação de um documento.
+// personType é o tipo de pessoa (F/J) do cliente ou fornecedor dono do documento.
+func (v *DocumentValidator) ValidateForCreation(personType string, req models.CreateDocumentRequest) error {
+	return v.validate(0, personType, documentInput(req))
+}
+
+// ValidateForUpdate valida os dados para atualização de um documento
+func (v *DocumentValidator) ValidateForUpdate(id uint, personType string, req models.UpdateDocumentRequest) error {
+	return v.validate(id, personType, documentInput(req))
+}
+
+// validate aplica as regras específicas de cada tipo de documento
+func (v *DocumentValidator) validate(id uint, personType string, in documentInput) error {
+	var errors ValidationErrors
+
+	number := brdoc.Clean(in.Number)
+
+	// Carregar a UF de emissão, quando informada
+	var state *models.State
+	if in.StateID != nil {
+		found, err := v.locationRepo.FindStateByID(*in.StateID)
+		if err != nil {
+			return err
+		}
+		if found == nil {
+			errors.AddError("state_id", "estado não encontrado")
+		}
+		state = found
+	}
+
+	switch in.Type {
+	case models.DocumentTypeCPF:
+		if !brdoc.IsCPF(number) {
+			errors.AddError("number", "CPF inválido")
+		}
+		if personType != brdoc.PersonTypeIndividual {
+			errors.AddError("type", "CPF só pode ser cadastrado para pessoa física")
+		}
+	case models.DocumentTypeCNPJ:
+		if !brdoc.IsCNPJ(number) {
+			errors.AddError("number", "CNPJ inválido")
+		}
+		if personType != brdoc.PersonTypeCompany {
+			errors.AddError("type", "CNPJ só pode ser cadastrado para pessoa jurídica")
+		}
+	case models.DocumentTypeRG:
+		if len(number) < 5 || len(number) > 14 {
+			errors.AddError("number", "RG deve ter entre 5 e 14 caracteres")
+		}
+		if in.StateID == nil {
+			errors.AddError("state_id", "a UF de emissão é obrigatória para RG")
+		}
+		if strings.TrimSpace(in.Department) == "" {
+			errors.AddError("department", "o órgão emissor é obrigatório para RG")
+		}
+	case models.DocumentTypeIE:
+		if strings.EqualFold(number, brdoc.IEExempt) {
+			errors.AddError("number", "contribuinte isento não deve cadastrar Inscrição Estadual")
+		} else if in.StateID == nil {
+			errors.AddError("state_id", "a UF é obrigatória para Inscrição Estadual")
+		} else if state != nil && !brdoc.IsIE(state.UF, number) {
+			errors.AddError("number", "Inscrição Estadual inválida para a UF "+state.UF)
+		}
+	case models.DocumentTypeIM:
+		if len(number) == 0 || len(number) > 20 {
+			errors.AddError("number", "Inscrição Municipal deve ter até 20 caracteres")
+		}
+	case models.DocumentTypeCNH:
+		if len(number) != 11 || brdoc.OnlyDigits(number) != number {
+			errors.AddError("number", "CNH deve conter 11 dígitos")
+		}
+		if personType != brdoc.PersonTypeIndividual {
+			errors.AddError("type", "CNH só pode ser cadastrada para pessoa física")
+		}
+	case models.DocumentTypeAlvara, models.DocumentTypeLicenca, models.DocumentTypeCertificado:
+		if len(number) == 0 || len(number) > 60 {
+			errors.AddError("number", "o número deve ter até 60 caracteres")
+		}
+		if in.Validate == nil {
+			errors.AddError("validate", "a data de validade é obrigatória para alvarás, licenças e certificados")
+		}
+	default:
+		errors.AddError("type", "tipo de documento inválido, use CPF, CNPJ, RG, IE, IM, CNH, ALVARA, LICENCA ou CERTIFICADO")
+	}
+
+	if in.IsMandatory && in.Validate == nil {
+		errors.AddError("validate", "a data de validade é obrigatória para documentos obrigatórios")
+	}
+
+	// Validar datas de emissão e validade
+	if in.EmissionDate != nil && in.EmissionDate.After(time.Now()) {
+		errors.AddError("emission_date", "a data de emissão não pode ser futura")
+	}
+	if in.EmissionDate != nil && in.Validate != nil && !in.Validate.After(*in.EmissionDate) {
+		errors.AddError("validate", "a data de validade deve ser posterior à data de emissão")
+	}
+
+	// Verificar se o documento já está cadastrado
+	if number != "" {
+		exists, err := v.documentRepo.ExistsByNumberExcept(number, id)
+		if err != nil {
+			return err
+		}
+		if exists {
+			errors.AddError("number", "documento já está em uso")
+		}
+	}
+
+	if errors.HasErrors() {
+		return errors
+	}
+	return nil
+}
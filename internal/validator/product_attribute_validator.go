@@ -0,0 +1,83 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+)
+
+// ProductAttributeValidator valida regras de negócio relacionadas aos atributos das variantes
+type ProductAttributeValidator struct {
+	attributeRepo repository.ProductAttributeRepository
+}
+
+// NewProductAttributeValidator cria um novo validador de atributos das variantes
+func NewProductAttributeValidator(attributeRepo repository.ProductAttributeRepository) *ProductAttributeValidator {
+	return &ProductAttributeValidator{
+		attributeRepo: attributeRepo,
+	}
+}
+
+// ValidateForCreation valida os dados para cadastro de um atributo. Os códigos dos valores já devem estar
+// preenchidos.
+func (v *ProductAttributeValidator) ValidateForCreation(req models.CreateProductAttributeRequest) error {
+	var errors ValidationErrors
+
+	exists, err := v.attributeRepo.ExistsByNameExcept(strings.TrimSpace(req.Name), 0)
+	if err != nil {
+		return err
+	}
+	if exists {
+		errors.AddError("name", "já existe um atributo com este nome")
+	}
+
+	validateValues(&errors, nil, req.Values)
+
+	if errors.HasErrors() {
+		return errors
+	}
+	return nil
+}
+
+// ValidateNewValues valida os valores a incluir no atributo. Os códigos já devem estar preenchidos.
+func (v *ProductAttributeValidator) ValidateNewValues(attribute *models.ProductAttribute, req models.AddProductAttributeValuesRequest) error {
+	var errors ValidationErrors
+
+	validateValues(&errors, attribute.Values, req.Values)
+
+	if errors.HasErrors() {
+		return errors
+	}
+	return nil
+}
+
+// validateValues verifica se os valores e os códigos são únicos no atributo, considerando os existentes
+// e os demais valores da requisição
+func validateValues(errors *ValidationErrors, existing []models.ProductAttributeValue, values []models.CreateProductAttributeValueRequest) {
+	usedValues := make(map[string]bool, len(existing)+len(values))
+	usedCodes := make(map[string]bool, len(existing)+len(values))
+	for _, value := range existing {
+		usedValues[strings.ToLower(value.Value)] = true
+		usedCodes[value.Code] = true
+	}
+
+	for i, value := range values {
+		field := fmt.Sprintf("values[%d]", i)
+		if key := strings.ToLower(strings.TrimSpace(value.Value)); usedValues[key] {
+			errors.AddError(field+".value", "valor repetido no atributo")
+		} else {
+			usedValues[key] = true
+		}
+
+		switch {
+		case value.Code == "":
+			errors.AddError(field+".code", "informe um código com letras ou números")
+		case usedCodes[value.Code]:
+			errors.AddError(field+".code", "código repetido no atributo")
+		default:
+			usedCodes[value.Code] = true
+		}
+	}
+}
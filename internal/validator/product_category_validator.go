@@ -0,0 +1,109 @@
+package validator
+
+import (
+	"strings"
+
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+)
+
+// ProductCategoryValidator valida regras de negócio relacionadas à árvore de categorias de produtos
+type ProductCategoryValidator struct {
+	categoryRepo repository.ProductCategoryRepository
+}
+
+// NewProductCategoryValidator cria um novo validador de categorias de produtos
+func NewProductCategoryValidator(categoryRepo repository.ProductCategoryRepository) *ProductCategoryValidator {
+	return &ProductCategoryValidator{
+		categoryRepo: categoryRepo,
+	}
+}
+
+// ValidateForCreation valida os dados para criação de uma categoria
+func (v *ProductCategoryValidator) ValidateForCreation(req models.CreateProductCategoryRequest) error {
+	var errors ValidationErrors
+
+	if req.ParentID != nil {
+		parent, err := v.categoryRepo.FindByID(*req.ParentID)
+		if err != nil {
+			return err
+		}
+		if parent == nil {
+			errors.AddError("parent_id", "categoria pai não encontrada")
+		}
+	}
+
+	if err := v.validateName(&errors, req.ParentID, req.Name, 0); err != nil {
+		return err
+	}
+
+	if errors.HasErrors() {
+		return errors
+	}
+	return nil
+}
+
+// ValidateForUpdate valida os dados para atualização da categoria, que permanece sob o mesmo pai
+func (v *ProductCategoryValidator) ValidateForUpdate(category *models.ProductCategory, req models.UpdateProductCategoryRequest) error {
+	var errors ValidationErrors
+
+	if err := v.validateName(&errors, category.ParentID, req.Name, category.ID); err != nil {
+		return err
+	}
+
+	if errors.HasErrors() {
+		return errors
+	}
+	return nil
+}
+
+// ValidateMove valida a mudança de pai da categoria: o novo pai deve existir e não pode ser a própria
+// categoria nem uma de suas subcategorias, o que criaria um ciclo
+func (v *ProductCategoryValidator) ValidateMove(category *models.ProductCategory, req models.MoveProductCategoryRequest) error {
+	var errors ValidationErrors
+
+	if req.ParentID != nil {
+		parent, err := v.categoryRepo.FindByID(*req.ParentID)
+		if err != nil {
+			return err
+		}
+		if parent == nil {
+			errors.AddError("parent_id", "categoria pai não encontrada")
+		} else {
+			cycle, err := v.categoryRepo.IsDescendant(parent.ID, category.ID)
+			if err != nil {
+				return err
+			}
+			if cycle {
+				errors.AddError("parent_id", "a categoria não pode ser movida para dentro dela mesma ou de uma subcategoria")
+			}
+		}
+	}
+
+	if err := v.validateName(&errors, req.ParentID, category.Name, category.ID); err != nil {
+		return err
+	}
+
+	if errors.HasErrors() {
+		return errors
+	}
+	return nil
+}
+
+// validateName verifica se o nome não está vazio e é único entre as categorias irmãs
+func (v *ProductCategoryValidator) validateName(errors *ValidationErrors, parentID *uint, name string, id uint) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		errors.AddError("name", "o nome é obrigatório")
+		return nil
+	}
+
+	exists, err := v.categoryRepo.ExistsByNameExcept(parentID, name, id)
+	if err != nil {
+		return err
+	}
+	if exists {
+		errors.AddError("name", "já existe uma categoria com este nome no mesmo nível")
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+package validator
+
+import (
+	"strings"
+
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+)
+
+// ProductValidator valida regras de negócio relacionadas a produtos
+type ProductValidator struct {
+	productRepo   repository.ProductRepository
+	componentRepo repository.ProductComponentRepository
+}
+
+// NewProductValidator cria um novo validador de produtos
+func NewProductValidator(productRepo repository.ProductRepository, componentRepo repository.ProductComponentRepository) *ProductValidator {
+	return &ProductValidator{
+		productRepo:   productRepo,
+		componentRepo: componentRepo,
+	}
+}
+
+// ValidateForCreation valida os dados para criação de um produto
+func (v *ProductValidator) ValidateForCreation(req models.CreateProductRequest) error {
+	var errors ValidationErrors
+
+	if err := v.validateCommon(&errors, 0, req.SKU, req.Barcode, req.CategoryID, req.UnitID, req.MinStock, req.MaxStock); err != nil {
+		return err
+	}
+	if req.TracksLots && req.Kind == models.ProductKindKit {
+		errors.AddError("tracks_lots", "kits não têm estoque próprio e não controlam lotes")
+	}
+	if req.TracksSerials && req.Kind == models.ProductKindKit {
+		errors.AddError("tracks_serials", "kits não têm estoque próprio e não têm número de série")
+	} else if req.TracksSerials && req.TracksLots {
+		errors.AddError("tracks_serials", "o produto controla lotes ou número de série, não os dois")
+	}
+
+	if errors.HasErrors() {
+		return errors
+	}
+	return nil
+}
+
+// ValidateForUpdate valida os dados para atualização de um produto. A unidade base não pode mudar enquanto
+// houver estoque, variantes ou conversões exclusivas do produto, pois eles estão expressos nela; nas
+// variantes, a unidade é sempre a do produto pai. O tipo do produto segue as regras de validateKindChange, e
+// o controle de lotes e o de número de série só podem ser ligados ou desligados com o estoque zerado.
+func (v *ProductValidator) ValidateForUpdate(product *models.Product, req models.UpdateProductRequest) error {
+	var errors ValidationErrors
+
+	if err := v.validateCommon(&errors, product.ID, req.SKU, req.Barcode, req.CategoryID, req.UnitID, req.MinStock, req.MaxStock); err != nil {
+		return err
+	}
+
+	if !sameUnit(product.UnitID, req.UnitID) {
+		if err := v.validateUnitChange(&errors, product); err != nil {
+			return err
+		}
+	}
+
+	if req.Kind != "" && req.Kind != product.Kind {
+		if err := v.validateKindChange(&errors, product, req.Kind); err != nil {
+			return err
+		}
+	}
+
+	kind := product.Kind
+	if req.Kind != "" {
+		kind = req.Kind
+	}
+	if req.TracksLots && kind == models.ProductKindKit {
+		errors.AddError("tracks_lots", "kits não têm estoque próprio e não controlam lotes")
+	} else if req.TracksLots != product.TracksLots && product.CurrentStock != 0 {
+		errors.AddError("tracks_lots", "o produto possui estoque: zere o estoque antes de alterar o controle de lotes")
+	}
+	if err := v.validateSerialTracking(&errors, product, req, kind); err != nil {
+		return err
+	}
+
+	if errors.HasErrors() {
+		return errors
+	}
+	return nil
+}
+
+// validateCommon valida as regras compartilhadas entre criação e atualização. Os SKUs e códigos de barras
+// de produtos excluídos continuam reservados, pois a restrição de unicidade do banco os inclui.
+func (v *ProductValidator) validateCommon(errors *ValidationErrors, id uint, sku, barcode string, categoryID, unitID *uint, minStock float64, maxStock *float64) error {
+	exists, err := v.productRepo.ExistsBySKUExcept(strings.TrimSpace(sku), id)
+	if err != nil {
+		return err
+	}
+	if exists {
+		errors.AddError("sku", "SKU já está em uso")
+	}
+
+	if barcode = strings.TrimSpace(barcode); barcode != "" {
+		exists, err := v.productRepo.ExistsByBarcodeExcept(barcode, id)
+		if err != nil {
+			return err
+		}
+		if exists {
+			errors.AddError("barcode", "código de barras já está em uso")
+		}
+	}
+
+	if categoryID != nil {
+		exists, err := v.productRepo.CategoryExists(*categoryID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			errors.AddError("category_id", "categoria não encontrada")
+		}
+	}
+
+	if unitID != nil {
+		exists, err := v.productRepo.UnitExists(*unitID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			errors.AddError("unit_id", "unidade de medida não encontrada")
+		}
+	}
+
+	if maxStock != nil && *maxStock < minStock {
+		errors.AddError("max_stock", "o estoque máximo não pode ser menor que o estoque mínimo")
+	}
+
+	return nil
+}
+
+// validateUnitChange verifica se a unidade base do produto pode ser alterada
+func (v *ProductValidator) validateUnitChange(errors *ValidationErrors, product *models.Product) error {
+	if product.ParentID != nil {
+		errors.AddError("unit_id", "a unidade da variante é a do produto pai")
+		return nil
+	}
+	if product.CurrentStock != 0 {
+		errors.AddError("unit_id", "a unidade não pode ser alterada enquanto o produto tiver estoque")
+		return nil
+	}
+
+	summaries, err := v.productRepo.FindVariantSummaries([]uint{product.ID})
+	if err != nil {
+		return err
+	}
+	if len(summaries) > 0 {
+		errors.AddError("unit_id", "a unidade não pode ser alterada em produtos com variantes")
+		return nil
+	}
+
+	hasConversions, err := v.productRepo.HasUnitConversions(product.ID)
+	if err != nil {
+		return err
+	}
+	if hasConversions {
+		errors.AddError("unit_id", "a unidade não pode ser alterada enquanto o produto tiver conversões de unidades exclusivas")
+	}
+	return nil
+}
+
+// validateKindChange verifica se o tipo do produto pode ser alterado. Variantes e produtos com variantes são
+// sempre simples; kits não têm estoque próprio nem podem ser componentes, então o produto precisa estar sem
+// estoque e fora de outras composições para virar kit; e a composição precisa ser removida antes de o produto voltar a ser simples.
+func (v *ProductValidator) validateKindChange(errors *ValidationErrors, product *models.Product, kind string) error {
+	if product.ParentID != nil {
+		errors.AddError("kind", "variantes são sempre produtos simples")
+		return nil
+	}
+
+	summaries, err := v.productRepo.FindVariantSummaries([]uint{product.ID})
+	if err != nil {
+		return err
+	}
+	if len(summaries) > 0 {
+		errors.AddError("kind", "produtos com variantes são sempre produtos simples")
+		return nil
+	}
+
+	if kind == models.ProductKindKit {
+		if product.CurrentStock != 0 {
+			errors.AddError("kind", "o produto possui estoque próprio: zere o estoque antes de transformá-lo em kit")
+			return nil
+		}
+		isComponent, err := v.componentRepo.IsComponent(product.ID)
+		if err != nil {
+			return err
+		}
+		if isComponent {
+			errors.AddError("kind", "o produto é componente de outros produtos e kits não podem ser componentes")
+			return nil
+		}
+	}
+
+	if kind == models.ProductKindSimple {
+		components, err := v.componentRepo.FindByProduct(product.ID)
+		if err != nil {
+			return err
+		}
+		if len(components) > 0 {
+			errors.AddError("kind", "remova a composição do produto antes de alterá-lo para simples")
+		}
+	}
+	return nil
+}
+
+// sameUnit informa se as duas unidades opcionais são iguais
+func sameUnit(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// validateSerialTracking verifica o controle por número de série: kits não têm número de série, o produto
+// controla lotes ou número de série, e o controle só muda com o estoque zerado. Componentes de kits e de
+// fichas técnicas não podem ter número de série.
+func (v *ProductValidator) validateSerialTracking(errors *ValidationErrors, product *models.Product, req models.UpdateProductRequest, kind string) error {
+	if req.TracksSerials && kind == models.ProductKindKit {
+		errors.AddError("tracks_serials", "kits não têm estoque próprio e não têm número de série")
+		return nil
+	}
+	if req.TracksSerials && req.TracksLots {
+		errors.AddError("tracks_serials", "o produto controla lotes ou número de série, não os dois")
+		return nil
+	}
+	if req.TracksSerials == product.TracksSerials {
+		return nil
+	}
+	if product.CurrentStock != 0 {
+		errors.AddError("tracks_serials", "o produto possui estoque: zere o estoque antes de alterar o controle de número de série")
+		return nil
+	}
+
+	if req.TracksSerials {
+		isComponent, err := v.componentRepo.IsComponent(product.ID)
+		if err != nil {
+			return err
+		}
+		if isComponent {
+			errors.AddError("tracks_serials", "o produto é componente de outros produtos e componentes não podem ter número de série")
+		}
+	}
+	return nil
+}
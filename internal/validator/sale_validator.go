@@ -0,0 +1,169 @@
+package validator
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+)
+
+// SaleValidator valida regras de negócio relacionadas às vendas
+type SaleValidator struct {
+	productRepo     repository.ProductRepository
+	componentRepo   repository.ProductComponentRepository
+	locationRepo    repository.StockLocationRepository
+	reservationRepo repository.StockReservationRepository
+	lotRepo         repository.StockLotRepository
+}
+
+// NewSaleValidator cria um novo validador de vendas
+func NewSaleValidator(
+	productRepo repository.ProductRepository,
+	componentRepo repository.ProductComponentRepository,
+	locationRepo repository.StockLocationRepository,
+	reservationRepo repository.StockReservationRepository,
+	lotRepo repository.StockLotRepository,
+) *SaleValidator {
+	return &SaleValidator{
+		productRepo:     productRepo,
+		componentRepo:   componentRepo,
+		locationRepo:    locationRepo,
+		reservationRepo: reservationRepo,
+		lotRepo:         lotRepo,
+	}
+}
+
+// ValidateStock verifica se o estoque disponível no local da venda (ou no local padrão) cobre os itens: o
+// saldo do produto no local menos as reservas ativas das outras vendas. Nos produtos com controle de lotes o
+// saldo é o dos lotes não vencidos na data informada, os mesmos de que a saída FEFO do faturamento dá baixa.
+// Os kits consomem os componentes, na quantidade da composição. Os itens devem estar na unidade do produto.
+func (v *SaleValidator) ValidateStock(sale models.Sale, today time.Time) error {
+	var errors ValidationErrors
+
+	location, err := v.saleLocation(sale.LocationID)
+	if err != nil {
+		return err
+	}
+	if location == nil {
+		errors.AddError("location_id", "local de estoque não encontrado e nenhum local padrão cadastrado")
+		return errors
+	}
+
+	productIDs := make([]uint, 0, len(sale.Items))
+	for _, item := range sale.Items {
+		productIDs = append(productIDs, item.ProductID)
+	}
+	products, err := v.productRepo.FindByIDs(productIDs)
+	if err != nil {
+		return err
+	}
+	productsByID := make(map[uint]models.Product, len(products))
+	kitIDs := make([]uint, 0)
+	for _, product := range products {
+		productsByID[product.ID] = product
+		if product.Kind == models.ProductKindKit {
+			kitIDs = append(kitIDs, product.ID)
+		}
+	}
+	components, err := v.componentRepo.FindByProducts(kitIDs)
+	if err != nil {
+		return err
+	}
+	componentsByKit := make(map[uint][]models.ProductComponent, len(kitIDs))
+	for _, component := range components {
+		componentsByKit[component.ProductID] = append(componentsByKit[component.ProductID], component)
+		if component.Component != nil {
+			productsByID[component.ComponentID] = *component.Component
+		}
+	}
+
+	// Quantidade necessária de cada produto com estoque, com o primeiro item que o consome
+	required := make(map[uint]float64)
+	firstItem := make(map[uint]int)
+	order := make([]uint, 0)
+	need := func(index int, productID uint, quantity float64) {
+		if _, ok := required[productID]; !ok {
+			firstItem[productID] = index
+			order = append(order, productID)
+		}
+		required[productID] += quantity
+	}
+	for i, item := range sale.Items {
+		product, ok := productsByID[item.ProductID]
+		if !ok {
+			errors.AddError(fmt.Sprintf("items[%d].product_id", i), fmt.Sprintf("produto %d não encontrado", item.ProductID))
+			continue
+		}
+		if product.Kind != models.ProductKindKit {
+			need(i, product.ID, item.Quantity)
+			continue
+		}
+		if len(componentsByKit[product.ID]) == 0 {
+			errors.AddError(fmt.Sprintf("items[%d].product_id", i), fmt.Sprintf("o kit %s não possui composição", product.SKU))
+			continue
+		}
+		for _, component := range componentsByKit[product.ID] {
+			need(i, component.ComponentID, component.Quantity*item.Quantity)
+		}
+	}
+	if errors.HasErrors() {
+		return errors
+	}
+
+	reserved, err := v.reservationRepo.FindReservedAt(order, location.ID, sale.ID)
+	if err != nil {
+		return err
+	}
+	reservedByProduct := make(map[uint]float64, len(reserved))
+	for _, r := range reserved {
+		reservedByProduct[r.ProductID] = r.Quantity
+	}
+
+	for _, productID := range order {
+		product := productsByID[productID]
+		var balance float64
+		if product.TracksLots {
+			balance, err = v.lotRepo.SumAvailable(productID, location.ID, today)
+		} else {
+			balance, err = v.locationRepo.FindBalance(productID, location.ID)
+		}
+		if err != nil {
+			return err
+		}
+		available := roundQuantity(balance - reservedByProduct[productID])
+		quantity := roundQuantity(required[productID])
+		if quantity > available {
+			errors.AddError(fmt.Sprintf("items[%d].quantity", firstItem[productID]), fmt.Sprintf(
+				"estoque disponível insuficiente de %s em %s: necessário %s, disponível %s (saldo %s, reservado %s)",
+				product.SKU, location.Code, formatQuantity(quantity), formatQuantity(math.Max(available, 0)),
+				formatQuantity(roundQuantity(balance)), formatQuantity(roundQuantity(reservedByProduct[productID])),
+			))
+		}
+	}
+
+	if errors.HasErrors() {
+		return errors
+	}
+	return nil
+}
+
+// saleLocation retorna o local de estoque informado ou, sem ele, o local padrão
+func (v *SaleValidator) saleLocation(locationID *uint) (*models.StockLocation, error) {
+	if locationID == nil {
+		return v.locationRepo.FindDefault()
+	}
+	return v.locationRepo.FindByID(*locationID)
+}
+
+// roundQuantity arredonda quantidades para as quatro casas decimais gravadas no banco
+func roundQuantity(value float64) float64 {
+	return math.Round(value*10000) / 10000
+}
+
+// formatQuantity formata a quantidade para mensagens, sem zeros à direita
+func formatQuantity(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
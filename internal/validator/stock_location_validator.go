@@ -0,0 +1,109 @@
+package validator
+
+import (
+	"strings"
+
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+)
+
+// StockLocationValidator valida regras de negócio relacionadas aos locais de estoque
+type StockLocationValidator struct {
+	locationRepo repository.StockLocationRepository
+}
+
+// NewStockLocationValidator cria um novo validador de locais de estoque
+func NewStockLocationValidator(locationRepo repository.StockLocationRepository) *StockLocationValidator {
+	return &StockLocationValidator{
+		locationRepo: locationRepo,
+	}
+}
+
+// ValidateForCreation valida os dados para cadastro de um local de estoque
+func (v *StockLocationValidator) ValidateForCreation(req models.CreateStockLocationRequest) error {
+	var errors ValidationErrors
+
+	if err := v.validateCommon(&errors, 0, req.Code, req.ParentID); err != nil {
+		return err
+	}
+
+	if errors.HasErrors() {
+		return errors
+	}
+	return nil
+}
+
+// ValidateForUpdate valida os dados para atualização de um local de estoque. Sempre há um local padrão:
+// ele deixa de ser o padrão quando outro local é marcado como padrão. Locais com saldo de estoque não podem
+// ser desativados, e depósitos com endereços não podem virar endereços.
+func (v *StockLocationValidator) ValidateForUpdate(location *models.StockLocation, req models.UpdateStockLocationRequest) error {
+	var errors ValidationErrors
+
+	if err := v.validateCommon(&errors, location.ID, req.Code, req.ParentID); err != nil {
+		return err
+	}
+
+	if req.ParentID != nil && location.ParentID == nil {
+		hasChildren, err := v.locationRepo.HasChildren(location.ID)
+		if err != nil {
+			return err
+		}
+		if hasChildren {
+			errors.AddError("parent_id", "o depósito possui endereços e não pode ficar dentro de outro depósito")
+		}
+	}
+
+	if location.IsDefault && !req.IsDefault {
+		errors.AddError("is_default", "marque outro local como padrão para substituir este")
+	}
+	if req.IsDefault && !req.IsActive {
+		errors.AddError("is_active", "o local padrão não pode ser desativado")
+	}
+	if location.IsActive && !req.IsActive {
+		hasBalance, err := v.locationRepo.HasBalance(location.ID)
+		if err != nil {
+			return err
+		}
+		if hasBalance {
+			errors.AddError("is_active", "o local possui saldo de estoque: transfira o estoque antes de desativá-lo")
+		}
+	}
+
+	if errors.HasErrors() {
+		return errors
+	}
+	return nil
+}
+
+// validateCommon valida as regras compartilhadas entre cadastro e atualização. Os endereços ficam
+// diretamente dentro de um depósito ativo.
+func (v *StockLocationValidator) validateCommon(errors *ValidationErrors, id uint, code string, parentID *uint) error {
+	exists, err := v.locationRepo.ExistsByCodeExcept(strings.TrimSpace(code), id)
+	if err != nil {
+		return err
+	}
+	if exists {
+		errors.AddError("code", "código já está em uso")
+	}
+
+	if parentID == nil {
+		return nil
+	}
+	if *parentID == id {
+		errors.AddError("parent_id", "o local não pode estar dentro de si mesmo")
+		return nil
+	}
+	parent, err := v.locationRepo.FindByID(*parentID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case parent == nil:
+		errors.AddError("parent_id", "depósito não encontrado")
+	case parent.ParentID != nil:
+		errors.AddError("parent_id", "o local informado é um endereço; informe o depósito")
+	case !parent.IsActive:
+		errors.AddError("parent_id", "o depósito está inativo")
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package validator
+
+import (
+	"strings"
+
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+)
+
+// SupplierProductValidator valida regras de negócio do catálogo dos fornecedores
+type SupplierProductValidator struct {
+	supplierProductRepo repository.SupplierProductRepository
+	productRepo         repository.ProductRepository
+}
+
+// NewSupplierProductValidator cria um novo validador do catálogo dos fornecedores
+func NewSupplierProductValidator(supplierProductRepo repository.SupplierProductRepository, productRepo repository.ProductRepository) *SupplierProductValidator {
+	return &SupplierProductValidator{
+		supplierProductRepo: supplierProductRepo,
+		productRepo:         productRepo,
+	}
+}
+
+// Validate valida um item do catálogo do fornecedor. id é zero na criação.
+func (v *SupplierProductValidator) Validate(supplierID, id uint, req models.SupplierProductRequest) error {
+	var errors ValidationErrors
+
+	product, err := v.productRepo.FindByID(req.ProductID)
+	if err != nil {
+		return err
+	}
+	if product == nil {
+		errors.AddError("product_id", "produto não encontrado")
+	} else {
+		exists, err := v.supplierProductRepo.ExistsBySupplierAndProductExcept(supplierID, req.ProductID, id)
+		if err != nil {
+			return err
+		}
+		if exists {
+			errors.AddError("product_id", "o produto já está no catálogo do fornecedor")
+		}
+	}
+
+	exists, err := v.supplierProductRepo.ExistsBySupplierCodeExcept(supplierID, strings.TrimSpace(req.SupplierCode), id)
+	if err != nil {
+		return err
+	}
+	if exists {
+		errors.AddError("supplier_code", "código já está em uso no catálogo do fornecedor")
+	}
+
+	if errors.HasErrors() {
+		return errors
+	}
+	return nil
+}
@@ -3,7 +3,7 @@ package validator
 import (
 	"simple-erp-service/internal/data-structure/models"
 	"simple-erp-service/internal/repository"
-	"simple-erp-service/internal/utils"
+	"simple-erp-service/internal/utils/brdoc"
 )
 
 // SupplierValidator valida regras de negócio relacionadas a fornecedores
@@ -22,20 +22,35 @@ func NewSupplierValidator(supplierRepo repository.SupplierRepository) *SupplierV
 func (v *SupplierValidator) ValidateForCreation(req models.CreateSupplierRequest) error {
 	var errors ValidationErrors
 
-	// Verificar se o documento já existe
+	// Validar tipo de pessoa
+	if !brdoc.IsValidPersonType(req.PersonType) {
+		errors.AddError("person_type", "tipo de pessoa inválido, use F (Física) ou J (Jurídica)")
+	}
+
+	// Validar CPF/CNPJ e verificar se o documento já existe
 	if req.DocumentNumber != "" {
-		// Remover caracteres não numéricos para comparação
-		document := utils.RemoveMask(req.DocumentNumber)
+		document := brdoc.Clean(req.DocumentNumber)
+
+		if brdoc.IsValidPersonType(req.PersonType) {
+			if msg := brdoc.ValidatePersonDocument(req.PersonType, document); msg != "" {
+				errors.AddError("document_number", msg)
+			}
+		}
 
 		exists, err := v.supplierRepo.ExistsByDocument(document)
 		if err != nil {
 			return err
 		}
 		if exists {
-			errors.AddError("document", "documento já está em uso")
+			errors.AddError("document_number", "documento já está em uso")
 		}
 	}
 
+	// Pessoa jurídica deve informar a razão social
+	if req.PersonType == brdoc.PersonTypeCompany && req.CompanyName == "" {
+		errors.AddError("company_name", "a razão social é obrigatória para pessoa jurídica")
+	}
+
 	// Validar nome (se fornecido)
 	if req.FirstName != "" && len(req.FirstName) < 3 {
 		errors.AddError("first_name", "o primeiro nome deve ter pelo menos 3 caracteres")
@@ -64,17 +79,23 @@ func (v *SupplierValidator) ValidateForUpdate(id uint, req models.UpdateSupplier
 		return errors
 	}
 
-	// Verificar se o documento já está em uso por outro fornecedor (se fornecido)
-	if req.DocumentNumber != "" && req.DocumentNumber != supplier.DocumentNumber {
-		// Remover caracteres não numéricos para comparação
-		document := utils.RemoveMask(req.DocumentNumber)
+	// O tipo de pessoa não pode ser alterado, então o documento é validado contra o tipo gravado
+	if req.DocumentNumber != "" {
+		document := brdoc.Clean(req.DocumentNumber)
 
-		exists, err := v.supplierRepo.ExistsByDocumentExcept(document, id)
-		if err != nil {
-			return err
+		if msg := brdoc.ValidatePersonDocument(supplier.PersonType, document); msg != "" {
+			errors.AddError("document_number", msg)
 		}
-		if exists {
-			errors.AddError("document", "documento já está em uso")
+
+		// Verificar se o documento já está em uso por outro fornecedor
+		if document != supplier.DocumentNumber {
+			exists, err := v.supplierRepo.ExistsByDocumentExcept(document, id)
+			if err != nil {
+				return err
+			}
+			if exists {
+				errors.AddError("document_number", "documento já está em uso")
+			}
 		}
 	}
 
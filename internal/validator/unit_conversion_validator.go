@@ -0,0 +1,74 @@
+package validator
+
+import (
+	"simple-erp-service/internal/data-structure/models"
+	"simple-erp-service/internal/repository"
+)
+
+// UnitConversionValidator valida regras de negócio relacionadas às conversões de unidades
+type UnitConversionValidator struct {
+	unitRepo    repository.MeasurementUnitRepository
+	productRepo repository.ProductRepository
+}
+
+// NewUnitConversionValidator cria um novo validador de conversões de unidades
+func NewUnitConversionValidator(unitRepo repository.MeasurementUnitRepository, productRepo repository.ProductRepository) *UnitConversionValidator {
+	return &UnitConversionValidator{
+		unitRepo:    unitRepo,
+		productRepo: productRepo,
+	}
+}
+
+// ValidateForCreation valida os dados para cadastro de uma conversão. As conversões exclusivas de um
+// produto devem envolver a unidade do produto, pois só são usadas para converter quantidades para ela.
+// Só pode haver uma conversão entre as mesmas unidades, em qualquer sentido, em cada escopo.
+func (v *UnitConversionValidator) ValidateForCreation(req models.CreateUnitConversionRequest) error {
+	var errors ValidationErrors
+
+	if err := v.validateUnit(&errors, "from_unit_id", req.FromUnitID); err != nil {
+		return err
+	}
+	if err := v.validateUnit(&errors, "to_unit_id", req.ToUnitID); err != nil {
+		return err
+	}
+
+	if req.ProductID != nil {
+		product, err := v.productRepo.FindByID(*req.ProductID)
+		if err != nil {
+			return err
+		}
+		switch {
+		case product == nil:
+			errors.AddError("product_id", "produto não encontrado")
+		case product.UnitID == nil:
+			errors.AddError("product_id", "o produto não possui unidade de medida")
+		case *product.UnitID != req.FromUnitID && *product.UnitID != req.ToUnitID:
+			errors.AddError("product_id", "a conversão deve envolver a unidade do produto")
+		}
+	}
+
+	exists, err := v.unitRepo.ExistsConversionExcept(req.ProductID, req.FromUnitID, req.ToUnitID, 0)
+	if err != nil {
+		return err
+	}
+	if exists {
+		errors.AddError("to_unit_id", "já existe uma conversão entre essas unidades")
+	}
+
+	if errors.HasErrors() {
+		return errors
+	}
+	return nil
+}
+
+// validateUnit verifica se a unidade de medida existe
+func (v *UnitConversionValidator) validateUnit(errors *ValidationErrors, field string, unitID uint) error {
+	unit, err := v.unitRepo.FindUnitByID(unitID)
+	if err != nil {
+		return err
+	}
+	if unit == nil {
+		errors.AddError(field, "unidade de medida não encontrada")
+	}
+	return nil
+}
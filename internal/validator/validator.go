@@ -6,8 +6,8 @@ import (
 
 // ValidationError representa um erro de validação
 type ValidationError struct {
-	Field   string
-	Message string
+	Field   string `json:"field"`
+	Message string `json:"message"`
 }
 
 // ValidationErrors representa uma coleção de erros de validação
@@ -40,4 +40,13 @@ func (ve ValidationErrors) HasErrors() bool {
 func IsValidationError(err error) bool {
 	var ve ValidationErrors
 	return errors.As(err, &ve)
-}
\ No newline at end of file
+}
+
+// GetValidationErrors extrai a lista de erros por campo de um erro de validação
+func GetValidationErrors(err error) ValidationErrors {
+	var ve ValidationErrors
+	if errors.As(err, &ve) {
+		return ve
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"log"
+	"simple-erp-service/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// encryptionBatchSize é a quantidade de registros criptografados por transação
+const encryptionBatchSize = 500
+
+// encryptPlaintextColumns criptografa os valores legados em texto puro das colunas criptografadas e
+// preenche os seus índices cegos. A rotação de chaves usa a mesma rotina pelo comando cmd/reencrypt.
+func encryptPlaintextColumns(db *gorm.DB) error {
+	encryptionRepo := repository.NewEncryptionRepository(db)
+
+	for _, column := range repository.EncryptedColumns {
+		updated, err := encryptionRepo.Reencrypt(column, false, encryptionBatchSize)
+		if err != nil {
+			log.Printf("Erro ao criptografar %s.%s: %v", column.Table, column.Column, err)
+			return err
+		}
+		if updated > 0 {
+			log.Printf("%s.%s: %d registros criptografados", column.Table, column.Column, updated)
+		}
+	}
+
+	return nil
+}
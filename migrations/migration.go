@@ -13,7 +13,7 @@ func MigrateDB(db *gorm.DB) error {
 
 	// Lista de todos os modelos para migração
 	models := []interface{}{
-		//&models.Account{},
+		&models.Account{},
 
 		&models.User{},
 		&models.Permission{},
@@ -22,29 +22,54 @@ func MigrateDB(db *gorm.DB) error {
 		&models.Country{},
 		&models.State{},
 		&models.City{},
+		&models.PostalCode{},
 		&models.Address{},
 
 		&models.Contact{},
 		&models.Document{},
 
-		//&models.SaleItem{},
-		//&models.Sale{},
+		&models.PaymentMethod{},
+		&models.SaleItem{},
+		&models.Sale{},
 
-		//&models.PurchaseItem{},
-		//&models.Purchase{},
+		&models.PurchaseItem{},
+		&models.Purchase{},
+		&models.PurchaseReturnItem{},
+		&models.PurchaseReturn{},
 
-		//&models.Transaction{},
-		//&models.PaymentMethod{},
-		//&models.Payment{},
+		&models.Transaction{},
+		&models.Payment{},
 
 		&models.Customer{},
 		&models.Supplier{},
 
-		// &models.InventoryMovement{},
-		// &models.MeasurementUnit{},
-		// &models.ProductCategory{},
-		// &models.Product{},
+		&models.InventoryMovement{},
+		&models.StockLocation{},
+		&models.StockBalance{},
+		&models.StockTransfer{},
+		&models.StockLot{},
+		&models.StockLotBalance{},
+		&models.SaleItemLot{},
+		&models.SerialNumber{},
+		&models.SerialNumberEvent{},
+		&models.InventoryCount{},
+		&models.InventoryCountItem{},
+		&models.InventoryCountEntry{},
+		&models.StockReservation{},
+		&models.MeasurementUnit{},
+		&models.ProductCategory{},
+		&models.Product{},
+		&models.ProductAttribute{},
+		&models.ProductAttributeValue{},
+		&models.ProductVariantValue{},
+		&models.ProductComponent{},
+		&models.ProductionOrder{},
+		&models.ProductionOrderComponent{},
+		&models.UnitConversion{},
+		&models.SupplierProduct{},
 		&models.SystemLog{},
+		&models.ImportJob{},
+		&models.Notification{},
 	}
 
 	// Executar migrações
@@ -54,6 +79,26 @@ func MigrateDB(db *gorm.DB) error {
 		return err
 	}
 
+	// Extensões, funções e índices usados pelas buscas sem acentos
+	if err := setupSearch(db); err != nil {
+		return err
+	}
+
+	// Local de estoque padrão e saldos por local do estoque anterior aos locais
+	if err := setupStockLocations(db); err != nil {
+		return err
+	}
+
+	// Custo das movimentações anteriores ao custo médio, usado na valorização em datas passadas
+	if err := backfillMovementCosts(db); err != nil {
+		return err
+	}
+
+	// Criptografia dos dados pessoais ainda gravados em texto puro
+	if err := encryptPlaintextColumns(db); err != nil {
+		return err
+	}
+
 	log.Println("Migrações concluídas com sucesso!")
 	return nil
 }
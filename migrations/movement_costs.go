@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// backfillMovementCosts grava o custo nas movimentações anteriores ao custo médio, que não têm custo
+// registrado. O custo usado é o preço de custo do produto na migração, o custo em vigor até a primeira
+// entrada com custo, de modo que a valorização em datas passadas não acompanhe as mudanças de custo
+// posteriores.
+func backfillMovementCosts(db *gorm.DB) error {
+	result := db.Exec(`
+		UPDATE inventory_movements m
+		SET average_cost = p.cost_price,
+			unit_cost = CASE WHEN m.unit_cost = 0 THEN p.cost_price ELSE m.unit_cost END
+		FROM products p
+		WHERE p.id = m.product_id AND m.average_cost IS NULL
+	`)
+	if result.Error != nil {
+		log.Printf("Erro ao registrar o custo das movimentações de estoque: %v", result.Error)
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		log.Printf("%d movimentações de estoque com o custo registrado", result.RowsAffected)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package migrations
+
+import (
+	"fmt"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// searchIndex descreve um índice trigram sobre uma expressão sem acentos
+type searchIndex struct {
+	Name       string
+	Table      string
+	Expression string
+}
+
+// searchIndexes lista as colunas usadas pelas buscas textuais das listagens.
+// Novas listagens pesquisáveis devem registrar aqui as expressões usadas em repository.TextSearch.
+var searchIndexes = []searchIndex{
+	{"idx_customers_full_name_trgm", "customers", "first_name || ' ' || last_name"},
+	{"idx_customers_company_name_trgm", "customers", "company_name"},
+	{"idx_suppliers_full_name_trgm", "suppliers", "first_name || ' ' || last_name"},
+	{"idx_suppliers_company_name_trgm", "suppliers", "company_name"},
+	{"idx_suppliers_document_number_trgm", "suppliers", "document_number"},
+	{"idx_contact_name_trgm", "contact", "name"},
+	{"idx_products_name_trgm", "products", "name"},
+	{"idx_products_sku_trgm", "products", "sku"},
+	{"idx_products_barcode_trgm", "products", "barcode"},
+	{"idx_supplier_products_code_trgm", "supplier_products", "supplier_code"},
+	{"idx_city_name_trgm", "city", "name"},
+}
+
+// setupSearch habilita as extensões unaccent e pg_trgm, cria a função imutável f_unaccent
+// (o unaccent nativo não pode ser usado em índices) e os índices GIN das buscas textuais
+func setupSearch(db *gorm.DB) error {
+	statements := []string{
+		`CREATE EXTENSION IF NOT EXISTS unaccent`,
+		`CREATE EXTENSION IF NOT EXISTS pg_trgm`,
+		`CREATE OR REPLACE FUNCTION f_unaccent(text) RETURNS text AS
+			$$ SELECT public.unaccent('public.unaccent'::regdictionary, $1) $$
+			LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT`,
+	}
+
+	// Colunas que passaram a ser criptografadas não admitem busca parcial
+	for _, name := range []string{"idx_customers_document_number_trgm", "idx_contact_contact_trgm"} {
+		statements = append(statements, "DROP INDEX IF EXISTS "+name)
+	}
+
+	for _, index := range searchIndexes {
+		statements = append(statements, fmt.Sprintf(
+			"CREATE INDEX IF NOT EXISTS %s ON %s USING gin (f_unaccent(%s) gin_trgm_ops)",
+			index.Name, index.Table, index.Expression,
+		))
+	}
+
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			log.Printf("Erro ao configurar busca textual: %v", err)
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package migrations
+
+import (
+	"log"
+	"simple-erp-service/internal/data-structure/models"
+
+	"gorm.io/gorm"
+)
+
+// setupStockLocations cria o local de estoque padrão quando ainda não há nenhum local e registra nele o
+// estoque dos produtos que ainda não têm saldo por local, para que o estoque atual de cada produto continue
+// sendo a soma dos seus saldos. Cada saldo registrado tem as movimentações de saldo inicial: a saída do
+// estoque sem local e a entrada no local padrão, pelo preço de custo, para que as movimentações de cada
+// local continuem somando o seu saldo.
+func setupStockLocations(db *gorm.DB) error {
+	var count int64
+	if err := db.Unscoped().Model(&models.StockLocation{}).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		location := models.StockLocation{Code: "PRINCIPAL", Name: "Depósito principal", IsDefault: true, IsActive: true}
+		if err := db.Create(&location).Error; err != nil {
+			log.Printf("Erro ao criar o local de estoque padrão: %v", err)
+			return err
+		}
+	}
+
+	var location models.StockLocation
+	result := db.Where("is_default = ?", true).Limit(1).Find(&location)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`
+			INSERT INTO inventory_movements (created_at, updated_at, product_id, location_id, quantity,
+				previous_stock, new_stock, movement_type, reference_type, notes, unit_cost, average_cost)
+			SELECT NOW(), NOW(), p.id, m.location_id, m.sign * p.current_stock,
+				CASE WHEN m.sign < 0 THEN p.current_stock ELSE 0 END,
+				CASE WHEN m.sign < 0 THEN 0 ELSE p.current_stock END,
+				m.movement_type, ?, ?, p.cost_price, p.cost_price
+			FROM products p
+			CROSS JOIN (VALUES (NULL::bigint, -1, ?), (?::bigint, 1, ?)) AS m(location_id, sign, movement_type)
+			WHERE p.current_stock <> 0
+			AND NOT EXISTS (SELECT 1 FROM stock_balances b WHERE b.product_id = p.id)
+			ORDER BY p.id, m.sign
+		`, models.MovementReferenceOpening, "Saldo inicial do local "+location.Code,
+			models.MovementTypeOut, location.ID, models.MovementTypeIn).Error
+		if err != nil {
+			log.Printf("Erro ao registrar as movimentações de saldo inicial: %v", err)
+			return err
+		}
+
+		result := tx.Exec(`
+			INSERT INTO stock_balances (product_id, location_id, quantity, updated_at)
+			SELECT p.id, ?, p.current_stock, NOW() FROM products p
+			WHERE p.current_stock <> 0
+			AND NOT EXISTS (SELECT 1 FROM stock_balances b WHERE b.product_id = p.id)
+		`, location.ID)
+		if result.Error != nil {
+			log.Printf("Erro ao registrar os saldos por local de estoque: %v", result.Error)
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			log.Printf("%d produtos com o estoque registrado no local %s", result.RowsAffected, location.Code)
+		}
+
+		return nil
+	})
+}